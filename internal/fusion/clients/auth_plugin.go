@@ -0,0 +1,108 @@
+package clients
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/rest"
+)
+
+// Kinds of AuthPlugin
+const (
+	// AuthPluginExec is a client-go credential plugin, a command printing an ExecCredential
+	AuthPluginExec = "exec"
+	// AuthPluginProvider is a legacy auth provider built into client-go, such as oidc
+	AuthPluginProvider = "auth-provider"
+)
+
+// lookPath is replaced in tests to simulate the commands installed
+var lookPath = exec.LookPath
+
+// AuthPlugin is the plugin the credentials of a cluster come from, as used by cloud
+// clusters: an exec plugin such as aws or gke-gcloud-auth-plugin, or an auth provider
+type AuthPlugin struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	// Path is where the exec plugin command was found on PATH, empty when it was not
+	Path string `json:"path,omitempty"`
+}
+
+// AuthPluginFor returns the auth plugin of a client configuration, nil when it uses
+// static credentials such as a token or a client certificate
+func AuthPluginFor(config *rest.Config) *AuthPlugin {
+	switch {
+	case config == nil:
+		return nil
+	case config.ExecProvider != nil:
+		plugin := &AuthPlugin{Kind: AuthPluginExec, Name: config.ExecProvider.Command}
+		plugin.Path, _ = lookPath(config.ExecProvider.Command)
+		return plugin
+	case config.AuthProvider != nil:
+		return &AuthPlugin{Kind: AuthPluginProvider, Name: config.AuthProvider.Name}
+	}
+	return nil
+}
+
+// AuthPluginError is a credential failure of a cluster authenticating through an auth
+// plugin: the plugin could not provide a credential or the API server rejected it
+type AuthPluginError struct {
+	Cluster string
+	Plugin  AuthPlugin
+	Err     error
+}
+
+// Error names the plugin and what to check, first of all a command missing from PATH
+func (e *AuthPluginError) Error() string {
+	switch {
+	case e.Plugin.Kind == AuthPluginProvider:
+		return fmt.Sprintf("cluster %s authenticates with the %s auth provider, which failed (log in again to renew the kubeconfig token): %v",
+			e.Cluster, e.Plugin.Name, e.Err)
+	case e.Plugin.Path == "":
+		return fmt.Sprintf("cluster %s authenticates with the exec plugin %q, which is not on the server's PATH (install it or fix the command in the kubeconfig): %v",
+			e.Cluster, e.Plugin.Name, e.Err)
+	default:
+		return fmt.Sprintf("cluster %s authenticates with the exec plugin %q at %s, which failed (run it to check the login of the server's user): %v",
+			e.Cluster, e.Plugin.Name, e.Plugin.Path, e.Err)
+	}
+}
+
+// Unwrap returns the credential failure
+func (e *AuthPluginError) Unwrap() error {
+	return e.Err
+}
+
+// ExplainAuthError turns a credential failure of a client authenticating through an
+// auth plugin into an AuthPluginError naming the plugin. Other errors, and the errors of
+// clients with static credentials, are returned as is.
+func ExplainAuthError(client *ClusterClient, err error) error {
+	if err == nil || client == nil || !isCredentialError(err) {
+		return err
+	}
+	var pluginErr *AuthPluginError
+	if errors.As(err, &pluginErr) {
+		return err
+	}
+	plugin := AuthPluginFor(client.Config)
+	if plugin == nil {
+		return err
+	}
+	return &AuthPluginError{Cluster: client.Name, Plugin: *plugin, Err: err}
+}
+
+// isCredentialError reports whether err is a credential rejected by the API server or
+// one client-go could not get from the plugin. client-go flattens plugin failures into
+// strings, such as "getting credentials: exec: executable aws not found".
+func isCredentialError(err error) bool {
+	if apierrors.IsUnauthorized(err) {
+		return true
+	}
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "getting credentials") ||
+		strings.Contains(message, "exec plugin") ||
+		strings.Contains(message, "unauthorized")
+}
+
+// Made with Bob
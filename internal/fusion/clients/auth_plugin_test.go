@@ -0,0 +1,104 @@
+package clients
+
+import (
+	"fmt"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+type AuthPluginSuite struct {
+	suite.Suite
+	originalLookPath func(string) (string, error)
+}
+
+func (s *AuthPluginSuite) SetupTest() {
+	s.originalLookPath = lookPath
+	// Only aws is installed
+	lookPath = func(file string) (string, error) {
+		if file == "aws" {
+			return "/usr/local/bin/aws", nil
+		}
+		return "", exec.ErrNotFound
+	}
+}
+
+func (s *AuthPluginSuite) TearDownTest() {
+	lookPath = s.originalLookPath
+}
+
+// execClient returns a client authenticating with the exec plugin command
+func execClient(command string) *ClusterClient {
+	return &ClusterClient{Name: "eks-1", Config: &rest.Config{
+		Host:         "https://eks-1:443",
+		ExecProvider: &clientcmdapi.ExecConfig{Command: command, APIVersion: "client.authentication.k8s.io/v1"},
+	}}
+}
+
+func (s *AuthPluginSuite) TestAuthPluginFor() {
+	s.Run("finds the exec plugin on PATH", func() {
+		s.Equal(&AuthPlugin{Kind: AuthPluginExec, Name: "aws", Path: "/usr/local/bin/aws"}, AuthPluginFor(execClient("aws").Config))
+	})
+	s.Run("reports an exec plugin missing from PATH", func() {
+		s.Equal(&AuthPlugin{Kind: AuthPluginExec, Name: "gke-gcloud-auth-plugin"}, AuthPluginFor(execClient("gke-gcloud-auth-plugin").Config))
+	})
+	s.Run("reports an auth provider", func() {
+		config := &rest.Config{AuthProvider: &clientcmdapi.AuthProviderConfig{Name: "oidc"}}
+		s.Equal(&AuthPlugin{Kind: AuthPluginProvider, Name: "oidc"}, AuthPluginFor(config))
+	})
+	s.Run("is nil for static credentials", func() {
+		s.Nil(AuthPluginFor(&rest.Config{BearerToken: "token"}))
+		s.Nil(AuthPluginFor(nil))
+	})
+	s.Run("is set on clients built from an exec context", func() {
+		kubeconfig := "apiVersion: v1\nkind: Config\n" +
+			"clusters:\n- name: gke-1\n  cluster:\n    server: https://gke-1:443\n" +
+			"contexts:\n- name: gke-1\n  context:\n    cluster: gke-1\n    user: gke\n" +
+			"users:\n- name: gke\n  user:\n    exec:\n      apiVersion: client.authentication.k8s.io/v1beta1\n      command: gke-gcloud-auth-plugin\n"
+		client, err := NewRegistry().NewContextClientFromBytes([]byte(kubeconfig), "gke-1")
+		s.Require().NoError(err, "a missing command does not stop the registration")
+		s.Equal(&AuthPlugin{Kind: AuthPluginExec, Name: "gke-gcloud-auth-plugin"}, AuthPluginFor(client.Config))
+	})
+}
+
+func (s *AuthPluginSuite) TestExplainAuthError() {
+	unauthorized := apierrors.NewUnauthorized("token expired")
+	s.Run("names a failing exec plugin", func() {
+		err := ExplainAuthError(execClient("aws"), unauthorized)
+		s.EqualError(err, `cluster eks-1 authenticates with the exec plugin "aws" at /usr/local/bin/aws, which failed (run it to check the login of the server's user): token expired`)
+		s.True(apierrors.IsUnauthorized(err), "the cause is kept")
+		s.Equal(ErrorKindAuth, ClassifyError(err))
+		s.False(IsTransientError(err))
+	})
+	s.Run("tells an exec plugin is not on PATH", func() {
+		cause := fmt.Errorf(`Get "https://eks-1:443/version": getting credentials: exec: executable gke-gcloud-auth-plugin not found`)
+		err := ExplainAuthError(execClient("gke-gcloud-auth-plugin"), cause)
+		s.ErrorContains(err, `cluster eks-1 authenticates with the exec plugin "gke-gcloud-auth-plugin", which is not on the server's PATH`)
+		s.ErrorIs(err, cause)
+	})
+	s.Run("names a failing auth provider", func() {
+		client := &ClusterClient{Name: "oidc-1", Config: &rest.Config{AuthProvider: &clientcmdapi.AuthProviderConfig{Name: "oidc"}}}
+		s.ErrorContains(ExplainAuthError(client, unauthorized), "cluster oidc-1 authenticates with the oidc auth provider, which failed")
+	})
+	s.Run("explains once", func() {
+		err := ExplainAuthError(execClient("aws"), unauthorized)
+		s.Same(err, ExplainAuthError(execClient("aws"), err))
+	})
+	s.Run("keeps other errors", func() {
+		forbidden := apierrors.NewForbidden(schema.GroupResource{Resource: "nodes"}, "", fmt.Errorf("denied"))
+		s.Same(forbidden, ExplainAuthError(execClient("aws"), forbidden))
+		s.Same(unauthorized, ExplainAuthError(&ClusterClient{Name: "prod-1", Config: &rest.Config{BearerToken: "token"}}, unauthorized))
+		s.NoError(ExplainAuthError(execClient("aws"), nil))
+	})
+}
+
+func TestAuthPluginSuite(t *testing.T) {
+	suite.Run(t, new(AuthPluginSuite))
+}
+
+// Made with Bob
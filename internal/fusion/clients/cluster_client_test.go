@@ -0,0 +1,150 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/rest"
+)
+
+type ClusterClientSuite struct {
+	suite.Suite
+}
+
+var drPolicyGVR = schema.GroupVersionResource{Group: "ramendr.openshift.io", Version: "v1alpha1", Resource: "drpolicies"}
+
+func (s *ClusterClientSuite) TestGetCR() {
+	policy := &unstructured.Unstructured{}
+	policy.SetAPIVersion("ramendr.openshift.io/v1alpha1")
+	policy.SetKind("DRPolicy")
+	policy.SetName("metro")
+	backup := &unstructured.Unstructured{}
+	backup.SetAPIVersion("data-protection.isf.ibm.com/v1alpha1")
+	backup.SetKind("Backup")
+	backup.SetNamespace("ibm-spectrum-fusion-ns")
+	backup.SetName("nightly")
+	backupGVR := schema.GroupVersionResource{Group: "data-protection.isf.ibm.com", Version: "v1alpha1", Resource: "backups"}
+
+	client := &ClusterClient{
+		Name: "prod-1",
+		Dynamic: fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+			drPolicyGVR: "DRPolicyList",
+			backupGVR:   "BackupList",
+		}, policy, backup),
+	}
+
+	s.Run("gets a cluster-scoped resource", func() {
+		obj, err := client.GetCR(context.Background(), drPolicyGVR, "", "metro")
+		s.Require().NoError(err)
+		s.Equal("DRPolicy", obj.GetKind())
+	})
+	s.Run("gets a namespaced resource", func() {
+		obj, err := client.GetCR(context.Background(), backupGVR, "ibm-spectrum-fusion-ns", "nightly")
+		s.Require().NoError(err)
+		s.Equal("nightly", obj.GetName())
+	})
+	s.Run("returns the API error for a missing resource", func() {
+		_, err := client.GetCR(context.Background(), drPolicyGVR, "", "regional")
+		s.Equal(ErrorKindNotFound, ClassifyError(err))
+	})
+}
+
+func (s *ClusterClientSuite) TestDynamicClient() {
+	s.Run("builds one from Config when missing", func() {
+		client := &ClusterClient{Name: "prod-1", Config: &rest.Config{Host: "https://prod-1:6443"}}
+		dynamicClient, err := client.DynamicClient()
+		s.NoError(err)
+		s.NotNil(dynamicClient)
+	})
+	s.Run("fails without Config", func() {
+		_, err := (&ClusterClient{Name: "prod-1"}).DynamicClient()
+		s.ErrorContains(err, "prod-1")
+	})
+}
+
+func (s *ClusterClientSuite) TestResourceFor() {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Group: "ramendr.openshift.io", Version: "v1alpha1", Kind: "DRPolicy"}, meta.RESTScopeRoot)
+	client := &ClusterClient{Name: "prod-1", RESTMapper: mapper}
+
+	gvr, err := client.ResourceFor(schema.GroupVersionKind{Group: "ramendr.openshift.io", Version: "v1alpha1", Kind: "DRPolicy"})
+	s.Require().NoError(err)
+	s.Equal(drPolicyGVR, gvr)
+}
+
+func (s *ClusterClientSuite) TestNewClusterClient() {
+	client, err := newClusterClient("prod-1", "prod-1", &rest.Config{Host: "https://prod-1:6443"})
+	s.Require().NoError(err)
+	s.NotNil(client.Clientset)
+	s.NotNil(client.Dynamic)
+	s.NotNil(client.RESTMapper)
+}
+
+func (s *ClusterClientSuite) TestImpersonate() {
+	var mu sync.Mutex
+	var users []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		users = append(users, r.Header.Get("Impersonate-User"))
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"kind":"Namespace","apiVersion":"v1","metadata":{"name":"shop"}}`)
+	}))
+	defer server.Close()
+
+	wrapped := 0
+	config := &rest.Config{Host: server.URL}
+	config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			wrapped++
+			mu.Unlock()
+			return rt.RoundTrip(req)
+		})
+	})
+	base, err := newClusterClient("prod-1", "prod-1", config)
+	s.Require().NoError(err)
+
+	impersonated, err := base.Impersonate("jane", []string{"payments-admins"})
+	s.Require().NoError(err)
+	_, err = impersonated.Clientset.CoreV1().Namespaces().Get(context.Background(), "shop", metav1.GetOptions{})
+	s.Require().NoError(err)
+	_, err = base.Clientset.CoreV1().Namespaces().Get(context.Background(), "shop", metav1.GetOptions{})
+	s.Require().NoError(err)
+
+	s.Run("the derived client acts as the user", func() {
+		s.Equal("jane", users[0])
+		s.Equal("prod-1", impersonated.Name)
+		s.Equal([]string{"payments-admins"}, impersonated.Config.Impersonate.Groups)
+	})
+	s.Run("the base client is unchanged", func() {
+		s.Empty(users[1])
+		s.Same(config, base.Config)
+		s.Empty(base.Config.Impersonate.UserName)
+		s.Empty(base.Config.Impersonate.Groups)
+	})
+	s.Run("the derived client keeps the wrapped round trippers", func() {
+		s.Equal(2, wrapped)
+	})
+	s.Run("a user is required", func() {
+		_, err := base.Impersonate("", []string{"payments-admins"})
+		s.EqualError(err, "impersonating cluster prod-1 requires a user")
+	})
+}
+
+func TestClusterClientSuite(t *testing.T) {
+	suite.Run(t, new(ClusterClientSuite))
+}
+
+// Made with Bob
@@ -0,0 +1,113 @@
+package clients
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// maxDiagnosticFileSize is the size at which the diagnostic file is rotated
+const maxDiagnosticFileSize = 10 * 1024 * 1024
+
+var (
+	diagnosticFile     *rotatingFile
+	diagnosticFileOnce sync.Once
+)
+
+// getDiagnosticFile returns the file named by the FUSION_LOG_BODY_FILE environment
+// variable, or nil when it is unset or cannot be written.
+func getDiagnosticFile() *rotatingFile {
+	diagnosticFileOnce.Do(func() {
+		path := strings.TrimSpace(os.Getenv("FUSION_LOG_BODY_FILE"))
+		if path == "" {
+			return
+		}
+		file, err := newRotatingFile(path, maxDiagnosticFileSize)
+		if err != nil {
+			klog.Warningf("[diagnostic] cannot write FUSION_LOG_BODY_FILE, logging to klog only: %v", err)
+			return
+		}
+		diagnosticFile = file
+	})
+	return diagnosticFile
+}
+
+// diagnosticf logs a diagnostic line at the FUSION_LOG_BODY_VLEVEL verbosity and tees it
+// to the diagnostic file
+func diagnosticf(format string, args ...interface{}) {
+	klog.V(getLogVLevel()).Infof(format, args...)
+	if file := getDiagnosticFile(); file != nil {
+		file.writeLine(fmt.Sprintf(format, args...))
+	}
+}
+
+// rotatingFile is a size-capped log file keeping a single backup (<path>.1)
+// It is safe for concurrent use.
+type rotatingFile struct {
+	mu      sync.Mutex
+	path    string
+	maxSize int64
+	file    *os.File
+	size    int64
+}
+
+// newRotatingFile opens path for appending, failing if it cannot be written
+func newRotatingFile(path string, maxSize int64) (*rotatingFile, error) {
+	r := &rotatingFile{path: path, maxSize: maxSize}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// open opens the file for appending, the caller must hold the lock unless r is not shared yet
+func (r *rotatingFile) open() error {
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	r.file = file
+	r.size = info.Size()
+	return nil
+}
+
+// writeLine appends a timestamped line, rotating first when it would exceed the cap
+// Write errors are dropped, diagnostics must never fail requests
+func (r *rotatingFile) writeLine(line string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry := time.Now().UTC().Format(time.RFC3339Nano) + " " + line + "\n"
+	if r.size > 0 && r.size+int64(len(entry)) > r.maxSize {
+		r.rotate()
+	}
+	if r.file == nil {
+		return
+	}
+	n, _ := r.file.WriteString(entry)
+	r.size += int64(n)
+}
+
+// rotate moves the current file to the backup, replacing any previous backup
+// The caller must hold the lock
+func (r *rotatingFile) rotate() {
+	if r.file != nil {
+		_ = r.file.Close()
+		r.file = nil
+	}
+	_ = os.Rename(r.path, r.path+".1")
+	if err := r.open(); err != nil {
+		klog.V(6).Infof("[diagnostic] failed to reopen %s after rotation: %v", r.path, err)
+	}
+}
+
+// Made with Bob
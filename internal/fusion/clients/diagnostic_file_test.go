@@ -0,0 +1,78 @@
+package clients
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DiagnosticFileSuite struct {
+	suite.Suite
+}
+
+func (s *DiagnosticFileSuite) TestRotatingFile() {
+	s.Run("appends timestamped lines", func() {
+		path := filepath.Join(s.T().TempDir(), "diagnostic.log")
+		file, err := newRotatingFile(path, 1024)
+		s.Require().NoError(err)
+		file.writeLine("[diagnostic] GET https://prod-1:6443/api -> 200")
+
+		content, err := os.ReadFile(path)
+		s.Require().NoError(err)
+		s.Contains(string(content), " [diagnostic] GET https://prod-1:6443/api -> 200\n")
+	})
+	s.Run("rotates to a single backup at the size cap", func() {
+		path := filepath.Join(s.T().TempDir(), "diagnostic.log")
+		file, err := newRotatingFile(path, 200)
+		s.Require().NoError(err)
+		for i := 0; i < 20; i++ {
+			file.writeLine(fmt.Sprintf("line %02d %s", i, strings.Repeat("x", 40)))
+		}
+
+		current, err := os.Stat(path)
+		s.Require().NoError(err)
+		s.LessOrEqual(current.Size(), int64(200))
+		backup, err := os.Stat(path + ".1")
+		s.Require().NoError(err)
+		s.LessOrEqual(backup.Size(), int64(200))
+		s.NoFileExists(path + ".2")
+
+		content, _ := os.ReadFile(path)
+		s.Contains(string(content), "line 19")
+	})
+	s.Run("is safe for concurrent writers", func() {
+		path := filepath.Join(s.T().TempDir(), "diagnostic.log")
+		file, err := newRotatingFile(path, 1024*1024)
+		s.Require().NoError(err)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				file.writeLine(fmt.Sprintf("request %d", i))
+			}(i)
+		}
+		wg.Wait()
+
+		content, err := os.ReadFile(path)
+		s.Require().NoError(err)
+		lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+		s.Len(lines, 50, "every line is written whole")
+	})
+	s.Run("fails when the path is not writable", func() {
+		_, err := newRotatingFile(filepath.Join(s.T().TempDir(), "missing", "diagnostic.log"), 1024)
+		s.Error(err)
+	})
+}
+
+func TestDiagnosticFileSuite(t *testing.T) {
+	suite.Run(t, new(DiagnosticFileSuite))
+}
+
+// Made with Bob
@@ -7,16 +7,28 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"k8s.io/klog/v2"
 )
 
 const (
-	// maxBodyReadSize is the maximum number of bytes read from a response body for logging.
-	maxBodyReadSize = 16 * 1024
+	// defaultMaxBodyReadSize is the default number of bytes read from a response body for logging.
+	defaultMaxBodyReadSize = 16 * 1024
+	// minBodyReadSize and maxBodyReadSize bound FUSION_LOG_BODY_MAX_BYTES.
+	minBodyReadSize = 1024
+	maxBodyReadSize = 4 * 1024 * 1024
+	// defaultLogVLevel is the default klog verbosity of diagnostic output.
+	defaultLogVLevel = 6
+	// maxLogVLevel bounds FUSION_LOG_BODY_VLEVEL.
+	maxLogVLevel = 10
 	// maxHexDumpSize is the maximum number of bytes shown in a hex dump for protobuf responses.
 	maxHexDumpSize = 256
 )
@@ -24,50 +36,204 @@ const (
 var (
 	logBodyMode     string
 	logBodyModeOnce sync.Once
+
+	logHeaders     bool
+	logHeadersOnce sync.Once
+
+	logBodyMaxBytes     int
+	logBodyMaxBytesOnce sync.Once
+
+	logVLevel     klog.Level
+	logVLevelOnce sync.Once
 )
 
+// redacted replaces sensitive values in diagnostic output
+const redacted = "REDACTED"
+
+// sensitiveHeaders are never logged with their value
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+	"X-Auth-Token":        true,
+	"X-Csrf-Token":        true,
+}
+
+// sensitiveQueryParams are masked in logged URLs
+var sensitiveQueryParams = map[string]bool{
+	"token":         true,
+	"access_token":  true,
+	"id_token":      true,
+	"refresh_token": true,
+	"password":      true,
+	"secret":        true,
+	"client_secret": true,
+}
+
+// bearerTokenPattern matches bearer tokens wherever they appear in logged text
+var bearerTokenPattern = regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9\-._~+/]+=*`)
+
 // getLogBodyMode returns the diagnostic body logging mode from the FUSION_LOG_BODY
-// environment variable. Valid values are "none" (default), "summary", and "full".
+// environment variable. Valid values are "none" (default), "summary", "full", and "errors",
+// which logs the summary of failed (4xx and 5xx) responses only.
 func getLogBodyMode() string {
 	logBodyModeOnce.Do(func() {
 		logBodyMode = strings.ToLower(strings.TrimSpace(os.Getenv("FUSION_LOG_BODY")))
-		if logBodyMode == "" {
+		switch logBodyMode {
+		case "summary", "full", "errors":
+		default:
 			logBodyMode = "none"
 		}
 	})
 	return logBodyMode
 }
 
+// getLogBodyMaxBytes returns how many bytes of a response body are read for logging, from
+// the FUSION_LOG_BODY_MAX_BYTES environment variable.
+func getLogBodyMaxBytes() int {
+	logBodyMaxBytesOnce.Do(func() {
+		logBodyMaxBytes = parseLogBodyMaxBytes(os.Getenv("FUSION_LOG_BODY_MAX_BYTES"))
+	})
+	return logBodyMaxBytes
+}
+
+// parseLogBodyMaxBytes parses a body cap, clamped to [minBodyReadSize, maxBodyReadSize]
+// Empty or invalid values give the default.
+func parseLogBodyMaxBytes(val string) int {
+	size, err := strconv.Atoi(strings.TrimSpace(val))
+	if err != nil {
+		return defaultMaxBodyReadSize
+	}
+	return min(max(size, minBodyReadSize), maxBodyReadSize)
+}
+
+// getLogVLevel returns the klog verbosity of diagnostic output, from the
+// FUSION_LOG_BODY_VLEVEL environment variable.
+func getLogVLevel() klog.Level {
+	logVLevelOnce.Do(func() {
+		logVLevel = parseLogVLevel(os.Getenv("FUSION_LOG_BODY_VLEVEL"))
+	})
+	return logVLevel
+}
+
+// parseLogVLevel parses a klog verbosity, clamped to [0, maxLogVLevel]
+// Empty or invalid values give the default.
+func parseLogVLevel(val string) klog.Level {
+	level, err := strconv.Atoi(strings.TrimSpace(val))
+	if err != nil {
+		return defaultLogVLevel
+	}
+	return klog.Level(min(max(level, 0), maxLogVLevel))
+}
+
+// getLogHeaders reports whether the FUSION_LOG_HEADERS environment variable enables
+// logging of the (redacted) request and response headers.
+func getLogHeaders() bool {
+	logHeadersOnce.Do(func() {
+		logHeaders, _ = strconv.ParseBool(strings.TrimSpace(os.Getenv("FUSION_LOG_HEADERS")))
+	})
+	return logHeaders
+}
+
+// redactURL returns the URL with credentials and sensitive query parameters masked
+func redactURL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	clean := *u
+	if clean.User != nil {
+		clean.User = url.User(clean.User.Username())
+	}
+	if clean.RawQuery != "" {
+		query := clean.Query()
+		for name := range query {
+			if sensitiveQueryParams[strings.ToLower(name)] {
+				query.Set(name, redacted)
+			}
+		}
+		clean.RawQuery = query.Encode()
+	}
+	return clean.String()
+}
+
+// redactHeaders formats headers in a stable order with sensitive values masked
+func redactHeaders(header http.Header) string {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		canonical := http.CanonicalHeaderKey(name)
+		for _, value := range header[name] {
+			if sensitiveHeaders[canonical] || strings.HasPrefix(canonical, "Impersonate-Extra-") {
+				value = redacted
+			}
+			fmt.Fprintf(&b, "\n  %s: %s", canonical, redactText(value))
+		}
+	}
+	return b.String()
+}
+
+// redactText masks bearer tokens in free-form text such as response bodies
+func redactText(s string) string {
+	return bearerTokenPattern.ReplaceAllString(s, "${1}"+redacted)
+}
+
 // DiagnosticRoundTripper wraps an http.RoundTripper and logs request/response
-// diagnostics at klog V(6). The logging detail is controlled by the
-// FUSION_LOG_BODY environment variable (none, summary, full).
+// diagnostics at klog V(6), also written to FUSION_LOG_BODY_FILE when set. The logging
+// detail is controlled by the FUSION_LOG_BODY environment variable (none, summary, full,
+// errors), and FUSION_LOG_HEADERS adds the request and response headers. Credentials are
+// always redacted.
+// When FUSION_METRICS_ENABLED is true every request is also recorded in RoundTripMetrics.
 type DiagnosticRoundTripper struct {
 	delegate http.RoundTripper
 }
 
 func (d *DiagnosticRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
 	mode := getLogBodyMode()
-	if mode == "none" {
+	headers := getLogHeaders()
+	metrics := getRoundTripMetrics()
+	if mode == "none" && !headers && metrics == nil {
 		return d.delegate.RoundTrip(req)
 	}
 
+	start := time.Now()
 	resp, err := d.delegate.RoundTrip(req)
+	if metrics != nil {
+		metrics.observe(req, resp, err, time.Since(start))
+	}
 	if err != nil {
 		return resp, err
 	}
 
-	d.logDiagnostics(req, resp, mode)
+	if headers {
+		diagnosticf("[diagnostic] %s %s -> %d request headers:%s\nresponse headers:%s",
+			req.Method, redactURL(req.URL), resp.StatusCode, redactHeaders(req.Header), redactHeaders(resp.Header))
+	}
+	if mode != "none" {
+		d.logDiagnostics(req, resp, mode)
+	}
 	return resp, nil
 }
 
 func (d *DiagnosticRoundTripper) logDiagnostics(req *http.Request, resp *http.Response, mode string) {
+	// Successful responses are not logged, nor their body read, in errors mode
+	if mode == "errors" && resp.StatusCode < 400 {
+		return
+	}
+
 	contentType := resp.Header.Get("Content-Type")
 	isProtobuf := strings.Contains(contentType, "protobuf")
 
 	// Read body up to the cap
-	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodyReadSize))
+	maxBytes := getLogBodyMaxBytes()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, int64(maxBytes)))
 	if err != nil {
-		klog.V(6).Infof("[diagnostic] %s %s -> %d (failed to read body: %v)", req.Method, req.URL, resp.StatusCode, err)
+		diagnosticf("[diagnostic] %s %s -> %d (failed to read body: %v)", req.Method, redactURL(req.URL), resp.StatusCode, err)
 		return
 	}
 	// Read any remaining bytes to detect truncation, then restore the full body
@@ -77,26 +243,27 @@ func (d *DiagnosticRoundTripper) logDiagnostics(req *http.Request, resp *http.Re
 	bodySize := int64(len(body)) + int64(len(remaining))
 
 	switch mode {
-	case "summary":
+	case "summary", "errors":
 		d.logSummary(req, resp, contentType, isProtobuf, body, bodySize)
 	case "full":
 		d.logSummary(req, resp, contentType, isProtobuf, body, bodySize)
-		d.logFullBody(isProtobuf, body)
+		d.logFullBody(isProtobuf, body, bodySize, maxBytes)
 	}
 }
 
 func (d *DiagnosticRoundTripper) logSummary(req *http.Request, resp *http.Response, contentType string, isProtobuf bool, body []byte, bodySize int64) {
+	requestURL := redactURL(req.URL)
 	if isProtobuf {
-		klog.V(6).Infof("[diagnostic] %s %s -> %d Content-Type=%s protobuf %d bytes",
-			req.Method, req.URL, resp.StatusCode, contentType, bodySize)
+		diagnosticf("[diagnostic] %s %s -> %d Content-Type=%s protobuf %d bytes",
+			req.Method, requestURL, resp.StatusCode, contentType, bodySize)
 		return
 	}
 
 	// Try to extract JSON metadata
 	var obj map[string]interface{}
 	if err := json.Unmarshal(body, &obj); err != nil {
-		klog.V(6).Infof("[diagnostic] %s %s -> %d Content-Type=%s %d bytes (non-JSON or parse error)",
-			req.Method, req.URL, resp.StatusCode, contentType, bodySize)
+		diagnosticf("[diagnostic] %s %s -> %d Content-Type=%s %d bytes (non-JSON or parse error)",
+			req.Method, requestURL, resp.StatusCode, contentType, bodySize)
 		return
 	}
 
@@ -113,35 +280,39 @@ func (d *DiagnosticRoundTripper) logSummary(req *http.Request, resp *http.Respon
 	}
 
 	if itemCount >= 0 {
-		klog.V(6).Infof("[diagnostic] %s %s -> %d Content-Type=%s kind=%s apiVersion=%s resourceVersion=%s items=%d %d bytes",
-			req.Method, req.URL, resp.StatusCode, contentType, kind, apiVersion, resourceVersion, itemCount, bodySize)
+		diagnosticf("[diagnostic] %s %s -> %d Content-Type=%s kind=%s apiVersion=%s resourceVersion=%s items=%d %d bytes",
+			req.Method, requestURL, resp.StatusCode, contentType, kind, apiVersion, resourceVersion, itemCount, bodySize)
 	} else {
-		klog.V(6).Infof("[diagnostic] %s %s -> %d Content-Type=%s kind=%s apiVersion=%s resourceVersion=%s %d bytes",
-			req.Method, req.URL, resp.StatusCode, contentType, kind, apiVersion, resourceVersion, bodySize)
+		diagnosticf("[diagnostic] %s %s -> %d Content-Type=%s kind=%s apiVersion=%s resourceVersion=%s %d bytes",
+			req.Method, requestURL, resp.StatusCode, contentType, kind, apiVersion, resourceVersion, bodySize)
 	}
 }
 
-func (d *DiagnosticRoundTripper) logFullBody(isProtobuf bool, body []byte) {
+func (d *DiagnosticRoundTripper) logFullBody(isProtobuf bool, body []byte, bodySize int64, maxBytes int) {
 	if isProtobuf {
 		dumpSize := len(body)
 		if dumpSize > maxHexDumpSize {
 			dumpSize = maxHexDumpSize
 		}
-		klog.V(6).Infof("[diagnostic] body (hex, first %d bytes):\n%s", dumpSize, hex.Dump(body[:dumpSize]))
+		diagnosticf("[diagnostic] body (hex, first %d bytes):\n%s", dumpSize, hex.Dump(body[:dumpSize]))
 		return
 	}
 
 	var pretty bytes.Buffer
 	if err := json.Indent(&pretty, body, "", "  "); err != nil {
-		klog.V(6).Infof("[diagnostic] body (raw, %d bytes):\n%s", len(body), truncateString(string(body), maxBodyReadSize))
+		diagnosticf("[diagnostic] body (raw, %d bytes):\n%s", bodySize, redactText(truncateString(string(body), maxBytes, bodySize)))
 		return
 	}
-	klog.V(6).Infof("[diagnostic] body (json, %d bytes):\n%s", len(body), truncateString(pretty.String(), maxBodyReadSize))
+	diagnosticf("[diagnostic] body (json, %d bytes):\n%s", bodySize, redactText(truncateString(pretty.String(), maxBytes, bodySize)))
 }
 
-func truncateString(s string, maxLen int) string {
-	if len(s) <= maxLen {
+// truncateString caps s at maxLen, reporting the total size of the response body it came from
+func truncateString(s string, maxLen int, totalSize int64) string {
+	if len(s) <= maxLen && int64(len(s)) >= totalSize {
 		return s
 	}
-	return s[:maxLen] + fmt.Sprintf("... (truncated, %d total bytes)", len(s))
+	if len(s) > maxLen {
+		s = s[:maxLen]
+	}
+	return s + fmt.Sprintf("... (truncated, %d total bytes)", totalSize)
 }
@@ -0,0 +1,131 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery"
+)
+
+// DefaultDiscoveryTTL is how long discovered API resources of a cluster are reused
+const DefaultDiscoveryTTL = 30 * time.Second
+
+// DiscoveryCache memoizes API discovery per cluster, keyed on the API server host,
+// so repeated CRD probes against the same cluster share one discovery round-trip
+type DiscoveryCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]*discoveryEntry
+	fetch   func(client *ClusterClient) ([]*metav1.APIResourceList, error)
+}
+
+// discoveryEntry holds the discovered resources of one API server, done is closed
+// once the discovery finished
+type discoveryEntry struct {
+	done      chan struct{}
+	resources []*metav1.APIResourceList
+	err       error
+	expires   time.Time
+}
+
+// NewDiscoveryCache creates a discovery cache whose entries expire after ttl
+func NewDiscoveryCache(ttl time.Duration) *DiscoveryCache {
+	return &DiscoveryCache{
+		ttl:     ttl,
+		entries: make(map[string]*discoveryEntry),
+		fetch:   discoverServerResources,
+	}
+}
+
+// ServerResources returns the API resources served by the cluster, running discovery
+// only when no unexpired entry exists for its API server. Concurrent callers for the
+// same cluster wait for a single discovery. Failed discoveries are not cached.
+// Discovery itself takes no context: a caller whose ctx is done stops waiting right away
+// while the discovery finishes in the background, bounded by the client timeout.
+func (c *DiscoveryCache) ServerResources(ctx context.Context, client *ClusterClient) ([]*metav1.APIResourceList, error) {
+	if client.Config == nil {
+		return nil, fmt.Errorf("cluster %s has no REST config", client.Name)
+	}
+	host := client.Config.Host
+
+	c.mu.Lock()
+	entry, ok := c.entries[host]
+	if !ok || (!entry.expires.IsZero() && time.Now().After(entry.expires)) {
+		entry = &discoveryEntry{done: make(chan struct{})}
+		c.entries[host] = entry
+		go c.discover(host, entry, client)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-entry.done:
+		return entry.resources, entry.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// discover runs the discovery of an entry, dropping the entry again when it fails
+func (c *DiscoveryCache) discover(host string, entry *discoveryEntry, client *ClusterClient) {
+	resources, err := c.fetch(client)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry.resources, entry.err = resources, err
+	if err != nil {
+		if c.entries[host] == entry {
+			delete(c.entries, host)
+		}
+	} else {
+		entry.expires = time.Now().Add(c.ttl)
+	}
+	close(entry.done)
+}
+
+// Invalidate drops the cached discovery of an API server host
+func (c *DiscoveryCache) Invalidate(host string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, host)
+}
+
+// Clear drops every cached discovery
+func (c *DiscoveryCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]*discoveryEntry)
+}
+
+// discoverServerResources runs a full API discovery against the cluster
+func discoverServerResources(client *ClusterClient) ([]*metav1.APIResourceList, error) {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(client.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discovery client for cluster %s: %w", client.Name, err)
+	}
+	_, resources, err := discoveryClient.ServerGroupsAndResources()
+	if err != nil {
+		return nil, err
+	}
+	return resources, nil
+}
+
+// Shared discovery cache used by all registries
+var globalDiscoveryCache = NewDiscoveryCache(DefaultDiscoveryTTL)
+
+// ServerResources returns the cached API resources of a cluster
+func ServerResources(ctx context.Context, client *ClusterClient) ([]*metav1.APIResourceList, error) {
+	return globalDiscoveryCache.ServerResources(ctx, client)
+}
+
+// InvalidateDiscovery drops the cached discovery of a cluster, for example after
+// it was re-registered with a different configuration
+func InvalidateDiscovery(client *ClusterClient) {
+	if client == nil || client.Config == nil {
+		return
+	}
+	globalDiscoveryCache.Invalidate(client.Config.Host)
+}
+
+// Made with Bob
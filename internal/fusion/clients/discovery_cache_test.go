@@ -0,0 +1,108 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+type DiscoveryCacheSuite struct {
+	suite.Suite
+}
+
+// countingCache returns a cache whose discovery is counted instead of hitting an API server
+func countingCache(ttl time.Duration, calls *atomic.Int32, err error) *DiscoveryCache {
+	cache := NewDiscoveryCache(ttl)
+	cache.fetch = func(client *ClusterClient) ([]*metav1.APIResourceList, error) {
+		calls.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		if err != nil {
+			return nil, err
+		}
+		return []*metav1.APIResourceList{{GroupVersion: "v1"}}, nil
+	}
+	return cache
+}
+
+func (s *DiscoveryCacheSuite) TestServerResources() {
+	client := &ClusterClient{Name: "prod-1", Config: &rest.Config{Host: "https://prod-1:6443"}}
+
+	s.Run("concurrent callers share one discovery", func() {
+		var calls atomic.Int32
+		cache := countingCache(time.Minute, &calls, nil)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				resources, err := cache.ServerResources(context.Background(), client)
+				s.NoError(err)
+				s.Len(resources, 1)
+			}()
+		}
+		wg.Wait()
+		s.Equal(int32(1), calls.Load())
+	})
+
+	s.Run("invalidate forces a new discovery", func() {
+		var calls atomic.Int32
+		cache := countingCache(time.Minute, &calls, nil)
+
+		_, _ = cache.ServerResources(context.Background(), client)
+		cache.Invalidate(client.Config.Host)
+		_, _ = cache.ServerResources(context.Background(), client)
+		s.Equal(int32(2), calls.Load())
+	})
+
+	s.Run("expired entries are refreshed", func() {
+		var calls atomic.Int32
+		cache := countingCache(time.Millisecond, &calls, nil)
+
+		_, _ = cache.ServerResources(context.Background(), client)
+		time.Sleep(5 * time.Millisecond)
+		_, _ = cache.ServerResources(context.Background(), client)
+		s.Equal(int32(2), calls.Load())
+	})
+
+	s.Run("a cancelled caller stops waiting for the discovery", func() {
+		cache := NewDiscoveryCache(time.Minute)
+		release := make(chan struct{})
+		defer close(release)
+		cache.fetch = func(client *ClusterClient) ([]*metav1.APIResourceList, error) {
+			<-release
+			return nil, nil
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(10*time.Millisecond, cancel)
+		start := time.Now()
+		_, err := cache.ServerResources(ctx, client)
+		s.ErrorIs(err, context.Canceled)
+		s.Less(time.Since(start), time.Second)
+	})
+
+	s.Run("failed discoveries are not cached", func() {
+		var calls atomic.Int32
+		cache := countingCache(time.Minute, &calls, fmt.Errorf("connection refused"))
+
+		_, err := cache.ServerResources(context.Background(), client)
+		s.Error(err)
+		_, err = cache.ServerResources(context.Background(), client)
+		s.Error(err)
+		s.Equal(int32(2), calls.Load())
+	})
+}
+
+func TestDiscoveryCacheSuite(t *testing.T) {
+	suite.Run(t, new(DiscoveryCacheSuite))
+}
+
+// Made with Bob
@@ -0,0 +1,81 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"syscall"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+// ErrorKind classifies why an operation failed on a cluster, so clients can tell
+// a missing permission from an unreachable cluster and decide whether to retry
+type ErrorKind string
+
+const (
+	// ErrorKindTimeout means the operation ran out of time
+	ErrorKindTimeout ErrorKind = "timeout"
+	// ErrorKindForbidden means the credentials are missing or lack permission
+	ErrorKindForbidden ErrorKind = "forbidden"
+	// ErrorKindAuth means the auth plugin of the cluster could not provide credentials
+	// or the API server rejected them, see AuthPluginError
+	ErrorKindAuth ErrorKind = "auth"
+	// ErrorKindNotFound means a resource, CRD or registered cluster does not exist
+	ErrorKindNotFound ErrorKind = "notfound"
+	// ErrorKindConnection means the API server could not be reached
+	ErrorKindConnection ErrorKind = "connection"
+	// ErrorKindUnknown is any other failure
+	ErrorKindUnknown ErrorKind = "unknown"
+)
+
+// ClassifyError determines the ErrorKind of an error, returning an empty kind for nil
+func ClassifyError(err error) ErrorKind {
+	if err == nil {
+		return ""
+	}
+
+	var netErr net.Error
+	var pluginErr *AuthPluginError
+	switch {
+	case errors.As(err, &pluginErr):
+		return ErrorKindAuth
+	case errors.Is(err, context.DeadlineExceeded), apierrors.IsTimeout(err), apierrors.IsServerTimeout(err):
+		return ErrorKindTimeout
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return ErrorKindTimeout
+	case apierrors.IsForbidden(err), apierrors.IsUnauthorized(err):
+		return ErrorKindForbidden
+	case apierrors.IsNotFound(err), meta.IsNoMatchError(err):
+		return ErrorKindNotFound
+	case errors.Is(err, syscall.ECONNREFUSED), errors.Is(err, syscall.ECONNRESET), errors.Is(err, syscall.EHOSTUNREACH):
+		return ErrorKindConnection
+	}
+
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	if errors.As(err, &opErr) || errors.As(err, &dnsErr) {
+		return ErrorKindConnection
+	}
+
+	// Errors flattened to strings along the way still carry their cause in the message
+	message := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(message, "authenticates with the"), strings.Contains(message, "getting credentials"):
+		return ErrorKindAuth
+	case strings.Contains(message, "deadline exceeded"), strings.Contains(message, "timeout"):
+		return ErrorKindTimeout
+	case strings.Contains(message, "forbidden"), strings.Contains(message, "unauthorized"):
+		return ErrorKindForbidden
+	case strings.Contains(message, "not found"), strings.Contains(message, "could not find the requested resource"):
+		return ErrorKindNotFound
+	case strings.Contains(message, "connection refused"), strings.Contains(message, "no such host"),
+		strings.Contains(message, "connection reset"), strings.Contains(message, "no route to host"):
+		return ErrorKindConnection
+	}
+	return ErrorKindUnknown
+}
+
+// Made with Bob
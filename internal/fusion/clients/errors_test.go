@@ -0,0 +1,50 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type ErrorsSuite struct {
+	suite.Suite
+}
+
+func (s *ErrorsSuite) TestClassifyError() {
+	resource := schema.GroupResource{Group: "ocs.openshift.io", Resource: "storageclusters"}
+	for _, tc := range []struct {
+		name string
+		err  error
+		kind ErrorKind
+	}{
+		{"nil", nil, ""},
+		{"context deadline", fmt.Errorf("list failed: %w", context.DeadlineExceeded), ErrorKindTimeout},
+		{"server timeout", apierrors.NewServerTimeout(resource, "list", 5), ErrorKindTimeout},
+		{"forbidden", fmt.Errorf("failed: %w", apierrors.NewForbidden(resource, "", fmt.Errorf("denied"))), ErrorKindForbidden},
+		{"unauthorized", apierrors.NewUnauthorized("token expired"), ErrorKindForbidden},
+		{"auth plugin", &AuthPluginError{Cluster: "eks-1", Plugin: AuthPlugin{Kind: AuthPluginExec, Name: "aws"}, Err: apierrors.NewUnauthorized("token expired")}, ErrorKindAuth},
+		{"flattened auth plugin", fmt.Errorf("Get \"https://eks-1/version\": getting credentials: exec: executable aws not found"), ErrorKindAuth},
+		{"not found", apierrors.NewNotFound(resource, "ocs-storagecluster"), ErrorKindNotFound},
+		{"connection refused", &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}, ErrorKindConnection},
+		{"dns", &net.DNSError{Err: "no such host", Name: "api.example.com"}, ErrorKindConnection},
+		{"flattened timeout", fmt.Errorf("context deadline exceeded"), ErrorKindTimeout},
+		{"unregistered cluster", fmt.Errorf("failed to get client: cluster edge-1 not found in registry"), ErrorKindNotFound},
+		{"other", fmt.Errorf("boom"), ErrorKindUnknown},
+	} {
+		s.Run(tc.name, func() {
+			s.Equal(tc.kind, ClassifyError(tc.err))
+		})
+	}
+}
+
+func TestErrorsSuite(t *testing.T) {
+	suite.Run(t, new(ErrorsSuite))
+}
+
+// Made with Bob
@@ -0,0 +1,29 @@
+package clients
+
+import (
+	"fmt"
+	"slices"
+
+	"k8s.io/client-go/rest"
+)
+
+// Impersonate returns a client of the same cluster acting as user and groups through
+// Kubernetes impersonation. The client is derived for one call: c is left unchanged and
+// the derived client keeps its round trippers, the DiagnosticRoundTripper included.
+func (c *ClusterClient) Impersonate(user string, groups []string) (*ClusterClient, error) {
+	if c.Config == nil {
+		return nil, fmt.Errorf("cluster %s has no client config to impersonate with", c.Name)
+	}
+	if user == "" {
+		return nil, fmt.Errorf("impersonating cluster %s requires a user", c.Name)
+	}
+
+	config := rest.CopyConfig(c.Config)
+	config.Impersonate = rest.ImpersonationConfig{
+		UserName: user,
+		Groups:   slices.Clone(groups),
+	}
+	return newClusterClient(c.Name, c.Context, config)
+}
+
+// Made with Bob
@@ -4,13 +4,15 @@ import (
 	"context"
 
 	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 )
 
 // KubernetesClient wraps the standard Kubernetes client for Fusion-specific operations
 type KubernetesClient struct {
-	client api.KubernetesClient
+	client kubernetes.Interface
 }
 
 // NewKubernetesClient creates a new Fusion Kubernetes client wrapper
@@ -20,17 +22,25 @@ func NewKubernetesClient(client api.KubernetesClient) *KubernetesClient {
 	}
 }
 
+// NewClusterKubernetesClient creates a Fusion Kubernetes client wrapper of a registered cluster
+func NewClusterKubernetesClient(client *ClusterClient) *KubernetesClient {
+	return &KubernetesClient{
+		client: client.Clientset,
+	}
+}
+
 // ListStorageClasses retrieves all storage classes in the cluster
 func (c *KubernetesClient) ListStorageClasses(ctx context.Context) (*storagev1.StorageClassList, error) {
 	return c.client.StorageV1().StorageClasses().List(ctx, metav1.ListOptions{})
 }
 
-// ListPVCs retrieves all PVCs in a given namespace
-func (c *KubernetesClient) ListPVCs(ctx context.Context, namespace string) (interface{}, error) {
+// ListPVCs retrieves a page of the PVCs in a given namespace, opts carries the page
+// limit and continue token
+func (c *KubernetesClient) ListPVCs(ctx context.Context, namespace string, opts metav1.ListOptions) (*corev1.PersistentVolumeClaimList, error) {
 	if namespace == "" {
 		namespace = metav1.NamespaceAll
 	}
-	return c.client.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+	return c.client.CoreV1().PersistentVolumeClaims(namespace).List(ctx, opts)
 }
 
 // Made with Bob
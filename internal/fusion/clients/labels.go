@@ -0,0 +1,47 @@
+package clients
+
+import (
+	"fmt"
+	"maps"
+)
+
+// ClusterLabels returns a copy of the labels of a cluster, nil when it has none
+// Labels are kept by the registry for selector targets, see PatchClusterLabels
+func (r *Registry) ClusterLabels(clusterName string) map[string]string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return maps.Clone(r.labels[clusterName])
+}
+
+// PatchClusterLabels applies a JSON merge patch to the labels of a registered cluster:
+// a value sets the label and nil removes it, other labels are kept. It returns the
+// labels after the patch. Labels stay with the cluster until it is unregistered.
+func (r *Registry) PatchClusterLabels(clusterName string, patch map[string]*string) (map[string]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.clients[clusterName]; !exists {
+		return nil, fmt.Errorf("cluster %s not found in registry", clusterName)
+	}
+
+	labels := maps.Clone(r.labels[clusterName])
+	if labels == nil {
+		labels = make(map[string]string, len(patch))
+	}
+	for key, value := range patch {
+		if value == nil {
+			delete(labels, key)
+			continue
+		}
+		labels[key] = *value
+	}
+	if len(labels) == 0 {
+		delete(r.labels, clusterName)
+	} else {
+		r.labels[clusterName] = labels
+	}
+	return maps.Clone(labels), nil
+}
+
+// Made with Bob
@@ -0,0 +1,85 @@
+package clients
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+type MetricsSuite struct {
+	suite.Suite
+	original *RoundTripMetrics
+}
+
+func (s *MetricsSuite) SetupTest() {
+	s.original = getRoundTripMetrics()
+	setLogModes("none", false)
+}
+
+func (s *MetricsSuite) TearDownTest() {
+	roundTripMetrics = s.original
+}
+
+func (s *MetricsSuite) send(rt http.RoundTripper, method, host string) {
+	req, err := http.NewRequest(method, "https://"+host+"/api/v1/nodes", nil)
+	s.Require().NoError(err)
+	if resp, err := rt.RoundTrip(req); err == nil {
+		_ = resp.Body.Close()
+	}
+}
+
+func (s *MetricsSuite) TestRoundTripMetrics() {
+	metrics := NewRoundTripMetrics()
+	roundTripMetrics = metrics
+
+	status := http.StatusOK
+	rt := &DiagnosticRoundTripper{delegate: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Host == "down:6443" {
+			return nil, errors.New("connection refused")
+		}
+		return &http.Response{StatusCode: status, Header: http.Header{}, Body: io.NopCloser(strings.NewReader("{}"))}, nil
+	})}
+
+	s.send(rt, http.MethodGet, "prod-1:6443")
+	s.send(rt, http.MethodGet, "prod-1:6443")
+	status = http.StatusForbidden
+	s.send(rt, http.MethodGet, "prod-1:6443")
+	s.send(rt, http.MethodGet, "down:6443")
+
+	s.Run("counts responses by cluster, method and code", func() {
+		s.Equal(float64(2), testutil.ToFloat64(metrics.responses.WithLabelValues("prod-1:6443", "GET", "200")))
+		s.Equal(float64(1), testutil.ToFloat64(metrics.responses.WithLabelValues("prod-1:6443", "GET", "403")))
+		s.Equal(float64(1), testutil.ToFloat64(metrics.responses.WithLabelValues("down:6443", "GET", "error")))
+	})
+	s.Run("observes the duration of every request", func() {
+		s.Equal(2, testutil.CollectAndCount(metrics.duration))
+	})
+	s.Run("registers as a collector", func() {
+		registry := prometheus.NewPedanticRegistry()
+		s.NoError(registry.Register(metrics))
+		s.Equal(5, testutil.CollectAndCount(metrics))
+	})
+}
+
+func (s *MetricsSuite) TestDisabled() {
+	roundTripMetrics = nil
+	var calls int
+	rt := &DiagnosticRoundTripper{delegate: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader("{}"))}, nil
+	})}
+	s.send(rt, http.MethodGet, "prod-1:6443")
+	s.Equal(1, calls)
+}
+
+func TestMetricsSuite(t *testing.T) {
+	suite.Run(t, new(MetricsSuite))
+}
+
+// Made with Bob
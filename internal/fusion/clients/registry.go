@@ -2,15 +2,40 @@ package clients
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
+	fusionconfig "github.com/containers/kubernetes-mcp-server/internal/fusion/config"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/klog/v2"
+)
+
+// MaxRequestTimeout bounds each HTTP request to a cluster. Operations are bounded by
+// their context deadline, the cluster timeout or a target override, this only keeps
+// requests without a deadline, such as discovery, from hanging forever.
+const MaxRequestTimeout = time.Duration(fusionconfig.MaxClusterTimeoutSeconds) * time.Second
+
+// DefaultMaxConcurrency is the default number of clusters operated on concurrently
+const DefaultMaxConcurrency = 16
+
+// Client-side rate limits applied to every registered cluster client. Status tools list
+// resources across many namespaces, so they are higher than client-go's 5 QPS / 10 burst.
+const (
+	DefaultQPS   float32 = 50
+	DefaultBurst int     = 100
 )
 
 // ClusterClient wraps a Kubernetes client with metadata
@@ -19,20 +44,62 @@ type ClusterClient struct {
 	Clientset kubernetes.Interface
 	Config    *rest.Config
 	Context   string
+
+	// Dynamic and RESTMapper give unstructured access to custom resources
+	// They share Config, so their requests also go through the DiagnosticRoundTripper
+	Dynamic    dynamic.Interface
+	RESTMapper meta.RESTMapper
+
+	// versions caches ServerVersion and OpenShiftVersion
+	versions versionCache
+}
+
+// ContextErrors maps the kubeconfig contexts that could not be registered to the reason
+type ContextErrors map[string]error
+
+// Error lists the failed contexts sorted by name
+func (e ContextErrors) Error() string {
+	names := make([]string, 0, len(e))
+	for name := range e {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	messages := make([]string, 0, len(names))
+	for _, name := range names {
+		messages = append(messages, fmt.Sprintf("context %s: %v", name, e[name]))
+	}
+	return strings.Join(messages, "; ")
 }
 
 // Registry manages multiple Kubernetes cluster clients
 type Registry struct {
-	clients map[string]*ClusterClient
-	mu      sync.RWMutex
-	timeout time.Duration
+	clients        map[string]*ClusterClient
+	labels         map[string]map[string]string
+	failed         ContextErrors
+	statuses       *StatusCache
+	mu             sync.RWMutex
+	timeout        time.Duration
+	maxConcurrency int
+	retry          RetryPolicy
+	qps            float32
+	burst          int
+
+	// source is the kubeconfig RegisterDefault loaded the registry from, see Reload
+	source *kubeconfigSource
 }
 
 // NewRegistry creates a new client registry
 func NewRegistry() *Registry {
 	return &Registry{
-		clients: make(map[string]*ClusterClient),
-		timeout: 30 * time.Second,
+		clients:        make(map[string]*ClusterClient),
+		labels:         make(map[string]map[string]string),
+		failed:         make(ContextErrors),
+		statuses:       NewStatusCache(),
+		timeout:        30 * time.Second,
+		maxConcurrency: DefaultMaxConcurrency,
+		retry:          DefaultRetryPolicy,
+		qps:            DefaultQPS,
+		burst:          DefaultBurst,
 	}
 }
 
@@ -43,56 +110,180 @@ func (r *Registry) SetTimeout(timeout time.Duration) {
 	r.timeout = timeout
 }
 
+// Timeout returns the default timeout for cluster operations
+func (r *Registry) Timeout() time.Duration {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.timeout
+}
+
+// SetMaxConcurrency sets how many clusters ExecuteOnAllClusters operates on at once
+// A value of 0 or less means unbounded
+func (r *Registry) SetMaxConcurrency(maxConcurrency int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.maxConcurrency = maxConcurrency
+}
+
+// MaxConcurrency returns how many clusters are operated on at once, 0 or less when unbounded
+func (r *Registry) MaxConcurrency() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.maxConcurrency
+}
+
+// SetRetryPolicy sets how cluster operations are retried on transient errors
+func (r *Registry) SetRetryPolicy(policy RetryPolicy) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.retry = policy
+}
+
+// RetryPolicy returns how cluster operations are retried on transient errors
+func (r *Registry) RetryPolicy() RetryPolicy {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.retry
+}
+
+// SetRateLimit sets the client-side QPS and burst of clients registered afterwards
+// Clients already in the registry keep the limits they were built with
+func (r *Registry) SetRateLimit(qps float32, burst int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.qps = qps
+	r.burst = burst
+}
+
+// RateLimit returns the client-side QPS and burst applied to new clients
+func (r *Registry) RateLimit() (float32, int) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.qps, r.burst
+}
+
 // RegisterInCluster registers the in-cluster configuration
 func (r *Registry) RegisterInCluster() error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	config, err := rest.InClusterConfig()
+	config, err := inClusterConfig()
 	if err != nil {
 		return fmt.Errorf("failed to get in-cluster config: %w", err)
 	}
 	config.AcceptContentTypes = "application/json"
 	config.ContentType = "application/json"
+	config.Timeout = MaxRequestTimeout
+	config.QPS = r.qps
+	config.Burst = r.burst
 	config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
 		return &DiagnosticRoundTripper{delegate: rt}
 	})
 
-	clientset, err := kubernetes.NewForConfig(config)
+	client, err := newClusterClient("in-cluster", "in-cluster", config)
 	if err != nil {
-		return fmt.Errorf("failed to create clientset: %w", err)
-	}
-
-	r.clients["in-cluster"] = &ClusterClient{
-		Name:      "in-cluster",
-		Clientset: clientset,
-		Config:    config,
-		Context:   "in-cluster",
+		return err
 	}
 
+	r.setClient(client)
 	return nil
 }
 
 // RegisterFromKubeconfig registers clients from a kubeconfig file
-func (r *Registry) RegisterFromKubeconfig(kubeconfigPath string) error {
+// A context that cannot be registered does not stop the others, it is returned in the
+// ContextErrors; the error is only set when the kubeconfig itself cannot be loaded
+func (r *Registry) RegisterFromKubeconfig(kubeconfigPath string) (ContextErrors, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	// Load kubeconfig
 	config, err := clientcmd.LoadFromFile(kubeconfigPath)
 	if err != nil {
-		return fmt.Errorf("failed to load kubeconfig: %w", err)
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
 	}
 
-	// Register each context
+	_, failed := r.registerContexts(config)
+	return failed, nil
+}
+
+// RegisterFromKubeconfigBytes registers clients from kubeconfig content, so callers with
+// an inline kubeconfig need no temporary file. It returns the names of the registered
+// contexts, sorted, and the contexts that could not be registered. The content is trusted:
+// files it references are read on this host, tool callers go through
+// services.ClusterService.RegisterContent, which refuses them.
+func (r *Registry) RegisterFromKubeconfigBytes(kubeconfig []byte) ([]string, ContextErrors, error) {
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	registered, failed := r.registerContexts(config)
+	return registered, failed, nil
+}
+
+// RegisterFromKubeconfigReader registers clients from kubeconfig content read from
+// reader, see RegisterFromKubeconfigBytes
+func (r *Registry) RegisterFromKubeconfigReader(reader io.Reader) ([]string, ContextErrors, error) {
+	kubeconfig, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read kubeconfig: %w", err)
+	}
+	return r.RegisterFromKubeconfigBytes(kubeconfig)
+}
+
+// RegisterFromKubeconfigPaths registers clients from several kubeconfig files, merged
+// with the same rules kubectl applies to a colon-separated KUBECONFIG, see
+// RegisterFromKubeconfig
+func (r *Registry) RegisterFromKubeconfigPaths(kubeconfigPaths []string) (ContextErrors, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	loadingRules := &clientcmd.ClientConfigLoadingRules{Precedence: kubeconfigPaths}
+	config, err := loadingRules.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	_, failed := r.registerContexts(config)
+	return failed, nil
+}
+
+// registerContexts registers every context of a kubeconfig and returns the names of the
+// registered ones, sorted, and the contexts that failed, nil when none did. The failures
+// are also kept for FailedContexts.
+// Callers must hold the write lock
+func (r *Registry) registerContexts(config *api.Config) ([]string, ContextErrors) {
+	registered := make([]string, 0, len(config.Contexts))
+	var failed ContextErrors
 	for contextName, context := range config.Contexts {
 		if err := r.registerContext(config, contextName, context); err != nil {
-			// Log error but continue with other contexts
+			// Keep going with the other contexts
+			if failed == nil {
+				failed = make(ContextErrors)
+			}
+			failed[contextName] = err
+			r.failed[contextName] = err
 			continue
 		}
+		registered = append(registered, contextName)
 	}
+	sort.Strings(registered)
+	return registered, failed
+}
 
-	return nil
+// FailedContexts returns the kubeconfig contexts that could not be registered and have
+// not been registered since
+func (r *Registry) FailedContexts() ContextErrors {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	failed := make(ContextErrors, len(r.failed))
+	for name, err := range r.failed {
+		failed[name] = err
+	}
+	return failed
 }
 
 // RegisterContext registers a specific context from kubeconfig
@@ -115,6 +306,79 @@ func (r *Registry) RegisterContext(kubeconfigPath, contextName string) error {
 
 // registerContext is an internal helper to register a context
 func (r *Registry) registerContext(config *api.Config, contextName string, context *api.Context) error {
+	client, err := newContextClient(config, contextName, r.qps, r.burst)
+	if err != nil {
+		return err
+	}
+
+	r.setClient(client)
+	r.untrack(contextName)
+	return nil
+}
+
+// setClient stores a client, dropping cached discovery of any client it replaces
+// Callers must hold the write lock
+func (r *Registry) setClient(client *ClusterClient) {
+	InvalidateDiscovery(r.clients[client.Name])
+	InvalidateDiscovery(client)
+	r.clients[client.Name] = client
+	delete(r.failed, client.Name)
+	r.statuses.Invalidate(client.Name)
+}
+
+// StatusCache returns the cache of component statuses of the registered clusters
+func (r *Registry) StatusCache() *StatusCache {
+	return r.statuses
+}
+
+// NewContextClient builds a client for a kubeconfig context without registering it
+// Callers can verify the client before adding it with AddClient
+func (r *Registry) NewContextClient(kubeconfigPath, contextName string) (*ClusterClient, error) {
+	config, err := clientcmd.LoadFromFile(kubeconfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	return r.newConfigClient(config, contextName)
+}
+
+// NewContextClientFromBytes builds a client for a context of kubeconfig content without
+// registering it, see NewContextClient
+func (r *Registry) NewContextClientFromBytes(kubeconfig []byte, contextName string) (*ClusterClient, error) {
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	return r.newConfigClient(config, contextName)
+}
+
+// NewContextClientFromConfig builds a client for a context of a loaded kubeconfig without
+// registering it, see NewContextClient
+func (r *Registry) NewContextClientFromConfig(config *api.Config, contextName string) (*ClusterClient, error) {
+	return r.newConfigClient(config, contextName)
+}
+
+// newConfigClient builds a client for a context of a loaded kubeconfig with the
+// registry's rate limits
+func (r *Registry) newConfigClient(config *api.Config, contextName string) (*ClusterClient, error) {
+	if _, exists := config.Contexts[contextName]; !exists {
+		return nil, fmt.Errorf("context %s not found in kubeconfig", contextName)
+	}
+
+	qps, burst := r.RateLimit()
+	return newContextClient(config, contextName, qps, burst)
+}
+
+// AddClient registers a pre-built client, replacing any client with the same name
+func (r *Registry) AddClient(client *ClusterClient) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.setClient(client)
+	r.untrack(client.Name)
+}
+
+// newContextClient builds a cluster client for a kubeconfig context
+func newContextClient(config *api.Config, contextName string, qps float32, burst int) (*ClusterClient, error) {
 	// Build client config for this context
 	clientConfig := clientcmd.NewNonInteractiveClientConfig(
 		*config,
@@ -125,7 +389,7 @@ func (r *Registry) registerContext(config *api.Config, contextName string, conte
 
 	restConfig, err := clientConfig.ClientConfig()
 	if err != nil {
-		return fmt.Errorf("failed to create client config for context %s: %w", contextName, err)
+		return nil, fmt.Errorf("failed to create client config for context %s: %w", contextName, err)
 	}
 	restConfig.AcceptContentTypes = "application/json"
 	restConfig.ContentType = "application/json"
@@ -133,32 +397,38 @@ func (r *Registry) registerContext(config *api.Config, contextName string, conte
 		return &DiagnosticRoundTripper{delegate: rt}
 	})
 
-	// Set timeout
-	restConfig.Timeout = r.timeout
+	// Operation timeouts come from the context, so a target may raise them past the
+	// cluster timeout. The request timeout is only a ceiling.
+	restConfig.Timeout = MaxRequestTimeout
+	restConfig.QPS = qps
+	restConfig.Burst = burst
 
-	clientset, err := kubernetes.NewForConfig(restConfig)
-	if err != nil {
-		return fmt.Errorf("failed to create clientset for context %s: %w", contextName, err)
+	// The plugin only runs on the first request, a missing command is worth a warning now
+	if plugin := AuthPluginFor(restConfig); plugin != nil && plugin.Kind == AuthPluginExec && plugin.Path == "" {
+		klog.Warningf("IBM Fusion context %s authenticates with the exec plugin %q, which is not on PATH", contextName, plugin.Name)
 	}
 
-	r.clients[contextName] = &ClusterClient{
-		Name:      contextName,
-		Clientset: clientset,
-		Config:    restConfig,
-		Context:   contextName,
+	client, err := newClusterClient(contextName, contextName, restConfig)
+	if err != nil {
+		// An invalid exec plugin configuration fails here
+		return nil, ExplainAuthError(&ClusterClient{Name: contextName, Config: restConfig}, err)
 	}
-
-	return nil
+	return client, nil
 }
 
 // GetClient returns a client for the specified cluster
+// An unregistered cluster fails with the registered names closest to it, see UnknownClusterError
 func (r *Registry) GetClient(clusterName string) (*ClusterClient, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	client, exists := r.clients[clusterName]
 	if !exists {
-		return nil, fmt.Errorf("cluster %s not found in registry", clusterName)
+		registered := make([]string, 0, len(r.clients))
+		for name := range r.clients {
+			registered = append(registered, name)
+		}
+		return nil, UnknownClusterError(clusterName, registered)
 	}
 
 	return client, nil
@@ -205,7 +475,17 @@ func (r *Registry) UnregisterCluster(clusterName string) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	r.removeClient(clusterName)
+	r.untrack(clusterName)
+}
+
+// removeClient removes a client, dropping its cached discovery, statuses and labels
+// Callers must hold the write lock
+func (r *Registry) removeClient(clusterName string) {
+	InvalidateDiscovery(r.clients[clusterName])
 	delete(r.clients, clusterName)
+	delete(r.labels, clusterName)
+	r.statuses.Invalidate(clusterName)
 }
 
 // Clear removes all registered clients
@@ -213,7 +493,16 @@ func (r *Registry) Clear() {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	for _, client := range r.clients {
+		InvalidateDiscovery(client)
+	}
 	r.clients = make(map[string]*ClusterClient)
+	r.labels = make(map[string]map[string]string)
+	r.failed = make(ContextErrors)
+	r.statuses.Clear()
+	if r.source != nil {
+		r.source.contexts = nil
+	}
 }
 
 // ExecuteOnCluster executes a function on a specific cluster with timeout
@@ -223,18 +512,21 @@ func (r *Registry) ExecuteOnCluster(ctx context.Context, clusterName string, fn
 		return nil, err
 	}
 
-	// Create context with timeout
-	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	// Create context with timeout, retries happen within it
+	ctx, cancel := context.WithTimeout(ctx, r.Timeout())
 	defer cancel()
+	policy := r.RetryPolicy()
 
 	// Execute function
 	resultChan := make(chan interface{}, 1)
 	errorChan := make(chan error, 1)
 
 	go func() {
-		result, err := fn(client)
+		result, _, err := Retry(ctx, policy, func() (interface{}, error) {
+			return fn(client)
+		})
 		if err != nil {
-			errorChan <- err
+			errorChan <- ExplainAuthError(client, err)
 			return
 		}
 		resultChan <- result
@@ -247,30 +539,51 @@ func (r *Registry) ExecuteOnCluster(ctx context.Context, clusterName string, fn
 	case err := <-errorChan:
 		return nil, err
 	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.Canceled) {
+			return nil, fmt.Errorf("operation cancelled for cluster %s: %w", clusterName, ctx.Err())
+		}
 		return nil, fmt.Errorf("operation timed out for cluster %s", clusterName)
 	}
 }
 
-// ExecuteOnAllClusters executes a function on all clusters concurrently
+// ExecuteOnAllClusters executes a function on all clusters concurrently,
+// running at most maxConcurrency operations at a time
 func (r *Registry) ExecuteOnAllClusters(ctx context.Context, fn func(*ClusterClient) (interface{}, error)) map[string]ClusterResult {
 	clients := r.GetAllClients()
 	results := make(map[string]ClusterResult, len(clients))
 	var wg sync.WaitGroup
 	var mu sync.Mutex
 
+	r.mu.RLock()
+	limit := r.maxConcurrency
+	r.mu.RUnlock()
+	if limit <= 0 || limit > len(clients) {
+		limit = len(clients)
+	}
+	sem := make(chan struct{}, limit)
+
 	for name, client := range clients {
 		wg.Add(1)
 		go func(clusterName string, clusterClient *ClusterClient) {
 			defer wg.Done()
 
-			result, err := r.ExecuteOnCluster(ctx, clusterName, fn)
+			sem <- struct{}{}
+			defer func() { <-sem }()
 
-			mu.Lock()
-			results[clusterName] = ClusterResult{
+			data, err := r.ExecuteOnCluster(ctx, clusterName, fn)
+
+			clusterResult := ClusterResult{
 				ClusterName: clusterName,
-				Result:      result,
-				Error:       err,
+				Data:        data,
+				Success:     err == nil,
+			}
+			if err != nil {
+				clusterResult.Error = err.Error()
+				clusterResult.ErrorKind = ClassifyError(err)
 			}
+
+			mu.Lock()
+			results[clusterName] = clusterResult
 			mu.Unlock()
 		}(name, client)
 	}
@@ -279,11 +592,29 @@ func (r *Registry) ExecuteOnAllClusters(ctx context.Context, fn func(*ClusterCli
 	return results
 }
 
-// ClusterResult represents the result of an operation on a cluster
+// ClusterResult represents the result of an operation on a single cluster
+// This is the only definition, targeting.ClusterResult is an alias of it
 type ClusterResult struct {
-	ClusterName string
-	Result      interface{}
-	Error       error
+	// ClusterName identifies the cluster
+	ClusterName string `json:"clusterName"`
+
+	// Data contains the cluster-specific result
+	Data interface{} `json:"data,omitempty"`
+
+	// Error contains any error that occurred
+	Error string `json:"error,omitempty"`
+
+	// ErrorKind classifies Error, see ClassifyError
+	ErrorKind ErrorKind `json:"errorKind,omitempty"`
+
+	// Attempts is the number of attempts made when the operation was retried
+	Attempts int `json:"attempts,omitempty"`
+
+	// Success indicates if the operation succeeded
+	Success bool `json:"success"`
+
+	// Skipped indicates the operation never ran on this cluster (fail-fast)
+	Skipped bool `json:"skipped,omitempty"`
 }
 
 // Global registry instance (singleton pattern for simplicity)
@@ -293,18 +624,79 @@ var (
 	globalRegistryMu   sync.Mutex
 )
 
+// inClusterConfig and homeKubeconfig are replaced in tests to simulate the environment
+var (
+	inClusterConfig = rest.InClusterConfig
+	homeKubeconfig  = clientcmd.RecommendedHomeFile
+)
+
 // GetOrCreateRegistry returns the global registry, creating it if needed
 // It initializes with the provided Kubernetes client if this is the first call
 func GetOrCreateRegistry(k8sClient interface{}) *Registry {
 	globalRegistryOnce.Do(func() {
 		globalRegistry = NewRegistry()
-		// Try to register from default kubeconfig
-		// This is best-effort and won't fail if kubeconfig is not available
-		_ = globalRegistry.RegisterFromKubeconfig(clientcmd.RecommendedHomeFile)
+		cfg, err := fusionconfig.Load()
+		if err != nil {
+			cfg = fusionconfig.LoadFromEnv()
+		}
+		// Configured cluster timeout, set before clients are built with it
+		if timeout := cfg.ClusterTimeout(); timeout > 0 {
+			globalRegistry.SetTimeout(timeout)
+		}
+		// Configured overrides of the client-side rate limits
+		qps, burst := globalRegistry.RateLimit()
+		if cfg.QPS > 0 {
+			qps = cfg.QPS
+		}
+		if cfg.Burst > 0 {
+			burst = cfg.Burst
+		}
+		globalRegistry.SetRateLimit(qps, burst)
+		// This is best-effort and won't fail if no configuration is available
+		failed, _ := globalRegistry.RegisterDefault()
+		for contextName, err := range failed {
+			klog.Warningf("IBM Fusion skipped kubeconfig context %s: %v", contextName, err)
+		}
 	})
+	// Pick up edits of the kubeconfig made since it was loaded, such as a context added
+	// or a token renewed by a new login
+	reloaded, err := globalRegistry.ReloadIfStale()
+	if err != nil {
+		klog.Warningf("IBM Fusion cannot reload the kubeconfig: %v", err)
+	} else if reloaded != nil {
+		klog.V(1).Infof("IBM Fusion reloaded the kubeconfig: added %v, updated %v, removed %v",
+			reloaded.Added, reloaded.Updated, reloaded.Removed)
+		for contextName, err := range reloaded.Failed {
+			klog.Warningf("IBM Fusion skipped kubeconfig context %s: %v", contextName, err)
+		}
+	}
 	return globalRegistry
 }
 
+// RegisterDefault registers the clusters of the environment the server runs in: the
+// in-cluster configuration when running in a pod, otherwise the kubeconfig files listed
+// in KUBECONFIG, otherwise ~/.kube/config. Contexts of the kubeconfig that cannot be
+// registered are returned in the ContextErrors.
+// The kubeconfig is remembered for Reload, even when it cannot be loaded yet.
+func (r *Registry) RegisterDefault() (ContextErrors, error) {
+	if err := r.RegisterInCluster(); err == nil {
+		return nil, nil
+	}
+	source := &kubeconfigSource{paths: []string{homeKubeconfig}}
+	if paths := filepath.SplitList(os.Getenv(clientcmd.RecommendedConfigPathEnvVar)); len(paths) > 0 {
+		source = &kubeconfigSource{paths: paths, merged: true}
+	}
+	r.mu.Lock()
+	r.source = source
+	r.mu.Unlock()
+
+	reloaded, err := r.Reload()
+	if err != nil {
+		return nil, err
+	}
+	return reloaded.Failed, nil
+}
+
 // ResetGlobalRegistry resets the global registry (useful for testing)
 func ResetGlobalRegistry() {
 	globalRegistryMu.Lock()
@@ -0,0 +1,358 @@
+package clients
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+type RegistrySuite struct {
+	suite.Suite
+	originalInClusterConfig func() (*rest.Config, error)
+	originalHomeKubeconfig  string
+}
+
+func (s *RegistrySuite) SetupTest() {
+	s.originalInClusterConfig = inClusterConfig
+	s.originalHomeKubeconfig = homeKubeconfig
+	// Outside a pod by default, and no ~/.kube/config from the machine running the tests
+	inClusterConfig = func() (*rest.Config, error) { return nil, rest.ErrNotInCluster }
+	homeKubeconfig = filepath.Join(s.T().TempDir(), "missing")
+	s.T().Setenv(clientcmd.RecommendedConfigPathEnvVar, "")
+}
+
+func (s *RegistrySuite) TearDownTest() {
+	inClusterConfig = s.originalInClusterConfig
+	homeKubeconfig = s.originalHomeKubeconfig
+}
+
+// writeKubeconfig writes a kubeconfig with one context per name and returns its path
+func (s *RegistrySuite) writeKubeconfig(names ...string) string {
+	path := filepath.Join(s.T().TempDir(), "kubeconfig")
+	s.Require().NoError(os.WriteFile(path, kubeconfigFor(names...), 0600))
+	return path
+}
+
+// kubeconfigFor returns a kubeconfig with one context per name
+func kubeconfigFor(names ...string) []byte {
+	var clusters, contexts strings.Builder
+	for _, name := range names {
+		clusters.WriteString("- name: " + name + "\n  cluster:\n    server: https://" + name + ":6443\n")
+		contexts.WriteString("- name: " + name + "\n  context:\n    cluster: " + name + "\n    user: user\n")
+	}
+	kubeconfig := "apiVersion: v1\nkind: Config\n" +
+		"clusters:\n" + clusters.String() +
+		"contexts:\n" + contexts.String() +
+		"users:\n- name: user\n  user:\n    token: token\n"
+	return []byte(kubeconfig)
+}
+
+func (s *RegistrySuite) registeredNames(registry *Registry) []string {
+	names := registry.ListClusterNames()
+	sort.Strings(names)
+	return names
+}
+
+func (s *RegistrySuite) TestRegisterDefault() {
+	s.Run("prefers the in-cluster configuration", func() {
+		inClusterConfig = func() (*rest.Config, error) { return &rest.Config{Host: "https://kubernetes.default.svc"}, nil }
+		defer func() { inClusterConfig = func() (*rest.Config, error) { return nil, rest.ErrNotInCluster } }()
+		s.T().Setenv(clientcmd.RecommendedConfigPathEnvVar, s.writeKubeconfig("prod-1"))
+
+		registry := NewRegistry()
+		_, err := registry.RegisterDefault()
+		s.NoError(err)
+		s.Equal([]string{"in-cluster"}, s.registeredNames(registry))
+	})
+	s.Run("honors KUBECONFIG", func() {
+		s.T().Setenv(clientcmd.RecommendedConfigPathEnvVar, s.writeKubeconfig("prod-1"))
+		homeKubeconfig = s.writeKubeconfig("home")
+
+		registry := NewRegistry()
+		_, err := registry.RegisterDefault()
+		s.NoError(err)
+		s.Equal([]string{"prod-1"}, s.registeredNames(registry))
+	})
+	s.Run("merges a multi-file KUBECONFIG", func() {
+		paths := []string{s.writeKubeconfig("prod-1"), s.writeKubeconfig("prod-2", "dr-1")}
+		s.T().Setenv(clientcmd.RecommendedConfigPathEnvVar, strings.Join(paths, string(filepath.ListSeparator)))
+
+		registry := NewRegistry()
+		_, err := registry.RegisterDefault()
+		s.NoError(err)
+		s.Equal([]string{"dr-1", "prod-1", "prod-2"}, s.registeredNames(registry))
+	})
+	s.Run("falls back to the home kubeconfig", func() {
+		s.T().Setenv(clientcmd.RecommendedConfigPathEnvVar, "")
+		homeKubeconfig = s.writeKubeconfig("home")
+
+		registry := NewRegistry()
+		_, err := registry.RegisterDefault()
+		s.NoError(err)
+		s.Equal([]string{"home"}, s.registeredNames(registry))
+	})
+	s.Run("fails when no configuration is available", func() {
+		s.T().Setenv(clientcmd.RecommendedConfigPathEnvVar, "")
+		homeKubeconfig = filepath.Join(s.T().TempDir(), "missing")
+
+		registry := NewRegistry()
+		_, err := registry.RegisterDefault()
+		s.Error(err)
+		s.Empty(registry.ListClusterNames())
+	})
+}
+
+func (s *RegistrySuite) TestRegisterInCluster() {
+	s.Run("returns the in-cluster error outside a pod", func() {
+		registry := NewRegistry()
+		err := registry.RegisterInCluster()
+		s.True(errors.Is(err, rest.ErrNotInCluster))
+		s.False(registry.HasCluster("in-cluster"))
+	})
+}
+
+func (s *RegistrySuite) TestRegisterFromKubeconfigFailedContexts() {
+	// The broken context names a cluster the kubeconfig does not define
+	kubeconfig := strings.Replace(string(kubeconfigFor("prod-1", "prod-2")), "contexts:\n",
+		"contexts:\n- name: broken\n  context:\n    cluster: missing\n    user: user\n", 1)
+	path := filepath.Join(s.T().TempDir(), "kubeconfig")
+	s.Require().NoError(os.WriteFile(path, []byte(kubeconfig), 0600))
+	registry := NewRegistry()
+
+	s.Run("registers the other contexts and returns the broken one", func() {
+		failed, err := registry.RegisterFromKubeconfig(path)
+		s.Require().NoError(err)
+		s.Equal([]string{"prod-1", "prod-2"}, s.registeredNames(registry))
+		s.Len(failed, 1)
+		s.ErrorContains(failed["broken"], "failed to create client config for context broken")
+	})
+	s.Run("keeps the failure until the context is registered", func() {
+		s.Contains(registry.FailedContexts(), "broken")
+		registry.AddClient(&ClusterClient{Name: "broken"})
+		s.Empty(registry.FailedContexts())
+	})
+}
+
+func (s *RegistrySuite) TestRegisterFromKubeconfigBytes() {
+	s.Run("registers every context", func() {
+		registry := NewRegistry()
+		registry.SetTimeout(7 * time.Second)
+		registered, failed, err := registry.RegisterFromKubeconfigBytes(kubeconfigFor("prod-2", "prod-1", "dr-1"))
+		s.Require().NoError(err)
+		s.Nil(failed)
+		s.Equal([]string{"dr-1", "prod-1", "prod-2"}, registered)
+		s.Equal(registered, s.registeredNames(registry))
+
+		client, err := registry.GetClient("prod-1")
+		s.Require().NoError(err)
+		s.Equal("https://prod-1:6443", client.Config.Host)
+		s.Equal(MaxRequestTimeout, client.Config.Timeout, "operations are bounded by their context, not the request timeout")
+		s.Equal(7*time.Second, registry.Timeout())
+		s.NotNil(client.Config.WrapTransport, "requests go through the DiagnosticRoundTripper")
+	})
+	s.Run("skips contexts that cannot be built", func() {
+		// The broken context names a cluster the kubeconfig does not define
+		kubeconfig := strings.Replace(string(kubeconfigFor("prod-1")), "contexts:\n",
+			"contexts:\n- name: broken\n  context:\n    cluster: missing\n    user: user\n", 1)
+		registry := NewRegistry()
+		registered, failed, err := registry.RegisterFromKubeconfigBytes([]byte(kubeconfig))
+		s.Require().NoError(err)
+		s.Equal([]string{"prod-1"}, registered)
+		s.Require().Contains(failed, "broken")
+		s.ErrorContains(failed, "context broken: failed to create client config for context broken")
+		s.Equal(failed, registry.FailedContexts())
+	})
+	s.Run("reads from a reader", func() {
+		registry := NewRegistry()
+		registered, _, err := registry.RegisterFromKubeconfigReader(bytes.NewReader(kubeconfigFor("prod-1")))
+		s.Require().NoError(err)
+		s.Equal([]string{"prod-1"}, registered)
+	})
+	s.Run("builds a single context client without registering it", func() {
+		registry := NewRegistry()
+		client, err := registry.NewContextClientFromBytes(kubeconfigFor("prod-1", "prod-2"), "prod-2")
+		s.Require().NoError(err)
+		s.Equal("https://prod-2:6443", client.Config.Host)
+		s.Empty(registry.ListClusterNames())
+
+		_, err = registry.NewContextClientFromBytes(kubeconfigFor("prod-1"), "dr-1")
+		s.EqualError(err, "context dr-1 not found in kubeconfig")
+	})
+	s.Run("rejects content that is not a kubeconfig", func() {
+		registry := NewRegistry()
+		_, _, err := registry.RegisterFromKubeconfigBytes([]byte("clusters: [not"))
+		s.ErrorContains(err, "failed to load kubeconfig")
+		s.Empty(registry.ListClusterNames())
+	})
+}
+
+func (s *RegistrySuite) TestReload() {
+	path := filepath.Join(s.T().TempDir(), "kubeconfig")
+	s.Require().NoError(os.WriteFile(path, kubeconfigFor("prod-1"), 0600))
+	s.T().Setenv(clientcmd.RecommendedConfigPathEnvVar, path)
+	registry := NewRegistry()
+	_, err := registry.RegisterDefault()
+	s.Require().NoError(err)
+	original, err := registry.GetClient("prod-1")
+	s.Require().NoError(err)
+
+	s.Run("does nothing while the kubeconfig is unchanged", func() {
+		reloaded, err := registry.ReloadIfStale()
+		s.NoError(err)
+		s.Nil(reloaded)
+	})
+	s.Run("registers a context added after the initial load", func() {
+		s.Require().NoError(os.WriteFile(path, kubeconfigFor("prod-1", "prod-2"), 0600))
+		reloaded, err := registry.ReloadIfStale()
+		s.Require().NoError(err)
+		s.Require().NotNil(reloaded)
+		s.Equal([]string{path}, reloaded.Paths)
+		s.Equal([]string{"prod-2"}, reloaded.Added)
+		s.Empty(reloaded.Updated)
+		s.Empty(reloaded.Removed)
+		s.Equal([]string{"prod-1", "prod-2"}, s.registeredNames(registry))
+		client, err := registry.GetClient("prod-1")
+		s.Require().NoError(err)
+		s.Same(original, client, "unchanged contexts keep their client")
+	})
+	s.Run("rebuilds a context whose credentials changed", func() {
+		kubeconfig := strings.Replace(string(kubeconfigFor("prod-1", "prod-2")), "token: token", "token: renewed", 1)
+		s.Require().NoError(os.WriteFile(path, []byte(kubeconfig), 0600))
+		reloaded, err := registry.Reload()
+		s.Require().NoError(err)
+		s.Empty(reloaded.Added)
+		s.Equal([]string{"prod-1", "prod-2"}, reloaded.Updated)
+		client, err := registry.GetClient("prod-1")
+		s.Require().NoError(err)
+		s.Equal("renewed", client.Config.BearerToken)
+	})
+	s.Run("removes deleted contexts and keeps clusters registered at runtime", func() {
+		runtime, err := registry.NewContextClientFromBytes(kubeconfigFor("dr-1"), "dr-1")
+		s.Require().NoError(err)
+		registry.AddClient(runtime)
+		// prod-1 replaced at runtime is no longer the kubeconfig's
+		replaced, err := registry.NewContextClientFromBytes(kubeconfigFor("prod-1"), "prod-1")
+		s.Require().NoError(err)
+		registry.AddClient(replaced)
+
+		s.Require().NoError(os.WriteFile(path, kubeconfigFor("prod-1", "prod-3"), 0600))
+		reloaded, err := registry.Reload()
+		s.Require().NoError(err)
+		s.Equal([]string{"prod-3"}, reloaded.Added)
+		s.Empty(reloaded.Updated)
+		s.Equal([]string{"prod-2"}, reloaded.Removed)
+		s.Equal([]string{"dr-1", "prod-1", "prod-3"}, s.registeredNames(registry))
+		client, err := registry.GetClient("prod-1")
+		s.Require().NoError(err)
+		s.Same(replaced, client)
+	})
+	s.Run("registers an unregistered context still in the kubeconfig again", func() {
+		registry.UnregisterCluster("prod-3")
+		reloaded, err := registry.Reload()
+		s.Require().NoError(err)
+		s.Equal([]string{"prod-3"}, reloaded.Added)
+		s.True(registry.HasCluster("prod-3"))
+	})
+	s.Run("keeps the registry when the kubeconfig cannot be loaded", func() {
+		s.Require().NoError(os.WriteFile(path, []byte("clusters: [not"), 0600))
+		_, err := registry.ReloadIfStale()
+		s.ErrorContains(err, "failed to load kubeconfig")
+		s.Equal([]string{"dr-1", "prod-1", "prod-3"}, s.registeredNames(registry))
+
+		reloaded, err := registry.ReloadIfStale()
+		s.NoError(err, "retried once the kubeconfig is written again")
+		s.Nil(reloaded)
+	})
+	s.Run("loads a kubeconfig created after startup", func() {
+		homeKubeconfig = filepath.Join(s.T().TempDir(), "config")
+		s.T().Setenv(clientcmd.RecommendedConfigPathEnvVar, "")
+		registry := NewRegistry()
+		_, err := registry.RegisterDefault()
+		s.Error(err)
+		reloaded, err := registry.ReloadIfStale()
+		s.NoError(err, "a missing kubeconfig is not reloaded until created")
+		s.Nil(reloaded)
+
+		s.Require().NoError(os.WriteFile(homeKubeconfig, kubeconfigFor("home"), 0600))
+		reloaded, err = registry.ReloadIfStale()
+		s.Require().NoError(err)
+		s.Equal([]string{"home"}, reloaded.Added)
+		s.Equal([]string{"home"}, s.registeredNames(registry))
+	})
+	s.Run("fails when not loaded from a kubeconfig", func() {
+		_, err := NewRegistry().Reload()
+		s.EqualError(err, "nothing to reload: the registry was not loaded from a kubeconfig")
+	})
+}
+
+func (s *RegistrySuite) TestRateLimit() {
+	s.Run("defaults above client-go limits", func() {
+		qps, burst := NewRegistry().RateLimit()
+		s.Equal(DefaultQPS, qps)
+		s.Equal(DefaultBurst, burst)
+		s.Greater(qps, rest.DefaultQPS)
+		s.Greater(burst, rest.DefaultBurst)
+	})
+	s.Run("applies to kubeconfig and in-cluster clients", func() {
+		registry := NewRegistry()
+		registry.SetRateLimit(7, 14)
+		_, err := registry.RegisterFromKubeconfig(s.writeKubeconfig("prod-1"))
+		s.NoError(err)
+		inClusterConfig = func() (*rest.Config, error) { return &rest.Config{Host: "https://kubernetes.default.svc"}, nil }
+		defer func() { inClusterConfig = func() (*rest.Config, error) { return nil, rest.ErrNotInCluster } }()
+		s.NoError(registry.RegisterInCluster())
+
+		for _, name := range []string{"prod-1", "in-cluster"} {
+			client, err := registry.GetClient(name)
+			s.Require().NoError(err)
+			s.Equal(float32(7), client.Config.QPS, name)
+			s.Equal(14, client.Config.Burst, name)
+		}
+	})
+}
+
+func (s *RegistrySuite) TestUnknownCluster() {
+	registry := NewRegistry()
+	s.Run("an empty registry says so", func() {
+		_, err := registry.GetClient("prod-1")
+		s.EqualError(err, "cluster prod-1 not found in registry, no clusters are registered")
+	})
+	for _, name := range []string{"prod-east-1", "prod-east-2", "prod-west-1", "dr-site"} {
+		registry.AddClient(&ClusterClient{Name: name})
+	}
+	s.Run("suggests the closest names", func() {
+		_, err := registry.GetClient("Prod-East-3")
+		s.EqualError(err, "cluster Prod-East-3 not found in registry, did you mean prod-east-1 or prod-east-2 or prod-west-1?")
+		_, err = registry.GetClient("dr-sit")
+		s.EqualError(err, "cluster dr-sit not found in registry, did you mean dr-site?")
+		s.Equal(ErrorKindNotFound, ClassifyError(err))
+	})
+	s.Run("lists the registered clusters when none is close", func() {
+		_, err := registry.GetClient("staging")
+		s.EqualError(err, "cluster staging not found in registry, registered clusters: dr-site, prod-east-1, prod-east-2, prod-west-1")
+	})
+	s.Run("caps the registered clusters listed", func() {
+		names := make([]string, 0, 12)
+		for i := range 12 {
+			names = append(names, fmt.Sprintf("edge-%02d", i))
+		}
+		s.EqualError(UnknownClusterError("prod-1", names),
+			"cluster prod-1 not found in registry, registered clusters: edge-00, edge-01, edge-02, edge-03, edge-04, edge-05, edge-06, edge-07, edge-08, edge-09 and 2 more")
+	})
+}
+
+func TestRegistrySuite(t *testing.T) {
+	suite.Run(t, new(RegistrySuite))
+}
+
+// Made with Bob
@@ -0,0 +1,197 @@
+package clients
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+// kubeconfigSource is the kubeconfig the registry was loaded from by RegisterDefault.
+// It remembers the entries each of its contexts was built from, so that Reload can tell
+// the contexts added, changed and removed since, and the state of its files when last
+// loaded, so that ReloadIfStale can tell when they were edited.
+type kubeconfigSource struct {
+	// paths are the kubeconfig files, merged like a colon-separated KUBECONFIG when
+	// merged is set, otherwise a single file that must exist
+	paths  []string
+	merged bool
+
+	// contexts are the contexts registered, or that failed to register, from the files
+	// Clusters registered or unregistered by other means are removed from it
+	contexts map[string]contextEntry
+	stamp    string
+}
+
+// contextEntry is what a client is built from: a context with its cluster and user
+type contextEntry struct {
+	context  *api.Context
+	cluster  *api.Cluster
+	authInfo *api.AuthInfo
+}
+
+// newContextEntry returns the entries of a kubeconfig a context refers to
+func newContextEntry(config *api.Config, contextName string) contextEntry {
+	context := config.Contexts[contextName]
+	return contextEntry{
+		context:  context,
+		cluster:  config.Clusters[context.Cluster],
+		authInfo: config.AuthInfos[context.AuthInfo],
+	}
+}
+
+// load reads the kubeconfig files
+func (s *kubeconfigSource) load() (*api.Config, error) {
+	if !s.merged {
+		return clientcmd.LoadFromFile(s.paths[0])
+	}
+	loadingRules := &clientcmd.ClientConfigLoadingRules{Precedence: s.paths}
+	return loadingRules.Load()
+}
+
+// currentStamp returns the modification time and size of the kubeconfig files, which
+// change whenever one of them is written
+func (s *kubeconfigSource) currentStamp() string {
+	stamps := make([]string, 0, len(s.paths))
+	for _, path := range s.paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			stamps = append(stamps, path+":missing")
+			continue
+		}
+		stamps = append(stamps, fmt.Sprintf("%s:%d:%d", path, info.ModTime().UnixNano(), info.Size()))
+	}
+	return strings.Join(stamps, ",")
+}
+
+// ReloadResult is the outcome of a Reload: the kubeconfig files read, the contexts
+// registered for the first time, rebuilt because their entries changed or they had
+// failed, and removed because they left the kubeconfig, each sorted, and the contexts
+// that could not be registered
+type ReloadResult struct {
+	Paths   []string
+	Added   []string
+	Updated []string
+	Removed []string
+	Failed  ContextErrors
+}
+
+// Reload reads the kubeconfig the registry was loaded from again and reconciles the
+// registry with it: new contexts are registered, contexts whose context, cluster or user
+// entry changed are rebuilt and contexts no longer in the kubeconfig are removed.
+// Unchanged contexts keep their client and caches. Clusters registered at runtime are
+// left alone, as are clusters the kubeconfig no longer tracks because they were
+// replaced or unregistered, except that an unregistered context still in the
+// kubeconfig is registered again.
+//
+// Clients never hold a resolved credential: tokens and token files are read from the
+// kubeconfig on each Reload, and exec plugins are run by client-go, which fetches a new
+// credential when the cached one expires or the API server rejects it.
+// It fails when the registry was not loaded from a kubeconfig, such as in a pod, or the
+// kubeconfig cannot be loaded, in which case the registry is unchanged.
+func (r *Registry) Reload() (*ReloadResult, error) {
+	r.mu.RLock()
+	source := r.source
+	r.mu.RUnlock()
+	if source == nil {
+		return nil, fmt.Errorf("nothing to reload: the registry was not loaded from a kubeconfig")
+	}
+
+	// Stamped before loading, a write while loading leaves the registry stale. A
+	// kubeconfig that cannot be loaded is stamped too, so it is only retried once written.
+	stamp := source.currentStamp()
+	config, err := source.load()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	source.stamp = stamp
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	result := &ReloadResult{
+		Paths:   source.paths,
+		Added:   []string{},
+		Updated: []string{},
+		Removed: []string{},
+	}
+	previous := source.contexts
+	contexts := make(map[string]contextEntry, len(config.Contexts))
+	for contextName := range config.Contexts {
+		entry, known := previous[contextName]
+		_, registered := r.clients[contextName]
+		if registered && !known {
+			// Registered at runtime, or replaced since the kubeconfig was loaded
+			continue
+		}
+		current := newContextEntry(config, contextName)
+		contexts[contextName] = current
+		if registered && reflect.DeepEqual(entry, current) {
+			continue
+		}
+
+		client, err := newContextClient(config, contextName, r.qps, r.burst)
+		if err != nil {
+			if result.Failed == nil {
+				result.Failed = make(ContextErrors)
+			}
+			result.Failed[contextName] = err
+			r.failed[contextName] = err
+			continue
+		}
+		r.setClient(client)
+		if known {
+			result.Updated = append(result.Updated, contextName)
+		} else {
+			result.Added = append(result.Added, contextName)
+		}
+	}
+	for contextName := range previous {
+		if _, exists := contexts[contextName]; exists {
+			continue
+		}
+		delete(r.failed, contextName)
+		if _, registered := r.clients[contextName]; registered {
+			r.removeClient(contextName)
+			result.Removed = append(result.Removed, contextName)
+		}
+	}
+	source.contexts = contexts
+
+	sort.Strings(result.Added)
+	sort.Strings(result.Updated)
+	sort.Strings(result.Removed)
+	return result, nil
+}
+
+// ReloadIfStale calls Reload when a kubeconfig file the registry was loaded from was
+// written, created or deleted since it was last loaded. It returns a nil result when
+// there is nothing to reload, which only costs a stat of the files.
+func (r *Registry) ReloadIfStale() (*ReloadResult, error) {
+	r.mu.RLock()
+	source := r.source
+	var stamp string
+	if source != nil {
+		stamp = source.stamp
+	}
+	r.mu.RUnlock()
+	if source == nil || source.currentStamp() == stamp {
+		return nil, nil
+	}
+	return r.Reload()
+}
+
+// untrack removes a cluster from the contexts of the kubeconfig source, once it was
+// registered or unregistered by other means than Reload
+// Callers must hold the write lock
+func (r *Registry) untrack(clusterName string) {
+	if r.source != nil {
+		delete(r.source.contexts, clusterName)
+	}
+}
+
+// Made with Bob
@@ -0,0 +1,82 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// RetryPolicy controls how often a cluster operation is retried on transient errors
+// Attempts back off exponentially starting at BackoffMs, all within the per-cluster timeout
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, 1 disables retries
+	MaxAttempts int `json:"maxAttempts,omitempty"`
+	// BackoffMs is the delay before the first retry in milliseconds, doubled on each retry
+	BackoffMs int `json:"backoffMs,omitempty"`
+}
+
+// DefaultRetryPolicy retries a transient failure twice, after 500ms and 1s
+var DefaultRetryPolicy = RetryPolicy{MaxAttempts: 3, BackoffMs: 500}
+
+// backoff returns the delay before the given retry, counting retries from 1
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	return time.Duration(p.BackoffMs) * time.Millisecond << (retry - 1)
+}
+
+// IsTransientError reports whether an operation failing with err may succeed when retried:
+// timeouts, connection failures, throttling and 5xx responses. Forbidden, Auth and NotFound
+// never are.
+func IsTransientError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	switch ClassifyError(err) {
+	case ErrorKindTimeout, ErrorKindConnection:
+		return true
+	case ErrorKindForbidden, ErrorKindAuth, ErrorKindNotFound:
+		return false
+	}
+	if apierrors.IsTooManyRequests(err) || apierrors.IsInternalError(err) || apierrors.IsServiceUnavailable(err) {
+		return true
+	}
+	var status apierrors.APIStatus
+	if errors.As(err, &status) && status.Status().Code >= 500 {
+		return true
+	}
+	return false
+}
+
+// Retry runs fn until it succeeds, fails with a non-transient error or the policy's attempts
+// are used up. Retries share ctx, so they never extend past its deadline: no retry is
+// started when the backoff would end after the deadline. It returns the number of attempts made.
+func Retry(ctx context.Context, policy RetryPolicy, fn func() (interface{}, error)) (interface{}, int, error) {
+	maxAttempts := policy.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	var result interface{}
+	var err error
+	for attempt := 1; ; attempt++ {
+		result, err = fn()
+		if err == nil || attempt >= maxAttempts || !IsTransientError(err) || ctx.Err() != nil {
+			return result, attempt, err
+		}
+
+		delay := policy.backoff(attempt)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return result, attempt, err
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result, attempt, err
+		case <-timer.C:
+		}
+	}
+}
+
+// Made with Bob
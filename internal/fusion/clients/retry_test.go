@@ -0,0 +1,79 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type RetrySuite struct {
+	suite.Suite
+}
+
+func (s *RetrySuite) TestIsTransientError() {
+	resource := schema.GroupResource{Resource: "pods"}
+	s.True(IsTransientError(fmt.Errorf("dial: %w", syscall.ECONNREFUSED)))
+	s.True(IsTransientError(apierrors.NewServerTimeout(resource, "list", 1)))
+	s.True(IsTransientError(apierrors.NewInternalError(fmt.Errorf("etcd leader changed"))))
+	s.True(IsTransientError(apierrors.NewServiceUnavailable("apiserver restarting")))
+	s.True(IsTransientError(apierrors.NewTooManyRequests("slow down", 1)))
+	s.False(IsTransientError(apierrors.NewForbidden(resource, "", fmt.Errorf("denied"))))
+	s.False(IsTransientError(apierrors.NewNotFound(resource, "missing")))
+	s.False(IsTransientError(context.Canceled))
+	s.False(IsTransientError(fmt.Errorf("invalid argument")))
+	s.False(IsTransientError(nil))
+}
+
+func (s *RetrySuite) TestRetry() {
+	policy := RetryPolicy{MaxAttempts: 3, BackoffMs: 1}
+	s.Run("retries transient errors until success", func() {
+		calls := 0
+		result, attempts, err := Retry(context.Background(), policy, func() (interface{}, error) {
+			calls++
+			if calls < 3 {
+				return nil, apierrors.NewServiceUnavailable("restarting")
+			}
+			return "ok", nil
+		})
+		s.NoError(err)
+		s.Equal("ok", result)
+		s.Equal(3, attempts)
+	})
+	s.Run("gives up after MaxAttempts", func() {
+		_, attempts, err := Retry(context.Background(), policy, func() (interface{}, error) {
+			return nil, fmt.Errorf("dial: %w", syscall.ECONNREFUSED)
+		})
+		s.Error(err)
+		s.Equal(3, attempts)
+	})
+	s.Run("never retries forbidden", func() {
+		_, attempts, err := Retry(context.Background(), policy, func() (interface{}, error) {
+			return nil, apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", fmt.Errorf("denied"))
+		})
+		s.Error(err)
+		s.Equal(1, attempts)
+	})
+	s.Run("does not retry past the context deadline", func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		_, attempts, err := Retry(ctx, RetryPolicy{MaxAttempts: 5, BackoffMs: 1000}, func() (interface{}, error) {
+			return nil, apierrors.NewServiceUnavailable("restarting")
+		})
+		s.Error(err)
+		s.Equal(1, attempts)
+		s.Less(time.Since(start), time.Second)
+	})
+}
+
+func TestRetrySuite(t *testing.T) {
+	suite.Run(t, new(RetrySuite))
+}
+
+// Made with Bob
@@ -0,0 +1,86 @@
+package clients
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultStatusMaxAge is how old a cached component status may be unless the caller asks
+// for fresher data
+const DefaultStatusMaxAge = 10 * time.Second
+
+// StatusCache keeps the latest status of each component of each cluster, so callers
+// polling the same rollup don't re-probe every cluster each time. Entries are dropped
+// when their cluster is unregistered or replaced.
+type StatusCache struct {
+	mu      sync.Mutex
+	entries map[statusKey]statusEntry
+	now     func() time.Time
+}
+
+// statusKey identifies a component of a cluster
+type statusKey struct {
+	cluster   string
+	component string
+}
+
+// statusEntry holds a status and when it was stored
+type statusEntry struct {
+	status interface{}
+	stored time.Time
+}
+
+// NewStatusCache creates an empty status cache
+func NewStatusCache() *StatusCache {
+	return &StatusCache{
+		entries: make(map[statusKey]statusEntry),
+		now:     time.Now,
+	}
+}
+
+// Get returns the status stored for a component of a cluster and its age, when it is
+// at most maxAge old
+func (c *StatusCache) Get(cluster, component string, maxAge time.Duration) (interface{}, time.Duration, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[statusKey{cluster: cluster, component: component}]
+	if !ok {
+		return nil, 0, false
+	}
+	age := c.now().Sub(entry.stored)
+	if age > maxAge {
+		return nil, 0, false
+	}
+	return entry.status, age, true
+}
+
+// Put stores the status of a component of a cluster, replacing any older one
+func (c *StatusCache) Put(cluster, component string, status interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[statusKey{cluster: cluster, component: component}] = statusEntry{status: status, stored: c.now()}
+}
+
+// Invalidate drops every status of a cluster
+func (c *StatusCache) Invalidate(cluster string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.entries {
+		if key.cluster == cluster {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// Clear drops every status
+func (c *StatusCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[statusKey]statusEntry)
+}
+
+// Made with Bob
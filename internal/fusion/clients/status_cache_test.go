@@ -0,0 +1,65 @@
+package clients
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type StatusCacheSuite struct {
+	suite.Suite
+}
+
+func (s *StatusCacheSuite) TestGet() {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache := NewStatusCache()
+	cache.now = func() time.Time { return now }
+	cache.Put("prod-1", "dr", "ready")
+	now = now.Add(4 * time.Second)
+
+	s.Run("returns a status young enough with its age", func() {
+		status, age, ok := cache.Get("prod-1", "dr", 5*time.Second)
+		s.True(ok)
+		s.Equal("ready", status)
+		s.Equal(4*time.Second, age)
+	})
+	s.Run("misses a status older than maxAge", func() {
+		_, _, ok := cache.Get("prod-1", "dr", 3*time.Second)
+		s.False(ok)
+	})
+	s.Run("misses other components and clusters", func() {
+		_, _, ok := cache.Get("prod-1", "gdp", time.Minute)
+		s.False(ok)
+		_, _, ok = cache.Get("prod-2", "dr", time.Minute)
+		s.False(ok)
+	})
+}
+
+func (s *StatusCacheSuite) TestRegistryInvalidation() {
+	registry := NewRegistry()
+	registry.AddClient(&ClusterClient{Name: "prod-1"})
+	registry.AddClient(&ClusterClient{Name: "prod-2"})
+	cache := registry.StatusCache()
+	cache.Put("prod-1", "dr", "ready")
+	cache.Put("prod-2", "dr", "ready")
+
+	s.Run("unregistering a cluster drops its statuses", func() {
+		registry.UnregisterCluster("prod-1")
+		_, _, ok := cache.Get("prod-1", "dr", time.Minute)
+		s.False(ok)
+		_, _, ok = cache.Get("prod-2", "dr", time.Minute)
+		s.True(ok)
+	})
+	s.Run("replacing a cluster drops its statuses", func() {
+		registry.AddClient(&ClusterClient{Name: "prod-2"})
+		_, _, ok := cache.Get("prod-2", "dr", time.Minute)
+		s.False(ok)
+	})
+}
+
+func TestStatusCacheSuite(t *testing.T) {
+	suite.Run(t, new(StatusCacheSuite))
+}
+
+// Made with Bob
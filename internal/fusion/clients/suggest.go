@@ -0,0 +1,82 @@
+package clients
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maxListedClusters caps the registered clusters listed by an unknown cluster error
+const maxListedClusters = 10
+
+// UnknownClusterError is the error for a cluster name that is not registered. It
+// suggests the registered names closest to the name, or lists the registered clusters
+// when none is close, so a mistyped target can be corrected from the error alone.
+func UnknownClusterError(clusterName string, registered []string) error {
+	if len(registered) == 0 {
+		return fmt.Errorf("cluster %s not found in registry, no clusters are registered", clusterName)
+	}
+	if closest := ClosestClusterNames(clusterName, registered); len(closest) > 0 {
+		return fmt.Errorf("cluster %s not found in registry, did you mean %s?", clusterName, strings.Join(closest, " or "))
+	}
+	names := append([]string{}, registered...)
+	sort.Strings(names)
+	listed := strings.Join(names, ", ")
+	if len(names) > maxListedClusters {
+		listed = fmt.Sprintf("%s and %d more", strings.Join(names[:maxListedClusters], ", "), len(names)-maxListedClusters)
+	}
+	return fmt.Errorf("cluster %s not found in registry, registered clusters: %s", clusterName, listed)
+}
+
+// ClosestClusterNames returns up to three registered names within a small edit
+// distance of clusterName, closest first. Names are compared case-insensitively and
+// the distance allowed grows with the length of the name, a third of it but at least 2.
+func ClosestClusterNames(clusterName string, registered []string) []string {
+	type candidate struct {
+		name     string
+		distance int
+	}
+	limit := max(2, len(clusterName)/3)
+	var candidates []candidate
+	for _, name := range registered {
+		if distance := levenshtein(strings.ToLower(clusterName), strings.ToLower(name)); distance <= limit {
+			candidates = append(candidates, candidate{name: name, distance: distance})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].name < candidates[j].name
+	})
+	closest := make([]string, 0, 3)
+	for _, c := range candidates[:min(len(candidates), 3)] {
+		closest = append(closest, c.name)
+	}
+	return closest
+}
+
+// levenshtein returns the number of single rune insertions, deletions and
+// substitutions turning a into b
+func levenshtein(a, b string) int {
+	source, target := []rune(a), []rune(b)
+	previous := make([]int, len(target)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	current := make([]int, len(target)+1)
+	for i := 1; i <= len(source); i++ {
+		current[0] = i
+		for j := 1; j <= len(target); j++ {
+			cost := 1
+			if source[i-1] == target[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(target)]
+}
+
+// Made with Bob
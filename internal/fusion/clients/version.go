@@ -0,0 +1,133 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/version"
+)
+
+// VersionTTL is how long the versions of a cluster are reused, they only change on upgrade
+const VersionTTL = 10 * time.Minute
+
+// ClusterVersionGVR identifies the OpenShift ClusterVersion resource
+var ClusterVersionGVR = schema.GroupVersionResource{Group: "config.openshift.io", Version: "v1", Resource: "clusterversions"}
+
+// versionCache holds the versions read from a cluster, failed reads are not cached
+type versionCache struct {
+	mu               sync.Mutex
+	server           *version.Info
+	serverExpires    time.Time
+	openShift        string
+	openShiftExpires time.Time
+}
+
+// ServerVersion returns the Kubernetes version of the API server, reusing the last
+// discovered one for VersionTTL
+func (c *ClusterClient) ServerVersion() (*version.Info, error) {
+	c.versions.mu.Lock()
+	if c.versions.server != nil && time.Now().Before(c.versions.serverExpires) {
+		info := c.versions.server
+		c.versions.mu.Unlock()
+		return info, nil
+	}
+	c.versions.mu.Unlock()
+
+	if c.Clientset == nil {
+		return nil, fmt.Errorf("cluster %s has no clientset", c.Name)
+	}
+	info, err := c.Clientset.Discovery().ServerVersion()
+	if err != nil {
+		return nil, err
+	}
+	c.SetServerVersion(info)
+	return info, nil
+}
+
+// SetServerVersion caches a server version discovered outside ServerVersion
+func (c *ClusterClient) SetServerVersion(info *version.Info) {
+	c.versions.mu.Lock()
+	defer c.versions.mu.Unlock()
+	c.versions.server = info
+	c.versions.serverExpires = time.Now().Add(VersionTTL)
+}
+
+// OpenShiftVersion returns the OpenShift version of the cluster read from the
+// ClusterVersion named version: the latest completed update, or the desired version
+// while the first install is still running. It is empty, without an error, on a
+// cluster that is not OpenShift. Like ServerVersion it is reused for VersionTTL.
+func (c *ClusterClient) OpenShiftVersion(ctx context.Context) (string, error) {
+	c.versions.mu.Lock()
+	if time.Now().Before(c.versions.openShiftExpires) {
+		openShift := c.versions.openShift
+		c.versions.mu.Unlock()
+		return openShift, nil
+	}
+	c.versions.mu.Unlock()
+
+	openShift := ""
+	clusterVersion, err := c.GetCR(ctx, ClusterVersionGVR, "", "version")
+	switch {
+	case err == nil:
+		openShift = clusterVersionOf(clusterVersion)
+	case apierrors.IsNotFound(err), meta.IsNoMatchError(err):
+		// Not an OpenShift cluster
+	default:
+		return "", fmt.Errorf("failed to read the OpenShift ClusterVersion: %w", err)
+	}
+
+	c.versions.mu.Lock()
+	defer c.versions.mu.Unlock()
+	c.versions.openShift = openShift
+	c.versions.openShiftExpires = time.Now().Add(VersionTTL)
+	return openShift, nil
+}
+
+// clusterVersionOf returns the version of a ClusterVersion, status.history lists the
+// updates newest first
+func clusterVersionOf(clusterVersion *unstructured.Unstructured) string {
+	history, _, _ := unstructured.NestedSlice(clusterVersion.Object, "status", "history")
+	for _, entry := range history {
+		update, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if state, _ := update["state"].(string); state == "Completed" {
+			if v, _ := update["version"].(string); v != "" {
+				return v
+			}
+		}
+	}
+	desired, _, _ := unstructured.NestedString(clusterVersion.Object, "status", "desired", "version")
+	return desired
+}
+
+// VersionAtLeast reports whether a version such as v1.29.5+k3s1 or 4.16.3 is at least
+// major.minor, so services can pick the status layout of the release. A version that
+// does not parse is never at least anything.
+func VersionAtLeast(v string, major, minor int) bool {
+	parts := strings.SplitN(strings.TrimPrefix(v, "v"), ".", 3)
+	if len(parts) < 2 {
+		return false
+	}
+	gotMajor, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false
+	}
+	// Minor versions may carry a suffix, e.g. 29+ on managed clusters
+	gotMinor, err := strconv.Atoi(strings.TrimRightFunc(parts[1], func(r rune) bool { return r < '0' || r > '9' }))
+	if err != nil {
+		return false
+	}
+	return gotMajor > major || (gotMajor == major && gotMinor >= minor)
+}
+
+// Made with Bob
@@ -0,0 +1,120 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+type VersionSuite struct {
+	suite.Suite
+}
+
+// clusterVersion returns the ClusterVersion of a cluster updated from 4.15.20 to 4.16.3
+func clusterVersion(history ...interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("config.openshift.io/v1")
+	obj.SetKind("ClusterVersion")
+	obj.SetName("version")
+	_ = unstructured.SetNestedField(obj.Object, "4.16.3", "status", "desired", "version")
+	_ = unstructured.SetNestedSlice(obj.Object, history, "status", "history")
+	return obj
+}
+
+func (s *VersionSuite) TestServerVersion() {
+	clientset := kubefake.NewSimpleClientset()
+	clientset.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &version.Info{GitVersion: "v1.29.5"}
+	calls := 0
+	fail := false
+	clientset.PrependReactor("get", "version", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		calls++
+		if fail {
+			return true, nil, errors.New("connection refused")
+		}
+		return false, nil, nil
+	})
+	client := &ClusterClient{Name: "prod-1", Clientset: clientset}
+
+	s.Run("discovers the version once", func() {
+		for range 3 {
+			info, err := client.ServerVersion()
+			s.Require().NoError(err)
+			s.Equal("v1.29.5", info.GitVersion)
+		}
+		s.Equal(1, calls)
+	})
+	s.Run("does not cache failures", func() {
+		failing := &ClusterClient{Name: "prod-2", Clientset: clientset}
+		fail = true
+		_, err := failing.ServerVersion()
+		s.Error(err)
+		fail = false
+		info, err := failing.ServerVersion()
+		s.Require().NoError(err)
+		s.Equal("v1.29.5", info.GitVersion)
+	})
+	s.Run("uses a version set by the caller", func() {
+		calls = 0
+		set := &ClusterClient{Name: "prod-3", Clientset: clientset}
+		set.SetServerVersion(&version.Info{GitVersion: "v1.30.1"})
+		info, err := set.ServerVersion()
+		s.Require().NoError(err)
+		s.Equal("v1.30.1", info.GitVersion)
+		s.Zero(calls)
+	})
+}
+
+func (s *VersionSuite) TestOpenShiftVersion() {
+	newClient := func(objects ...runtime.Object) *ClusterClient {
+		return &ClusterClient{Name: "prod-1", Dynamic: fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{ClusterVersionGVR: "ClusterVersionList"}, objects...)}
+	}
+
+	s.Run("reads the latest completed update", func() {
+		client := newClient(clusterVersion(
+			map[string]interface{}{"state": "Partial", "version": "4.16.3"},
+			map[string]interface{}{"state": "Completed", "version": "4.15.20"},
+		))
+		v, err := client.OpenShiftVersion(context.Background())
+		s.Require().NoError(err)
+		s.Equal("4.15.20", v)
+	})
+	s.Run("falls back to the desired version during install", func() {
+		client := newClient(clusterVersion(map[string]interface{}{"state": "Partial", "version": "4.16.3"}))
+		v, err := client.OpenShiftVersion(context.Background())
+		s.Require().NoError(err)
+		s.Equal("4.16.3", v)
+	})
+	s.Run("is empty on a cluster that is not OpenShift", func() {
+		v, err := newClient().OpenShiftVersion(context.Background())
+		s.NoError(err)
+		s.Empty(v)
+	})
+}
+
+func (s *VersionSuite) TestVersionAtLeast() {
+	s.True(VersionAtLeast("4.16.3", 4, 16))
+	s.True(VersionAtLeast("4.17.0", 4, 16))
+	s.True(VersionAtLeast("5.0.0", 4, 16))
+	s.False(VersionAtLeast("4.15.20", 4, 16))
+	s.True(VersionAtLeast("v1.29.5+k3s1", 1, 29))
+	s.True(VersionAtLeast("v1.29+", 1, 28))
+	s.False(VersionAtLeast("", 1, 0))
+	s.False(VersionAtLeast("unknown", 1, 0))
+}
+
+func TestVersionSuite(t *testing.T) {
+	suite.Run(t, new(VersionSuite))
+}
+
+// Made with Bob
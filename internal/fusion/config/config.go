@@ -1,15 +1,136 @@
 package config
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
 )
 
+// MaxClusterTimeoutSeconds bounds the configurable per-cluster operation timeout
+const MaxClusterTimeoutSeconds = 3600
+
 // FusionConfig holds IBM Fusion-specific configuration
 type FusionConfig struct {
 	// Enabled controls whether Fusion tools are registered
-	Enabled bool
+	Enabled bool `json:"enabled"`
+
+	// QPS and Burst override the client-side rate limits of cluster clients
+	// Zero keeps the registry defaults
+	QPS   float32 `json:"qps,omitempty"`
+	Burst int     `json:"burst,omitempty"`
+
+	// ClusterTimeoutSeconds overrides the default per-cluster operation timeout
+	// Zero keeps the registry default of 30 seconds
+	ClusterTimeoutSeconds int `json:"clusterTimeout,omitempty"`
+
+	// EnabledTools restricts the registered tools to these names when not empty
+	// DisabledTools removes tools by name, and wins over EnabledTools
+	EnabledTools  []string `json:"enabledTools,omitempty"`
+	DisabledTools []string `json:"disabledTools,omitempty"`
+
+	// AllowedNamespaces restricts the namespaces services read when not empty, so the
+	// server can run with a service account limited to the Fusion namespaces
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+
+	// DefaultTarget is the target, in the tools' target format, applied when a caller
+	// omits the target type, e.g. {"type": "all"}
+	DefaultTarget json.RawMessage `json:"defaultTarget,omitempty"`
+
+	// AllowImpersonation lets callers act as another user or service account through the
+	// impersonateUser and impersonateGroups target options. The server's credentials must
+	// be allowed to impersonate as well.
+	AllowImpersonation bool `json:"allowImpersonation,omitempty"`
+
+	// AllowClusterRegistration enables fusion.clusters.register and unregister, which
+	// build clients from kubeconfigs the caller names
+	AllowClusterRegistration bool `json:"allowClusterRegistration,omitempty"`
+
+	// AllowAuthPlugins lets runtime registered kubeconfigs authenticate through exec
+	// plugins and auth providers, whose commands run on the server host
+	AllowAuthPlugins bool `json:"allowAuthPlugins,omitempty"`
+
+	// KubeconfigPaths are the kubeconfig files, or directories of them, callers may name
+	// Empty allows the kubeconfig files the server loads its clusters from only
+	KubeconfigPaths []string `json:"kubeconfigPaths,omitempty"`
+
+	// Groups names sets of clusters that targets of type group resolve against
+	// They are only read from the config file
+	Groups map[string]ClusterGroup `json:"groups,omitempty"`
+}
+
+// ClusterGroup lists the clusters of a named group, or the selector matching them
+type ClusterGroup struct {
+	Clusters []string `json:"clusters,omitempty"`
+	Selector string   `json:"selector,omitempty"`
+}
+
+// ClusterTimeout returns the configured per-cluster operation timeout, zero when unset
+// Values outside (0, MaxClusterTimeoutSeconds] are ignored with a warning
+func (c *FusionConfig) ClusterTimeout() time.Duration {
+	if c.ClusterTimeoutSeconds == 0 {
+		return 0
+	}
+	if c.ClusterTimeoutSeconds < 0 || c.ClusterTimeoutSeconds > MaxClusterTimeoutSeconds {
+		klog.Warningf("IBM Fusion cluster timeout %ds out of range (1-%d), using the default of 30s", c.ClusterTimeoutSeconds, MaxClusterTimeoutSeconds)
+		return 0
+	}
+	return time.Duration(c.ClusterTimeoutSeconds) * time.Second
+}
+
+// ToolEnabled reports whether the tool with the given name should be registered
+func (c *FusionConfig) ToolEnabled(name string) bool {
+	if slices.Contains(c.DisabledTools, name) {
+		return false
+	}
+	return len(c.EnabledTools) == 0 || slices.Contains(c.EnabledTools, name)
+}
+
+// ValidateToolNames returns an error naming every enabled or disabled tool that is not
+// one of knownTools, so typos are reported at startup instead of silently hiding tools
+func (c *FusionConfig) ValidateToolNames(knownTools []string) error {
+	var unknown []string
+	for _, name := range append(slices.Clone(c.EnabledTools), c.DisabledTools...) {
+		if !slices.Contains(knownTools, name) && !slices.Contains(unknown, name) {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown Fusion tools %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// Load loads Fusion configuration from the file named by FUSION_CONFIG when set,
+// and from environment variables only otherwise
+func Load() (*FusionConfig, error) {
+	if path := strings.TrimSpace(os.Getenv("FUSION_CONFIG")); path != "" {
+		return LoadFromFile(path)
+	}
+	return LoadFromEnv(), nil
+}
+
+// LoadFromFile loads Fusion configuration from a YAML file (fusion.yaml)
+// Environment variables that are set override the values of the file
+func LoadFromFile(path string) (*FusionConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Fusion config %s: %w", path, err)
+	}
+
+	cfg := &FusionConfig{}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse Fusion config %s: %w", path, err)
+	}
+
+	applyEnv(cfg)
+	return cfg, nil
 }
 
 // LoadFromEnv loads Fusion configuration from environment variables
@@ -18,6 +139,12 @@ func LoadFromEnv() *FusionConfig {
 		Enabled: false,
 	}
 
+	applyEnv(cfg)
+	return cfg
+}
+
+// applyEnv overrides cfg with the environment variables that are set to valid values
+func applyEnv(cfg *FusionConfig) {
 	// Check FUSION_TOOLS_ENABLED environment variable
 	if val := strings.TrimSpace(os.Getenv("FUSION_TOOLS_ENABLED")); val != "" {
 		enabled, err := strconv.ParseBool(val)
@@ -26,7 +153,92 @@ func LoadFromEnv() *FusionConfig {
 		}
 	}
 
-	return cfg
+	// Check FUSION_QPS and FUSION_BURST environment variables
+	if val := strings.TrimSpace(os.Getenv("FUSION_QPS")); val != "" {
+		qps, err := strconv.ParseFloat(val, 32)
+		if err == nil && qps > 0 {
+			cfg.QPS = float32(qps)
+		}
+	}
+	if val := strings.TrimSpace(os.Getenv("FUSION_BURST")); val != "" {
+		burst, err := strconv.Atoi(val)
+		if err == nil && burst > 0 {
+			cfg.Burst = burst
+		}
+	}
+
+	// Check FUSION_CLUSTER_TIMEOUT environment variable, FUSION_TIMEOUT is its older name
+	for _, name := range []string{"FUSION_TIMEOUT", "FUSION_CLUSTER_TIMEOUT"} {
+		val := strings.TrimSpace(os.Getenv(name))
+		if val == "" {
+			continue
+		}
+		seconds, err := strconv.Atoi(val)
+		if err != nil {
+			klog.Warningf("IBM Fusion %s=%q is not a number of seconds, using the default of 30s", name, val)
+			continue
+		}
+		cfg.ClusterTimeoutSeconds = seconds
+	}
+
+	// Check FUSION_ENABLED_TOOLS and FUSION_DISABLED_TOOLS environment variables
+	if val := strings.TrimSpace(os.Getenv("FUSION_ENABLED_TOOLS")); val != "" {
+		cfg.EnabledTools = splitList(val)
+	}
+	if val := strings.TrimSpace(os.Getenv("FUSION_DISABLED_TOOLS")); val != "" {
+		cfg.DisabledTools = splitList(val)
+	}
+
+	// Check FUSION_DEFAULT_TARGET environment variable, a target object or just its type
+	if val := strings.TrimSpace(os.Getenv("FUSION_DEFAULT_TARGET")); val != "" {
+		if !strings.HasPrefix(val, "{") {
+			val = fmt.Sprintf(`{"type": %q}`, val)
+		}
+		cfg.DefaultTarget = json.RawMessage(val)
+	}
+
+	// Check FUSION_ALLOW_IMPERSONATION environment variable
+	if val := strings.TrimSpace(os.Getenv("FUSION_ALLOW_IMPERSONATION")); val != "" {
+		allowed, err := strconv.ParseBool(val)
+		if err == nil {
+			cfg.AllowImpersonation = allowed
+		}
+	}
+
+	// Check FUSION_ALLOW_CLUSTER_REGISTRATION and FUSION_ALLOW_AUTH_PLUGINS environment variables
+	if val := strings.TrimSpace(os.Getenv("FUSION_ALLOW_CLUSTER_REGISTRATION")); val != "" {
+		allowed, err := strconv.ParseBool(val)
+		if err == nil {
+			cfg.AllowClusterRegistration = allowed
+		}
+	}
+	if val := strings.TrimSpace(os.Getenv("FUSION_ALLOW_AUTH_PLUGINS")); val != "" {
+		allowed, err := strconv.ParseBool(val)
+		if err == nil {
+			cfg.AllowAuthPlugins = allowed
+		}
+	}
+
+	// Check FUSION_KUBECONFIG_PATHS environment variable
+	if val := strings.TrimSpace(os.Getenv("FUSION_KUBECONFIG_PATHS")); val != "" {
+		cfg.KubeconfigPaths = splitList(val)
+	}
+
+	// Check FUSION_ALLOWED_NAMESPACES environment variable
+	if val := strings.TrimSpace(os.Getenv("FUSION_ALLOWED_NAMESPACES")); val != "" {
+		cfg.AllowedNamespaces = splitList(val)
+	}
+}
+
+// splitList splits a comma-separated list, dropping blank entries
+func splitList(val string) []string {
+	var items []string
+	for _, item := range strings.Split(val, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
 }
 
 // Made with Bob
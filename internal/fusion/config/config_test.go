@@ -1,7 +1,10 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 )
@@ -54,6 +57,259 @@ func (s *ConfigSuite) TestLoadFromEnv() {
 	})
 }
 
+func (s *ConfigSuite) TestLoadRateLimitFromEnv() {
+	s.Run("leaves rate limits unset by default", func() {
+		s.T().Setenv("FUSION_QPS", "")
+		s.T().Setenv("FUSION_BURST", "")
+		cfg := LoadFromEnv()
+		s.Zero(cfg.QPS)
+		s.Zero(cfg.Burst)
+	})
+
+	s.Run("reads QPS and burst", func() {
+		s.T().Setenv("FUSION_QPS", " 25.5 ")
+		s.T().Setenv("FUSION_BURST", "200")
+		cfg := LoadFromEnv()
+		s.Equal(float32(25.5), cfg.QPS)
+		s.Equal(200, cfg.Burst)
+	})
+
+	s.Run("ignores invalid and non-positive values", func() {
+		s.T().Setenv("FUSION_QPS", "fast")
+		s.T().Setenv("FUSION_BURST", "-1")
+		cfg := LoadFromEnv()
+		s.Zero(cfg.QPS)
+		s.Zero(cfg.Burst)
+	})
+}
+
+func (s *ConfigSuite) writeConfig(content string) string {
+	path := filepath.Join(s.T().TempDir(), "fusion.yaml")
+	s.Require().NoError(os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func (s *ConfigSuite) TestLoadFromFile() {
+	s.T().Setenv("FUSION_TOOLS_ENABLED", "")
+	s.T().Setenv("FUSION_QPS", "")
+	s.T().Setenv("FUSION_BURST", "")
+
+	s.Run("reads the file", func() {
+		cfg, err := LoadFromFile(s.writeConfig("enabled: true\nqps: 30\nburst: 60\n"))
+		s.Require().NoError(err)
+		s.True(cfg.Enabled)
+		s.Equal(float32(30), cfg.QPS)
+		s.Equal(60, cfg.Burst)
+	})
+
+	s.Run("environment variables override file values", func() {
+		s.T().Setenv("FUSION_TOOLS_ENABLED", "false")
+		s.T().Setenv("FUSION_QPS", "80")
+		cfg, err := LoadFromFile(s.writeConfig("enabled: true\nqps: 30\nburst: 60\n"))
+		s.Require().NoError(err)
+		s.False(cfg.Enabled, "FUSION_TOOLS_ENABLED overrides enabled")
+		s.Equal(float32(80), cfg.QPS, "FUSION_QPS overrides qps")
+		s.Equal(60, cfg.Burst, "burst is kept when FUSION_BURST is unset")
+	})
+
+	s.Run("fails on a missing file", func() {
+		_, err := LoadFromFile(filepath.Join(s.T().TempDir(), "missing.yaml"))
+		s.Error(err)
+	})
+
+	s.Run("fails on unknown fields", func() {
+		_, err := LoadFromFile(s.writeConfig("enabeld: true\n"))
+		s.ErrorContains(err, "enabeld")
+	})
+}
+
+func (s *ConfigSuite) TestLoad() {
+	s.T().Setenv("FUSION_TOOLS_ENABLED", "")
+
+	s.Run("uses FUSION_CONFIG when set", func() {
+		s.T().Setenv("FUSION_CONFIG", s.writeConfig("enabled: true\n"))
+		cfg, err := Load()
+		s.Require().NoError(err)
+		s.True(cfg.Enabled)
+	})
+
+	s.Run("uses environment variables otherwise", func() {
+		s.T().Setenv("FUSION_CONFIG", "")
+		s.T().Setenv("FUSION_TOOLS_ENABLED", "true")
+		cfg, err := Load()
+		s.Require().NoError(err)
+		s.True(cfg.Enabled)
+	})
+}
+
+func (s *ConfigSuite) TestToolSelection() {
+	s.Run("parses comma-separated tool lists", func() {
+		s.T().Setenv("FUSION_ENABLED_TOOLS", "fusion.backup.jobs.list, fusion.storage.summary,,")
+		s.T().Setenv("FUSION_DISABLED_TOOLS", "fusion.backup.create")
+		cfg := LoadFromEnv()
+		s.Equal([]string{"fusion.backup.jobs.list", "fusion.storage.summary"}, cfg.EnabledTools)
+		s.Equal([]string{"fusion.backup.create"}, cfg.DisabledTools)
+	})
+
+	s.Run("enables every tool by default", func() {
+		cfg := &FusionConfig{}
+		s.True(cfg.ToolEnabled("fusion.hcp.status"))
+	})
+
+	s.Run("restricts to enabled tools", func() {
+		cfg := &FusionConfig{EnabledTools: []string{"fusion.storage.summary"}}
+		s.True(cfg.ToolEnabled("fusion.storage.summary"))
+		s.False(cfg.ToolEnabled("fusion.hcp.status"))
+	})
+
+	s.Run("disabled tools win over enabled tools", func() {
+		cfg := &FusionConfig{EnabledTools: []string{"fusion.storage.summary"}, DisabledTools: []string{"fusion.storage.summary"}}
+		s.False(cfg.ToolEnabled("fusion.storage.summary"))
+	})
+
+	s.Run("reports unknown tool names", func() {
+		known := []string{"fusion.storage.summary", "fusion.backup.jobs.list"}
+		s.NoError((&FusionConfig{EnabledTools: []string{"fusion.storage.summary"}}).ValidateToolNames(known))
+
+		err := (&FusionConfig{
+			EnabledTools:  []string{"fusion.storage.sumary"},
+			DisabledTools: []string{"fusion.backup.job.list"},
+		}).ValidateToolNames(known)
+		s.ErrorContains(err, "fusion.storage.sumary")
+		s.ErrorContains(err, "fusion.backup.job.list")
+	})
+}
+
+func (s *ConfigSuite) TestLoadAllowedNamespaces() {
+	s.Run("unrestricted by default", func() {
+		s.T().Setenv("FUSION_ALLOWED_NAMESPACES", "")
+		s.Empty(LoadFromEnv().AllowedNamespaces)
+	})
+
+	s.Run("parses allowed namespaces", func() {
+		s.T().Setenv("FUSION_ALLOWED_NAMESPACES", "ibm-spectrum-fusion-ns, openshift-storage")
+		cfg := LoadFromEnv()
+		s.Equal([]string{"ibm-spectrum-fusion-ns", "openshift-storage"}, cfg.AllowedNamespaces)
+	})
+}
+
+func (s *ConfigSuite) TestLoadDefaultTarget() {
+	s.Run("accepts a target object", func() {
+		s.T().Setenv("FUSION_DEFAULT_TARGET", `{"type": "selector", "selector": "env=prod"}`)
+		s.JSONEq(`{"type": "selector", "selector": "env=prod"}`, string(LoadFromEnv().DefaultTarget))
+	})
+
+	s.Run("accepts a bare target type", func() {
+		s.T().Setenv("FUSION_DEFAULT_TARGET", "all")
+		s.JSONEq(`{"type": "all"}`, string(LoadFromEnv().DefaultTarget))
+	})
+
+	s.Run("reads the file", func() {
+		s.T().Setenv("FUSION_DEFAULT_TARGET", "")
+		cfg, err := LoadFromFile(s.writeConfig("defaultTarget:\n  type: multi\n  clusters: [prod-1, prod-2]\n"))
+		s.Require().NoError(err)
+		s.JSONEq(`{"type": "multi", "clusters": ["prod-1", "prod-2"]}`, string(cfg.DefaultTarget))
+	})
+}
+
+func (s *ConfigSuite) TestLoadAllowImpersonation() {
+	s.Run("is off by default", func() {
+		s.T().Setenv("FUSION_ALLOW_IMPERSONATION", "")
+		s.False(LoadFromEnv().AllowImpersonation)
+	})
+	s.Run("reads the environment variable", func() {
+		s.T().Setenv("FUSION_ALLOW_IMPERSONATION", "true")
+		s.True(LoadFromEnv().AllowImpersonation)
+	})
+	s.Run("reads the file", func() {
+		s.T().Setenv("FUSION_ALLOW_IMPERSONATION", "")
+		cfg, err := LoadFromFile(s.writeConfig("allowImpersonation: true\n"))
+		s.Require().NoError(err)
+		s.True(cfg.AllowImpersonation)
+	})
+}
+
+func (s *ConfigSuite) TestLoadClusterRegistration() {
+	s.Run("is off by default", func() {
+		s.T().Setenv("FUSION_ALLOW_CLUSTER_REGISTRATION", "")
+		s.T().Setenv("FUSION_ALLOW_AUTH_PLUGINS", "")
+		s.T().Setenv("FUSION_KUBECONFIG_PATHS", "")
+		cfg := LoadFromEnv()
+		s.False(cfg.AllowClusterRegistration)
+		s.False(cfg.AllowAuthPlugins)
+		s.Empty(cfg.KubeconfigPaths)
+	})
+	s.Run("reads the environment variables", func() {
+		s.T().Setenv("FUSION_ALLOW_CLUSTER_REGISTRATION", "true")
+		s.T().Setenv("FUSION_ALLOW_AUTH_PLUGINS", "true")
+		s.T().Setenv("FUSION_KUBECONFIG_PATHS", "/etc/fusion/kubeconfigs, /home/mcp/.kube/config")
+		cfg := LoadFromEnv()
+		s.True(cfg.AllowClusterRegistration)
+		s.True(cfg.AllowAuthPlugins)
+		s.Equal([]string{"/etc/fusion/kubeconfigs", "/home/mcp/.kube/config"}, cfg.KubeconfigPaths)
+	})
+	s.Run("reads the file", func() {
+		s.T().Setenv("FUSION_ALLOW_CLUSTER_REGISTRATION", "")
+		s.T().Setenv("FUSION_ALLOW_AUTH_PLUGINS", "")
+		s.T().Setenv("FUSION_KUBECONFIG_PATHS", "")
+		cfg, err := LoadFromFile(s.writeConfig("allowClusterRegistration: true\nallowAuthPlugins: true\nkubeconfigPaths: [/etc/fusion/kubeconfigs]\n"))
+		s.Require().NoError(err)
+		s.True(cfg.AllowClusterRegistration)
+		s.True(cfg.AllowAuthPlugins)
+		s.Equal([]string{"/etc/fusion/kubeconfigs"}, cfg.KubeconfigPaths)
+	})
+}
+
+func (s *ConfigSuite) TestLoadGroups() {
+	cfg, err := LoadFromFile(s.writeConfig("groups:\n  payments-prod:\n    clusters: [east-7, west-2]\n  analytics-dev:\n    selector: env=dev\n"))
+	s.Require().NoError(err)
+	s.Equal(map[string]ClusterGroup{
+		"payments-prod": {Clusters: []string{"east-7", "west-2"}},
+		"analytics-dev": {Selector: "env=dev"},
+	}, cfg.Groups)
+}
+
+func (s *ConfigSuite) TestClusterTimeout() {
+	s.T().Setenv("FUSION_TIMEOUT", "")
+
+	s.Run("unset by default", func() {
+		s.T().Setenv("FUSION_CLUSTER_TIMEOUT", "")
+		s.Zero(LoadFromEnv().ClusterTimeout())
+	})
+
+	s.Run("reads seconds", func() {
+		s.T().Setenv("FUSION_CLUSTER_TIMEOUT", " 90 ")
+		s.Equal(90*time.Second, LoadFromEnv().ClusterTimeout())
+	})
+
+	s.Run("accepts the older FUSION_TIMEOUT", func() {
+		s.T().Setenv("FUSION_CLUSTER_TIMEOUT", "")
+		s.T().Setenv("FUSION_TIMEOUT", "45")
+		s.Equal(45*time.Second, LoadFromEnv().ClusterTimeout())
+	})
+
+	s.Run("FUSION_CLUSTER_TIMEOUT wins over FUSION_TIMEOUT", func() {
+		s.T().Setenv("FUSION_CLUSTER_TIMEOUT", "120")
+		s.T().Setenv("FUSION_TIMEOUT", "45")
+		s.Equal(120*time.Second, LoadFromEnv().ClusterTimeout())
+	})
+
+	s.Run("falls back to the default on invalid values", func() {
+		s.T().Setenv("FUSION_TIMEOUT", "")
+		for _, val := range []string{"30s", "-5", "0", "86400"} {
+			s.T().Setenv("FUSION_CLUSTER_TIMEOUT", val)
+			s.Zero(LoadFromEnv().ClusterTimeout(), "value %q", val)
+		}
+	})
+
+	s.Run("reads the file", func() {
+		s.T().Setenv("FUSION_CLUSTER_TIMEOUT", "")
+		cfg, err := LoadFromFile(s.writeConfig("clusterTimeout: 60\n"))
+		s.Require().NoError(err)
+		s.Equal(time.Minute, cfg.ClusterTimeout())
+	})
+}
+
 func TestConfigSuite(t *testing.T) {
 	suite.Run(t, new(ConfigSuite))
 }
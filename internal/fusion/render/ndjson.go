@@ -0,0 +1,100 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"sync"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
+)
+
+// NDJSONWriter writes a fan-out result as JSON lines: one line per cluster result, or per
+// group of a collapsed result, then a summary line. Lines can be written as clusters
+// complete, and it is safe for concurrent use.
+type NDJSONWriter struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+// ndjsonSummary is the last line of an NDJSON result
+type ndjsonSummary struct {
+	SchemaVersion string                  `json:"schemaVersion"`
+	Target        targeting.Target        `json:"target"`
+	Summary       targeting.ResultSummary `json:"summary"`
+	Errors        map[string]string       `json:"errors,omitempty"`
+	DryRun        bool                    `json:"dryRun,omitempty"`
+}
+
+// WriteClusterResult appends the line of one cluster result
+func (w *NDJSONWriter) WriteClusterResult(clusterResult targeting.ClusterResult) error {
+	_, err := w.writeLine(clusterResult)
+	return err
+}
+
+// WriteGroup appends the line of one group of clusters of a collapsed result
+func (w *NDJSONWriter) WriteGroup(group targeting.ResultGroup) error {
+	_, err := w.writeLine(group)
+	return err
+}
+
+// WriteSummary appends the closing line with the target, summary and errors of result
+func (w *NDJSONWriter) WriteSummary(result *targeting.Result) error {
+	_, err := w.writeLine(ndjsonSummary{SchemaVersion: result.SchemaVersion, Target: result.Target, Summary: result.Summary, Errors: result.Errors, DryRun: result.DryRun})
+	return err
+}
+
+// Bytes returns the lines written so far
+func (w *NDJSONWriter) Bytes() []byte {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return bytes.Clone(w.buf.Bytes())
+}
+
+// writeLine appends v as one line, returning the line without its newline
+func (w *NDJSONWriter) writeLine(v interface{}) ([]byte, error) {
+	line, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf.Write(line)
+	w.buf.WriteByte('\n')
+	return line, nil
+}
+
+// marshalNDJSON encodes a fan-out result as JSON lines, the groups of a collapsed result
+// first then the cluster results ordered by cluster name, and any other value as a
+// single line
+func marshalNDJSON(v interface{}) ([]byte, error) {
+	result, ok := v.(*targeting.Result)
+	if !ok {
+		line, err := json.Marshal(v)
+		return append(line, '\n'), err
+	}
+
+	names := make([]string, 0, len(result.ClusterResults))
+	for name := range result.ClusterResults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w := &NDJSONWriter{}
+	for _, group := range result.Groups {
+		if err := w.WriteGroup(group); err != nil {
+			return nil, err
+		}
+	}
+	for _, name := range names {
+		if err := w.WriteClusterResult(result.ClusterResults[name]); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.WriteSummary(result); err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
+
+// Made with Bob
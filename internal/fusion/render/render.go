@@ -0,0 +1,99 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/mcplog"
+	"github.com/google/jsonschema-go/jsonschema"
+	"sigs.k8s.io/yaml"
+)
+
+// Output formats accepted by the format input of the Fusion tools
+const (
+	FormatJSON   = "json"
+	FormatYAML   = "yaml"
+	FormatNDJSON = "ndjson"
+)
+
+// FormatSchema returns the JSON schema for the format input parameter
+func FormatSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:        "string",
+		Enum:        []interface{}{FormatJSON, FormatYAML, FormatNDJSON},
+		Description: "Output format of the result: json, yaml, or ndjson with one line per cluster followed by a summary line, suited to large fleets (default: json)",
+	}
+}
+
+// Marshal encodes v in the given format, indented JSON when format is empty
+func Marshal(v interface{}, format string) ([]byte, error) {
+	switch strings.ToLower(format) {
+	case "", FormatJSON:
+		return json.MarshalIndent(v, "", "  ")
+	case FormatYAML:
+		return yaml.Marshal(v)
+	case FormatNDJSON:
+		return marshalNDJSON(v)
+	default:
+		return nil, fmt.Errorf("unsupported format %q, expected %s, %s or %s", format, FormatJSON, FormatYAML, FormatNDJSON)
+	}
+}
+
+// StreamToolCallResult runs a fan-out and encodes it in the format requested by the tool
+// call's format argument. In ndjson format each cluster line is written in completion
+// order and, as soon as its cluster completes, sent to the client as an info MCP log
+// notification, so a client following the log sees the first clusters before the slowest
+// one finishes. The tool result, with every line and the summary, is still returned once
+// all clusters completed. Lines dropped by the target's result filters are not written.
+// run must pass onResult to services.ExecuteOnClustersStream and return the final result.
+func StreamToolCallResult(params api.ToolHandlerParams, target targeting.Target, run func(onResult func(targeting.ClusterResult)) *targeting.Result) *api.ToolCallResult {
+	format, _ := params.GetArguments()["format"].(string)
+	// Groups are only known once every cluster completed
+	if !strings.EqualFold(format, FormatNDJSON) || target.Collapse {
+		return ToolCallResult(params, run(nil))
+	}
+
+	w := &NDJSONWriter{}
+	var writeErr error
+	result := run(func(clusterResult targeting.ClusterResult) {
+		if !target.Keeps(clusterResult) {
+			return
+		}
+		line, err := w.writeLine(clusterResult)
+		if err != nil {
+			if writeErr == nil {
+				writeErr = err
+			}
+			return
+		}
+		mcplog.SendMCPLog(params.Context, mcplog.LevelInfo, string(line))
+	})
+	if writeErr == nil {
+		writeErr = w.WriteSummary(result)
+	}
+	if writeErr != nil {
+		return api.NewToolCallResult("", writeErr)
+	}
+	toolCallResult := api.NewToolCallResult(string(w.Bytes()), nil)
+	toolCallResult.StructuredContent = result
+	return toolCallResult
+}
+
+// ToolCallResult encodes v in the format requested by the tool call's format argument.
+// v is the structured content of the result too, tools with an output schema return it
+// as JSON whatever the format.
+func ToolCallResult(params api.ToolHandlerParams, v interface{}) *api.ToolCallResult {
+	format, _ := params.GetArguments()["format"].(string)
+	out, err := Marshal(v, format)
+	if err != nil {
+		return api.NewToolCallResult("", err)
+	}
+	toolCallResult := api.NewToolCallResult(string(out), nil)
+	toolCallResult.StructuredContent = v
+	return toolCallResult
+}
+
+// Made with Bob
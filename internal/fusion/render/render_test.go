@@ -0,0 +1,129 @@
+package render
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/stretchr/testify/suite"
+)
+
+type RenderSuite struct {
+	suite.Suite
+}
+
+func (s *RenderSuite) TestMarshal() {
+	value := map[string]interface{}{
+		"summary": map[string]int{"total": 2},
+		"data":    json.RawMessage(`{"installed":true}`),
+	}
+	s.Run("defaults to indented JSON", func() {
+		out, err := Marshal(value, "")
+		s.Require().NoError(err)
+		s.Equal("{\n  \"data\": {\n    \"installed\": true\n  },\n  \"summary\": {\n    \"total\": 2\n  }\n}", string(out))
+	})
+	s.Run("encodes YAML including raw JSON data", func() {
+		out, err := Marshal(value, "YAML")
+		s.Require().NoError(err)
+		s.Equal("data:\n  installed: true\nsummary:\n  total: 2\n", string(out))
+	})
+	s.Run("encodes other values as a single JSON line", func() {
+		out, err := Marshal(value, "ndjson")
+		s.Require().NoError(err)
+		s.Equal("{\"data\":{\"installed\":true},\"summary\":{\"total\":2}}\n", string(out))
+	})
+	s.Run("rejects unknown formats", func() {
+		_, err := Marshal(value, "xml")
+		s.ErrorContains(err, `unsupported format "xml"`)
+	})
+}
+
+func (s *RenderSuite) TestMarshalNDJSON() {
+	result := targeting.NewResult(targeting.Target{Type: targeting.TargetAll})
+	result.SetClusterResult(targeting.ClusterResult{ClusterName: "prod-2", Success: true, Data: json.RawMessage(`{"installed":true}`)})
+	result.SetClusterResult(targeting.ClusterResult{ClusterName: "prod-1", Error: "forbidden"})
+	result.Finalize()
+
+	out, err := Marshal(result, FormatNDJSON)
+	s.Require().NoError(err)
+	lines := strings.Split(strings.TrimSuffix(string(out), "\n"), "\n")
+	s.Require().Len(lines, 3)
+	s.Run("one line per cluster ordered by name", func() {
+		s.Contains(lines[0], `"clusterName":"prod-1"`)
+		s.Contains(lines[1], `"clusterName":"prod-2"`)
+		s.Contains(lines[1], `"data":{"installed":true}`)
+	})
+	s.Run("closing summary line", func() {
+		var summary struct {
+			SchemaVersion string                  `json:"schemaVersion"`
+			Summary       targeting.ResultSummary `json:"summary"`
+			Errors        map[string]string       `json:"errors"`
+		}
+		s.Require().NoError(json.Unmarshal([]byte(lines[2]), &summary))
+		s.Equal(targeting.SchemaVersion, summary.SchemaVersion)
+		s.Equal(2, summary.Summary.Total)
+		s.Equal(1, summary.Summary.Failed)
+		s.Equal("forbidden", summary.Errors["prod-1"])
+	})
+}
+
+func (s *RenderSuite) TestNDJSONWriter() {
+	w := &NDJSONWriter{}
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			s.NoError(w.WriteClusterResult(targeting.ClusterResult{ClusterName: fmt.Sprintf("cluster-%d", i), Success: true}))
+		}(i)
+	}
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimSuffix(string(w.Bytes()), "\n"), "\n")
+	s.Len(lines, 20)
+	for _, line := range lines {
+		s.True(json.Valid([]byte(line)), "every line is a whole JSON document: %s", line)
+	}
+}
+
+// formatRequest is a tool call requesting the given output format
+type formatRequest string
+
+func (f formatRequest) GetArguments() map[string]any {
+	return map[string]any{"format": string(f)}
+}
+
+func (s *RenderSuite) TestToolCallResult() {
+	result := targeting.NewResult(targeting.Target{Type: targeting.TargetAll})
+	result.SetClusterResult(targeting.ClusterResult{ClusterName: "prod-1", Success: true, Data: json.RawMessage(`{"installed":true}`)})
+	result.Finalize()
+
+	for _, format := range []string{FormatJSON, FormatYAML, FormatNDJSON} {
+		s.Run("structured content of format "+format, func() {
+			params := api.ToolHandlerParams{Context: context.Background(), ToolCallRequest: formatRequest(format)}
+			toolCallResult := ToolCallResult(params, result)
+			s.Require().NoError(toolCallResult.Error)
+			s.Same(result, toolCallResult.StructuredContent, "the result whatever the text format")
+
+			streamed := StreamToolCallResult(params, targeting.Target{Type: targeting.TargetAll}, func(onResult func(targeting.ClusterResult)) *targeting.Result {
+				if onResult != nil {
+					onResult(result.ClusterResults["prod-1"])
+				}
+				return result
+			})
+			s.Require().NoError(streamed.Error)
+			s.Same(result, streamed.StructuredContent)
+		})
+	}
+}
+
+func TestRenderSuite(t *testing.T) {
+	suite.Run(t, new(RenderSuite))
+}
+
+// Made with Bob
@@ -0,0 +1,263 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+)
+
+// MonitoringNamespace is the namespace of the OpenShift platform monitoring stack
+const MonitoringNamespace = "openshift-monitoring"
+
+// Alert sources reported in AlertList.Source
+const (
+	AlertSourceAlertmanager = "alertmanager"
+	AlertSourceThanos       = "thanos"
+)
+
+// RouteGVR identifies OpenShift Route resources
+var RouteGVR = schema.GroupVersionResource{
+	Group:    "route.openshift.io",
+	Version:  "v1",
+	Resource: "routes",
+}
+
+// FusionAlertKeywords select the storage, DR and Fusion related alerts by a
+// case-insensitive substring of their alertname
+var FusionAlertKeywords = []string{
+	"ceph", "odf", "noobaa", "persistentvolume", "volumesync", "ramen", "drpc",
+	"velero", "oadp", "fusion", "spectrum", "gpfs",
+}
+
+// AlertsService provides access to firing alerts of the cluster monitoring stack
+type AlertsService struct{}
+
+// NewAlertsService creates a new alerts service
+func NewAlertsService() *AlertsService {
+	return &AlertsService{}
+}
+
+// AlertInfo describes a firing alert
+type AlertInfo struct {
+	Name        string     `json:"name"`
+	Severity    string     `json:"severity,omitempty"`
+	Summary     string     `json:"summary,omitempty"`
+	Namespace   string     `json:"namespace,omitempty"`
+	ActiveSince *time.Time `json:"activeSince,omitempty"`
+}
+
+// AlertList reports the firing alerts of a cluster. Source is empty when no
+// Alertmanager or Thanos Querier could be found, with Message explaining why.
+type AlertList struct {
+	Source  string      `json:"source,omitempty"`
+	URL     string      `json:"url,omitempty"`
+	Alerts  []AlertInfo `json:"alerts"`
+	Message string      `json:"message,omitempty"`
+}
+
+// alertSources are the Services alerts are read from, in order of preference
+var alertSources = []struct {
+	source  string
+	service monitoringService
+}{
+	{AlertSourceAlertmanager, monitoringService{name: "alertmanager-main", port: "web"}},
+	{AlertSourceThanos, monitoringService{name: "thanos-querier", port: "web"}},
+}
+
+// ListFiring queries the in-cluster Alertmanager, or the Thanos Querier when no
+// Alertmanager Service exists, for firing alerts. Unless all is set only alerts matching
+// FusionAlertKeywords are returned. Requests go through the API server service proxy so
+// they carry the same credentials, and reach the same endpoint, as API calls.
+func (s *AlertsService) ListFiring(ctx context.Context, client *clients.ClusterClient, all bool) (*AlertList, error) {
+	result := &AlertList{
+		Alerts: []AlertInfo{},
+	}
+
+	source, service, err := s.discover(ctx, client.Clientset)
+	if err != nil {
+		return nil, err
+	}
+	if source == "" {
+		result.Message = fmt.Sprintf("no alert source: neither an alertmanager-main nor a thanos-querier service exists in %s", MonitoringNamespace)
+		return result, nil
+	}
+	result.Source = source
+
+	var alerts []AlertInfo
+	switch source {
+	case AlertSourceAlertmanager:
+		result.URL = s.url(client, service, "/api/v2/alerts")
+		alerts, err = s.fetchAlertmanager(ctx, client.Clientset, service)
+	default:
+		result.URL = s.url(client, service, "/api/v1/alerts")
+		alerts, err = s.fetchThanos(ctx, client.Clientset, service)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, alert := range alerts {
+		if all || matchesKeywords(alert.Name, FusionAlertKeywords) {
+			result.Alerts = append(result.Alerts, alert)
+		}
+	}
+	sort.SliceStable(result.Alerts, func(i, j int) bool {
+		if result.Alerts[i].Severity != result.Alerts[j].Severity {
+			return severityRank(result.Alerts[i].Severity) < severityRank(result.Alerts[j].Severity)
+		}
+		return result.Alerts[i].Name < result.Alerts[j].Name
+	})
+
+	return result, nil
+}
+
+// discover finds the Alertmanager Service, falling back to the Thanos Querier
+func (s *AlertsService) discover(ctx context.Context, clientset kubernetes.Interface) (string, monitoringService, error) {
+	for _, candidate := range alertSources {
+		_, err := clientset.CoreV1().Services(MonitoringNamespace).Get(ctx, candidate.service.name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return "", monitoringService{}, fmt.Errorf("failed to get service %s/%s: %w", MonitoringNamespace, candidate.service.name, err)
+		}
+		return candidate.source, candidate.service, nil
+	}
+
+	return "", monitoringService{}, nil
+}
+
+// url is the API server URL proxying path of the Service
+func (s *AlertsService) url(client *clients.ClusterClient, service monitoringService, path string) string {
+	var host string
+	if client.Config != nil {
+		host = strings.TrimSuffix(client.Config.Host, "/")
+	}
+	return host + service.proxyPath(path)
+}
+
+// fetchAlertmanager reads active, neither silenced nor inhibited, alerts from the
+// Alertmanager v2 API
+func (s *AlertsService) fetchAlertmanager(ctx context.Context, clientset kubernetes.Interface, service monitoringService) ([]AlertInfo, error) {
+	var response []struct {
+		Labels      map[string]string `json:"labels"`
+		Annotations map[string]string `json:"annotations"`
+		StartsAt    time.Time         `json:"startsAt"`
+	}
+	params := map[string]string{"active": "true", "silenced": "false", "inhibited": "false"}
+	if err := service.getJSON(ctx, clientset, "/api/v2/alerts", params, &response); err != nil {
+		return nil, err
+	}
+
+	alerts := make([]AlertInfo, 0, len(response))
+	for _, alert := range response {
+		startsAt := alert.StartsAt
+		alerts = append(alerts, newAlertInfo(alert.Labels, alert.Annotations, &startsAt))
+	}
+	return alerts, nil
+}
+
+// fetchThanos reads firing alerts from the Prometheus compatible alerts API of the Thanos Querier
+func (s *AlertsService) fetchThanos(ctx context.Context, clientset kubernetes.Interface, service monitoringService) ([]AlertInfo, error) {
+	var response struct {
+		Data struct {
+			Alerts []struct {
+				Labels      map[string]string `json:"labels"`
+				Annotations map[string]string `json:"annotations"`
+				State       string            `json:"state"`
+				ActiveAt    *time.Time        `json:"activeAt"`
+			} `json:"alerts"`
+		} `json:"data"`
+	}
+	if err := service.getJSON(ctx, clientset, "/api/v1/alerts", nil, &response); err != nil {
+		return nil, err
+	}
+
+	alerts := make([]AlertInfo, 0, len(response.Data.Alerts))
+	for _, alert := range response.Data.Alerts {
+		if alert.State != "firing" {
+			continue
+		}
+		alerts = append(alerts, newAlertInfo(alert.Labels, alert.Annotations, alert.ActiveAt))
+	}
+	return alerts, nil
+}
+
+// newAlertInfo builds an AlertInfo from alert labels and annotations
+func newAlertInfo(labels, annotations map[string]string, activeSince *time.Time) AlertInfo {
+	summary := annotations["summary"]
+	if summary == "" {
+		summary = annotations["message"]
+	}
+	if summary == "" {
+		summary = annotations["description"]
+	}
+	return AlertInfo{
+		Name:        labels["alertname"],
+		Severity:    labels["severity"],
+		Summary:     summary,
+		Namespace:   labels["namespace"],
+		ActiveSince: activeSince,
+	}
+}
+
+// monitoringService is a port of a Service of the monitoring stack. It is queried through
+// the API server service proxy, so requests stay on the API endpoint, verified against its
+// CA, rather than sending the cluster credentials to a Route host.
+type monitoringService struct {
+	name string
+	port string
+}
+
+// proxyPath is the API server path proxying path of the Service
+func (m monitoringService) proxyPath(path string) string {
+	return fmt.Sprintf("/api/v1/namespaces/%s/services/https:%s:%s/proxy%s", MonitoringNamespace, m.name, m.port, path)
+}
+
+// getJSON issues a GET request for path of the Service through the API server service
+// proxy and decodes the JSON response body
+func (m monitoringService) getJSON(ctx context.Context, clientset kubernetes.Interface, path string, params map[string]string, out interface{}) error {
+	body, err := clientset.CoreV1().Services(MonitoringNamespace).ProxyGet("https", m.name, m.port, path, params).DoRaw(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query %s of service %s/%s: %w", path, MonitoringNamespace, m.name, err)
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to decode response of %s of service %s/%s: %w", path, MonitoringNamespace, m.name, err)
+	}
+	return nil
+}
+
+// matchesKeywords reports whether name contains any of the keywords, ignoring case
+func matchesKeywords(name string, keywords []string) bool {
+	lower := strings.ToLower(name)
+	for _, keyword := range keywords {
+		if strings.Contains(lower, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// severityRank orders alert severities from most to least severe
+func severityRank(severity string) int {
+	switch severity {
+	case "critical":
+		return 0
+	case "warning":
+		return 1
+	case "info":
+		return 2
+	}
+	return 3
+}
+
+// Made with Bob
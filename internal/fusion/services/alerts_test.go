@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+type AlertsSuite struct {
+	suite.Suite
+}
+
+// alertmanagerAlerts are active alerts of the Alertmanager v2 API
+const alertmanagerAlerts = `[
+	{"labels": {"alertname": "KubePodCrashLooping", "severity": "warning", "namespace": "shop"}, "annotations": {"summary": "Pod is crash looping"}, "startsAt": "2026-10-16T08:00:00Z"},
+	{"labels": {"alertname": "CephClusterWarningState", "severity": "warning", "namespace": "openshift-storage"}, "annotations": {"message": "Storage cluster is in warning state"}, "startsAt": "2026-10-16T09:00:00Z"},
+	{"labels": {"alertname": "VeleroBackupFailed", "severity": "info"}, "annotations": {"description": "Backup failed"}, "startsAt": "2026-10-16T10:00:00Z"},
+	{"labels": {"alertname": "CephClusterCriticallyFull", "severity": "critical", "namespace": "openshift-storage"}, "annotations": {"summary": "Storage cluster is critically full"}, "startsAt": "2026-10-16T07:00:00Z"}
+]`
+
+// thanosAlerts are alerts of the Prometheus compatible alerts API of the Thanos Querier
+const thanosAlerts = `{"status": "success", "data": {"alerts": [
+	{"labels": {"alertname": "ODFMirrorDaemonStatus", "severity": "critical"}, "annotations": {"summary": "Mirror daemon is unhealthy"}, "state": "firing", "activeAt": "2026-10-16T06:00:00Z"},
+	{"labels": {"alertname": "NooBaaBucketErrorState", "severity": "warning"}, "annotations": {"summary": "Bucket in error"}, "state": "pending", "activeAt": "2026-10-16T11:00:00Z"}
+]}}`
+
+// alertsServer serves the given monitoring Services and, through the service proxy,
+// the Alertmanager and Thanos Querier alerts APIs. Every other request is not found.
+func alertsServer(services ...string) *httptest.Server {
+	exists := map[string]bool{}
+	for _, name := range services {
+		exists[name] = true
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		alertmanager := monitoringService{name: "alertmanager-main", port: "web"}
+		thanos := monitoringService{name: "thanos-querier", port: "web"}
+		switch r.URL.Path {
+		case "/api/v1/namespaces/openshift-monitoring/services/alertmanager-main", "/api/v1/namespaces/openshift-monitoring/services/thanos-querier":
+			name := r.URL.Path[len("/api/v1/namespaces/openshift-monitoring/services/"):]
+			if exists[name] {
+				_, _ = fmt.Fprintf(w, `{"kind":"Service","apiVersion":"v1","metadata":{"name":%q,"namespace":"openshift-monitoring"}}`, name)
+				return
+			}
+		case alertmanager.proxyPath("/api/v2/alerts"):
+			if r.URL.Query().Get("active") == "true" && r.URL.Query().Get("silenced") == "false" && r.URL.Query().Get("inhibited") == "false" {
+				_, _ = fmt.Fprint(w, alertmanagerAlerts)
+				return
+			}
+		case thanos.proxyPath("/api/v1/alerts"):
+			_, _ = fmt.Fprint(w, thanosAlerts)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprint(w, `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`)
+	}))
+}
+
+func (s *AlertsSuite) TestDiscover() {
+	service := NewAlertsService()
+	monitoringServiceObject := func(name string) *corev1.Service {
+		return &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: MonitoringNamespace}}
+	}
+
+	s.Run("prefers the Alertmanager", func() {
+		clientset := fake.NewSimpleClientset(monitoringServiceObject("alertmanager-main"), monitoringServiceObject("thanos-querier"))
+		source, found, err := service.discover(context.Background(), clientset)
+		s.Require().NoError(err)
+		s.Equal(AlertSourceAlertmanager, source)
+		s.Equal("alertmanager-main", found.name)
+	})
+	s.Run("falls back to the Thanos Querier", func() {
+		clientset := fake.NewSimpleClientset(monitoringServiceObject("thanos-querier"))
+		source, found, err := service.discover(context.Background(), clientset)
+		s.Require().NoError(err)
+		s.Equal(AlertSourceThanos, source)
+		s.Equal("thanos-querier", found.name)
+	})
+	s.Run("finds no source when neither exists", func() {
+		source, _, err := service.discover(context.Background(), fake.NewSimpleClientset())
+		s.Require().NoError(err)
+		s.Empty(source)
+	})
+	s.Run("fails on other errors", func() {
+		clientset := fake.NewSimpleClientset()
+		clientset.PrependReactor("get", "services", func(k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, errors.New("connection refused")
+		})
+		_, _, err := service.discover(context.Background(), clientset)
+		s.EqualError(err, "failed to get service openshift-monitoring/alertmanager-main: connection refused")
+	})
+}
+
+func (s *AlertsSuite) TestFetch() {
+	server := alertsServer()
+	defer server.Close()
+	client := probeClient(s.T(), server)
+	service := NewAlertsService()
+
+	s.Run("decodes Alertmanager alerts", func() {
+		alerts, err := service.fetchAlertmanager(context.Background(), client.Clientset, alertSources[0].service)
+		s.Require().NoError(err)
+		s.Require().Len(alerts, 4)
+		s.Equal("KubePodCrashLooping", alerts[0].Name)
+		s.Equal("warning", alerts[0].Severity)
+		s.Equal("shop", alerts[0].Namespace)
+		s.Equal("Pod is crash looping", alerts[0].Summary)
+		s.Equal(time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC), alerts[0].ActiveSince.UTC())
+		s.Equal("Storage cluster is in warning state", alerts[1].Summary, "the message annotation when there is no summary")
+		s.Equal("Backup failed", alerts[2].Summary, "the description annotation when there is no message")
+	})
+	s.Run("decodes firing Thanos Querier alerts", func() {
+		alerts, err := service.fetchThanos(context.Background(), client.Clientset, alertSources[1].service)
+		s.Require().NoError(err)
+		s.Require().Len(alerts, 1, "pending alerts are skipped")
+		s.Equal("ODFMirrorDaemonStatus", alerts[0].Name)
+		s.Equal("critical", alerts[0].Severity)
+		s.Equal(time.Date(2026, 10, 16, 6, 0, 0, 0, time.UTC), alerts[0].ActiveSince.UTC())
+	})
+	s.Run("reports API errors", func() {
+		_, err := service.fetchThanos(context.Background(), client.Clientset, monitoringService{name: "missing", port: "web"})
+		s.ErrorContains(err, "failed to query /api/v1/alerts of service openshift-monitoring/missing")
+	})
+}
+
+func (s *AlertsSuite) TestListFiring() {
+	service := NewAlertsService()
+
+	s.Run("returns Fusion related alerts, most severe first", func() {
+		server := alertsServer("alertmanager-main", "thanos-querier")
+		defer server.Close()
+		list, err := service.ListFiring(context.Background(), probeClient(s.T(), server), false)
+		s.Require().NoError(err)
+		s.Equal(AlertSourceAlertmanager, list.Source)
+		s.Equal(server.URL+"/api/v1/namespaces/openshift-monitoring/services/https:alertmanager-main:web/proxy/api/v2/alerts", list.URL)
+		names := make([]string, 0, len(list.Alerts))
+		for _, alert := range list.Alerts {
+			names = append(names, alert.Name)
+		}
+		s.Equal([]string{"CephClusterCriticallyFull", "CephClusterWarningState", "VeleroBackupFailed"}, names)
+	})
+	s.Run("returns every alert when all is set", func() {
+		server := alertsServer("alertmanager-main")
+		defer server.Close()
+		list, err := service.ListFiring(context.Background(), probeClient(s.T(), server), true)
+		s.Require().NoError(err)
+		names := make([]string, 0, len(list.Alerts))
+		for _, alert := range list.Alerts {
+			names = append(names, alert.Name)
+		}
+		s.Equal([]string{"CephClusterCriticallyFull", "CephClusterWarningState", "KubePodCrashLooping", "VeleroBackupFailed"}, names, "by severity, then by name")
+	})
+	s.Run("falls back to the Thanos Querier", func() {
+		server := alertsServer("thanos-querier")
+		defer server.Close()
+		list, err := service.ListFiring(context.Background(), probeClient(s.T(), server), false)
+		s.Require().NoError(err)
+		s.Equal(AlertSourceThanos, list.Source)
+		s.Require().Len(list.Alerts, 1)
+		s.Equal("ODFMirrorDaemonStatus", list.Alerts[0].Name)
+	})
+	s.Run("reports no alert source", func() {
+		server := alertsServer()
+		defer server.Close()
+		list, err := service.ListFiring(context.Background(), probeClient(s.T(), server), false)
+		s.Require().NoError(err)
+		s.Empty(list.Source)
+		s.Empty(list.Alerts)
+		s.Equal("no alert source: neither an alertmanager-main nor a thanos-querier service exists in openshift-monitoring", list.Message)
+	})
+}
+
+func (s *AlertsSuite) TestMatchesKeywords() {
+	s.True(matchesKeywords("CephOSDDiskNotResponding", FusionAlertKeywords))
+	s.True(matchesKeywords("KubePersistentVolumeFillingUp", FusionAlertKeywords), "case-insensitive")
+	s.False(matchesKeywords("Watchdog", FusionAlertKeywords))
+}
+
+func TestAlertsSuite(t *testing.T) {
+	suite.Run(t, new(AlertsSuite))
+}
+
+// Made with Bob
@@ -2,13 +2,21 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
 	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/utils/ptr"
 )
 
 // BackupService provides backup and restore operations
@@ -30,64 +38,822 @@ type BackupJob struct {
 	Status     string    `json:"status"`
 	StartTime  time.Time `json:"startTime,omitempty"`
 	Completion time.Time `json:"completionTime,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
 	Age        string    `json:"age"`
 }
 
-// BackupJobsList represents a list of backup jobs
+// OADPNamespace is the namespace OADP installs Velero and its resources into
+const OADPNamespace = "openshift-adp"
+
+// Backup sources reported in BackupJobsList.Source
+const (
+	BackupSourceVelero = "velero"
+	BackupSourceJobs   = "jobs"
+)
+
+// VeleroBackupGVR identifies Velero Backup resources
+var VeleroBackupGVR = schema.GroupVersionResource{
+	Group:    "velero.io",
+	Version:  "v1",
+	Resource: "backups",
+}
+
+// VeleroBackup represents a Velero Backup custom resource
+type VeleroBackup struct {
+	Name            string     `json:"name"`
+	Namespace       string     `json:"namespace"`
+	Phase           string     `json:"phase"`
+	StorageLocation string     `json:"storageLocation,omitempty"`
+	StartTime       *time.Time `json:"startTime,omitempty"`
+	Completion      *time.Time `json:"completionTime,omitempty"`
+	ItemsBackedUp   int64      `json:"itemsBackedUp"`
+	TotalItems      int64      `json:"totalItems"`
+	Errors          int64      `json:"errors,omitempty"`
+	Warnings        int64      `json:"warnings,omitempty"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	Age             string     `json:"age"`
+}
+
+// BackupJobsList represents a list of backups, read from Velero Backup resources or,
+// without the Velero CRD, from backup Jobs. Source tells which one produced the data.
+// The counts cover every backup, Backups and Jobs are capped to the newest ones.
 type BackupJobsList struct {
 	ComponentStatus
-	Jobs []BackupJob `json:"jobs,omitempty"`
+	BackupCounts
+	Source  string         `json:"source,omitempty"`
+	Backups []VeleroBackup `json:"backups,omitempty"`
+	Jobs    []BackupJob    `json:"jobs,omitempty"`
 }
 
-// ListJobs lists backup jobs
-func (s *BackupService) ListJobs(ctx context.Context, clusterClient *clients.ClusterClient) (*BackupJobsList, error) {
+// BackupCounts counts backups by phase (Velero) or status (Jobs). Truncated is set when
+// backups were left out of the detail list.
+type BackupCounts struct {
+	Total     int            `json:"total"`
+	ByPhase   map[string]int `json:"byPhase,omitempty"`
+	Truncated bool           `json:"truncated,omitempty"`
+}
+
+// count records a backup in the given phase
+func (c *BackupCounts) count(phase string) {
+	if c.ByPhase == nil {
+		c.ByPhase = map[string]int{}
+	}
+	c.Total++
+	c.ByPhase[phase]++
+}
+
+// ListJobs lists backups, preferring Velero Backup resources and falling back to
+// backup Jobs when the Velero CRD is not installed. Backups are read page by page and
+// at most maxItems of the newest are returned (DefaultMaxItems when 0 or less). OADP is
+// looked for in namespace when set, in OADPNamespace otherwise.
+func (s *BackupService) ListJobs(ctx context.Context, clusterClient *clients.ClusterClient, namespace string, maxItems int) (*BackupJobsList, error) {
 	result := &BackupJobsList{
 		Jobs: []BackupJob{},
 	}
 
 	// Check for OADP namespace (OpenShift API for Data Protection)
-	oadpNamespace := "openshift-adp"
-	if !CheckNamespaceExists(ctx, clusterClient, oadpNamespace) {
-		result.ComponentStatus = NotInstalledStatus("OADP namespace not found")
+	namespace, reason := findNamespace(ctx, clusterClient, namespace, OADPNamespace)
+	if namespace == "" {
+		result.ComponentStatus = NotInstalledStatus(reason, "OADP namespace not found")
 		return result, nil
 	}
 
 	result.Installed = true
 
 	// Check for Velero CRD (OADP uses Velero)
-	veleroGVR := schema.GroupVersionResource{
-		Group:    "velero.io",
-		Version:  "v1",
-		Resource: "backups",
-	}
+	if CheckCRDExists(ctx, clusterClient, VeleroBackupGVR) {
+		result.Ready = true
+		result.Source = BackupSourceVelero
 
-	if !CheckCRDExists(ctx, clusterClient, veleroGVR) {
-		result.Ready = false
-		result.Message = "Velero CRDs not found"
+		backups, counts, err := s.ListBackups(ctx, clusterClient, namespace, maxItems)
+		if err != nil {
+			logDegraded(ctx, err, "Cannot list Velero backups")
+			result.noteForbidden(err)
+			result.Message = fmt.Sprintf("Failed to list Velero backups: %v", err)
+			return result, nil
+		}
+		result.Backups = backups
+		result.BackupCounts = counts
+		result.Message = fmt.Sprintf("Found %d Velero backups", counts.Total)
 		return result, nil
 	}
 
-	result.Ready = true
+	result.Ready = false
+	result.Source = BackupSourceJobs
 
 	// List backup jobs (using standard Kubernetes Jobs as fallback)
-	jobs, err := clusterClient.Clientset.BatchV1().Jobs(oadpNamespace).List(ctx, metav1.ListOptions{
-		LabelSelector: "app.kubernetes.io/component=backup",
+	maxItems = effectiveMaxItems(maxItems)
+	var newest []batchv1.Job
+	err := listPages(ctx, metav1.ListOptions{LabelSelector: "app.kubernetes.io/component=backup"}, func(opts metav1.ListOptions) (string, error) {
+		jobs, err := clusterClient.Clientset.BatchV1().Jobs(namespace).List(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		for i := range jobs.Items {
+			result.count(s.convertJob(&jobs.Items[i]).Status)
+		}
+		newest = keepNewest(append(newest, jobs.Items...), maxItems, func(job batchv1.Job) time.Time { return job.CreationTimestamp.Time })
+		return jobs.Continue, nil
 	})
 	if err != nil {
-		result.Message = fmt.Sprintf("Failed to list jobs: %v", err)
+		logDegraded(ctx, err, "Cannot list backup Jobs")
+		result.noteForbidden(err)
+		result.Message = fmt.Sprintf("Velero CRDs not found, failed to list jobs: %v", err)
 		return result, nil
 	}
 
 	// Convert to BackupJob format
-	for _, job := range jobs.Items {
-		backupJob := s.convertJob(&job)
-		result.Jobs = append(result.Jobs, backupJob)
+	for i := range newest {
+		result.Jobs = append(result.Jobs, s.convertJob(&newest[i]))
+	}
+	result.Truncated = len(result.Jobs) < result.Total
+
+	result.Message = fmt.Sprintf("Velero CRDs not found, found %d backup jobs", result.Total)
+	return result, nil
+}
+
+// ListBackups lists Velero Backup resources in namespace page by page, returning the
+// newest maxItems (DefaultMaxItems when 0 or less) and counts covering all of them
+func (s *BackupService) ListBackups(ctx context.Context, clusterClient *clients.ClusterClient, namespace string, maxItems int) ([]VeleroBackup, BackupCounts, error) {
+	counts := BackupCounts{}
+	dynamicClient, err := clusterClient.DynamicClient()
+	if err != nil {
+		return nil, counts, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
+	maxItems = effectiveMaxItems(maxItems)
+	var newest []unstructured.Unstructured
+	_, err = countPages(ctx, dynamicClient.Resource(VeleroBackupGVR).Namespace(namespace), metav1.ListOptions{}, func(items []unstructured.Unstructured) {
+		for _, item := range items {
+			counts.count(veleroPhase(item))
+		}
+		newest = keepNewest(append(newest, items...), maxItems, func(item unstructured.Unstructured) time.Time {
+			return item.GetCreationTimestamp().Time
+		})
+	})
+	if err != nil {
+		return nil, counts, err
+	}
+
+	backups := make([]VeleroBackup, 0, len(newest))
+	for _, item := range newest {
+		backups = append(backups, s.convertBackup(item))
+	}
+	counts.Truncated = len(backups) < counts.Total
+	return backups, counts, nil
+}
+
+// keepNewest sorts items newest first and drops all but the first n
+func keepNewest[T any](items []T, n int, created func(T) time.Time) []T {
+	sort.SliceStable(items, func(i, j int) bool {
+		return created(items[i]).After(created(items[j]))
+	})
+	if len(items) > n {
+		items = items[:n]
+	}
+	return items
+}
+
+// veleroPhase returns the phase of a Velero Backup, New until Velero picks it up
+func veleroPhase(item unstructured.Unstructured) string {
+	phase, _, _ := unstructured.NestedString(item.Object, "status", "phase")
+	if phase == "" {
+		return "New"
+	}
+	return phase
+}
+
+// convertBackup converts a Velero Backup resource to VeleroBackup
+func (s *BackupService) convertBackup(item unstructured.Unstructured) VeleroBackup {
+	backup := VeleroBackup{
+		Name:      item.GetName(),
+		Namespace: item.GetNamespace(),
+		CreatedAt: item.GetCreationTimestamp().Time,
+		Age:       HumanizeAge(item.GetCreationTimestamp().Time),
+	}
+
+	backup.Phase = veleroPhase(item)
+	backup.StorageLocation, _, _ = unstructured.NestedString(item.Object, "spec", "storageLocation")
+	backup.StartTime = nestedTime(item.Object, "status", "startTimestamp")
+	backup.Completion = nestedTime(item.Object, "status", "completionTimestamp")
+	backup.ItemsBackedUp, _, _ = unstructured.NestedInt64(item.Object, "status", "progress", "itemsBackedUp")
+	backup.TotalItems, _, _ = unstructured.NestedInt64(item.Object, "status", "progress", "totalItems")
+	backup.Errors, _, _ = unstructured.NestedInt64(item.Object, "status", "errors")
+	backup.Warnings, _, _ = unstructured.NestedInt64(item.Object, "status", "warnings")
+
+	return backup
+}
+
+// nestedTime reads an RFC3339 timestamp field, returning nil when it is absent or invalid
+func nestedTime(obj map[string]interface{}, fields ...string) *time.Time {
+	value, found, _ := unstructured.NestedString(obj, fields...)
+	if !found || value == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// BackupRequest describes an on-demand Velero backup
+type BackupRequest struct {
+	Name               string   `json:"name"`
+	IncludedNamespaces []string `json:"includedNamespaces,omitempty"`
+	TTL                string   `json:"ttl,omitempty"`
+	StorageLocation    string   `json:"storageLocation,omitempty"`
+	// SnapshotVolumes takes volume snapshots of the PVs, unset leaves the Velero default
+	SnapshotVolumes *bool `json:"snapshotVolumes,omitempty"`
+	// IdempotencyKey makes retries safe, a backup already created with the key is returned
+	IdempotencyKey string `json:"idempotencyKey,omitempty"`
+	DryRun         bool   `json:"dryRun,omitempty"`
+}
+
+// CreatedBackup describes a Velero Backup created on demand. Existing is set when the
+// backup was created earlier with the same idempotency key.
+type CreatedBackup struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Phase     string `json:"phase"`
+	Existing  bool   `json:"existing,omitempty"`
+	DryRunPreview
+}
+
+// CreateBackup creates a Velero Backup in the OADP namespace. It fails when OADP or
+// the Velero CRD is not installed, or when a backup with the same name exists. A backup
+// created earlier with the same idempotency key is returned instead, or is a conflict
+// when its spec differs.
+func (s *BackupService) CreateBackup(ctx context.Context, clusterClient *clients.ClusterClient, request BackupRequest) (*CreatedBackup, error) {
+	if request.Name == "" {
+		return nil, fmt.Errorf("backup name is required")
+	}
+	if err := validateIdempotencyKey(request.IdempotencyKey); err != nil {
+		return nil, err
+	}
+	spec := map[string]interface{}{}
+	setStringSlice(spec, "includedNamespaces", request.IncludedNamespaces)
+	if request.TTL != "" {
+		ttl, err := time.ParseDuration(request.TTL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ttl %q: %w", request.TTL, err)
+		}
+		if ttl < 0 {
+			return nil, fmt.Errorf("invalid ttl %q: cannot be negative", request.TTL)
+		}
+		spec["ttl"] = ttl.String()
+	}
+	if request.StorageLocation != "" {
+		spec["storageLocation"] = request.StorageLocation
+	}
+	if request.SnapshotVolumes != nil {
+		spec["snapshotVolumes"] = *request.SnapshotVolumes
 	}
 
-	result.Message = fmt.Sprintf("Found %d backup jobs", len(result.Jobs))
+	dynamicClient, err := s.veleroClient(ctx, clusterClient, VeleroBackupGVR)
+	if err != nil {
+		return nil, err
+	}
+
+	backups := dynamicClient.Resource(VeleroBackupGVR).Namespace(OADPNamespace)
+	existing, err := findIdempotent(ctx, backups, VeleroBackupGVR, request.IdempotencyKey, spec)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return &CreatedBackup{
+			Name:      existing.GetName(),
+			Namespace: existing.GetNamespace(),
+			Phase:     veleroPhase(*existing),
+			Existing:  true,
+		}, nil
+	}
+	if _, err := backups.Get(ctx, request.Name, metav1.GetOptions{}); err == nil {
+		return nil, fmt.Errorf("backup %s already exists in namespace %s", request.Name, OADPNamespace)
+	} else if !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to check for existing backup %s: %w", request.Name, err)
+	}
+
+	backup := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": VeleroBackupGVR.GroupVersion().String(),
+		"kind":       "Backup",
+		"metadata": map[string]interface{}{
+			"name":      request.Name,
+			"namespace": OADPNamespace,
+		},
+		"spec": spec,
+	}}
+	stampCreated(backup, request.IdempotencyKey)
+
+	created, err := backups.Create(ctx, backup, metav1.CreateOptions{DryRun: dryRunOption(request.DryRun)})
+	if err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("backup %s already exists in namespace %s", request.Name, OADPNamespace)
+		}
+		return nil, fmt.Errorf("failed to create backup %s: %w", request.Name, err)
+	}
+
+	result := &CreatedBackup{
+		Name:      created.GetName(),
+		Namespace: created.GetNamespace(),
+		Phase:     veleroPhase(*created),
+	}
+	if request.DryRun {
+		result.DryRunPreview = DryRunPreview{DryRun: true, Preview: created.Object}
+	}
 	return result, nil
 }
 
+// DefaultNamespaceBackupRetentionDays is how long BackupNamespace keeps a backup by default
+const DefaultNamespaceBackupRetentionDays = 7
+
+// VeleroBackupStorageLocationGVR identifies Velero BackupStorageLocation resources
+var VeleroBackupStorageLocationGVR = schema.GroupVersionResource{
+	Group:    "velero.io",
+	Version:  "v1",
+	Resource: "backupstoragelocations",
+}
+
+// NamespaceBackupRequest describes a backup of a single namespace
+type NamespaceBackupRequest struct {
+	Namespace      string `json:"namespace"`
+	RetentionDays  int    `json:"retentionDays,omitempty"`
+	IdempotencyKey string `json:"idempotencyKey,omitempty"`
+	DryRun         bool   `json:"dryRun,omitempty"`
+}
+
+// NamespaceBackup describes a namespace backup created on demand. EstimatedItems counts
+// the namespaced resources Velero will find, it is nil when API discovery failed.
+type NamespaceBackup struct {
+	CreatedBackup
+	BackupNamespace string `json:"backupNamespace"`
+	StorageLocation string `json:"storageLocation,omitempty"`
+	TTL             string `json:"ttl"`
+	EstimatedItems  *int   `json:"estimatedItems,omitempty"`
+}
+
+// BackupNamespace backs up one namespace with volume snapshots into the default backup
+// storage location, under a timestamped name and kept for RetentionDays (default 7)
+func (s *BackupService) BackupNamespace(ctx context.Context, clusterClient *clients.ClusterClient, request NamespaceBackupRequest) (*NamespaceBackup, error) {
+	if request.Namespace == "" {
+		return nil, fmt.Errorf("namespace is required")
+	}
+	retentionDays := request.RetentionDays
+	if retentionDays < 0 {
+		return nil, fmt.Errorf("retentionDays cannot be negative: %d", retentionDays)
+	}
+	if retentionDays == 0 {
+		retentionDays = DefaultNamespaceBackupRetentionDays
+	}
+	if !CheckNamespaceExists(ctx, clusterClient, request.Namespace) {
+		return nil, fmt.Errorf("namespace %s not found", request.Namespace)
+	}
+
+	backup := &NamespaceBackup{
+		BackupNamespace: request.Namespace,
+		TTL:             (time.Duration(retentionDays) * 24 * time.Hour).String(),
+	}
+	backup.StorageLocation = s.defaultStorageLocation(ctx, clusterClient)
+
+	created, err := s.CreateBackup(ctx, clusterClient, BackupRequest{
+		Name:               fmt.Sprintf("%s-%s", request.Namespace, time.Now().UTC().Format("20060102150405")),
+		IncludedNamespaces: []string{request.Namespace},
+		TTL:                backup.TTL,
+		StorageLocation:    backup.StorageLocation,
+		SnapshotVolumes:    ptr.To(true),
+		IdempotencyKey:     request.IdempotencyKey,
+		DryRun:             request.DryRun,
+	})
+	if err != nil {
+		return nil, err
+	}
+	backup.CreatedBackup = *created
+
+	if resources, err := serverResources(ctx, clusterClient); err != nil {
+		logDegraded(ctx, err, "Cannot discover resources to estimate backup items", "namespace", request.Namespace)
+	} else if dynamicClient, err := clusterClient.DynamicClient(); err == nil {
+		estimated := estimateNamespaceItems(ctx, dynamicClient, resources, request.Namespace)
+		backup.EstimatedItems = &estimated
+	}
+	return backup, nil
+}
+
+// defaultStorageLocation returns the BackupStorageLocation marked default, empty when
+// none is, leaving the choice to Velero
+func (s *BackupService) defaultStorageLocation(ctx context.Context, clusterClient *clients.ClusterClient) string {
+	dynamicClient, err := clusterClient.DynamicClient()
+	if err != nil {
+		return ""
+	}
+	list, err := dynamicClient.Resource(VeleroBackupStorageLocationGVR).Namespace(OADPNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		logDegraded(ctx, err, "Cannot list BackupStorageLocations")
+		return ""
+	}
+	for _, item := range list.Items {
+		if isDefault, _, _ := unstructured.NestedBool(item.Object, "spec", "default"); isDefault {
+			return item.GetName()
+		}
+	}
+	return ""
+}
+
+// estimateNamespaceItems counts the objects of every listable namespaced resource in a
+// namespace, one single-item page per resource using the server's remaining item count.
+// Resources that cannot be listed are skipped.
+func estimateNamespaceItems(ctx context.Context, dynamicClient dynamic.Interface, resources []*metav1.APIResourceList, namespace string) int {
+	seen := make(map[schema.GroupResource]bool)
+	estimated := 0
+	for _, list := range resources {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			continue
+		}
+		for _, resource := range list.APIResources {
+			groupResource := schema.GroupResource{Group: gv.Group, Resource: resource.Name}
+			// Subresources aren't backed up and a resource served in several versions counts once
+			if !resource.Namespaced || strings.Contains(resource.Name, "/") || !slices.Contains(resource.Verbs, "list") || seen[groupResource] {
+				continue
+			}
+			seen[groupResource] = true
+			items, err := dynamicClient.Resource(gv.WithResource(resource.Name)).Namespace(namespace).List(ctx, metav1.ListOptions{Limit: 1})
+			if err != nil {
+				continue
+			}
+			estimated += len(items.Items)
+			if remaining := items.GetRemainingItemCount(); remaining != nil {
+				estimated += int(*remaining)
+			}
+		}
+	}
+	return estimated
+}
+
+// VeleroRestoreGVR identifies Velero Restore resources
+var VeleroRestoreGVR = schema.GroupVersionResource{
+	Group:    "velero.io",
+	Version:  "v1",
+	Resource: "restores",
+}
+
+// RestoreRequest describes a Velero restore from an existing backup
+type RestoreRequest struct {
+	Name               string            `json:"name,omitempty"`
+	BackupName         string            `json:"backupName"`
+	NamespaceMapping   map[string]string `json:"namespaceMapping,omitempty"`
+	IncludedNamespaces []string          `json:"includedNamespaces,omitempty"`
+	ExcludedNamespaces []string          `json:"excludedNamespaces,omitempty"`
+	IncludedResources  []string          `json:"includedResources,omitempty"`
+	ExcludedResources  []string          `json:"excludedResources,omitempty"`
+	// IdempotencyKey makes retries safe, a restore already created with the key is returned
+	IdempotencyKey string `json:"idempotencyKey,omitempty"`
+	DryRun         bool   `json:"dryRun,omitempty"`
+}
+
+// CreatedRestore describes a Velero Restore created from a backup
+type CreatedRestore struct {
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace"`
+	BackupName string `json:"backupName"`
+	Phase      string `json:"phase"`
+	Existing   bool   `json:"existing,omitempty"`
+	DryRunPreview
+}
+
+// CreateRestore creates a Velero Restore of a Completed backup in the OADP namespace
+// Rejections by the API server, including admission webhooks, are returned verbatim.
+// A restore created earlier with the same idempotency key is returned instead.
+func (s *BackupService) CreateRestore(ctx context.Context, clusterClient *clients.ClusterClient, request RestoreRequest) (*CreatedRestore, error) {
+	if request.BackupName == "" {
+		return nil, fmt.Errorf("backup name is required")
+	}
+	if err := validateIdempotencyKey(request.IdempotencyKey); err != nil {
+		return nil, err
+	}
+	name := request.Name
+	if name == "" {
+		name = fmt.Sprintf("%s-%s", request.BackupName, time.Now().UTC().Format("20060102150405"))
+	}
+
+	spec := map[string]interface{}{
+		"backupName": request.BackupName,
+	}
+	if len(request.NamespaceMapping) > 0 {
+		mapping := make(map[string]interface{}, len(request.NamespaceMapping))
+		for from, to := range request.NamespaceMapping {
+			mapping[from] = to
+		}
+		spec["namespaceMapping"] = mapping
+	}
+	setStringSlice(spec, "includedNamespaces", request.IncludedNamespaces)
+	setStringSlice(spec, "excludedNamespaces", request.ExcludedNamespaces)
+	setStringSlice(spec, "includedResources", request.IncludedResources)
+	setStringSlice(spec, "excludedResources", request.ExcludedResources)
+
+	dynamicClient, err := s.veleroClient(ctx, clusterClient, VeleroRestoreGVR)
+	if err != nil {
+		return nil, err
+	}
+
+	restores := dynamicClient.Resource(VeleroRestoreGVR).Namespace(OADPNamespace)
+	existing, err := findIdempotent(ctx, restores, VeleroRestoreGVR, request.IdempotencyKey, spec)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return &CreatedRestore{
+			Name:       existing.GetName(),
+			Namespace:  existing.GetNamespace(),
+			BackupName: request.BackupName,
+			Phase:      veleroPhase(*existing),
+			Existing:   true,
+		}, nil
+	}
+
+	backup, err := dynamicClient.Resource(VeleroBackupGVR).Namespace(OADPNamespace).Get(ctx, request.BackupName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("backup %s not found in namespace %s", request.BackupName, OADPNamespace)
+		}
+		return nil, fmt.Errorf("failed to get backup %s: %w", request.BackupName, err)
+	}
+	if phase, _, _ := unstructured.NestedString(backup.Object, "status", "phase"); phase != "Completed" {
+		return nil, fmt.Errorf("backup %s is in phase %q, only Completed backups can be restored", request.BackupName, phase)
+	}
+
+	restore := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": VeleroRestoreGVR.GroupVersion().String(),
+		"kind":       "Restore",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": OADPNamespace,
+		},
+		"spec": spec,
+	}}
+	stampCreated(restore, request.IdempotencyKey)
+
+	created, err := restores.Create(ctx, restore, metav1.CreateOptions{DryRun: dryRunOption(request.DryRun)})
+	if err != nil {
+		var status apierrors.APIStatus
+		if errors.As(err, &status) {
+			return nil, fmt.Errorf("restore %s rejected: %s", name, status.Status().Message)
+		}
+		return nil, fmt.Errorf("failed to create restore %s: %w", name, err)
+	}
+
+	result := &CreatedRestore{
+		Name:       created.GetName(),
+		Namespace:  created.GetNamespace(),
+		BackupName: request.BackupName,
+		Phase:      veleroPhase(*created),
+	}
+	if request.DryRun {
+		result.DryRunPreview = DryRunPreview{DryRun: true, Preview: created.Object}
+	}
+	return result, nil
+}
+
+// setStringSlice sets a string list field of an unstructured spec when it is not empty
+func setStringSlice(spec map[string]interface{}, field string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+	list := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		list = append(list, value)
+	}
+	spec[field] = list
+}
+
+// veleroClient verifies OADP and the given Velero CRD are installed and returns a
+// dynamic client for the cluster
+func (s *BackupService) veleroClient(ctx context.Context, clusterClient *clients.ClusterClient, gvr schema.GroupVersionResource) (dynamic.Interface, error) {
+	if !CheckNamespaceExists(ctx, clusterClient, OADPNamespace) {
+		return nil, fmt.Errorf("OADP is not installed: namespace %s not found", OADPNamespace)
+	}
+	if !CheckCRDExists(ctx, clusterClient, gvr) {
+		return nil, fmt.Errorf("velero is not installed: %s CRD not found", gvr.GroupResource().String())
+	}
+
+	dynamicClient, err := clusterClient.DynamicClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+	return dynamicClient, nil
+}
+
+// VeleroScheduleGVR identifies Velero Schedule resources
+var VeleroScheduleGVR = schema.GroupVersionResource{
+	Group:    "velero.io",
+	Version:  "v1",
+	Resource: "schedules",
+}
+
+// BackupSchedule represents a Velero Schedule custom resource
+type BackupSchedule struct {
+	Name               string     `json:"name"`
+	Namespace          string     `json:"namespace"`
+	Schedule           string     `json:"schedule"`
+	IncludedNamespaces []string   `json:"includedNamespaces"`
+	Paused             bool       `json:"paused"`
+	Phase              string     `json:"phase,omitempty"`
+	LastBackup         *time.Time `json:"lastBackup,omitempty"`
+}
+
+// BackupSchedulesList represents the Velero schedules of a cluster
+type BackupSchedulesList struct {
+	ComponentStatus
+	Schedules []BackupSchedule `json:"schedules"`
+	Paused    int              `json:"paused"`
+}
+
+// ListSchedules lists Velero Schedule resources in the OADP namespace, namespace when
+// set or OADPNamespace otherwise
+func (s *BackupService) ListSchedules(ctx context.Context, clusterClient *clients.ClusterClient, namespace string) (*BackupSchedulesList, error) {
+	result := &BackupSchedulesList{
+		Schedules: []BackupSchedule{},
+	}
+
+	namespace, reason := findNamespace(ctx, clusterClient, namespace, OADPNamespace)
+	if namespace == "" {
+		result.ComponentStatus = NotInstalledStatus(reason, "OADP namespace not found")
+		return result, nil
+	}
+
+	result.Installed = true
+
+	if !CheckCRDExists(ctx, clusterClient, VeleroScheduleGVR) {
+		result.Ready = false
+		result.Reason = ReasonCRDAbsent
+		result.Message = "Velero Schedule CRD not found"
+		return result, nil
+	}
+
+	result.Ready = true
+
+	dynamicClient, err := clusterClient.DynamicClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
+	list, err := dynamicClient.Resource(VeleroScheduleGVR).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		logDegraded(ctx, err, "Cannot list Velero schedules")
+		result.noteForbidden(err)
+		result.Message = fmt.Sprintf("Failed to list schedules: %v", err)
+		return result, nil
+	}
+
+	for _, item := range list.Items {
+		schedule := BackupSchedule{
+			Name:               item.GetName(),
+			Namespace:          item.GetNamespace(),
+			IncludedNamespaces: []string{},
+		}
+		schedule.Schedule, _, _ = unstructured.NestedString(item.Object, "spec", "schedule")
+		schedule.Paused, _, _ = unstructured.NestedBool(item.Object, "spec", "paused")
+		schedule.Phase, _, _ = unstructured.NestedString(item.Object, "status", "phase")
+		schedule.LastBackup = nestedTime(item.Object, "status", "lastBackup")
+		if namespaces, _, _ := unstructured.NestedStringSlice(item.Object, "spec", "template", "includedNamespaces"); namespaces != nil {
+			schedule.IncludedNamespaces = namespaces
+		}
+
+		if schedule.Paused {
+			result.Paused++
+		}
+		result.Schedules = append(result.Schedules, schedule)
+	}
+
+	sort.Slice(result.Schedules, func(i, j int) bool {
+		return result.Schedules[i].Name < result.Schedules[j].Name
+	})
+
+	result.Message = fmt.Sprintf("Found %d schedules, %d paused", len(result.Schedules), result.Paused)
+	return result, nil
+}
+
+// BackupStorageLocation phases reported by Velero after validating the object store
+const (
+	StorageLocationAvailable   = "Available"
+	StorageLocationUnavailable = "Unavailable"
+)
+
+// BackupStorageLocation represents a Velero BackupStorageLocation, the object store
+// backups are written to
+type BackupStorageLocation struct {
+	Name               string     `json:"name"`
+	Provider           string     `json:"provider"`
+	Bucket             string     `json:"bucket,omitempty"`
+	Prefix             string     `json:"prefix,omitempty"`
+	Default            bool       `json:"default"`
+	Phase              string     `json:"phase,omitempty"`
+	LastValidationTime *time.Time `json:"lastValidationTime,omitempty"`
+	Message            string     `json:"message,omitempty"`
+}
+
+// BackupStorageLocationsList represents the backup storage locations of a cluster.
+// Unavailable names the locations Velero cannot reach, any of them makes the cluster
+// not ready since backups into it fail or never leave the cluster.
+type BackupStorageLocationsList struct {
+	ComponentStatus
+	Locations   []BackupStorageLocation `json:"locations"`
+	Unavailable []string                `json:"unavailable"`
+}
+
+// ListStorageLocations lists Velero BackupStorageLocation resources in the OADP
+// namespace, namespace when set or OADPNamespace otherwise, with the phase of their
+// last validation
+func (s *BackupService) ListStorageLocations(ctx context.Context, clusterClient *clients.ClusterClient, namespace string) (*BackupStorageLocationsList, error) {
+	result := &BackupStorageLocationsList{
+		Locations:   []BackupStorageLocation{},
+		Unavailable: []string{},
+	}
+
+	namespace, reason := findNamespace(ctx, clusterClient, namespace, OADPNamespace)
+	if namespace == "" {
+		result.ComponentStatus = NotInstalledStatus(reason, "OADP namespace not found")
+		return result, nil
+	}
+
+	result.Installed = true
+
+	if !CheckCRDExists(ctx, clusterClient, VeleroBackupStorageLocationGVR) {
+		result.Ready = false
+		result.Reason = ReasonCRDAbsent
+		result.Message = "Velero BackupStorageLocation CRD not found"
+		return result, nil
+	}
+
+	result.Ready = true
+
+	dynamicClient, err := clusterClient.DynamicClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
+	s.listStorageLocations(ctx, dynamicClient, namespace, result)
+	return result, nil
+}
+
+// listStorageLocations fills result with the storage locations and their readiness.
+// No location at all is as bad as an unavailable one, a location not validated yet
+// leaves the severity unknown.
+func (s *BackupService) listStorageLocations(ctx context.Context, dynamicClient dynamic.Interface, namespace string, result *BackupStorageLocationsList) {
+	list, err := dynamicClient.Resource(VeleroBackupStorageLocationGVR).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		logDegraded(ctx, err, "Cannot list BackupStorageLocations")
+		result.noteForbidden(err)
+		result.Message = fmt.Sprintf("Failed to list backup storage locations: %v", err)
+		result.RaiseSeverity(SeverityUnknown)
+		return
+	}
+
+	pending := 0
+	for _, item := range list.Items {
+		location := BackupStorageLocation{Name: item.GetName()}
+		location.Provider, _, _ = unstructured.NestedString(item.Object, "spec", "provider")
+		location.Bucket, _, _ = unstructured.NestedString(item.Object, "spec", "objectStorage", "bucket")
+		location.Prefix, _, _ = unstructured.NestedString(item.Object, "spec", "objectStorage", "prefix")
+		location.Default, _, _ = unstructured.NestedBool(item.Object, "spec", "default")
+		location.Phase, _, _ = unstructured.NestedString(item.Object, "status", "phase")
+		location.LastValidationTime = nestedTime(item.Object, "status", "lastValidationTime")
+		location.Message, _, _ = unstructured.NestedString(item.Object, "status", "message")
+
+		switch location.Phase {
+		case StorageLocationAvailable:
+		case StorageLocationUnavailable:
+			result.Unavailable = append(result.Unavailable, location.Name)
+		default:
+			pending++
+		}
+		result.Locations = append(result.Locations, location)
+	}
+
+	sort.Slice(result.Locations, func(i, j int) bool {
+		return result.Locations[i].Name < result.Locations[j].Name
+	})
+	sort.Strings(result.Unavailable)
+
+	switch {
+	case len(result.Locations) == 0:
+		result.Ready = false
+		result.Reason = ReasonNoHealthyInstance
+		result.Message = "No backup storage location configured, backups have nowhere to go"
+	case len(result.Unavailable) > 0:
+		result.Ready = false
+		if len(result.Unavailable) == len(result.Locations) {
+			result.Reason = ReasonNoHealthyInstance
+		}
+		result.Message = fmt.Sprintf("%d of %d backup storage locations unavailable: %s",
+			len(result.Unavailable), len(result.Locations), strings.Join(result.Unavailable, ", "))
+	default:
+		result.Message = fmt.Sprintf("Found %d backup storage locations, %d not validated yet", len(result.Locations), pending)
+		if pending > 0 {
+			result.RaiseSeverity(SeverityUnknown)
+		}
+	}
+}
+
 // convertJob converts a Kubernetes Job to BackupJob
 func (s *BackupService) convertJob(job *batchv1.Job) BackupJob {
 	status := "Unknown"
@@ -99,13 +865,12 @@ func (s *BackupService) convertJob(job *batchv1.Job) BackupJob {
 		status = "Running"
 	}
 
-	age := time.Since(job.CreationTimestamp.Time).Round(time.Second).String()
-
 	backupJob := BackupJob{
 		Name:      job.Name,
 		Namespace: job.Namespace,
 		Status:    status,
-		Age:       age,
+		CreatedAt: job.CreationTimestamp.Time,
+		Age:       HumanizeAge(job.CreationTimestamp.Time),
 	}
 
 	if job.Status.StartTime != nil {
@@ -0,0 +1,210 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// VeleroPodVolumeBackupGVR identifies Velero PodVolumeBackup resources, the file system
+// backups of pod volumes
+var VeleroPodVolumeBackupGVR = schema.GroupVersionResource{
+	Group:    "velero.io",
+	Version:  "v1",
+	Resource: "podvolumebackups",
+}
+
+// VeleroDataUploadGVR identifies Velero DataUpload resources, the data movement of CSI
+// snapshots to the backup storage
+var VeleroDataUploadGVR = schema.GroupVersionResource{
+	Group:    "velero.io",
+	Version:  "v2alpha1",
+	Resource: "datauploads",
+}
+
+// veleroBackupNameLabel labels the resources Velero creates for a backup with its name
+const veleroBackupNameLabel = "velero.io/backup-name"
+
+// SnapshotCounts counts the volume snapshots a backup attempted and completed
+type SnapshotCounts struct {
+	Attempted int64 `json:"attempted"`
+	Completed int64 `json:"completed"`
+}
+
+// VolumeBackupProgress reports the progress of one volume of a backup, moved by a
+// PodVolumeBackup (Pod and Volume set) or a DataUpload (PVC set)
+type VolumeBackupProgress struct {
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace,omitempty"`
+	Pod        string `json:"pod,omitempty"`
+	Volume     string `json:"volume,omitempty"`
+	PVC        string `json:"pvc,omitempty"`
+	Phase      string `json:"phase"`
+	BytesDone  int64  `json:"bytesDone"`
+	TotalBytes int64  `json:"totalBytes"`
+	Message    string `json:"message,omitempty"`
+}
+
+// BackupDetail is the full detail of one Velero Backup: its spec, its status, kept
+// verbatim in Status, and the progress of the volumes it backs up. LogLocation is where
+// Velero stores the backup log in the object storage, empty when the storage location
+// cannot be read.
+type BackupDetail struct {
+	VeleroBackup
+	IncludedNamespaces       []string               `json:"includedNamespaces,omitempty"`
+	ExcludedNamespaces       []string               `json:"excludedNamespaces,omitempty"`
+	IncludedResources        []string               `json:"includedResources,omitempty"`
+	ExcludedResources        []string               `json:"excludedResources,omitempty"`
+	TTL                      string                 `json:"ttl,omitempty"`
+	SnapshotVolumes          *bool                  `json:"snapshotVolumes,omitempty"`
+	DefaultVolumesToFsBackup *bool                  `json:"defaultVolumesToFsBackup,omitempty"`
+	Expiration               *time.Time             `json:"expiration,omitempty"`
+	FailureReason            string                 `json:"failureReason,omitempty"`
+	ValidationErrors         []string               `json:"validationErrors,omitempty"`
+	VolumeSnapshots          SnapshotCounts         `json:"volumeSnapshots"`
+	CSIVolumeSnapshots       SnapshotCounts         `json:"csiVolumeSnapshots"`
+	LogLocation              string                 `json:"logLocation,omitempty"`
+	Status                   map[string]interface{} `json:"status,omitempty"`
+	PodVolumeBackups         []VolumeBackupProgress `json:"podVolumeBackups"`
+	DataUploads              []VolumeBackupProgress `json:"dataUploads"`
+}
+
+// DescribeBackup returns the detail of the named Velero Backup in the OADP namespace
+// It fails when OADP or the Velero CRD is not installed or when there is no such backup,
+// the not found error wrapping the API error so that it classifies as notfound.
+func (s *BackupService) DescribeBackup(ctx context.Context, clusterClient *clients.ClusterClient, name string) (*BackupDetail, error) {
+	if name == "" {
+		return nil, fmt.Errorf("backup name is required")
+	}
+	dynamicClient, err := s.veleroClient(ctx, clusterClient, VeleroBackupGVR)
+	if err != nil {
+		return nil, err
+	}
+	return s.describeBackup(ctx, dynamicClient, name)
+}
+
+// describeBackup reads the backup, the storage location of its log and its volume
+// backups. Volume backups that cannot be listed, for instance on a Velero release
+// without DataUploads, are left empty.
+func (s *BackupService) describeBackup(ctx context.Context, dynamicClient dynamic.Interface, name string) (*BackupDetail, error) {
+	backup, err := dynamicClient.Resource(VeleroBackupGVR).Namespace(OADPNamespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("backup %s not found in namespace %s: %w", name, OADPNamespace, err)
+		}
+		return nil, fmt.Errorf("failed to get backup %s: %w", name, err)
+	}
+
+	detail := &BackupDetail{
+		VeleroBackup:     s.convertBackup(*backup),
+		PodVolumeBackups: []VolumeBackupProgress{},
+		DataUploads:      []VolumeBackupProgress{},
+	}
+	detail.IncludedNamespaces, _, _ = unstructured.NestedStringSlice(backup.Object, "spec", "includedNamespaces")
+	detail.ExcludedNamespaces, _, _ = unstructured.NestedStringSlice(backup.Object, "spec", "excludedNamespaces")
+	detail.IncludedResources, _, _ = unstructured.NestedStringSlice(backup.Object, "spec", "includedResources")
+	detail.ExcludedResources, _, _ = unstructured.NestedStringSlice(backup.Object, "spec", "excludedResources")
+	detail.TTL, _, _ = unstructured.NestedString(backup.Object, "spec", "ttl")
+	detail.SnapshotVolumes = nestedBool(backup.Object, "spec", "snapshotVolumes")
+	detail.DefaultVolumesToFsBackup = nestedBool(backup.Object, "spec", "defaultVolumesToFsBackup")
+	detail.Expiration = nestedTime(backup.Object, "status", "expiration")
+	detail.FailureReason, _, _ = unstructured.NestedString(backup.Object, "status", "failureReason")
+	detail.ValidationErrors, _, _ = unstructured.NestedStringSlice(backup.Object, "status", "validationErrors")
+	detail.VolumeSnapshots.Attempted, _, _ = unstructured.NestedInt64(backup.Object, "status", "volumeSnapshotsAttempted")
+	detail.VolumeSnapshots.Completed, _, _ = unstructured.NestedInt64(backup.Object, "status", "volumeSnapshotsCompleted")
+	detail.CSIVolumeSnapshots.Attempted, _, _ = unstructured.NestedInt64(backup.Object, "status", "csiVolumeSnapshotsAttempted")
+	detail.CSIVolumeSnapshots.Completed, _, _ = unstructured.NestedInt64(backup.Object, "status", "csiVolumeSnapshotsCompleted")
+	detail.Status, _, _ = unstructured.NestedMap(backup.Object, "status")
+	detail.LogLocation = s.backupLogLocation(ctx, dynamicClient, name, detail.StorageLocation)
+
+	selector := metav1.ListOptions{LabelSelector: veleroBackupNameLabel + "=" + name}
+	if list, err := dynamicClient.Resource(VeleroPodVolumeBackupGVR).Namespace(OADPNamespace).List(ctx, selector); err != nil {
+		logDegraded(ctx, err, "Cannot list PodVolumeBackups", "backup", name)
+	} else {
+		for _, item := range list.Items {
+			detail.PodVolumeBackups = append(detail.PodVolumeBackups, convertVolumeBackup(item))
+		}
+	}
+	if list, err := dynamicClient.Resource(VeleroDataUploadGVR).Namespace(OADPNamespace).List(ctx, selector); err != nil {
+		logDegraded(ctx, err, "Cannot list DataUploads", "backup", name)
+	} else {
+		for _, item := range list.Items {
+			detail.DataUploads = append(detail.DataUploads, convertVolumeBackup(item))
+		}
+	}
+	sortVolumeBackups(detail.PodVolumeBackups)
+	sortVolumeBackups(detail.DataUploads)
+
+	return detail, nil
+}
+
+// backupLogLocation returns the object storage path Velero writes the backup log to,
+// <bucket>/<prefix>/backups/<name>/<name>-logs.gz in the backup's storage location
+func (s *BackupService) backupLogLocation(ctx context.Context, dynamicClient dynamic.Interface, name, storageLocation string) string {
+	if storageLocation == "" {
+		return ""
+	}
+	location, err := dynamicClient.Resource(VeleroBackupStorageLocationGVR).Namespace(OADPNamespace).Get(ctx, storageLocation, metav1.GetOptions{})
+	if err != nil {
+		logDegraded(ctx, err, "Cannot get the BackupStorageLocation of a backup", "backup", name, "storageLocation", storageLocation)
+		return ""
+	}
+	bucket, _, _ := unstructured.NestedString(location.Object, "spec", "objectStorage", "bucket")
+	if bucket == "" {
+		return ""
+	}
+	prefix, _, _ := unstructured.NestedString(location.Object, "spec", "objectStorage", "prefix")
+	return path.Join(bucket, prefix, "backups", name, name+"-logs.gz")
+}
+
+// convertVolumeBackup converts a PodVolumeBackup or a DataUpload to VolumeBackupProgress
+func convertVolumeBackup(item unstructured.Unstructured) VolumeBackupProgress {
+	progress := VolumeBackupProgress{Name: item.GetName()}
+	switch item.GetKind() {
+	case "DataUpload":
+		progress.Namespace, _, _ = unstructured.NestedString(item.Object, "spec", "sourceNamespace")
+		progress.PVC, _, _ = unstructured.NestedString(item.Object, "spec", "sourcePVC")
+	default:
+		progress.Namespace, _, _ = unstructured.NestedString(item.Object, "spec", "pod", "namespace")
+		progress.Pod, _, _ = unstructured.NestedString(item.Object, "spec", "pod", "name")
+		progress.Volume, _, _ = unstructured.NestedString(item.Object, "spec", "volume")
+	}
+	progress.Phase, _, _ = unstructured.NestedString(item.Object, "status", "phase")
+	if progress.Phase == "" {
+		progress.Phase = "New"
+	}
+	progress.BytesDone, _, _ = unstructured.NestedInt64(item.Object, "status", "progress", "bytesDone")
+	progress.TotalBytes, _, _ = unstructured.NestedInt64(item.Object, "status", "progress", "totalBytes")
+	progress.Message, _, _ = unstructured.NestedString(item.Object, "status", "message")
+	return progress
+}
+
+// sortVolumeBackups orders volume backups by namespace and name for stable output
+func sortVolumeBackups(progress []VolumeBackupProgress) {
+	sort.Slice(progress, func(i, j int) bool {
+		if progress[i].Namespace != progress[j].Namespace {
+			return progress[i].Namespace < progress[j].Namespace
+		}
+		return progress[i].Name < progress[j].Name
+	})
+}
+
+// nestedBool reads a boolean field, returning nil when it is absent
+func nestedBool(obj map[string]interface{}, fields ...string) *bool {
+	value, found, err := unstructured.NestedBool(obj, fields...)
+	if !found || err != nil {
+		return nil
+	}
+	return &value
+}
+
+// Made with Bob
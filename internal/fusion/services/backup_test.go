@@ -0,0 +1,512 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+)
+
+type BackupSuite struct {
+	suite.Suite
+}
+
+// veleroBackup returns a Velero Backup created age ago in the given phase
+func veleroBackup(name, phase string, age time.Duration) unstructured.Unstructured {
+	backup := unstructured.Unstructured{}
+	backup.SetAPIVersion("velero.io/v1")
+	backup.SetKind("Backup")
+	backup.SetNamespace(OADPNamespace)
+	backup.SetName(name)
+	backup.SetCreationTimestamp(metav1.NewTime(time.Now().Add(-age)))
+	if phase != "" {
+		_ = unstructured.SetNestedField(backup.Object, phase, "status", "phase")
+	}
+	return backup
+}
+
+func (s *BackupSuite) TestListBackupsPages() {
+	// Two pages, served in order of the continue token
+	pages := [][]unstructured.Unstructured{
+		{veleroBackup("daily-3", "Completed", 3*time.Hour), veleroBackup("daily-1", "Failed", time.Hour)},
+		{veleroBackup("daily-2", "Completed", 2*time.Hour), veleroBackup("manual", "", time.Minute)},
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{VeleroBackupGVR: "BackupList"})
+	calls := 0
+	dynamicClient.PrependReactor("list", "backups", func(k8stesting.Action) (bool, runtime.Object, error) {
+		if calls >= len(pages) {
+			return true, nil, fmt.Errorf("unexpected page %d", calls+1)
+		}
+		list := &unstructured.UnstructuredList{Items: pages[calls]}
+		calls++
+		if calls < len(pages) {
+			list.SetContinue(fmt.Sprintf("page-%d", calls+1))
+		}
+		return true, list, nil
+	})
+	client := &clients.ClusterClient{Name: "prod-1", Dynamic: dynamicClient}
+
+	backups, counts, err := NewBackupService(nil).ListBackups(context.Background(), client, OADPNamespace, 2)
+	s.Require().NoError(err)
+
+	s.Run("reads every page", func() {
+		s.Equal(2, calls)
+	})
+	s.Run("counts cover every backup", func() {
+		s.Equal(4, counts.Total)
+		s.Equal(map[string]int{"Completed": 2, "Failed": 1, "New": 1}, counts.ByPhase)
+		s.True(counts.Truncated)
+	})
+	s.Run("details keep the newest backups", func() {
+		s.Require().Len(backups, 2)
+		s.Equal("manual", backups[0].Name)
+		s.Equal("New", backups[0].Phase)
+		s.Equal("daily-1", backups[1].Name)
+	})
+}
+
+// veleroObject returns an unstructured Velero resource in the OADP namespace
+func veleroObject(apiVersion, kind, name string, labels map[string]string, fields map[string]interface{}) *unstructured.Unstructured {
+	object := &unstructured.Unstructured{Object: fields}
+	object.SetAPIVersion(apiVersion)
+	object.SetKind(kind)
+	object.SetNamespace(OADPNamespace)
+	object.SetName(name)
+	object.SetLabels(labels)
+	return object
+}
+
+func (s *BackupSuite) TestDescribeBackup() {
+	backup := veleroBackup("daily", "PartiallyFailed", time.Hour)
+	_ = unstructured.SetNestedField(backup.Object, "default", "spec", "storageLocation")
+	_ = unstructured.SetNestedStringSlice(backup.Object, []string{"shop"}, "spec", "includedNamespaces")
+	_ = unstructured.SetNestedField(backup.Object, true, "spec", "snapshotVolumes")
+	_ = unstructured.SetNestedField(backup.Object, int64(2), "status", "errors")
+	_ = unstructured.SetNestedField(backup.Object, int64(1), "status", "csiVolumeSnapshotsAttempted")
+	owned := map[string]string{veleroBackupNameLabel: "daily"}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			VeleroBackupGVR:                "BackupList",
+			VeleroBackupStorageLocationGVR: "BackupStorageLocationList",
+			VeleroPodVolumeBackupGVR:       "PodVolumeBackupList",
+			VeleroDataUploadGVR:            "DataUploadList",
+		},
+		&backup,
+		veleroObject("velero.io/v1", "BackupStorageLocation", "default", nil, map[string]interface{}{
+			"spec": map[string]interface{}{"objectStorage": map[string]interface{}{"bucket": "backups", "prefix": "velero"}},
+		}),
+		veleroObject("velero.io/v1", "PodVolumeBackup", "daily-web", owned, map[string]interface{}{
+			"spec":   map[string]interface{}{"pod": map[string]interface{}{"namespace": "shop", "name": "web-0"}, "volume": "data"},
+			"status": map[string]interface{}{"phase": "InProgress", "progress": map[string]interface{}{"bytesDone": int64(10), "totalBytes": int64(40)}},
+		}),
+		veleroObject("velero.io/v1", "PodVolumeBackup", "weekly-web", map[string]string{veleroBackupNameLabel: "weekly"}, map[string]interface{}{}),
+		veleroObject("velero.io/v2alpha1", "DataUpload", "daily-db", owned, map[string]interface{}{
+			"spec":   map[string]interface{}{"sourceNamespace": "shop", "sourcePVC": "db-data"},
+			"status": map[string]interface{}{"phase": "Failed", "message": "snapshot timed out"},
+		}),
+	)
+	service := NewBackupService(nil)
+
+	detail, err := service.describeBackup(context.Background(), dynamicClient, "daily")
+	s.Require().NoError(err)
+	s.Run("reports the spec and status of the backup", func() {
+		s.Equal("PartiallyFailed", detail.Phase)
+		s.Equal([]string{"shop"}, detail.IncludedNamespaces)
+		s.Equal(ptr.To(true), detail.SnapshotVolumes)
+		s.Nil(detail.DefaultVolumesToFsBackup)
+		s.Equal(int64(2), detail.Errors)
+		s.Equal(SnapshotCounts{Attempted: 1}, detail.CSIVolumeSnapshots)
+		s.Equal("PartiallyFailed", detail.Status["phase"])
+	})
+	s.Run("locates the backup log in the storage location", func() {
+		s.Equal("backups/velero/backups/daily/daily-logs.gz", detail.LogLocation)
+	})
+	s.Run("reports the volume backups of the backup only", func() {
+		s.Equal([]VolumeBackupProgress{{Name: "daily-web", Namespace: "shop", Pod: "web-0", Volume: "data", Phase: "InProgress", BytesDone: 10, TotalBytes: 40}}, detail.PodVolumeBackups)
+		s.Equal([]VolumeBackupProgress{{Name: "daily-db", Namespace: "shop", PVC: "db-data", Phase: "Failed", Message: "snapshot timed out"}}, detail.DataUploads)
+	})
+	s.Run("a missing backup is not found", func() {
+		_, err := service.describeBackup(context.Background(), dynamicClient, "hourly")
+		s.Require().Error(err)
+		s.True(strings.HasPrefix(err.Error(), "backup hourly not found in namespace openshift-adp"), err.Error())
+		s.Equal(clients.ErrorKindNotFound, clients.ClassifyError(err))
+	})
+}
+
+func (s *BackupSuite) TestBackupNamespace() {
+	s.Run("rejects a missing namespace", func() {
+		client := &clients.ClusterClient{Name: "prod-1", Clientset: fake.NewSimpleClientset()}
+		_, err := NewBackupService(nil).BackupNamespace(context.Background(), client, NamespaceBackupRequest{Namespace: "shop"})
+		s.EqualError(err, "namespace shop not found")
+	})
+	s.Run("rejects a negative retention", func() {
+		client := &clients.ClusterClient{Name: "prod-1", Clientset: fake.NewSimpleClientset()}
+		_, err := NewBackupService(nil).BackupNamespace(context.Background(), client, NamespaceBackupRequest{Namespace: "shop", RetentionDays: -1})
+		s.EqualError(err, "retentionDays cannot be negative: -1")
+	})
+}
+
+// veleroCluster is a cluster with the OADP namespace and the Velero Backup and Restore
+// CRDs, serving objects through its dynamic client. Use it with veleroContext.
+func veleroCluster(objects ...runtime.Object) *clients.ClusterClient {
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{VeleroBackupGVR: "BackupList", VeleroRestoreGVR: "RestoreList"}, objects...)
+	clientset := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: OADPNamespace}})
+	return &clients.ClusterClient{Name: "prod-1", Clientset: clientset, Dynamic: dynamicClient}
+}
+
+// veleroContext is a context in which veleroCluster serves the Velero CRDs
+func veleroContext() context.Context {
+	return discoveredContext("prod-1", VeleroBackupGVR, VeleroRestoreGVR)
+}
+
+// createdDryRun reports whether the last create of a fake dynamic client was a dry run
+func createdDryRun(client *clients.ClusterClient) bool {
+	dryRun := false
+	for _, action := range client.Dynamic.(*dynamicfake.FakeDynamicClient).Actions() {
+		if create, ok := action.(k8stesting.CreateActionImpl); ok {
+			dryRun = len(create.CreateOptions.DryRun) > 0
+		}
+	}
+	return dryRun
+}
+
+func (s *BackupSuite) TestCreateBackup() {
+	service := NewBackupService(nil)
+	request := BackupRequest{Name: "manual", IncludedNamespaces: []string{"shop"}, TTL: "72h", StorageLocation: "default", SnapshotVolumes: ptr.To(true)}
+
+	s.Run("fails when OADP is not installed", func() {
+		client := veleroCluster()
+		client.Clientset = fake.NewSimpleClientset()
+		_, err := service.CreateBackup(veleroContext(), client, request)
+		s.EqualError(err, "OADP is not installed: namespace openshift-adp not found")
+	})
+	s.Run("fails when the Backup CRD is missing", func() {
+		_, err := service.CreateBackup(discoveredContext("prod-1", VeleroRestoreGVR), veleroCluster(), request)
+		s.EqualError(err, "velero is not installed: backups.velero.io CRD not found")
+	})
+	s.Run("rejects an invalid ttl", func() {
+		invalid := request
+		invalid.TTL = "3 days"
+		_, err := service.CreateBackup(veleroContext(), veleroCluster(), invalid)
+		s.ErrorContains(err, `invalid ttl "3 days"`)
+	})
+	s.Run("rejects a negative ttl", func() {
+		negative := request
+		negative.TTL = "-1h"
+		_, err := service.CreateBackup(veleroContext(), veleroCluster(), negative)
+		s.EqualError(err, `invalid ttl "-1h": cannot be negative`)
+	})
+	s.Run("refuses an existing name", func() {
+		existing := veleroBackup("manual", "Completed", time.Hour)
+		_, err := service.CreateBackup(veleroContext(), veleroCluster(&existing), request)
+		s.EqualError(err, "backup manual already exists in namespace openshift-adp")
+	})
+	s.Run("refuses a name created concurrently", func() {
+		client := veleroCluster()
+		client.Dynamic.(*dynamicfake.FakeDynamicClient).PrependReactor("create", "backups", func(k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, apierrors.NewAlreadyExists(VeleroBackupGVR.GroupResource(), "manual")
+		})
+		_, err := service.CreateBackup(veleroContext(), client, request)
+		s.EqualError(err, "backup manual already exists in namespace openshift-adp")
+	})
+	s.Run("writes the spec of the request", func() {
+		client := veleroCluster()
+		created, err := service.CreateBackup(veleroContext(), client, request)
+		s.Require().NoError(err)
+		s.Equal(CreatedBackup{Name: "manual", Namespace: OADPNamespace, Phase: "New"}, *created)
+		s.False(createdDryRun(client))
+		backup, err := client.Dynamic.Resource(VeleroBackupGVR).Namespace(OADPNamespace).Get(context.Background(), "manual", metav1.GetOptions{})
+		s.Require().NoError(err)
+		s.Equal(map[string]interface{}{
+			"includedNamespaces": []interface{}{"shop"},
+			"ttl":                "72h0m0s",
+			"storageLocation":    "default",
+			"snapshotVolumes":    true,
+		}, backup.Object["spec"])
+	})
+	s.Run("previews the backup of a dry run", func() {
+		client := veleroCluster()
+		dryRun := request
+		dryRun.DryRun = true
+		created, err := service.CreateBackup(veleroContext(), client, dryRun)
+		s.Require().NoError(err)
+		s.True(created.DryRun)
+		s.Equal("manual", created.Preview["metadata"].(map[string]interface{})["name"])
+		s.Equal("72h0m0s", created.Preview["spec"].(map[string]interface{})["ttl"])
+		s.True(createdDryRun(client), "the backup is created with the dry run option")
+	})
+}
+
+func (s *BackupSuite) TestCreateRestore() {
+	service := NewBackupService(nil)
+	request := RestoreRequest{Name: "manual-restore", BackupName: "daily", NamespaceMapping: map[string]string{"shop": "shop-restored"}}
+	completed := veleroBackup("daily", "Completed", time.Hour)
+
+	s.Run("fails when the backup is not found", func() {
+		_, err := service.CreateRestore(veleroContext(), veleroCluster(), request)
+		s.EqualError(err, "backup daily not found in namespace openshift-adp")
+	})
+	s.Run("refuses a backup that is not Completed", func() {
+		for _, phase := range []string{"InProgress", "PartiallyFailed", ""} {
+			backup := veleroBackup("daily", phase, time.Hour)
+			_, err := service.CreateRestore(veleroContext(), veleroCluster(&backup), request)
+			s.EqualError(err, fmt.Sprintf("backup daily is in phase %q, only Completed backups can be restored", phase))
+		}
+	})
+	s.Run("returns admission rejections verbatim", func() {
+		client := veleroCluster(&completed)
+		message := `admission webhook "vrestore.kb.io" denied the request: namespace shop-restored already exists`
+		client.Dynamic.(*dynamicfake.FakeDynamicClient).PrependReactor("create", "restores", func(k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, &apierrors.StatusError{ErrStatus: metav1.Status{
+				Status:  metav1.StatusFailure,
+				Message: message,
+				Reason:  metav1.StatusReasonForbidden,
+				Code:    403,
+			}}
+		})
+		_, err := service.CreateRestore(veleroContext(), client, request)
+		s.EqualError(err, "restore manual-restore rejected: "+message)
+	})
+	s.Run("restores a Completed backup", func() {
+		client := veleroCluster(&completed)
+		created, err := service.CreateRestore(veleroContext(), client, request)
+		s.Require().NoError(err)
+		s.Equal(CreatedRestore{Name: "manual-restore", Namespace: OADPNamespace, BackupName: "daily", Phase: "New"}, *created)
+		restore, err := client.Dynamic.Resource(VeleroRestoreGVR).Namespace(OADPNamespace).Get(context.Background(), "manual-restore", metav1.GetOptions{})
+		s.Require().NoError(err)
+		s.Equal(map[string]interface{}{
+			"backupName":       "daily",
+			"namespaceMapping": map[string]interface{}{"shop": "shop-restored"},
+		}, restore.Object["spec"])
+	})
+}
+
+func (s *BackupSuite) TestEstimateNamespaceItems() {
+	configMaps := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	secrets := schema.GroupVersionResource{Version: "v1", Resource: "secrets"}
+	deployments := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{configMaps: "ConfigMapList", secrets: "SecretList", deployments: "DeploymentList"})
+	listed := map[string]int{}
+	dynamicClient.PrependReactor("list", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		resource := action.GetResource().Resource
+		listed[resource]++
+		switch resource {
+		case "configmaps":
+			// One item of a page of 1, the server reports 4 more
+			list := &unstructured.UnstructuredList{Items: []unstructured.Unstructured{{}}}
+			list.SetRemainingItemCount(ptr.To(int64(4)))
+			return true, list, nil
+		case "deployments":
+			return true, &unstructured.UnstructuredList{Items: []unstructured.Unstructured{{}}}, nil
+		}
+		return true, nil, fmt.Errorf("forbidden")
+	})
+	resources := []*metav1.APIResourceList{
+		{GroupVersion: "v1", APIResources: []metav1.APIResource{
+			{Name: "configmaps", Namespaced: true, Verbs: []string{"list"}},
+			{Name: "secrets", Namespaced: true, Verbs: []string{"list"}},
+			{Name: "pods/log", Namespaced: true, Verbs: []string{"get"}},
+			{Name: "namespaces", Namespaced: false, Verbs: []string{"list"}},
+		}},
+		{GroupVersion: "apps/v1", APIResources: []metav1.APIResource{{Name: "deployments", Namespaced: true, Verbs: []string{"list"}}}},
+		{GroupVersion: "apps/v1beta1", APIResources: []metav1.APIResource{{Name: "deployments", Namespaced: true, Verbs: []string{"list"}}}},
+	}
+
+	s.Equal(6, estimateNamespaceItems(context.Background(), dynamicClient, resources, "shop"))
+	s.Run("lists each resource once and skips subresources and cluster resources", func() {
+		s.Equal(map[string]int{"configmaps": 1, "secrets": 1, "deployments": 1}, listed)
+	})
+}
+
+// storageLocation returns a BackupStorageLocation in the given phase, not validated yet
+// when phase is empty
+func storageLocation(name, phase string, isDefault bool) *unstructured.Unstructured {
+	fields := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"provider":      "aws",
+			"default":       isDefault,
+			"objectStorage": map[string]interface{}{"bucket": name + "-bucket", "prefix": "velero"},
+		},
+	}
+	if phase != "" {
+		fields["status"] = map[string]interface{}{"phase": phase, "lastValidationTime": "2025-01-01T10:00:00Z"}
+	}
+	return veleroObject("velero.io/v1", "BackupStorageLocation", name, nil, fields)
+}
+
+func (s *BackupSuite) TestListStorageLocations() {
+	list := func(objects ...runtime.Object) *BackupStorageLocationsList {
+		dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{VeleroBackupStorageLocationGVR: "BackupStorageLocationList"}, objects...)
+		result := &BackupStorageLocationsList{ComponentStatus: InstalledStatus(true, "", ""), Locations: []BackupStorageLocation{}, Unavailable: []string{}}
+		NewBackupService(nil).listStorageLocations(context.Background(), dynamicClient, OADPNamespace, result)
+		result.ResolveSeverity()
+		return result
+	}
+
+	s.Run("reports the spec and validation of each location", func() {
+		result := list(storageLocation("default", StorageLocationAvailable, true), storageLocation("archive", StorageLocationAvailable, false))
+		s.True(result.Ready)
+		s.Equal(SeverityOK, result.Severity)
+		s.Require().Len(result.Locations, 2)
+		s.Equal(BackupStorageLocation{
+			Name:               "archive",
+			Provider:           "aws",
+			Bucket:             "archive-bucket",
+			Prefix:             "velero",
+			Phase:              StorageLocationAvailable,
+			LastValidationTime: ptr.To(time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)),
+		}, result.Locations[0])
+		s.True(result.Locations[1].Default)
+		s.Empty(result.Unavailable)
+	})
+	s.Run("an unavailable location breaks the backups", func() {
+		result := list(storageLocation("default", StorageLocationUnavailable, true), storageLocation("archive", StorageLocationAvailable, false))
+		s.False(result.Ready)
+		s.Equal(SeverityCritical, result.Severity)
+		s.Equal([]string{"default"}, result.Unavailable)
+		s.Equal("1 of 2 backup storage locations unavailable: default", result.Message)
+	})
+	s.Run("no location leaves backups nowhere to go", func() {
+		result := list()
+		s.False(result.Ready)
+		s.Equal(SeverityCritical, result.Severity)
+	})
+	s.Run("a location not validated yet is unknown", func() {
+		result := list(storageLocation("default", "", true))
+		s.True(result.Ready)
+		s.Equal(SeverityUnknown, result.Severity)
+		s.Nil(result.Locations[0].LastValidationTime)
+	})
+}
+
+func (s *BackupSuite) TestWaitForBackup() {
+	// watchedClient serves an InProgress backup at resource version 10 and answers each
+	// watch with the events of the next entry of watches, recording the resource versions
+	watchedClient := func(watches [][]watch.Event, resourceVersions *[]string) *dynamicfake.FakeDynamicClient {
+		backup := veleroBackup("daily", "InProgress", time.Hour)
+		backup.SetResourceVersion("10")
+		_ = unstructured.SetNestedField(backup.Object, time.Now().Add(-time.Hour).UTC().Format(time.RFC3339), "status", "startTimestamp")
+		dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{VeleroBackupGVR: "BackupList"}, &backup)
+		dynamicClient.PrependWatchReactor("backups", func(action k8stesting.Action) (bool, watch.Interface, error) {
+			*resourceVersions = append(*resourceVersions, action.(k8stesting.WatchActionImpl).GetWatchRestrictions().ResourceVersion)
+			watcher := watch.NewFakeWithChanSize(4, false)
+			if len(*resourceVersions) <= len(watches) {
+				for _, event := range watches[len(*resourceVersions)-1] {
+					watcher.Action(event.Type, event.Object)
+				}
+			}
+			return true, watcher, nil
+		})
+		return dynamicClient
+	}
+	phased := func(phase, resourceVersion string) *unstructured.Unstructured {
+		backup := veleroBackup("daily", phase, time.Hour)
+		backup.SetResourceVersion(resourceVersion)
+		return &backup
+	}
+	service := NewBackupService(nil)
+
+	s.Run("follows the backup to a terminal phase", func() {
+		var resourceVersions []string
+		dynamicClient := watchedClient([][]watch.Event{{
+			{Type: watch.Bookmark, Object: phased("", "11")},
+			{Type: watch.Modified, Object: phased("Completed", "12")},
+		}}, &resourceVersions)
+		wait, err := service.waitForBackup(context.Background(), dynamicClient, "daily")
+		s.Require().NoError(err)
+		s.Equal("Completed", wait.Phase)
+		s.True(wait.Terminal)
+		s.False(wait.TimedOut)
+		s.Equal([]string{"10"}, resourceVersions, "the watch starts from the backup read")
+	})
+	s.Run("resumes from the last bookmark when the watch closes", func() {
+		var resourceVersions []string
+		dynamicClient := watchedClient([][]watch.Event{
+			{{Type: watch.Bookmark, Object: phased("", "15")}},
+			{{Type: watch.Modified, Object: phased("PartiallyFailed", "16")}},
+		}, &resourceVersions)
+		// The first watcher closes once its events are read
+		dynamicClient.PrependWatchReactor("backups", func(action k8stesting.Action) (bool, watch.Interface, error) {
+			if len(resourceVersions) > 0 {
+				return false, nil, nil
+			}
+			resourceVersions = append(resourceVersions, action.(k8stesting.WatchActionImpl).GetWatchRestrictions().ResourceVersion)
+			events := make(chan watch.Event, 1)
+			events <- watch.Event{Type: watch.Bookmark, Object: phased("", "15")}
+			close(events)
+			return true, watch.NewProxyWatcher(events), nil
+		})
+		wait, err := service.waitForBackup(context.Background(), dynamicClient, "daily")
+		s.Require().NoError(err)
+		s.Equal("PartiallyFailed", wait.Phase)
+		s.Equal([]string{"10", "15"}, resourceVersions)
+	})
+	s.Run("reads the backup again when the resource version expired", func() {
+		var resourceVersions []string
+		dynamicClient := watchedClient([][]watch.Event{
+			{{Type: watch.Error, Object: &apierrors.NewResourceExpired("too old resource version").ErrStatus}},
+			{{Type: watch.Modified, Object: phased("Failed", "20")}},
+		}, &resourceVersions)
+		wait, err := service.waitForBackup(context.Background(), dynamicClient, "daily")
+		s.Require().NoError(err)
+		s.Equal("Failed", wait.Phase)
+		s.Equal([]string{"10", "10"}, resourceVersions)
+	})
+	s.Run("returns the current phase when the time runs out", func() {
+		var resourceVersions []string
+		dynamicClient := watchedClient(nil, &resourceVersions)
+		ctx, cancel := context.WithTimeout(context.Background(), backupWaitMargin+100*time.Millisecond)
+		defer cancel()
+		wait, err := service.waitForBackup(ctx, dynamicClient, "daily")
+		s.Require().NoError(err)
+		s.Equal("InProgress", wait.Phase)
+		s.True(wait.TimedOut)
+		s.Equal("1h", wait.Duration)
+		s.NoError(ctx.Err(), "the wait ends before the operation deadline")
+	})
+	s.Run("a cancelled request fails the wait", func() {
+		var resourceVersions []string
+		dynamicClient := watchedClient(nil, &resourceVersions)
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(100*time.Millisecond, cancel)
+		_, err := service.waitForBackup(ctx, dynamicClient, "daily")
+		s.ErrorIs(err, context.Canceled)
+	})
+	s.Run("a deleted backup fails the wait", func() {
+		var resourceVersions []string
+		dynamicClient := watchedClient([][]watch.Event{{{Type: watch.Deleted, Object: phased("", "11")}}}, &resourceVersions)
+		_, err := service.waitForBackup(context.Background(), dynamicClient, "daily")
+		s.EqualError(err, "backup daily was deleted while waiting for it")
+	})
+	s.Run("a missing backup is not found", func() {
+		var resourceVersions []string
+		_, err := service.waitForBackup(context.Background(), watchedClient(nil, &resourceVersions), "hourly")
+		s.Equal(clients.ErrorKindNotFound, clients.ClassifyError(err))
+	})
+}
+
+func TestBackupSuite(t *testing.T) {
+	suite.Run(t, new(BackupSuite))
+}
+
+// Made with Bob
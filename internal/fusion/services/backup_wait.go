@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
+)
+
+// TerminalBackupPhases are the Velero Backup phases a backup never leaves
+var TerminalBackupPhases = []string{"Completed", "PartiallyFailed", "Failed", "FailedValidation"}
+
+// backupWaitMargin is how long before the deadline of its context a wait gives up, so
+// that the current phase is returned before the cluster operation itself times out
+const backupWaitMargin = time.Second
+
+// BackupWait reports the phase a backup reached while waited for. Duration is how long
+// the backup ran, from its start to its completion or to now when it is still running.
+// TimedOut is set when the wait ended before a terminal phase, Phase is then the current one.
+type BackupWait struct {
+	VeleroBackup
+	Terminal bool   `json:"terminal"`
+	TimedOut bool   `json:"timedOut"`
+	Duration string `json:"duration,omitempty"`
+	Waited   string `json:"waited"`
+}
+
+// WaitForBackup watches a Velero Backup until it reaches a terminal phase or the deadline
+// of ctx comes near, whichever is first. A cancelled ctx fails the wait.
+func (s *BackupService) WaitForBackup(ctx context.Context, clusterClient *clients.ClusterClient, name string) (*BackupWait, error) {
+	if name == "" {
+		return nil, fmt.Errorf("backup name is required")
+	}
+	dynamicClient, err := s.veleroClient(ctx, clusterClient, VeleroBackupGVR)
+	if err != nil {
+		return nil, err
+	}
+	return s.waitForBackup(ctx, dynamicClient, name)
+}
+
+// waitForBackup reads the backup, then follows its changes from the resource version read.
+// Bookmarks keep the resource version current, so a watch closed by the server resumes
+// where it stopped; an expired resource version reads the backup again.
+func (s *BackupService) waitForBackup(ctx context.Context, dynamicClient dynamic.Interface, name string) (*BackupWait, error) {
+	start := time.Now()
+	waitCtx := ctx
+	if deadline, ok := ctx.Deadline(); ok {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithDeadline(ctx, deadline.Add(-backupWaitMargin))
+		defer cancel()
+	}
+
+	backups := dynamicClient.Resource(VeleroBackupGVR).Namespace(OADPNamespace)
+	get := func() (*unstructured.Unstructured, error) {
+		backup, err := backups.Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("backup %s not found in namespace %s: %w", name, OADPNamespace, err)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get backup %s: %w", name, err)
+		}
+		return backup, nil
+	}
+
+	backup, err := get()
+	if err != nil {
+		return nil, err
+	}
+	resourceVersion := backup.GetResourceVersion()
+	for !slices.Contains(TerminalBackupPhases, veleroPhase(*backup)) && waitCtx.Err() == nil {
+		watcher, err := backups.Watch(waitCtx, metav1.ListOptions{
+			FieldSelector:       "metadata.name=" + name,
+			ResourceVersion:     resourceVersion,
+			AllowWatchBookmarks: true,
+		})
+		if err != nil {
+			if waitCtx.Err() != nil {
+				break
+			}
+			return nil, fmt.Errorf("failed to watch backup %s: %w", name, err)
+		}
+		latest, version, err := s.followBackup(waitCtx, watcher, backup, resourceVersion)
+		watcher.Stop()
+		switch {
+		case apierrors.IsResourceExpired(err) || apierrors.IsGone(err):
+			// The resource version expired, start over from the current backup
+			klog.FromContext(ctx).V(4).Info("Backup watch expired, reading the backup again", "backup", name)
+			if latest, err = get(); err != nil {
+				return nil, err
+			}
+			version = latest.GetResourceVersion()
+		case err != nil:
+			return nil, err
+		}
+		backup, resourceVersion = latest, version
+	}
+
+	// The caller went away, as opposed to the wait running out of time
+	if err := ctx.Err(); err != nil && !errors.Is(err, context.DeadlineExceeded) {
+		return nil, err
+	}
+
+	wait := &BackupWait{VeleroBackup: s.convertBackup(*backup), Waited: HumanizeAge(start)}
+	wait.Terminal = slices.Contains(TerminalBackupPhases, wait.Phase)
+	wait.TimedOut = !wait.Terminal
+	if wait.StartTime != nil {
+		end := time.Now()
+		if wait.Completion != nil {
+			end = *wait.Completion
+		}
+		wait.Duration = HumanizeDuration(end.Sub(*wait.StartTime))
+	}
+	return wait, nil
+}
+
+// followBackup reads the events of a watch on the backup until it reaches a terminal
+// phase, the watch closes or ctx ends. It returns the latest backup seen and the resource
+// version to resume from.
+func (s *BackupService) followBackup(ctx context.Context, watcher watch.Interface, backup *unstructured.Unstructured, resourceVersion string) (*unstructured.Unstructured, string, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return backup, resourceVersion, nil
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return backup, resourceVersion, nil
+			}
+			switch event.Type {
+			case watch.Error:
+				return backup, resourceVersion, apierrors.FromObject(event.Object)
+			case watch.Deleted:
+				return backup, resourceVersion, fmt.Errorf("backup %s was deleted while waiting for it", backup.GetName())
+			}
+			object, ok := event.Object.(*unstructured.Unstructured)
+			if !ok {
+				continue
+			}
+			resourceVersion = object.GetResourceVersion()
+			if event.Type == watch.Bookmark {
+				continue
+			}
+			backup = object
+			if slices.Contains(TerminalBackupPhases, veleroPhase(*backup)) {
+				return backup, resourceVersion, nil
+			}
+		}
+	}
+}
+
+// Made with Bob
@@ -0,0 +1,416 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// ClusterService provides operations on the cluster registry itself
+type ClusterService struct {
+	registry *clients.Registry
+}
+
+// NewClusterService creates a new cluster service
+func NewClusterService(registry *clients.Registry) *ClusterService {
+	return &ClusterService{
+		registry: registry,
+	}
+}
+
+// ClusterInfo describes a registered cluster
+type ClusterInfo struct {
+	Name             string `json:"name"`
+	Context          string `json:"context"`
+	Server           string `json:"server"`
+	Reachable        bool   `json:"reachable"`
+	ServerVersion    string `json:"serverVersion,omitempty"`
+	OpenShiftVersion string `json:"openshiftVersion,omitempty"`
+	// AuthPlugin is the exec plugin or auth provider the credentials come from, if any
+	AuthPlugin *clients.AuthPlugin `json:"authPlugin,omitempty"`
+	Error      string              `json:"error,omitempty"`
+}
+
+// ClusterList represents the registered clusters
+type ClusterList struct {
+	Clusters  []ClusterInfo `json:"clusters"`
+	Total     int           `json:"total"`
+	Reachable int           `json:"reachable"`
+	// FailedContexts are the kubeconfig contexts that could not be registered, with the reason
+	FailedContexts map[string]string `json:"failedContexts,omitempty"`
+}
+
+// List returns every registered cluster, probing each API server's /version
+// endpoint with CheckHealth to report reachability and the Kubernetes and OpenShift
+// versions. The version is always fetched, not cached, so reachability is current.
+func (s *ClusterService) List(ctx context.Context) (*ClusterList, error) {
+	list := &ClusterList{
+		Clusters: []ClusterInfo{},
+	}
+
+	// CheckHealth runs under the per-cluster context of each cluster's timeout
+	probes := ExecuteOnClusters(ctx, s.registry, targeting.Target{Type: targeting.TargetAll}, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
+		return s.CheckHealth(ctx, client)
+	}).ClusterResults
+
+	for name, client := range s.registry.GetAllClients() {
+		info := ClusterInfo{
+			Name:    name,
+			Context: client.Context,
+		}
+		if client.Config != nil {
+			info.Server = client.Config.Host
+			info.AuthPlugin = clients.AuthPluginFor(client.Config)
+		}
+		if probe, ok := probes[name]; ok {
+			info.Reachable = probe.Success
+			info.Error = probe.Error
+			var health ClusterHealth
+			if raw, ok := probe.Data.(json.RawMessage); ok && json.Unmarshal(raw, &health) == nil {
+				info.ServerVersion = health.ServerVersion
+				info.OpenShiftVersion = health.OpenShiftVersion
+			}
+		}
+		if info.Reachable {
+			list.Reachable++
+		}
+		list.Clusters = append(list.Clusters, info)
+	}
+
+	sort.Slice(list.Clusters, func(i, j int) bool {
+		return list.Clusters[i].Name < list.Clusters[j].Name
+	})
+	list.Total = len(list.Clusters)
+
+	for contextName, err := range s.registry.FailedContexts() {
+		if list.FailedContexts == nil {
+			list.FailedContexts = make(map[string]string)
+		}
+		list.FailedContexts[contextName] = err.Error()
+	}
+
+	return list, nil
+}
+
+// ClusterHealth describes API server connectivity of a cluster
+type ClusterHealth struct {
+	Server           string `json:"server"`
+	ServerVersion    string `json:"serverVersion"`
+	OpenShiftVersion string `json:"openshiftVersion,omitempty"`
+	Platform         string `json:"platform,omitempty"`
+	LatencyMs        int64  `json:"latencyMs"`
+}
+
+// CheckHealth measures the round-trip latency of the API server /version endpoint
+// An unreachable API server is reported as an error, one that does not answer before
+// ctx is done as timed out. The version is always fetched, not cached, so the latency
+// is a real round trip; it refreshes the cached one.
+func (s *ClusterService) CheckHealth(ctx context.Context, client *clients.ClusterClient) (*ClusterHealth, error) {
+	health := &ClusterHealth{}
+	if client.Config != nil {
+		health.Server = client.Config.Host
+	}
+
+	start := time.Now()
+	version, err := serverVersion(ctx, client.Clientset.Discovery().ServerVersion)
+	health.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		return nil, fmt.Errorf("API server not reachable after %dms: %w", health.LatencyMs, err)
+	}
+
+	client.SetServerVersion(version)
+	health.ServerVersion = version.GitVersion
+	health.Platform = version.Platform
+	// The API server answered, an unreadable ClusterVersion only loses the OpenShift version
+	health.OpenShiftVersion, _ = client.OpenShiftVersion(ctx)
+	return health, nil
+}
+
+// RegisteredCluster describes a cluster added to the registry at runtime
+type RegisteredCluster struct {
+	Name          string `json:"name"`
+	Context       string `json:"context"`
+	Server        string `json:"server"`
+	ServerVersion string `json:"serverVersion"`
+	Replaced      bool   `json:"replaced"`
+}
+
+// Register adds a kubeconfig context to the registry after verifying its API server
+// is reachable. An existing cluster with the same name is replaced. Registration must be
+// allowed, the kubeconfig must be within the allowed kubeconfig paths and, unless allowed,
+// the context cannot authenticate through an exec plugin or auth provider.
+func (s *ClusterService) Register(ctx context.Context, kubeconfigPath, contextName string) (*RegisteredCluster, error) {
+	if err := checkRegistration(); err != nil {
+		return nil, err
+	}
+	kubeconfigPath, err := CheckKubeconfigPath(kubeconfigPath)
+	if err != nil {
+		return nil, err
+	}
+	config, err := clientcmd.LoadFromFile(kubeconfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	return s.register(ctx, config, contextName)
+}
+
+// RegisterContent is Register for inline kubeconfig content. The context cannot reference
+// files for its credentials or CA, which would be read on the server host.
+func (s *ClusterService) RegisterContent(ctx context.Context, kubeconfig []byte, contextName string) (*RegisteredCluster, error) {
+	if err := checkRegistration(); err != nil {
+		return nil, err
+	}
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	if err := checkInlineFileReferences(config, contextName); err != nil {
+		return nil, err
+	}
+	return s.register(ctx, config, contextName)
+}
+
+// register adds a client built for a kubeconfig context once its API server answers
+func (s *ClusterService) register(ctx context.Context, config *clientcmdapi.Config, contextName string) (*RegisteredCluster, error) {
+	if err := checkAuthPlugin(config, contextName); err != nil {
+		return nil, err
+	}
+	client, err := s.registry.NewContextClientFromConfig(config, contextName)
+	if err != nil {
+		return nil, err
+	}
+	return s.addReachable(ctx, client)
+}
+
+// addReachable adds a client to the registry once its API server answers within the
+// registry timeout
+func (s *ClusterService) addReachable(ctx context.Context, client *clients.ClusterClient) (*RegisteredCluster, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.registry.Timeout())
+	defer cancel()
+	version, err := serverVersion(ctx, client.ServerVersion)
+	if err != nil {
+		return nil, fmt.Errorf("cluster %s is not reachable at %s: %w", client.Context, client.Config.Host, err)
+	}
+
+	registered := &RegisteredCluster{
+		Name:          client.Name,
+		Context:       client.Context,
+		Server:        client.Config.Host,
+		ServerVersion: version.GitVersion,
+		Replaced:      s.registry.HasCluster(client.Name),
+	}
+	s.registry.AddClient(client)
+
+	return registered, nil
+}
+
+// serverVersion reads the version of a cluster with fetch, giving up when ctx is done.
+// Discovery takes no context, a request still running then finishes in the background.
+func serverVersion(ctx context.Context, fetch func() (*version.Info, error)) (*version.Info, error) {
+	type outcome struct {
+		info *version.Info
+		err  error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		info, err := fetch()
+		done <- outcome{info: info, err: err}
+	}()
+	select {
+	case result := <-done:
+		return result.info, result.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// KubeconfigContext describes a context of a kubeconfig file
+type KubeconfigContext struct {
+	Name       string `json:"name"`
+	Cluster    string `json:"cluster"`
+	Server     string `json:"server,omitempty"`
+	Namespace  string `json:"namespace,omitempty"`
+	Current    bool   `json:"current,omitempty"`
+	Registered bool   `json:"registered"`
+}
+
+// KubeconfigContextList represents the contexts of a kubeconfig file
+type KubeconfigContextList struct {
+	Kubeconfig   string              `json:"kubeconfig"`
+	Contexts     []KubeconfigContext `json:"contexts"`
+	Total        int                 `json:"total"`
+	Unregistered int                 `json:"unregistered"`
+}
+
+// KubeconfigContexts lists the contexts of a kubeconfig file with the API server of their
+// cluster and whether a cluster of that name is registered. Nothing is contacted. Like
+// Register, the kubeconfig must be within the allowed kubeconfig paths.
+func (s *ClusterService) KubeconfigContexts(kubeconfigPath string) (*KubeconfigContextList, error) {
+	resolved, err := CheckKubeconfigPath(kubeconfigPath)
+	if err != nil {
+		return nil, err
+	}
+	config, err := clientcmd.LoadFromFile(resolved)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("kubeconfig %s not found", kubeconfigPath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig %s: %w", kubeconfigPath, err)
+	}
+
+	list := &KubeconfigContextList{
+		Kubeconfig: kubeconfigPath,
+		Contexts:   []KubeconfigContext{},
+	}
+	for name, kubeContext := range config.Contexts {
+		info := KubeconfigContext{
+			Name:       name,
+			Cluster:    kubeContext.Cluster,
+			Namespace:  kubeContext.Namespace,
+			Current:    name == config.CurrentContext,
+			Registered: s.registry.HasCluster(name),
+		}
+		if cluster, ok := config.Clusters[kubeContext.Cluster]; ok {
+			info.Server = cluster.Server
+		}
+		if !info.Registered {
+			list.Unregistered++
+		}
+		list.Contexts = append(list.Contexts, info)
+	}
+
+	sort.Slice(list.Contexts, func(i, j int) bool {
+		return list.Contexts[i].Name < list.Contexts[j].Name
+	})
+	list.Total = len(list.Contexts)
+
+	return list, nil
+}
+
+// UnregisteredCluster describes the outcome of removing a cluster from the registry
+type UnregisteredCluster struct {
+	Name    string `json:"name"`
+	Removed bool   `json:"removed"`
+}
+
+// Unregister removes a cluster from the registry, doing nothing when it is absent.
+// Like Register, it must be allowed.
+func (s *ClusterService) Unregister(name string) (*UnregisteredCluster, error) {
+	if err := checkRegistration(); err != nil {
+		return nil, err
+	}
+	removed := s.registry.HasCluster(name)
+	s.registry.UnregisterCluster(name)
+	return &UnregisteredCluster{
+		Name:    name,
+		Removed: removed,
+	}, nil
+}
+
+// ReloadedClusters describes the outcome of reloading the kubeconfig the registry was
+// loaded from, see clients.Registry.Reload. Total counts the clusters registered after.
+type ReloadedClusters struct {
+	Kubeconfig []string          `json:"kubeconfig"`
+	Added      []string          `json:"added"`
+	Updated    []string          `json:"updated"`
+	Removed    []string          `json:"removed"`
+	Failed     map[string]string `json:"failed,omitempty"`
+	Total      int               `json:"total"`
+}
+
+// Reload reconciles the registry with the kubeconfig it was loaded from
+func (s *ClusterService) Reload() (*ReloadedClusters, error) {
+	reloaded, err := s.registry.Reload()
+	if err != nil {
+		return nil, err
+	}
+	result := &ReloadedClusters{
+		Kubeconfig: reloaded.Paths,
+		Added:      reloaded.Added,
+		Updated:    reloaded.Updated,
+		Removed:    reloaded.Removed,
+		Total:      len(s.registry.ListClusterNames()),
+	}
+	if len(reloaded.Failed) > 0 {
+		result.Failed = make(map[string]string, len(reloaded.Failed))
+		for name, err := range reloaded.Failed {
+			result.Failed[name] = err.Error()
+		}
+	}
+	return result, nil
+}
+
+// LabeledCluster describes the labels of a cluster after a Label call, with the JSON
+// merge patch applied to them, null removing a label
+type LabeledCluster struct {
+	Name   string             `json:"name"`
+	Patch  map[string]*string `json:"patch"`
+	Labels map[string]string  `json:"labels"`
+}
+
+// Label applies label changes to a registered cluster, see ParseLabelChanges. The
+// labels are matched by selector targets and group selectors.
+func (s *ClusterService) Label(name string, changes []string) (*LabeledCluster, error) {
+	patch, err := ParseLabelChanges(changes)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := s.registry.PatchClusterLabels(name, patch)
+	if err != nil {
+		return nil, err
+	}
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	return &LabeledCluster{
+		Name:   name,
+		Patch:  patch,
+		Labels: labels,
+	}, nil
+}
+
+// ParseLabelChanges parses label changes in kubectl label syntax into a JSON merge patch
+// of labels: key=value sets a label and key- removes it. Keys and values must be valid
+// Kubernetes label keys and values, and a key cannot be changed twice.
+func ParseLabelChanges(changes []string) (map[string]*string, error) {
+	if len(changes) == 0 {
+		return nil, fmt.Errorf("at least one label change is required")
+	}
+	patch := make(map[string]*string, len(changes))
+	for _, change := range changes {
+		var key string
+		var value *string
+		if k, v, ok := strings.Cut(change, "="); ok {
+			if errs := validation.IsValidLabelValue(v); len(errs) > 0 {
+				return nil, fmt.Errorf("invalid label value %q of %s: %s", v, k, strings.Join(errs, "; "))
+			}
+			key, value = k, &v
+		} else if k, ok := strings.CutSuffix(change, "-"); ok {
+			key = k
+		} else {
+			return nil, fmt.Errorf("invalid label change %q: use key=value to set a label or key- to remove it", change)
+		}
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid label key %q: %s", key, strings.Join(errs, "; "))
+		}
+		if _, exists := patch[key]; exists {
+			return nil, fmt.Errorf("label %s is changed more than once", key)
+		}
+		patch[key] = value
+	}
+	return patch, nil
+}
+
+// Made with Bob
@@ -0,0 +1,514 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
+	"github.com/stretchr/testify/suite"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+type ClusterServiceSuite struct {
+	suite.Suite
+}
+
+// reachableCluster is a cluster whose discovery answers with gitVersion, running the
+// OpenShift release openShift when set
+func reachableCluster(name, gitVersion, openShift string) *clients.ClusterClient {
+	clientset := fake.NewSimpleClientset()
+	clientset.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &version.Info{GitVersion: gitVersion, Platform: "linux/amd64"}
+	var objects []runtime.Object
+	if openShift != "" {
+		clusterVersion := &unstructured.Unstructured{}
+		clusterVersion.SetAPIVersion("config.openshift.io/v1")
+		clusterVersion.SetKind("ClusterVersion")
+		clusterVersion.SetName("version")
+		_ = unstructured.SetNestedSlice(clusterVersion.Object, []interface{}{map[string]interface{}{"state": "Completed", "version": openShift}}, "status", "history")
+		objects = append(objects, clusterVersion)
+	}
+	return &clients.ClusterClient{
+		Name:      name,
+		Context:   name,
+		Clientset: clientset,
+		Config:    &rest.Config{Host: "https://api." + name + ".example.com:6443"},
+		Dynamic: dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{clients.ClusterVersionGVR: "ClusterVersionList"}, objects...),
+	}
+}
+
+// unreachableCluster is a cluster whose discovery fails to connect
+func unreachableCluster(name string) *clients.ClusterClient {
+	client := reachableCluster(name, "", "")
+	client.Clientset.(*fake.Clientset).PrependReactor("get", "version", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("dial tcp 10.0.0.1:6443: connect: connection refused")
+	})
+	return client
+}
+
+// clusterRegistry registers clients without retries, so unreachable clusters fail at once
+func clusterRegistry(clusters ...*clients.ClusterClient) *clients.Registry {
+	registry := clients.NewRegistry()
+	registry.SetRetryPolicy(clients.RetryPolicy{MaxAttempts: 1})
+	for _, client := range clusters {
+		registry.AddClient(client)
+	}
+	return registry
+}
+
+func (s *ClusterServiceSuite) TestList() {
+	registry := clusterRegistry(
+		reachableCluster("prod-1", "v1.29.5", "4.16.3"),
+		reachableCluster("kind", "v1.31.0", ""),
+		unreachableCluster("edge-1"),
+	)
+	list, err := NewClusterService(registry).List(context.Background())
+	s.Require().NoError(err)
+
+	s.Run("counts registered and reachable clusters", func() {
+		s.Equal(3, list.Total)
+		s.Equal(2, list.Reachable)
+		s.Nil(list.FailedContexts)
+	})
+	s.Run("reports the versions of reachable clusters", func() {
+		s.Require().Len(list.Clusters, 3)
+		s.Equal(ClusterInfo{Name: "kind", Context: "kind", Server: "https://api.kind.example.com:6443", Reachable: true, ServerVersion: "v1.31.0"}, list.Clusters[1])
+		s.Equal(ClusterInfo{Name: "prod-1", Context: "prod-1", Server: "https://api.prod-1.example.com:6443", Reachable: true, ServerVersion: "v1.29.5", OpenShiftVersion: "4.16.3"}, list.Clusters[2])
+	})
+	s.Run("reports the error of an unreachable cluster", func() {
+		edge := list.Clusters[0]
+		s.Equal("edge-1", edge.Name)
+		s.False(edge.Reachable)
+		s.Empty(edge.ServerVersion)
+		s.Contains(edge.Error, "connection refused")
+	})
+	s.Run("probes reachability without the cached version", func() {
+		client := reachableCluster("prod-2", "v1.29.5", "")
+		service := NewClusterService(clusterRegistry(client))
+		list, err := service.List(context.Background())
+		s.Require().NoError(err)
+		s.True(list.Clusters[0].Reachable)
+		client.Clientset.(*fake.Clientset).PrependReactor("get", "version", func(k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, errors.New("dial tcp 10.0.0.2:6443: connect: connection refused")
+		})
+		list, err = service.List(context.Background())
+		s.Require().NoError(err)
+		s.False(list.Clusters[0].Reachable, "the version cached by the first list is not reused")
+		s.Contains(list.Clusters[0].Error, "connection refused")
+	})
+}
+
+func (s *ClusterServiceSuite) TearDownTest() {
+	SetClusterRegistrationAllowed(false)
+	SetAuthPluginsAllowed(false)
+	SetKubeconfigPaths(nil)
+}
+
+// writeKubeconfig writes a kubeconfig with one context for the server to dir,
+// authenticating with user
+func (s *ClusterServiceSuite) writeKubeconfig(dir, contextName, server string, user *clientcmdapi.AuthInfo) string {
+	kubeconfig := clientcmdapi.NewConfig()
+	kubeconfig.Clusters[contextName] = &clientcmdapi.Cluster{Server: server, InsecureSkipTLSVerify: true}
+	kubeconfig.AuthInfos["user"] = user
+	kubeconfig.Contexts[contextName] = &clientcmdapi.Context{Cluster: contextName, AuthInfo: "user"}
+	path := filepath.Join(dir, contextName+".kubeconfig")
+	s.Require().NoError(clientcmd.WriteToFile(*kubeconfig, path))
+	return path
+}
+
+func (s *ClusterServiceSuite) TestRegisterGuards() {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"gitVersion": "v1.29.5"}`))
+	}))
+	defer server.Close()
+	allowed := s.T().TempDir()
+	kubeconfigPath := s.writeKubeconfig(allowed, "edge-1", server.URL, &clientcmdapi.AuthInfo{Token: "token"})
+	execPath := s.writeKubeconfig(allowed, "eks-1", server.URL, &clientcmdapi.AuthInfo{
+		Exec: &clientcmdapi.ExecConfig{Command: "fusion-test-missing-plugin", APIVersion: "client.authentication.k8s.io/v1", InteractiveMode: clientcmdapi.NeverExecInteractiveMode},
+	})
+	providerPath := s.writeKubeconfig(allowed, "gke-1", server.URL, &clientcmdapi.AuthInfo{AuthProvider: &clientcmdapi.AuthProviderConfig{Name: "oidc"}})
+	outside := s.writeKubeconfig(s.T().TempDir(), "other-1", server.URL, &clientcmdapi.AuthInfo{Token: "token"})
+	content, err := os.ReadFile(kubeconfigPath)
+	s.Require().NoError(err)
+
+	s.Run("refuses registration unless allowed", func() {
+		service := NewClusterService(clients.NewRegistry())
+		_, err := service.Register(context.Background(), kubeconfigPath, "edge-1")
+		s.ErrorContains(err, "cluster registration is disabled")
+		_, err = service.RegisterContent(context.Background(), content, "edge-1")
+		s.ErrorContains(err, "cluster registration is disabled")
+		_, err = service.Unregister("edge-1")
+		s.ErrorContains(err, "cluster registration is disabled")
+	})
+
+	SetClusterRegistrationAllowed(true)
+	SetKubeconfigPaths([]string{allowed})
+
+	s.Run("registers a kubeconfig within the allowed paths", func() {
+		registry := clients.NewRegistry()
+		registered, err := NewClusterService(registry).Register(context.Background(), kubeconfigPath, "edge-1")
+		s.Require().NoError(err)
+		s.Equal("v1.29.5", registered.ServerVersion)
+		s.True(registry.HasCluster("edge-1"))
+	})
+	s.Run("refuses a kubeconfig outside the allowed paths", func() {
+		_, err := NewClusterService(clients.NewRegistry()).Register(context.Background(), outside, "other-1")
+		s.ErrorContains(err, "is outside the allowed kubeconfig paths")
+	})
+	s.Run("refuses a symbolic link out of the allowed paths", func() {
+		link := filepath.Join(allowed, "link.kubeconfig")
+		s.Require().NoError(os.Symlink(outside, link))
+		_, err := NewClusterService(clients.NewRegistry()).Register(context.Background(), link, "other-1")
+		s.ErrorContains(err, "is outside the allowed kubeconfig paths")
+	})
+	s.Run("refuses relative paths escaping the allowed paths", func() {
+		_, err := NewClusterService(clients.NewRegistry()).Register(context.Background(), filepath.Join(allowed, "..", "..", "etc", "passwd"), "edge-1")
+		s.ErrorContains(err, "is outside the allowed kubeconfig paths")
+	})
+	s.Run("refuses exec plugins and auth providers unless allowed", func() {
+		service := NewClusterService(clients.NewRegistry())
+		_, err := service.Register(context.Background(), execPath, "eks-1")
+		s.ErrorContains(err, `context eks-1 authenticates with the exec plugin "fusion-test-missing-plugin", which would run on the server`)
+		_, err = service.Register(context.Background(), providerPath, "gke-1")
+		s.ErrorContains(err, "context gke-1 authenticates with the oidc auth provider")
+		execContent, err := os.ReadFile(execPath)
+		s.Require().NoError(err)
+		_, err = service.RegisterContent(context.Background(), execContent, "eks-1")
+		s.ErrorContains(err, "authenticates with the exec plugin")
+	})
+	s.Run("refuses inline content referencing server files", func() {
+		service := NewClusterService(clients.NewRegistry())
+		for field, user := range map[string]*clientcmdapi.AuthInfo{
+			"tokenFile":          {TokenFile: "/var/run/secrets/kubernetes.io/serviceaccount/token"},
+			"client-certificate": {ClientCertificate: "/etc/pki/client.crt", ClientKeyData: []byte("key")},
+			"client-key":         {ClientCertificateData: []byte("cert"), ClientKey: "/etc/pki/client.key"},
+		} {
+			path := s.writeKubeconfig(s.T().TempDir(), "edge-2", server.URL, user)
+			inline, err := os.ReadFile(path)
+			s.Require().NoError(err)
+			_, err = service.RegisterContent(context.Background(), inline, "edge-2")
+			s.ErrorContains(err, "context edge-2 of the inline kubeconfig references files on the server ("+field+")")
+		}
+		kubeconfig := clientcmdapi.NewConfig()
+		kubeconfig.Clusters["edge-2"] = &clientcmdapi.Cluster{Server: server.URL, CertificateAuthority: "/etc/kubernetes/ca.crt"}
+		kubeconfig.AuthInfos["user"] = &clientcmdapi.AuthInfo{Token: "token"}
+		kubeconfig.Contexts["edge-2"] = &clientcmdapi.Context{Cluster: "edge-2", AuthInfo: "user"}
+		inline, err := clientcmd.Write(*kubeconfig)
+		s.Require().NoError(err)
+		_, err = service.RegisterContent(context.Background(), inline, "edge-2")
+		s.ErrorContains(err, "(certificate-authority)")
+		s.False(service.registry.HasCluster("edge-2"))
+	})
+	s.Run("registers inline content with embedded credentials", func() {
+		registry := clients.NewRegistry()
+		registered, err := NewClusterService(registry).RegisterContent(context.Background(), content, "edge-1")
+		s.Require().NoError(err)
+		s.Equal("v1.29.5", registered.ServerVersion)
+		s.True(registry.HasCluster("edge-1"))
+	})
+	s.Run("runs exec plugins when allowed", func() {
+		SetAuthPluginsAllowed(true)
+		defer SetAuthPluginsAllowed(false)
+		_, err := NewClusterService(clients.NewRegistry()).Register(context.Background(), execPath, "eks-1")
+		s.ErrorContains(err, "cluster eks-1 is not reachable", "the plugin is run, and missing")
+	})
+}
+
+func (s *ClusterServiceSuite) TestCheckHealth() {
+	registry := clusterRegistry(
+		reachableCluster("prod-1", "v1.29.5", "4.16.3"),
+		reachableCluster("kind", "v1.31.0", ""),
+		unreachableCluster("edge-1"),
+	)
+	service := NewClusterService(registry)
+
+	s.Run("reports the versions of a reachable API server", func() {
+		client, err := registry.GetClient("prod-1")
+		s.Require().NoError(err)
+		health, err := service.CheckHealth(context.Background(), client)
+		s.Require().NoError(err)
+		s.Equal("https://api.prod-1.example.com:6443", health.Server)
+		s.Equal("v1.29.5", health.ServerVersion)
+		s.Equal("4.16.3", health.OpenShiftVersion)
+		s.Equal("linux/amd64", health.Platform)
+		s.GreaterOrEqual(health.LatencyMs, int64(0))
+	})
+	s.Run("fails for an unreachable API server", func() {
+		client, err := registry.GetClient("edge-1")
+		s.Require().NoError(err)
+		_, err = service.CheckHealth(context.Background(), client)
+		s.ErrorContains(err, "API server not reachable after")
+		s.ErrorContains(err, "connection refused")
+	})
+	s.Run("counts healthy and unhealthy clusters across the fleet", func() {
+		// The healthy and unhealthy aggregates of fusion.clusters.health
+		result := ExecuteOnClusters(context.Background(), registry, targeting.Target{Type: targeting.TargetAll}, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
+			return service.CheckHealth(ctx, client)
+		})
+		s.Equal(3, result.Summary.Total)
+		s.Equal(2, result.Summary.Succeeded)
+		s.Equal(1, result.Summary.Failed)
+		s.True(result.ClusterResults["prod-1"].Success)
+		s.True(result.ClusterResults["kind"].Success)
+		s.False(result.ClusterResults["edge-1"].Success)
+		s.Contains(result.Errors["edge-1"], "API server not reachable")
+	})
+	s.Run("gives up on a hanging API server at the cluster timeout", func() {
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		}))
+		defer server.Close()
+		defer close(release)
+		hanging := clusterRegistry(probeClient(s.T(), server))
+
+		start := time.Now()
+		result := ExecuteOnClusters(context.Background(), hanging, targeting.Target{Type: targeting.TargetAll, Timeout: 1}, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
+			return service.CheckHealth(ctx, client)
+		})
+		s.Less(time.Since(start), 5*time.Second)
+		s.False(result.ClusterResults["prod-1"].Success)
+		s.Equal(clients.ErrorKindTimeout, result.ClusterResults["prod-1"].ErrorKind)
+		s.Contains(result.Errors["prod-1"], "API server not reachable after")
+	})
+}
+
+func (s *ClusterServiceSuite) TestRegister() {
+	registry := clusterRegistry(reachableCluster("prod-1", "v1.29.5", ""))
+	service := NewClusterService(registry)
+
+	s.Run("adds a reachable cluster", func() {
+		registered, err := service.addReachable(context.Background(), reachableCluster("edge-1", "v1.31.0", ""))
+		s.Require().NoError(err)
+		s.Equal(&RegisteredCluster{Name: "edge-1", Context: "edge-1", Server: "https://api.edge-1.example.com:6443", ServerVersion: "v1.31.0"}, registered)
+		s.True(registry.HasCluster("edge-1"))
+	})
+	s.Run("replaces a cluster of the same name", func() {
+		replacement := reachableCluster("prod-1", "v1.30.2", "")
+		registered, err := service.addReachable(context.Background(), replacement)
+		s.Require().NoError(err)
+		s.True(registered.Replaced)
+		client, err := registry.GetClient("prod-1")
+		s.Require().NoError(err)
+		s.Same(replacement, client)
+	})
+	s.Run("refuses an unreachable cluster", func() {
+		_, err := service.addReachable(context.Background(), unreachableCluster("edge-2"))
+		s.ErrorContains(err, "cluster edge-2 is not reachable at https://api.edge-2.example.com:6443")
+		s.False(registry.HasCluster("edge-2"))
+	})
+	s.Run("gives up when the context is done", func() {
+		hanging := reachableCluster("edge-3", "", "")
+		release := make(chan struct{})
+		defer close(release)
+		hanging.Clientset.(*fake.Clientset).PrependReactor("get", "version", func(k8stesting.Action) (bool, runtime.Object, error) {
+			<-release
+			return true, nil, errors.New("released")
+		})
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := service.addReachable(ctx, hanging)
+		s.ErrorIs(err, context.DeadlineExceeded)
+		s.False(registry.HasCluster("edge-3"))
+	})
+}
+
+func (s *ClusterServiceSuite) TestUnregister() {
+	SetClusterRegistrationAllowed(true)
+	registry := clusterRegistry(reachableCluster("prod-1", "v1.29.5", ""), reachableCluster("prod-2", "v1.29.5", ""))
+	service := NewClusterService(registry)
+
+	s.Run("removes a registered cluster", func() {
+		unregistered, err := service.Unregister("prod-1")
+		s.Require().NoError(err)
+		s.Equal(&UnregisteredCluster{Name: "prod-1", Removed: true}, unregistered)
+		s.Equal([]string{"prod-2"}, registry.ListClusterNames())
+	})
+	s.Run("does nothing for an absent cluster", func() {
+		unregistered, err := service.Unregister("edge-1")
+		s.Require().NoError(err)
+		s.Equal(&UnregisteredCluster{Name: "edge-1", Removed: false}, unregistered)
+		s.Equal([]string{"prod-2"}, registry.ListClusterNames())
+	})
+}
+
+func (s *ClusterServiceSuite) TestKubeconfigContexts() {
+	kubeconfig := clientcmdapi.NewConfig()
+	kubeconfig.Clusters["prod-1"] = &clientcmdapi.Cluster{Server: "https://prod-1.example.com:6443"}
+	kubeconfig.Clusters["prod-2"] = &clientcmdapi.Cluster{Server: "https://prod-2.example.com:6443"}
+	kubeconfig.AuthInfos["admin"] = &clientcmdapi.AuthInfo{Token: "token"}
+	kubeconfig.Contexts["prod-1"] = &clientcmdapi.Context{Cluster: "prod-1", AuthInfo: "admin"}
+	kubeconfig.Contexts["prod-2"] = &clientcmdapi.Context{Cluster: "prod-2", AuthInfo: "admin", Namespace: "shop"}
+	kubeconfig.Contexts["stale"] = &clientcmdapi.Context{Cluster: "decommissioned", AuthInfo: "admin"}
+	kubeconfig.CurrentContext = "prod-2"
+	allowed := s.T().TempDir()
+	kubeconfigPath := filepath.Join(allowed, "kubeconfig")
+	s.Require().NoError(clientcmd.WriteToFile(*kubeconfig, kubeconfigPath))
+	SetKubeconfigPaths([]string{allowed})
+
+	registry := clients.NewRegistry()
+	registry.AddClient(&clients.ClusterClient{Name: "prod-1"})
+	service := NewClusterService(registry)
+
+	s.Run("lists every context with its server and registration", func() {
+		list, err := service.KubeconfigContexts(kubeconfigPath)
+		s.Require().NoError(err)
+		s.Equal(3, list.Total)
+		s.Equal(2, list.Unregistered)
+		s.Equal([]KubeconfigContext{
+			{Name: "prod-1", Cluster: "prod-1", Server: "https://prod-1.example.com:6443", Registered: true},
+			{Name: "prod-2", Cluster: "prod-2", Server: "https://prod-2.example.com:6443", Namespace: "shop", Current: true},
+			{Name: "stale", Cluster: "decommissioned"},
+		}, list.Contexts)
+	})
+	s.Run("reports a missing kubeconfig", func() {
+		missing := filepath.Join(allowed, "missing")
+		_, err := service.KubeconfigContexts(missing)
+		s.EqualError(err, "kubeconfig "+missing+" not found")
+	})
+	s.Run("reports an invalid kubeconfig", func() {
+		invalid := filepath.Join(allowed, "invalid")
+		s.Require().NoError(os.WriteFile(invalid, []byte("clusters: [not"), 0600))
+		_, err := service.KubeconfigContexts(invalid)
+		s.ErrorContains(err, "failed to load kubeconfig")
+	})
+	s.Run("refuses a kubeconfig outside the allowed paths", func() {
+		outside := filepath.Join(s.T().TempDir(), "kubeconfig")
+		s.Require().NoError(clientcmd.WriteToFile(*kubeconfig, outside))
+		_, err := service.KubeconfigContexts(outside)
+		s.ErrorContains(err, "is outside the allowed kubeconfig paths")
+		_, err = service.KubeconfigContexts("/etc/passwd")
+		s.ErrorContains(err, "is outside the allowed kubeconfig paths")
+	})
+}
+
+func (s *ClusterServiceSuite) TestCheckPermissions() {
+	service := NewClusterService(clients.NewRegistry())
+
+	s.Run("reports the identity and which accesses are allowed", func() {
+		clientset := fake.NewSimpleClientset()
+		clientset.PrependReactor("create", "selfsubjectreviews", func(k8stesting.Action) (bool, runtime.Object, error) {
+			return true, &authenticationv1.SelfSubjectReview{Status: authenticationv1.SelfSubjectReviewStatus{
+				UserInfo: authenticationv1.UserInfo{Username: "system:serviceaccount:fusion:mcp", Groups: []string{"system:authenticated"}},
+			}}, nil
+		})
+		clientset.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+			// Everything but Velero backups is allowed
+			review.Status.Allowed = review.Spec.ResourceAttributes.Group != VeleroBackupGVR.Group
+			return true, review, nil
+		})
+
+		report, err := service.CheckPermissions(context.Background(), &clients.ClusterClient{Name: "prod-1", Clientset: clientset})
+		s.Require().NoError(err)
+		s.Equal("system:serviceaccount:fusion:mcp", report.Username)
+		s.Len(report.Permissions, len(permissionChecks))
+		s.Equal(2, report.Denied)
+		s.Equal(len(permissionChecks)-2, report.Allowed)
+		for _, permission := range report.Permissions {
+			if permission.Group == VeleroBackupGVR.Group {
+				s.False(permission.Allowed)
+				s.Equal(OADPNamespace, permission.Namespace)
+			}
+		}
+	})
+	s.Run("fails when no access can be reviewed", func() {
+		clientset := fake.NewSimpleClientset()
+		clientset.PrependReactor("create", "*", func(k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, errors.New("connection refused")
+		})
+		_, err := service.CheckPermissions(context.Background(), &clients.ClusterClient{Name: "prod-1", Clientset: clientset})
+		s.EqualError(err, "failed to review permissions: connection refused")
+	})
+}
+
+func (s *ClusterServiceSuite) TestLabel() {
+	registry := clients.NewRegistry()
+	registry.AddClient(&clients.ClusterClient{Name: "prod-1"})
+	service := NewClusterService(registry)
+
+	s.Run("sets labels", func() {
+		labeled, err := service.Label("prod-1", []string{"env=prod", "region=us-east", "tier=gold"})
+		s.Require().NoError(err)
+		s.Equal(map[string]string{"env": "prod", "region": "us-east", "tier": "gold"}, labeled.Labels)
+		s.Equal(labeled.Labels, registry.ClusterLabels("prod-1"))
+	})
+	s.Run("removes and changes labels, keeping the others", func() {
+		labeled, err := service.Label("prod-1", []string{"tier-", "env=staging"})
+		s.Require().NoError(err)
+		staging := "staging"
+		s.Equal(map[string]*string{"tier": nil, "env": &staging}, labeled.Patch)
+		s.Equal(map[string]string{"env": "staging", "region": "us-east"}, labeled.Labels)
+		s.JSONEq(`{"tier":null,"env":"staging"}`, string(s.marshal(labeled.Patch)), "the patch is a JSON merge patch")
+	})
+	s.Run("removing an absent label does nothing", func() {
+		labeled, err := service.Label("prod-1", []string{"tier-"})
+		s.Require().NoError(err)
+		s.Equal(map[string]string{"env": "staging", "region": "us-east"}, labeled.Labels)
+	})
+	s.Run("rejects invalid changes", func() {
+		for change, message := range map[string]string{
+			"env":            `invalid label change "env": use key=value to set a label or key- to remove it`,
+			"-=prod":         `invalid label key "-"`,
+			"env=prod east":  `invalid label value "prod east" of env`,
+			"example.com/-":  `invalid label key "example.com/"`,
+			"tier/gold/x=on": `invalid label key "tier/gold/x"`,
+		} {
+			_, err := service.Label("prod-1", []string{change})
+			s.ErrorContains(err, message, change)
+		}
+		_, err := service.Label("prod-1", []string{"env=prod", "env-"})
+		s.EqualError(err, "label env is changed more than once")
+		_, err = service.Label("prod-1", nil)
+		s.EqualError(err, "at least one label change is required")
+		s.Equal(map[string]string{"env": "staging", "region": "us-east"}, registry.ClusterLabels("prod-1"), "nothing is applied")
+	})
+	s.Run("fails for an unregistered cluster", func() {
+		_, err := service.Label("edge-1", []string{"env=prod"})
+		s.EqualError(err, "cluster edge-1 not found in registry")
+	})
+	s.Run("labels leave with the cluster", func() {
+		SetClusterRegistrationAllowed(true)
+		_, err := service.Unregister("prod-1")
+		s.Require().NoError(err)
+		registry.AddClient(&clients.ClusterClient{Name: "prod-1"})
+		s.Nil(registry.ClusterLabels("prod-1"))
+	})
+}
+
+// marshal encodes a value as JSON
+func (s *ClusterServiceSuite) marshal(value interface{}) []byte {
+	data, err := json.Marshal(value)
+	s.Require().NoError(err)
+	return data
+}
+
+func TestClusterServiceSuite(t *testing.T) {
+	suite.Run(t, new(ClusterServiceSuite))
+}
+
+// Made with Bob
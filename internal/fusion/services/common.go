@@ -4,161 +4,649 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
 	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
+	"golang.org/x/sync/errgroup"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
 )
 
 // ClusterOperation represents an operation to execute on a cluster
 type ClusterOperation func(ctx context.Context, client *clients.ClusterClient) (interface{}, error)
 
+// ClusterResultHandler receives each cluster result as soon as its cluster completes
+type ClusterResultHandler func(clusterResult targeting.ClusterResult)
+
 // ExecuteOnClusters executes an operation across multiple clusters based on target
 func ExecuteOnClusters(ctx context.Context, registry *clients.Registry, target targeting.Target, operation ClusterOperation) *targeting.Result {
+	return ExecuteOnClustersStream(ctx, registry, target, operation, nil)
+}
+
+// ExecuteOnClustersStream is ExecuteOnClusters passing every cluster result to onResult in
+// completion order, before the full result is returned. onResult is never called concurrently:
+// it runs on the goroutine of the cluster that completed, holding the lock results are
+// recorded under.
+func ExecuteOnClustersStream(ctx context.Context, registry *clients.Registry, target targeting.Target, operation ClusterOperation, onResult ClusterResultHandler) *targeting.Result {
+	return executeOnClusters(ctx, registry, target, operation, onResult, target.RetryPolicyFor(registry.RetryPolicy()))
+}
+
+// ExecuteWriteOnClusters is ExecuteOnClusters for operations that change the clusters,
+// which are never retried: a write that timed out may still have been applied, and
+// replaying it could apply it twice. The retry policy of the target is ignored.
+func ExecuteWriteOnClusters(ctx context.Context, registry *clients.Registry, target targeting.Target, operation ClusterOperation) *targeting.Result {
+	return executeOnClusters(ctx, registry, target, operation, nil, clients.RetryPolicy{MaxAttempts: 1})
+}
+
+// executeOnClusters runs operation on the clusters of target, retrying transient
+// failures under the retry policy
+func executeOnClusters(ctx context.Context, registry *clients.Registry, target targeting.Target, operation ClusterOperation, onResult ClusterResultHandler, retry clients.RetryPolicy) *targeting.Result {
+	start := time.Now()
 	result := targeting.NewResult(target)
 
-	// Get cluster names based on target type
-	clusterNames, err := target.ResolveClusterNames(registry)
+	// Share namespace and CRD probes between the services of this invocation
+	if ProbeCacheFromContext(ctx) == nil {
+		ctx = WithProbeCache(ctx, NewClusterProbeCache())
+	}
+
+	// Get cluster names based on target type, failing fast on names nothing is registered as
+	clusterNames, resolution, err := target.ResolveClusterNames(ctx, registry)
+	if err == nil {
+		err = target.ValidateAgainstRegistry(registry)
+	}
+	if err == nil {
+		err = checkImpersonation(target)
+	}
 	if err != nil {
-		result.Summary = map[string]interface{}{"error": err.Error()}
+		result.Summary.Error = err.Error()
+		result.Summary.DurationMs = time.Since(start).Milliseconds()
 		return result
 	}
+	result.Summary.Resolution = resolution
+
+	// Fan out in a stable order, registry and selector resolution come from map iteration
+	clusterNames = sortedUnique(clusterNames)
+
+	// Shared context cancelled on the first failure when running in fail-fast mode
+	runCtx, cancelRun := context.WithCancel(ctx)
+	defer cancelRun()
+
+	// Bound the number of in-flight cluster operations, starting one blocks while the
+	// limit is reached. Operations never fail the group, their errors are cluster results.
+	var group errgroup.Group
+	if limit := target.EffectiveMaxConcurrency(); limit > 0 {
+		group.SetLimit(limit)
+	}
 
-	// Set timeout
-	timeout := 30 * time.Second
-	if target.Timeout > 0 {
-		timeout = time.Duration(target.Timeout) * time.Second
+	// Cluster results in completion order, each handed to onResult as it is recorded
+	var mu sync.Mutex
+	clusterResults := make([]targeting.ClusterResult, 0, len(clusterNames))
+	record := func(clusterResult targeting.ClusterResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		clusterResults = append(clusterResults, clusterResult)
+		if onResult != nil {
+			onResult(clusterResult)
+		}
+	}
+	fail := func(clusterResult targeting.ClusterResult) {
+		if target.FailFast {
+			cancelRun()
+		}
+		record(clusterResult)
 	}
 
 	// Execute on each cluster concurrently
-	var wg sync.WaitGroup
-	resultChan := make(chan targeting.ClusterResult, len(clusterNames))
+	for _, name := range clusterNames {
+		group.Go(func() error {
+			logger := LoggerFrom(ctx).WithValues(clusterKey, name)
+
+			// Don't start work on this cluster once the caller cancelled the call
+			if err := ctx.Err(); err != nil {
+				logger.V(2).Info("Cluster operation cancelled before starting", "err", err)
+				record(targeting.ClusterResult{
+					ClusterName: name,
+					Error:       fmt.Sprintf("cancelled before starting: %v", err),
+					ErrorKind:   clients.ClassifyError(err),
+				})
+				return nil
+			}
 
-	for _, clusterName := range clusterNames {
-		wg.Add(1)
-		go func(name string) {
-			defer wg.Done()
+			// Don't start work on this cluster once fail-fast has tripped
+			if target.FailFast && runCtx.Err() != nil {
+				record(targeting.ClusterResult{
+					ClusterName: name,
+					Skipped:     true,
+					Error:       "skipped: fail-fast cancelled remaining clusters after a failure",
+				})
+				return nil
+			}
 
-			// Create context with timeout
-			opCtx, cancel := context.WithTimeout(ctx, timeout)
+			// Create context with the cluster's timeout
+			opCtx, cancel := context.WithTimeout(runCtx, target.TimeoutFor(name, registry.Timeout()))
 			defer cancel()
+			opCtx = klog.NewContext(opCtx, logger)
 
 			// Get cluster client
 			client, err := registry.GetClient(name)
 			if err != nil {
-				resultChan <- targeting.ClusterResult{
+				logger.Error(err, "Cluster client unavailable")
+				fail(targeting.ClusterResult{
 					ClusterName: name,
 					Success:     false,
 					Error:       fmt.Sprintf("failed to get client: %v", err),
-				}
-				return
+					ErrorKind:   clients.ErrorKindNotFound,
+				})
+				return nil
+			}
+			// Impersonating targets run on a client derived for this call only
+			if client, err = clientFor(target, client); err != nil {
+				logger.Error(err, "Cannot impersonate", "user", target.ImpersonateUser)
+				fail(targeting.ClusterResult{
+					ClusterName: name,
+					Success:     false,
+					Error:       fmt.Sprintf("failed to impersonate %s: %v", target.ImpersonateUser, err),
+					ErrorKind:   clients.ErrorKindUnknown,
+				})
+				return nil
 			}
 
 			// Execute operation
-			data, err := operation(opCtx, client)
+			// Retry transient failures within the cluster's timeout
+			logger.V(4).Info("Running cluster operation")
+			opStart := time.Now()
+			data, attempts, err := clients.Retry(opCtx, retry, func() (interface{}, error) {
+				return operation(opCtx, client)
+			})
+			if attempts <= 1 {
+				attempts = 0
+			}
+			// Probes read a cancelled or expired context as "not found", so an operation
+			// finishing after its context ended has no trustworthy result
+			if err == nil && opCtx.Err() != nil {
+				err = opCtx.Err()
+			}
 			if err != nil {
-				resultChan <- targeting.ClusterResult{
+				// Credential failures of cloud clusters name their auth plugin
+				err = clients.ExplainAuthError(client, err)
+				// A deadline hit by the cluster timeout may surface as a generic error
+				kind := clients.ClassifyError(err)
+				if opCtx.Err() == context.DeadlineExceeded {
+					kind = clients.ErrorKindTimeout
+				}
+				logger.Error(err, "Cluster operation failed", "errorKind", kind, "attempts", max(attempts, 1), "duration", time.Since(opStart))
+				fail(targeting.ClusterResult{
 					ClusterName: name,
 					Success:     false,
 					Error:       err.Error(),
-				}
-				return
+					ErrorKind:   kind,
+					Attempts:    attempts,
+				})
+				return nil
 			}
 
-			// Marshal data to JSON
+			// Marshal data to JSON, component statuses carry the output shape version
+			// and a severity, derived from their readiness when the service set none
+			if versioned, ok := data.(schemaVersioned); ok {
+				versioned.SetSchemaVersion(targeting.SchemaVersion)
+			}
+			if rated, ok := data.(severityRated); ok {
+				rated.ResolveSeverity()
+			}
 			jsonData, err := json.Marshal(data)
 			if err != nil {
-				resultChan <- targeting.ClusterResult{
+				logger.Error(err, "Cannot marshal cluster operation result")
+				fail(targeting.ClusterResult{
 					ClusterName: name,
 					Success:     false,
 					Error:       fmt.Sprintf("failed to marshal data: %v", err),
-				}
-				return
+					ErrorKind:   clients.ErrorKindUnknown,
+				})
+				return nil
 			}
 
-			resultChan <- targeting.ClusterResult{
+			logger.V(4).Info("Cluster operation succeeded", "duration", time.Since(opStart))
+			record(targeting.ClusterResult{
 				ClusterName: name,
 				Success:     true,
 				Data:        json.RawMessage(jsonData),
-			}
-		}(clusterName)
+				Attempts:    attempts,
+			})
+			return nil
+		})
 	}
+	_ = group.Wait()
 
-	// Wait for all operations to complete
-	go func() {
-		wg.Wait()
-		close(resultChan)
-	}()
-
-	// Collect results
-	for clusterResult := range resultChan {
-		result.AddClusterResult(clusterResult.ClusterName, clusterResult.Data,
-			func() error {
-				if !clusterResult.Success {
-					return fmt.Errorf("%s", clusterResult.Error)
-				}
-				return nil
-			}())
+	// Collect the results, in completion order like they were streamed
+	for _, clusterResult := range clusterResults {
+		if clusterResult.Skipped {
+			result.AddSkippedCluster(clusterResult.ClusterName, clusterResult.Error)
+		} else {
+			result.SetClusterResult(clusterResult)
+		}
 	}
 
+	result.Finalize()
+	result.Summary.DurationMs = time.Since(start).Milliseconds()
 	return result
 }
 
+// sortedUnique returns names sorted with duplicates removed
+func sortedUnique(names []string) []string {
+	unique := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if !seen[name] {
+			seen[name] = true
+			unique = append(unique, name)
+		}
+	}
+	sort.Strings(unique)
+	return unique
+}
+
+// ForEachClusterData decodes the data of every successful cluster result into T
+// and passes it to fn, so tools can aggregate typed per-cluster data into the summary
+func ForEachClusterData[T any](result *targeting.Result, fn func(clusterName string, data T)) {
+	for name, clusterResult := range result.ClusterResults {
+		if !clusterResult.Success {
+			continue
+		}
+		raw, ok := clusterResult.Data.(json.RawMessage)
+		if !ok {
+			continue
+		}
+		var data T
+		if err := json.Unmarshal(raw, &data); err != nil {
+			continue
+		}
+		fn(name, data)
+	}
+}
+
+// FindCondition returns the status and message of a condition in a resource's
+// .status.conditions, and whether the condition is present
+func FindCondition(obj unstructured.Unstructured, conditionType string) (status, message string, found bool) {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if t, _, _ := unstructured.NestedString(condition, "type"); t != conditionType {
+			continue
+		}
+		status, _, _ = unstructured.NestedString(condition, "status")
+		message, _, _ = unstructured.NestedString(condition, "message")
+		return status, message, true
+	}
+	return "", "", false
+}
+
 // CheckCRDExists checks if a CRD exists in the cluster
+// Lookups go through the ClusterProbeCache of the context when it has one
 func CheckCRDExists(ctx context.Context, client *clients.ClusterClient, gvr schema.GroupVersionResource) bool {
-	discoveryClient := discovery.NewDiscoveryClientForConfigOrDie(client.Config)
+	return CheckCRDsExist(ctx, client, []schema.GroupVersionResource{gvr})[gvr]
+}
+
+// CheckCRDsExist checks which of the resources the cluster serves, answering for all of
+// them from a single discovery. A failed discovery reports every resource as missing.
+func CheckCRDsExist(ctx context.Context, client *clients.ClusterClient, gvrs []schema.GroupVersionResource) map[schema.GroupVersionResource]bool {
+	exists := make(map[schema.GroupVersionResource]bool, len(gvrs))
+	for _, gvr := range gvrs {
+		exists[gvr] = false
+	}
 
-	_, apiResourceList, err := discoveryClient.ServerGroupsAndResources()
+	apiResourceList, err := serverResources(ctx, client)
 	if err != nil {
-		return false
+		return exists
 	}
 
 	for _, list := range apiResourceList {
-		if list.GroupVersion == gvr.GroupVersion().String() {
-			for _, resource := range list.APIResources {
-				if resource.Name == gvr.Resource {
-					return true
-				}
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			continue
+		}
+		for _, resource := range list.APIResources {
+			gvr := gv.WithResource(resource.Name)
+			if _, requested := exists[gvr]; requested {
+				exists[gvr] = true
 			}
 		}
 	}
 
-	return false
+	return exists
 }
 
 // CheckNamespaceExists checks if a namespace exists
+// Namespaces outside the allowed namespaces are reported as missing
 func CheckNamespaceExists(ctx context.Context, client *clients.ClusterClient, namespace string) bool {
-	_, err := client.Clientset.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
-	return err == nil
+	exists, _ := lookupNamespace(ctx, client, namespace)
+	return exists
 }
 
-// CheckPodsInNamespace checks if there are pods in a namespace with a label selector
+// lookupNamespace is CheckNamespaceExists returning the error a namespace that reads as
+// missing failed with, nil when it does not exist or is outside the allowed namespaces
+func lookupNamespace(ctx context.Context, client *clients.ClusterClient, namespace string) (bool, error) {
+	if !NamespaceAllowed(namespace) {
+		return false, nil
+	}
+	if cache := ProbeCacheFromContext(ctx); cache != nil {
+		return cache.namespaceExists(ctx, client, namespace)
+	}
+	exists, err := getNamespace(ctx, client, namespace)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	return exists, err
+}
+
+// CheckPodsInNamespace counts the pods in a namespace matching a label selector,
+// reading them page by page
 func CheckPodsInNamespace(ctx context.Context, client *clients.ClusterClient, namespace, labelSelector string) (int, error) {
-	pods, err := client.Clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
-		LabelSelector: labelSelector,
+	if !NamespaceAllowed(namespace) {
+		return 0, fmt.Errorf("namespace %s is outside the allowed namespaces (scope restricted)", namespace)
+	}
+	count := 0
+	err := listPages(ctx, metav1.ListOptions{LabelSelector: labelSelector}, func(opts metav1.ListOptions) (string, error) {
+		pods, err := client.Clientset.CoreV1().Pods(namespace).List(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		count += len(pods.Items)
+		return pods.Continue, nil
 	})
 	if err != nil {
 		return 0, err
 	}
-	return len(pods.Items), nil
+	return count, nil
 }
 
-// ComponentStatus represents the status of a component
+const (
+	// listPageSize is how many items paginated listings request per page
+	listPageSize = 500
+
+	// DefaultMaxItems caps the items list-based services return in detail per cluster
+	DefaultMaxItems = 100
+)
+
+// listPages calls list once per page of a Kubernetes list, passing opts with the page
+// limit and continue token set. list returns the continue token of its page, listing
+// stops when it is empty, so callers can aggregate without holding every item.
+func listPages(ctx context.Context, opts metav1.ListOptions, list func(opts metav1.ListOptions) (string, error)) error {
+	if opts.Limit <= 0 {
+		opts.Limit = listPageSize
+	}
+	for {
+		next, err := list(opts)
+		if err != nil {
+			return err
+		}
+		if next == "" {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		opts.Continue = next
+	}
+}
+
+// effectiveMaxItems returns maxItems, or DefaultMaxItems when it is 0 or less
+func effectiveMaxItems(maxItems int) int {
+	if maxItems <= 0 {
+		return DefaultMaxItems
+	}
+	return maxItems
+}
+
+// ComponentStatus represents the status of a component. Ready summarizes Conditions
+// when the component reports any, see AddConditions.
+// Ready is kept for compatibility, Severity is the richer signal: an installed component
+// can be ready and still warn, for example Ceph in HEALTH_WARN.
 type ComponentStatus struct {
-	Installed bool   `json:"installed"`
-	Ready     bool   `json:"ready,omitempty"`
-	Version   string `json:"version,omitempty"`
-	Message   string `json:"message,omitempty"`
+	// SchemaVersion is set on the component status a cluster operation returns, see
+	// targeting.SchemaVersion
+	SchemaVersion string `json:"schemaVersion,omitempty"`
+	Installed     bool   `json:"installed"`
+	Ready         bool   `json:"ready,omitempty"`
+	// Severity is one of the Severity constants, empty when the component is not installed
+	Severity string `json:"severity,omitempty"`
+	Version  string `json:"version,omitempty"`
+	// Reason tells why the component is not installed or not ready, when known
+	Reason  NotInstalledReason `json:"reason,omitempty"`
+	Message string             `json:"message,omitempty"`
+	// Scope notes that the allowed namespaces restricted what was read
+	Scope      string            `json:"scope,omitempty"`
+	Conditions []StatusCondition `json:"conditions,omitempty"`
+}
+
+// NotInstalledReason tells why a component reads as not installed or not ready, so that
+// a missing operator can be told apart from missing permissions to see it
+type NotInstalledReason string
+
+// Reasons a component is not installed or not ready
+const (
+	// ReasonNamespaceAbsent means none of the namespaces of the component exists
+	ReasonNamespaceAbsent NotInstalledReason = "namespace-absent"
+	// ReasonCRDAbsent means the cluster doesn't serve the custom resources of the component
+	ReasonCRDAbsent NotInstalledReason = "crd-absent"
+	// ReasonNoHealthyInstance means the component is installed but none of its instances,
+	// such as filesystems or operator pods, is healthy
+	ReasonNoHealthyInstance NotInstalledReason = "no-healthy-instance"
+	// ReasonForbidden means the server credentials may not read what tells
+	ReasonForbidden NotInstalledReason = "forbidden"
+)
+
+// noteForbidden sets ReasonForbidden when err is a permission error, a component that
+// cannot be read is not necessarily broken
+func (c *ComponentStatus) noteForbidden(err error) {
+	if apierrors.IsForbidden(err) {
+		c.Reason = ReasonForbidden
+	}
+}
+
+// StatusCondition is a Kubernetes style condition of a component
+type StatusCondition struct {
+	Type               string `json:"type"`
+	Status             string `json:"status"`
+	Reason             string `json:"reason,omitempty"`
+	Message            string `json:"message,omitempty"`
+	LastTransitionTime string `json:"lastTransitionTime,omitempty"`
+}
+
+// Condition types whose status tells health. Positive types are healthy when True and
+// negative ones when False, other types such as Progressing or Upgradeable are informational.
+var (
+	positiveConditionTypes = []string{"Available", "Ready", "Healthy", "Validated", "PeerReady", "ReconcileComplete", "Connected"}
+	negativeConditionTypes = []string{"Degraded", "Failing", "ReconcileFailed"}
+)
+
+// Healthy reports whether the condition is in its good state, informational conditions
+// are always healthy
+func (c StatusCondition) Healthy() bool {
+	switch {
+	case slices.Contains(positiveConditionTypes, c.Type):
+		return c.Status == "True"
+	case slices.Contains(negativeConditionTypes, c.Type):
+		return c.Status == "False"
+	default:
+		return true
+	}
+}
+
+// String formats the condition as Type=Status, followed by its message when it has one
+func (c StatusCondition) String() string {
+	if c.Message == "" {
+		return fmt.Sprintf("%s=%s", c.Type, c.Status)
+	}
+	return fmt.Sprintf("%s=%s: %s", c.Type, c.Status, c.Message)
+}
+
+// AddConditions records conditions of the component and clears Ready when one of
+// them is not healthy
+func (c *ComponentStatus) AddConditions(conditions ...StatusCondition) {
+	c.Conditions = append(c.Conditions, conditions...)
+	for _, condition := range conditions {
+		if !condition.Healthy() {
+			c.Ready = false
+		}
+	}
+}
+
+// Component severities, from best to worst. Unknown is worse than warning: a component
+// whose health cannot be read may be failing.
+const (
+	SeverityOK       = "ok"
+	SeverityWarning  = "warning"
+	SeverityUnknown  = "unknown"
+	SeverityCritical = "critical"
+)
+
+// severityRanks orders the severities, an empty severity ranks below ok
+var severityRanks = map[string]int{
+	SeverityOK:       1,
+	SeverityWarning:  2,
+	SeverityUnknown:  3,
+	SeverityCritical: 4,
+}
+
+// WorstSeverity returns the worst of severities, empty when none is set
+func WorstSeverity(severities ...string) string {
+	worst := ""
+	for _, severity := range severities {
+		if severityRanks[severity] > severityRanks[worst] {
+			worst = severity
+		}
+	}
+	return worst
+}
+
+// RaiseSeverity sets the severity of the component to severity unless it already is worse
+func (c *ComponentStatus) RaiseSeverity(severity string) {
+	c.Severity = WorstSeverity(c.Severity, severity)
+}
+
+// severityRated is cluster operation data carrying a component severity
+type severityRated interface {
+	ResolveSeverity()
 }
 
-// NotInstalledStatus returns a status indicating component is not installed
-func NotInstalledStatus(message string) ComponentStatus {
+// ResolveSeverity completes the severity the service set from the readiness of the
+// component: an installed component that is not healthy is critical, one that is
+// healthy is at least ok. Components that aren't installed have no severity.
+func (c *ComponentStatus) ResolveSeverity() {
+	switch {
+	case !c.Installed:
+		c.Severity = ""
+	case !c.IsHealthy():
+		c.RaiseSeverity(SeverityCritical)
+	default:
+		c.RaiseSeverity(SeverityOK)
+	}
+}
+
+// schemaVersioned is cluster operation data reporting the output shape version
+type schemaVersioned interface {
+	SetSchemaVersion(version string)
+}
+
+// SetSchemaVersion records the output shape version of the status
+func (c *ComponentStatus) SetSchemaVersion(version string) {
+	c.SchemaVersion = version
+}
+
+// IsHealthy reports whether the component is installed, ready and all its conditions
+// are healthy
+func (c ComponentStatus) IsHealthy() bool {
+	if !c.Installed || !c.Ready {
+		return false
+	}
+	for _, condition := range c.Conditions {
+		if !condition.Healthy() {
+			return false
+		}
+	}
+	return true
+}
+
+// ReadConditions returns the .status.conditions of a resource
+func ReadConditions(obj unstructured.Unstructured) []StatusCondition {
+	items, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	conditions := make([]StatusCondition, 0, len(items))
+	for _, item := range items {
+		fields, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		condition := StatusCondition{}
+		condition.Type, _, _ = unstructured.NestedString(fields, "type")
+		condition.Status, _, _ = unstructured.NestedString(fields, "status")
+		condition.Reason, _, _ = unstructured.NestedString(fields, "reason")
+		condition.Message, _, _ = unstructured.NestedString(fields, "message")
+		condition.LastTransitionTime, _, _ = unstructured.NestedString(fields, "lastTransitionTime")
+		if condition.Type != "" {
+			conditions = append(conditions, condition)
+		}
+	}
+	return conditions
+}
+
+// CSVVersion returns the spec.version of the first ClusterServiceVersion whose name starts
+// with one of prefixes, searching namespaces in order. It is empty when none is found.
+func CSVVersion(ctx context.Context, dynamicClient dynamic.Interface, namespaces []string, prefixes ...string) string {
+	for _, ns := range namespaces {
+		if ns == "" || !NamespaceAllowed(ns) {
+			continue
+		}
+		csvs, err := dynamicClient.Resource(ClusterServiceVersionGVR).Namespace(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			logDegraded(ctx, err, "Cannot list ClusterServiceVersions", "namespace", ns)
+			continue
+		}
+		for _, csv := range csvs.Items {
+			for _, prefix := range prefixes {
+				if !strings.HasPrefix(csv.GetName(), prefix) {
+					continue
+				}
+				if version, _, _ := unstructured.NestedString(csv.Object, "spec", "version"); version != "" {
+					return version
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// DryRunPreview is embedded in the results of writes. With dryRun set the write was only
+// validated by the API server and Preview holds the object it would have persisted.
+type DryRunPreview struct {
+	DryRun  bool                   `json:"dryRun,omitempty"`
+	Preview map[string]interface{} `json:"preview,omitempty"`
+}
+
+// dryRunOption returns the DryRun option of a write, nil when the write is persisted
+func dryRunOption(dryRun bool) []string {
+	if dryRun {
+		return []string{metav1.DryRunAll}
+	}
+	return nil
+}
+
+// NotInstalledStatus returns a status indicating component is not installed and why
+func NotInstalledStatus(reason NotInstalledReason, message string) ComponentStatus {
 	return ComponentStatus{
 		Installed: false,
+		Reason:    reason,
 		Message:   message,
 	}
 }
@@ -173,4 +661,35 @@ func InstalledStatus(ready bool, version, message string) ComponentStatus {
 	}
 }
 
+// HumanizeDuration formats a duration in its two largest units, such as 45s, 5m30s, 3h5m
+// or 3d2h, dropping the smaller ones and a second unit of zero: 72h3m0s is 3d. Negative
+// durations, from clock skew between clusters, format as 0s.
+func HumanizeDuration(d time.Duration) string {
+	const day = 24 * time.Hour
+	twoUnits := func(major int64, majorUnit string, minor int64, minorUnit string) string {
+		if minor == 0 {
+			return fmt.Sprintf("%d%s", major, majorUnit)
+		}
+		return fmt.Sprintf("%d%s%d%s", major, majorUnit, minor, minorUnit)
+	}
+	switch {
+	case d < 0:
+		return "0s"
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int64(d/time.Second))
+	case d < time.Hour:
+		return twoUnits(int64(d/time.Minute), "m", int64(d%time.Minute/time.Second), "s")
+	case d < day:
+		return twoUnits(int64(d/time.Hour), "h", int64(d%time.Hour/time.Minute), "m")
+	default:
+		return twoUnits(int64(d/day), "d", int64(d%day/time.Hour), "h")
+	}
+}
+
+// HumanizeAge formats the time elapsed since t, see HumanizeDuration. Outputs report it
+// next to the RFC3339 timestamp it is computed from.
+func HumanizeAge(t time.Time) string {
+	return HumanizeDuration(time.Since(t))
+}
+
 // Made with Bob
@@ -0,0 +1,837 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
+	"github.com/go-logr/logr/funcr"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+)
+
+type CommonSuite struct {
+	suite.Suite
+}
+
+// newTestRegistry returns a registry seeded with one context per cluster name
+// The clusters point at placeholder servers, operations under test never contact them
+func (s *CommonSuite) newTestRegistry(clusterNames ...string) *clients.Registry {
+	kubeconfig := clientcmdapi.NewConfig()
+	for i, name := range clusterNames {
+		kubeconfig.Clusters[name] = &clientcmdapi.Cluster{Server: fmt.Sprintf("https://%s.example.com:%d", name, 6443+i)}
+		kubeconfig.AuthInfos[name] = &clientcmdapi.AuthInfo{Token: "token"}
+		kubeconfig.Contexts[name] = &clientcmdapi.Context{Cluster: name, AuthInfo: name}
+	}
+	kubeconfigPath := filepath.Join(s.T().TempDir(), "kubeconfig")
+	s.Require().NoError(clientcmd.WriteToFile(*kubeconfig, kubeconfigPath))
+
+	registry := clients.NewRegistry()
+	failed, err := registry.RegisterFromKubeconfig(kubeconfigPath)
+	s.Require().NoError(err)
+	s.Require().Empty(failed)
+	s.Require().Len(registry.ListClusterNames(), len(clusterNames))
+	return registry
+}
+
+func (s *CommonSuite) TestClusterResultShape() {
+	s.Run("targeting and clients share a single ClusterResult definition", func() {
+		var fromTargeting targeting.ClusterResult = clients.ClusterResult{
+			ClusterName: "cluster-a",
+			Success:     false,
+			Data:        map[string]string{"key": "value"},
+			Error:       "boom",
+		}
+		var fromClients clients.ClusterResult = fromTargeting
+		s.Equal("cluster-a", fromClients.ClusterName)
+		s.Equal("boom", fromClients.Error)
+	})
+
+	s.Run("Registry.ExecuteOnAllClusters produces the shared ClusterResult", func() {
+		registry := clients.NewRegistry()
+		var results map[string]targeting.ClusterResult = registry.ExecuteOnAllClusters(context.Background(), func(*clients.ClusterClient) (interface{}, error) {
+			return nil, nil
+		})
+		s.Empty(results)
+	})
+
+	s.Run("ExecuteOnClusters records failures using the shared ClusterResult", func() {
+		registry := s.newTestRegistry("cluster-a")
+		target := targeting.Target{Type: targeting.TargetMulti, Clusters: []string{"cluster-a", "missing"}}
+		result := ExecuteOnClusters(context.Background(), registry, target, func(context.Context, *clients.ClusterClient) (interface{}, error) {
+			return nil, nil
+		})
+		s.Require().Contains(result.ClusterResults, "missing")
+		var clusterResult clients.ClusterResult = result.ClusterResults["missing"]
+		s.Equal("missing", clusterResult.ClusterName)
+		s.False(clusterResult.Success)
+		s.Equal("failed to get client: cluster missing not found in registry, registered clusters: cluster-a", clusterResult.Error)
+		s.Equal(1, result.Summary.Failed)
+		s.Equal(1, result.Summary.Succeeded, "the registered cluster still reports")
+	})
+
+	s.Run("ExecuteOnClusters fails fast when no named cluster is registered", func() {
+		registry := s.newTestRegistry("cluster-a")
+		called := false
+		target := targeting.Target{Type: targeting.TargetSingle, Cluster: "cluster-b"}
+		result := ExecuteOnClusters(context.Background(), registry, target, func(context.Context, *clients.ClusterClient) (interface{}, error) {
+			called = true
+			return nil, nil
+		})
+		s.False(called)
+		s.Empty(result.ClusterResults)
+		s.Equal("cluster cluster-b not found in registry, did you mean cluster-a?", result.Summary.Error)
+	})
+}
+
+func (s *CommonSuite) TestHumanizeDuration() {
+	for _, tc := range []struct {
+		duration time.Duration
+		expected string
+	}{
+		{-5 * time.Second, "0s"},
+		{0, "0s"},
+		{999 * time.Millisecond, "0s"},
+		{59 * time.Second, "59s"},
+		{time.Minute, "1m"},
+		{5*time.Minute + 30*time.Second, "5m30s"},
+		{59*time.Minute + 59*time.Second, "59m59s"},
+		{time.Hour, "1h"},
+		{3*time.Hour + 5*time.Minute + 20*time.Second, "3h5m"},
+		{23*time.Hour + 59*time.Minute, "23h59m"},
+		{24 * time.Hour, "1d"},
+		{72*time.Hour + 3*time.Minute, "3d"},
+		{74*time.Hour + 59*time.Minute, "3d2h"},
+		{400 * 24 * time.Hour, "400d"},
+	} {
+		s.Equal(tc.expected, HumanizeDuration(tc.duration), tc.duration.String())
+	}
+	s.Equal("2h", HumanizeAge(time.Now().Add(-2*time.Hour)))
+	s.Equal("0s", HumanizeAge(time.Now().Add(time.Minute)), "a time ahead of the local clock")
+}
+
+func (s *CommonSuite) TestSchemaVersion() {
+	registry := s.newTestRegistry("cluster-a")
+	result := ExecuteOnClusters(context.Background(), registry, targeting.Target{Type: targeting.TargetAll}, func(context.Context, *clients.ClusterClient) (interface{}, error) {
+		return &ComponentStatus{Installed: true}, nil
+	})
+	s.Run("result reports the schema version", func() {
+		s.Equal(targeting.SchemaVersion, result.SchemaVersion)
+	})
+	s.Run("component status payloads report the schema version", func() {
+		var status ComponentStatus
+		s.Require().NoError(json.Unmarshal(result.ClusterResults["cluster-a"].Data.(json.RawMessage), &status))
+		s.Equal(targeting.SchemaVersion, status.SchemaVersion)
+	})
+	s.Run("payloads without a component status are left unchanged", func() {
+		result := ExecuteOnClusters(context.Background(), registry, targeting.Target{Type: targeting.TargetAll}, func(context.Context, *clients.ClusterClient) (interface{}, error) {
+			return map[string]string{"key": "value"}, nil
+		})
+		s.JSONEq(`{"key":"value"}`, string(result.ClusterResults["cluster-a"].Data.(json.RawMessage)))
+	})
+}
+
+func (s *CommonSuite) TestExecuteOnClustersMaxConcurrency() {
+	clusterNames := make([]string, 10)
+	for i := range clusterNames {
+		clusterNames[i] = fmt.Sprintf("cluster-%02d", i)
+	}
+	registry := s.newTestRegistry(clusterNames...)
+
+	for _, limit := range []int{1, 3} {
+		s.Run(fmt.Sprintf("never exceeds maxConcurrency=%d", limit), func() {
+			var inFlight, peak atomic.Int32
+			target := targeting.Target{Type: targeting.TargetAll, MaxConcurrency: ptr.To(limit)}
+			result := ExecuteOnClusters(context.Background(), registry, target, func(context.Context, *clients.ClusterClient) (interface{}, error) {
+				current := inFlight.Add(1)
+				for {
+					observed := peak.Load()
+					if current <= observed || peak.CompareAndSwap(observed, current) {
+						break
+					}
+				}
+				time.Sleep(20 * time.Millisecond)
+				inFlight.Add(-1)
+				return "ok", nil
+			})
+			s.Equal(len(clusterNames), result.Summary.Succeeded)
+			s.LessOrEqual(int(peak.Load()), limit)
+		})
+	}
+
+	s.Run("unbounded when maxConcurrency is 0", func() {
+		target := targeting.Target{Type: targeting.TargetAll, MaxConcurrency: ptr.To(0)}
+		result := ExecuteOnClusters(context.Background(), registry, target, func(context.Context, *clients.ClusterClient) (interface{}, error) {
+			return "ok", nil
+		})
+		s.Equal(len(clusterNames), result.Summary.Succeeded)
+	})
+}
+
+func (s *CommonSuite) TestExecuteOnClustersClusterTimeouts() {
+	registry := s.newTestRegistry("edge-1", "core-1")
+	slowOperation := func(ctx context.Context, _ *clients.ClusterClient) (interface{}, error) {
+		select {
+		case <-time.After(1500 * time.Millisecond):
+			return "ok", nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	s.Run("per-cluster override lets a slow cluster finish while others time out", func() {
+		target := targeting.Target{
+			Type:            targeting.TargetAll,
+			Timeout:         1,
+			ClusterTimeouts: map[string]int{"edge-1": 5},
+		}
+		result := ExecuteOnClusters(context.Background(), registry, target, slowOperation)
+		s.True(result.ClusterResults["edge-1"].Success)
+		s.False(result.ClusterResults["core-1"].Success)
+		s.Contains(result.ClusterResults["core-1"].Error, "deadline exceeded")
+	})
+
+	s.Run("negative overrides are rejected", func() {
+		target := targeting.Target{Type: targeting.TargetAll, ClusterTimeouts: map[string]int{"edge-1": -1}}
+		result := ExecuteOnClusters(context.Background(), registry, target, slowOperation)
+		s.Contains(result.Summary.Error, "cannot be negative")
+		s.Empty(result.ClusterResults)
+	})
+}
+
+func (s *CommonSuite) TestExecuteOnClustersStableOutput() {
+	registry := s.newTestRegistry("cluster-c", "cluster-a", "cluster-d", "cluster-b")
+	run := func() []byte {
+		result := ExecuteOnClusters(context.Background(), registry, targeting.Target{Type: targeting.TargetAll}, func(_ context.Context, client *clients.ClusterClient) (interface{}, error) {
+			if client.Name == "cluster-d" {
+				return nil, fmt.Errorf("forbidden")
+			}
+			return map[string]string{"server": client.Config.Host, "cluster": client.Name}, nil
+		})
+		// Timing is the only run-dependent field
+		result.Summary.DurationMs = 0
+		out, err := json.MarshalIndent(result, "", "  ")
+		s.Require().NoError(err)
+		return out
+	}
+
+	first := run()
+	s.Run("same target twice yields byte-identical output", func() {
+		for i := 0; i < 5; i++ {
+			s.Equal(string(first), string(run()))
+		}
+	})
+	s.Run("cluster results are ordered by cluster name", func() {
+		output := string(first)
+		a := strings.Index(output, `"cluster-a": {`)
+		b := strings.Index(output, `"cluster-b": {`)
+		c := strings.Index(output, `"cluster-c": {`)
+		s.True(a >= 0 && a < b && b < c, "expected cluster-a < cluster-b < cluster-c in\n%s", output)
+	})
+	s.Run("duplicate cluster names run once", func() {
+		calls := int32(0)
+		target := targeting.Target{Type: targeting.TargetMulti, Clusters: []string{"cluster-a", "cluster-a"}}
+		result := ExecuteOnClusters(context.Background(), registry, target, func(context.Context, *clients.ClusterClient) (interface{}, error) {
+			atomic.AddInt32(&calls, 1)
+			return nil, nil
+		})
+		s.Equal(int32(1), atomic.LoadInt32(&calls))
+		s.Equal(1, result.Summary.Total)
+	})
+}
+
+func (s *CommonSuite) TestExecuteOnClustersStream() {
+	registry := s.newTestRegistry("cluster-a", "cluster-b", "cluster-c")
+	release := make(chan struct{})
+	var streamed []string
+	result := ExecuteOnClustersStream(context.Background(), registry, targeting.Target{Type: targeting.TargetAll}, func(_ context.Context, client *clients.ClusterClient) (interface{}, error) {
+		// cluster-a completes last
+		if client.Name == "cluster-a" {
+			<-release
+		}
+		return map[string]string{"cluster": client.Name}, nil
+	}, func(clusterResult targeting.ClusterResult) {
+		streamed = append(streamed, clusterResult.ClusterName)
+		if len(streamed) == 2 {
+			close(release)
+		}
+	})
+
+	s.Run("every cluster result is streamed in completion order", func() {
+		s.Require().Len(streamed, 3)
+		s.Equal("cluster-a", streamed[2])
+		s.ElementsMatch([]string{"cluster-b", "cluster-c"}, streamed[:2])
+	})
+	s.Run("the batch result is still complete", func() {
+		s.Equal(3, result.Summary.Succeeded)
+		s.Len(result.ClusterResults, 3)
+	})
+}
+
+// TestExecuteOnClustersConcurrentCollection records many clusters completing together,
+// run it with -race to check that results are collected without data races
+func (s *CommonSuite) TestExecuteOnClustersConcurrentCollection() {
+	clusterNames := make([]string, 40)
+	for i := range clusterNames {
+		clusterNames[i] = fmt.Sprintf("cluster-%02d", i)
+	}
+	registry := s.newTestRegistry(clusterNames...)
+
+	s.Run("collects every result and streams them one at a time", func() {
+		var streaming atomic.Bool
+		var overlapped atomic.Bool
+		// Appended without a lock, the race detector flags concurrent onResult calls
+		var streamed []string
+		target := targeting.Target{Type: targeting.TargetAll, MaxConcurrency: ptr.To(8)}
+		result := ExecuteOnClustersStream(context.Background(), registry, target, func(_ context.Context, client *clients.ClusterClient) (interface{}, error) {
+			if strings.HasSuffix(client.Name, "7") {
+				return nil, fmt.Errorf("boom")
+			}
+			return map[string]string{"cluster": client.Name}, nil
+		}, func(clusterResult targeting.ClusterResult) {
+			if !streaming.CompareAndSwap(false, true) {
+				overlapped.Store(true)
+			}
+			time.Sleep(time.Millisecond)
+			streamed = append(streamed, clusterResult.ClusterName)
+			streaming.Store(false)
+		})
+
+		s.False(overlapped.Load(), "onResult is never called concurrently")
+		s.ElementsMatch(clusterNames, streamed)
+		s.Len(result.ClusterResults, len(clusterNames))
+		s.Equal(36, result.Summary.Succeeded)
+		s.Equal(4, result.Summary.Failed)
+		s.Equal("boom", result.Errors["cluster-17"])
+	})
+	s.Run("fail-fast skips the clusters not started after a failure", func() {
+		target := targeting.Target{Type: targeting.TargetAll, MaxConcurrency: ptr.To(1), FailFast: true}
+		result := ExecuteOnClusters(context.Background(), registry, target, func(_ context.Context, client *clients.ClusterClient) (interface{}, error) {
+			if client.Name == "cluster-02" {
+				return nil, fmt.Errorf("boom")
+			}
+			return "ok", nil
+		})
+
+		s.Len(result.ClusterResults, len(clusterNames))
+		s.Equal(2, result.Summary.Succeeded, "clusters run in name order one at a time")
+		s.Equal(1, result.Summary.Failed)
+		s.Equal(len(clusterNames)-3, result.Summary.Skipped)
+		s.True(result.ClusterResults["cluster-39"].Skipped)
+	})
+}
+
+func (s *CommonSuite) TestExecuteOnClustersCancellation() {
+	// Every API request hangs until its client goes away
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	kubeconfig := clientcmdapi.NewConfig()
+	clusterNames := []string{"prod-1", "prod-2", "prod-3", "prod-4"}
+	for _, name := range clusterNames {
+		kubeconfig.Clusters[name] = &clientcmdapi.Cluster{Server: server.URL}
+		kubeconfig.AuthInfos[name] = &clientcmdapi.AuthInfo{Token: "token"}
+		kubeconfig.Contexts[name] = &clientcmdapi.Context{Cluster: name, AuthInfo: name}
+	}
+	kubeconfigPath := filepath.Join(s.T().TempDir(), "kubeconfig")
+	s.Require().NoError(clientcmd.WriteToFile(*kubeconfig, kubeconfigPath))
+	registry := clients.NewRegistry()
+	failed, err := registry.RegisterFromKubeconfig(kubeconfigPath)
+	s.Require().NoError(err)
+	s.Require().Empty(failed)
+	registry.SetTimeout(time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+	start := time.Now()
+	result := ExecuteOnClusters(ctx, registry, targeting.Target{Type: targeting.TargetAll, MaxConcurrency: ptr.To(2)}, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
+		return NewFusionService().GetStatus(ctx, client, "")
+	})
+
+	s.Run("the call returns promptly instead of waiting for the cluster timeout", func() {
+		s.Less(time.Since(start), 5*time.Second)
+		s.Positive(requests.Load(), "the status call reached the API server")
+	})
+	s.Run("every cluster is reported as cancelled, not as not installed", func() {
+		s.Require().Len(result.ClusterResults, len(clusterNames))
+		for _, name := range clusterNames {
+			s.False(result.ClusterResults[name].Success, name)
+			s.Contains(result.ClusterResults[name].Error, "context canceled", name)
+		}
+		s.Equal(len(clusterNames), result.Summary.Failed)
+	})
+}
+
+func (s *CommonSuite) TestExecuteOnClustersClusterLogger() {
+	registry := s.newTestRegistry("edge-1", "core-1")
+	var mu sync.Mutex
+	var lines []string
+	logger := funcr.New(func(prefix, args string) {
+		mu.Lock()
+		defer mu.Unlock()
+		lines = append(lines, args)
+	}, funcr.Options{Verbosity: 4})
+	ctx := klog.NewContext(context.Background(), logger)
+
+	ExecuteOnClusters(ctx, registry, targeting.Target{Type: targeting.TargetAll}, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
+		LoggerFrom(ctx).Info("service message")
+		if client.Name == "core-1" {
+			return nil, fmt.Errorf("forbidden")
+		}
+		return "ok", nil
+	})
+
+	linesFor := func(cluster, msg string) int {
+		count := 0
+		for _, line := range lines {
+			if strings.Contains(line, `"cluster"="`+cluster+`"`) && strings.Contains(line, msg) {
+				count++
+			}
+		}
+		return count
+	}
+	s.Run("service logs carry the cluster name", func() {
+		s.Equal(1, linesFor("edge-1", "service message"))
+		s.Equal(1, linesFor("core-1", "service message"))
+	})
+	s.Run("failures are logged with the cluster name", func() {
+		s.Equal(1, linesFor("core-1", "Cluster operation failed"))
+		s.Zero(linesFor("edge-1", "Cluster operation failed"))
+	})
+}
+
+func (s *CommonSuite) TestListPages() {
+	s.Run("follows continue tokens until the last page", func() {
+		var seen []metav1.ListOptions
+		err := listPages(context.Background(), metav1.ListOptions{LabelSelector: "app=odf-operator"}, func(opts metav1.ListOptions) (string, error) {
+			seen = append(seen, opts)
+			if len(seen) < 3 {
+				return fmt.Sprintf("page-%d", len(seen)+1), nil
+			}
+			return "", nil
+		})
+		s.Require().NoError(err)
+		s.Require().Len(seen, 3)
+		s.Equal(int64(listPageSize), seen[0].Limit)
+		s.Empty(seen[0].Continue)
+		s.Equal("page-3", seen[2].Continue)
+		s.Equal("app=odf-operator", seen[2].LabelSelector)
+	})
+	s.Run("stops at the first failing page", func() {
+		calls := 0
+		err := listPages(context.Background(), metav1.ListOptions{}, func(metav1.ListOptions) (string, error) {
+			calls++
+			return "", fmt.Errorf("the server is currently unable to handle the request")
+		})
+		s.Error(err)
+		s.Equal(1, calls)
+	})
+	s.Run("stops between pages once the context is done", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		calls := 0
+		err := listPages(ctx, metav1.ListOptions{}, func(metav1.ListOptions) (string, error) {
+			calls++
+			cancel()
+			return "next", nil
+		})
+		s.ErrorIs(err, context.Canceled)
+		s.Equal(1, calls)
+	})
+}
+
+func (s *CommonSuite) TestCheckPodsInNamespacePages() {
+	clientset := fake.NewSimpleClientset()
+	calls := 0
+	clientset.PrependReactor("list", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+		calls++
+		// The fake clientset filters the page by the label selector
+		pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "odf-operator"}}}
+		page := &corev1.PodList{Items: []corev1.Pod{pod, pod}}
+		if calls == 1 {
+			page.Continue = "next"
+		}
+		return true, page, nil
+	})
+	client := &clients.ClusterClient{Name: "prod-1", Clientset: clientset}
+
+	count, err := CheckPodsInNamespace(context.Background(), client, "openshift-storage", "app=odf-operator")
+	s.Require().NoError(err)
+	s.Equal(4, count, "pods of every page are counted")
+	s.Equal(2, calls)
+}
+
+func (s *CommonSuite) TestExecuteOnClustersDefaultTarget() {
+	registry := s.newTestRegistry("cluster-a", "cluster-b", "cluster-c")
+	run := func(target targeting.Target) *targeting.Result {
+		return ExecuteOnClusters(context.Background(), registry, target, func(context.Context, *clients.ClusterClient) (interface{}, error) {
+			return nil, nil
+		})
+	}
+
+	s.Run("omitted target falls back to the placeholder cluster", func() {
+		result := run(targeting.Target{})
+		s.Equal(1, result.Summary.Total)
+		s.Contains(result.ClusterResults, "default")
+	})
+
+	s.Require().NoError(targeting.SetDefaultTarget(&targeting.Target{Type: targeting.TargetAll}))
+	defer func() { _ = targeting.SetDefaultTarget(nil) }()
+
+	s.Run("omitted target uses the default target", func() {
+		result := run(targeting.Target{})
+		s.Equal(3, result.Summary.Succeeded)
+		s.Equal(targeting.ResolvedRegistry, result.Summary.Resolution)
+	})
+	s.Run("options of a target without a type are kept", func() {
+		result := run(targeting.Target{OnlyFailures: true})
+		s.Equal(3, result.Summary.Total)
+		result.ApplyFilter()
+		s.Empty(result.ClusterResults)
+	})
+	s.Run("explicit target wins over the default target", func() {
+		result := run(targeting.Target{Type: targeting.TargetSingle, Cluster: "cluster-b"})
+		s.Equal(1, result.Summary.Total)
+		s.Contains(result.ClusterResults, "cluster-b")
+	})
+	s.Run("invalid default targets are rejected", func() {
+		s.Error(targeting.SetDefaultTarget(&targeting.Target{Type: targeting.TargetMulti}))
+		s.Error(targeting.SetDefaultTarget(&targeting.Target{}))
+	})
+}
+
+func (s *CommonSuite) TestExecuteOnClustersImpersonation() {
+	registry := s.newTestRegistry("cluster-a", "cluster-b")
+	target := targeting.Target{Type: targeting.TargetAll, ImpersonateUser: "jane", ImpersonateGroups: []string{"payments-admins"}}
+	var mu sync.Mutex
+	var users []string
+	run := func(target targeting.Target) *targeting.Result {
+		return ExecuteOnClusters(context.Background(), registry, target, func(_ context.Context, client *clients.ClusterClient) (interface{}, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			users = append(users, client.Config.Impersonate.UserName)
+			return nil, nil
+		})
+	}
+
+	s.Run("is refused unless allowed", func() {
+		result := run(target)
+		s.Contains(result.Summary.Error, "impersonation is disabled")
+		s.Empty(users)
+	})
+
+	SetImpersonationAllowed(true)
+	defer SetImpersonationAllowed(false)
+
+	s.Run("runs operations as the user on derived clients", func() {
+		result := run(target)
+		s.Equal(2, result.Summary.Succeeded)
+		s.Equal([]string{"jane", "jane"}, users)
+	})
+	s.Run("leaves the registered clients unchanged", func() {
+		for _, client := range registry.GetAllClients() {
+			s.Empty(client.Config.Impersonate.UserName, client.Name)
+		}
+		users = nil
+		run(targeting.Target{Type: targeting.TargetAll})
+		s.Equal([]string{"", ""}, users)
+	})
+}
+
+func (s *CommonSuite) TestExecuteOnClustersRetry() {
+	registry := s.newTestRegistry("cluster-a", "cluster-b")
+	registry.SetRetryPolicy(clients.RetryPolicy{MaxAttempts: 3, BackoffMs: 1})
+
+	var calls atomic.Int32
+	target := targeting.Target{Type: targeting.TargetAll}
+	result := ExecuteOnClusters(context.Background(), registry, target, func(_ context.Context, client *clients.ClusterClient) (interface{}, error) {
+		if client.Name == "cluster-b" {
+			return nil, fmt.Errorf("storageclusters is forbidden")
+		}
+		if calls.Add(1) == 1 {
+			return nil, fmt.Errorf("dial tcp: connection refused")
+		}
+		return "ok", nil
+	})
+	s.True(result.ClusterResults["cluster-a"].Success, "transient failure is retried")
+	s.Equal(2, result.ClusterResults["cluster-a"].Attempts)
+	s.False(result.ClusterResults["cluster-b"].Success)
+	s.Zero(result.ClusterResults["cluster-b"].Attempts, "forbidden is not retried")
+
+	s.Run("target override disables retries", func() {
+		calls.Store(0)
+		target := targeting.Target{Type: targeting.TargetMulti, Clusters: []string{"cluster-a"}, Retry: &clients.RetryPolicy{MaxAttempts: 1}}
+		result := ExecuteOnClusters(context.Background(), registry, target, func(context.Context, *clients.ClusterClient) (interface{}, error) {
+			calls.Add(1)
+			return nil, fmt.Errorf("dial tcp: connection refused")
+		})
+		s.False(result.ClusterResults["cluster-a"].Success)
+		s.Equal(int32(1), calls.Load())
+	})
+	s.Run("writes are never retried", func() {
+		calls.Store(0)
+		target := targeting.Target{Type: targeting.TargetMulti, Clusters: []string{"cluster-a"}, Retry: &clients.RetryPolicy{MaxAttempts: 3, BackoffMs: 1}}
+		result := ExecuteWriteOnClusters(context.Background(), registry, target, func(context.Context, *clients.ClusterClient) (interface{}, error) {
+			calls.Add(1)
+			return nil, fmt.Errorf("net/http: request canceled (Client.Timeout exceeded while awaiting headers)")
+		})
+		s.False(result.ClusterResults["cluster-a"].Success)
+		s.Zero(result.ClusterResults["cluster-a"].Attempts)
+		s.Equal(int32(1), calls.Load(), "a timed out create may have been applied")
+	})
+}
+
+func (s *CommonSuite) TestExecuteOnClustersErrorKind() {
+	registry := s.newTestRegistry("cluster-a", "cluster-b")
+	target := targeting.Target{Type: targeting.TargetMulti, Clusters: []string{"cluster-a", "cluster-b", "missing"}, Timeout: 1}
+	result := ExecuteOnClusters(context.Background(), registry, target, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
+		if client.Name == "cluster-a" {
+			<-ctx.Done()
+			return nil, fmt.Errorf("list storageclusters: %v", ctx.Err())
+		}
+		return nil, fmt.Errorf("storageclusters is forbidden: User cannot list resource")
+	})
+	s.Equal(clients.ErrorKindTimeout, result.ClusterResults["cluster-a"].ErrorKind)
+	s.Equal(clients.ErrorKindForbidden, result.ClusterResults["cluster-b"].ErrorKind)
+	s.Equal(clients.ErrorKindNotFound, result.ClusterResults["missing"].ErrorKind)
+	s.Equal(map[clients.ErrorKind]int{
+		clients.ErrorKindTimeout:   1,
+		clients.ErrorKindForbidden: 1,
+		clients.ErrorKindNotFound:  1,
+	}, result.Summary.ErrorKinds)
+}
+
+func (s *CommonSuite) TestResultApplyFilter() {
+	newResult := func(target targeting.Target) *targeting.Result {
+		result := targeting.NewResult(target)
+		result.AddClusterResult("ready", json.RawMessage(`{"installed":true,"ready":true}`), nil)
+		result.AddClusterResult("degraded", json.RawMessage(`{"installed":true}`), nil)
+		result.AddClusterResult("absent", json.RawMessage(`{"installed":false}`), nil)
+		result.AddClusterResult("broken", nil, fmt.Errorf("connection refused"))
+		result.AddSkippedCluster("skipped", "skipped: fail-fast")
+		result.Finalize()
+		return result
+	}
+	s.Run("no filter keeps every cluster", func() {
+		result := newResult(targeting.Target{Type: targeting.TargetAll})
+		result.ApplyFilter()
+		s.Len(result.ClusterResults, 5)
+		s.Zero(result.Summary.Omitted)
+	})
+	s.Run("onlyFailures keeps failed and skipped clusters", func() {
+		result := newResult(targeting.Target{Type: targeting.TargetAll, OnlyFailures: true})
+		result.ApplyFilter()
+		s.ElementsMatch([]string{"broken", "skipped"}, clusterNames(result))
+		s.Equal(3, result.Summary.Omitted)
+		s.Equal(5, result.Summary.Total, "summary counts the full population")
+		s.Equal(3, result.Summary.Succeeded)
+	})
+	s.Run("onlyReady keeps ready clusters", func() {
+		result := newResult(targeting.Target{Type: targeting.TargetAll, OnlyReady: true})
+		result.ApplyFilter()
+		s.ElementsMatch([]string{"ready"}, clusterNames(result))
+	})
+	s.Run("filters combine as a union", func() {
+		result := newResult(targeting.Target{Type: targeting.TargetAll, OnlyNotInstalled: true, OnlyFailures: true})
+		result.ApplyFilter()
+		s.ElementsMatch([]string{"absent", "broken", "skipped"}, clusterNames(result))
+	})
+}
+
+func (s *CommonSuite) TestResultCollapse() {
+	newResult := func(target targeting.Target) *targeting.Result {
+		result := targeting.NewResult(target)
+		result.AddClusterResult("prod-2", json.RawMessage(`{"installed":true,"ready":true,"namespace":"openshift-storage","capacity":{"bytes":9007199254740993}}`), nil)
+		result.AddClusterResult("prod-1", json.RawMessage(`{"namespace":"openshift-data-foundation","capacity":{"bytes":9007199254740993},"ready":true,"installed":true}`), nil)
+		result.AddClusterResult("prod-3", json.RawMessage(`{"installed":true,"ready":false}`), nil)
+		result.AddClusterResult("broken", nil, fmt.Errorf("connection refused"))
+		result.Finalize()
+		result.SetAggregate("ready", 2)
+		return result
+	}
+	s.Run("groups clusters reporting the same status", func() {
+		result := newResult(targeting.Target{Type: targeting.TargetAll, Collapse: true})
+		result.ApplyFilter()
+		s.Require().Len(result.Groups, 2)
+		s.Equal([]string{"prod-1", "prod-2"}, result.Groups[0].Clusters, "namespace and key order are ignored")
+		s.JSONEq(`{"installed":true,"ready":true,"capacity":{"bytes":9007199254740993}}`, string(result.Groups[0].Status.(json.RawMessage)))
+		s.Equal([]string{"prod-3"}, result.Groups[1].Clusters)
+		s.NotEqual(result.Groups[0].Fingerprint, result.Groups[1].Fingerprint)
+	})
+	s.Run("failed clusters stay in clusterResults", func() {
+		result := newResult(targeting.Target{Type: targeting.TargetAll, Collapse: true})
+		result.ApplyFilter()
+		s.ElementsMatch([]string{"broken"}, clusterNames(result))
+	})
+	s.Run("summary still counts every cluster", func() {
+		result := newResult(targeting.Target{Type: targeting.TargetAll, Collapse: true})
+		result.ApplyFilter()
+		s.Equal(4, result.Summary.Total)
+		s.Equal(3, result.Summary.Succeeded)
+		s.Equal(1, result.Summary.Failed)
+		s.Equal(2, result.Summary.Aggregates["ready"])
+	})
+	s.Run("fingerprints are stable", func() {
+		first := newResult(targeting.Target{Type: targeting.TargetAll, Collapse: true})
+		first.ApplyFilter()
+		second := newResult(targeting.Target{Type: targeting.TargetAll, Collapse: true})
+		second.ApplyFilter()
+		s.Equal(first.Groups, second.Groups)
+	})
+	s.Run("collapses after filtering", func() {
+		result := newResult(targeting.Target{Type: targeting.TargetAll, Collapse: true, OnlyReady: true})
+		result.ApplyFilter()
+		s.Require().Len(result.Groups, 1)
+		s.Equal([]string{"prod-1", "prod-2"}, result.Groups[0].Clusters)
+		s.Equal(2, result.Summary.Omitted)
+	})
+	s.Run("not collapsed unless asked", func() {
+		result := newResult(targeting.Target{Type: targeting.TargetAll})
+		result.ApplyFilter()
+		s.Empty(result.Groups)
+		s.Len(result.ClusterResults, 4)
+	})
+}
+
+func clusterNames(result *targeting.Result) []string {
+	names := make([]string, 0, len(result.ClusterResults))
+	for name := range result.ClusterResults {
+		names = append(names, name)
+	}
+	return names
+}
+
+func (s *CommonSuite) TestResultSchemaFor() {
+	s.Run("describes the cluster data type", func() {
+		schema, err := targeting.ResultSchemaFor[DataFoundationStatus]()
+		s.Require().NoError(err)
+		s.Equal("object", schema.Type)
+		data := schema.Properties["clusterResults"].AdditionalProperties.Properties["data"]
+		s.Require().NotNil(data)
+		s.Contains(data.Properties, "installed")
+		s.Contains(data.Properties, "cephHealth")
+		s.Equal(data, schema.Properties["groups"].Items.Properties["status"], "groups carry the same data")
+	})
+	s.Run("infers every status type", func() {
+		for name, infer := range map[string]func() error{
+			"ComponentStatus":       func() error { _, err := targeting.ResultSchemaFor[ComponentStatus](); return err },
+			"FusionStatus":          func() error { _, err := targeting.ResultSchemaFor[FusionStatus](); return err },
+			"Overview":              func() error { _, err := targeting.ResultSchemaFor[Overview](); return err },
+			"GDPStatus":             func() error { _, err := targeting.ResultSchemaFor[GDPStatus](); return err },
+			"DRStatus":              func() error { _, err := targeting.ResultSchemaFor[DRStatus](); return err },
+			"CatalogStatus":         func() error { _, err := targeting.ResultSchemaFor[CatalogStatus](); return err },
+			"CASStatus":             func() error { _, err := targeting.ResultSchemaFor[CASStatus](); return err },
+			"ServiceabilitySummary": func() error { _, err := targeting.ResultSchemaFor[ServiceabilitySummary](); return err },
+			"ObservabilitySummary":  func() error { _, err := targeting.ResultSchemaFor[ObservabilitySummary](); return err },
+			"VirtualizationStatus":  func() error { _, err := targeting.ResultSchemaFor[VirtualizationStatus](); return err },
+			"HCPStatus":             func() error { _, err := targeting.ResultSchemaFor[HCPStatus](); return err },
+		} {
+			s.NoError(infer(), name)
+		}
+	})
+}
+
+func (s *CommonSuite) TestComponentStatusConditions() {
+	s.Run("condition polarity", func() {
+		for _, tc := range []struct {
+			condition StatusCondition
+			healthy   bool
+		}{
+			{condition: StatusCondition{Type: "Available", Status: "True"}, healthy: true},
+			{condition: StatusCondition{Type: "Available", Status: "Unknown"}, healthy: false},
+			{condition: StatusCondition{Type: "Degraded", Status: "False"}, healthy: true},
+			{condition: StatusCondition{Type: "Degraded", Status: "True"}, healthy: false},
+			{condition: StatusCondition{Type: "Progressing", Status: "False"}, healthy: true},
+			{condition: StatusCondition{Type: "Upgradeable", Status: "False"}, healthy: true},
+		} {
+			s.Equal(tc.healthy, tc.condition.Healthy(), tc.condition.String())
+		}
+	})
+	s.Run("ready summarizes the conditions", func() {
+		status := InstalledStatus(true, "4.16.0", "running")
+		status.AddConditions(StatusCondition{Type: "Available", Status: "True"}, StatusCondition{Type: "Progressing", Status: "True"})
+		s.True(status.Ready)
+		s.True(status.IsHealthy())
+
+		status.AddConditions(StatusCondition{Type: "Degraded", Status: "True", Message: "mon quorum lost"})
+		s.False(status.Ready)
+		s.False(status.IsHealthy())
+	})
+	s.Run("a component that is not installed is not healthy", func() {
+		s.False(NotInstalledStatus(ReasonNamespaceAbsent, "not found").IsHealthy())
+	})
+	s.Run("conditions are read from the resource status", func() {
+		obj := unstructured.Unstructured{Object: map[string]interface{}{}}
+		_ = unstructured.SetNestedSlice(obj.Object, []interface{}{
+			map[string]interface{}{"type": "Available", "status": "True", "reason": "ReconcileCompleted", "message": "Reconcile completed successfully", "lastTransitionTime": "2026-01-01T12:00:00Z"},
+			map[string]interface{}{"status": "True"},
+			"not a condition",
+		}, "status", "conditions")
+		s.Equal([]StatusCondition{{
+			Type:               "Available",
+			Status:             "True",
+			Reason:             "ReconcileCompleted",
+			Message:            "Reconcile completed successfully",
+			LastTransitionTime: "2026-01-01T12:00:00Z",
+		}}, ReadConditions(obj))
+	})
+}
+
+func (s *CommonSuite) TestComponentStatusSeverity() {
+	s.Run("worst severity ranks unknown between warning and critical", func() {
+		s.Equal("", WorstSeverity())
+		s.Equal(SeverityWarning, WorstSeverity(SeverityOK, SeverityWarning, ""))
+		s.Equal(SeverityUnknown, WorstSeverity(SeverityWarning, SeverityUnknown))
+		s.Equal(SeverityCritical, WorstSeverity(SeverityCritical, SeverityUnknown))
+	})
+	s.Run("a healthy component is ok unless its service raised the severity", func() {
+		status := InstalledStatus(true, "", "running")
+		status.ResolveSeverity()
+		s.Equal(SeverityOK, status.Severity)
+
+		status = InstalledStatus(true, "", "running")
+		status.RaiseSeverity(SeverityWarning)
+		status.ResolveSeverity()
+		s.Equal(SeverityWarning, status.Severity)
+		s.True(status.Ready, "ready is unaffected by the severity")
+	})
+	s.Run("a component that is not ready is critical", func() {
+		status := InstalledStatus(false, "", "operator not ready")
+		status.RaiseSeverity(SeverityWarning)
+		status.ResolveSeverity()
+		s.Equal(SeverityCritical, status.Severity)
+	})
+	s.Run("a component that is not installed has no severity", func() {
+		status := NotInstalledStatus(ReasonNamespaceAbsent, "not found")
+		status.ResolveSeverity()
+		s.Empty(status.Severity)
+	})
+}
+
+func TestCommonSuite(t *testing.T) {
+	suite.Run(t, new(CommonSuite))
+}
+
+// Made with Bob
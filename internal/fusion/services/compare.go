@@ -0,0 +1,381 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
+)
+
+// compareCheck reads the status of a component for comparison. Ignored fields differ
+// between clusters by nature, such as workload counts, and are left out of the diff.
+type compareCheck struct {
+	status  func(ctx context.Context, client *clients.ClusterClient) (interface{}, error)
+	ignored []string
+}
+
+// compareIgnoredFields are left out of the diff of every component: they describe when
+// or how a status was read rather than the component
+var compareIgnoredFields = []string{"schemaVersion", "message", "scope", "lastTransitionTime"}
+
+// compareChecks are the components fusion.compare can diff, keyed by component name
+var compareChecks = map[string]compareCheck{
+	"storage": {
+		status: func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
+			return NewSnapshotService().GetClassInventory(ctx, client)
+		},
+	},
+	"datafoundation": {
+		status: func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
+			return NewDataFoundationService(nil).GetStatus(ctx, client, "")
+		},
+		ignored: []string{"capacity", "usedPercent", "nearFull", "critical", "cephDetails", "pods"},
+	},
+	"dr": {
+		status: func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
+			return NewDRService().GetStatus(ctx, client)
+		},
+		ignored: []string{"workloads"},
+	},
+	"gdp": {
+		status: func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
+			return NewGDPService().GetStatus(ctx, client, "")
+		},
+	},
+	"backup": {
+		status: func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
+			return NewBackupService(nil).ListJobs(ctx, client, "", 0)
+		},
+		ignored: []string{"backups", "jobs", "total", "byPhase", "truncated"},
+	},
+	"virtualization": {
+		status: func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
+			return NewVirtualizationService().GetStatus(ctx, client, "")
+		},
+		ignored: []string{"vmCount", "runningVmCount"},
+	},
+	"hcp": {
+		status: func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
+			return NewHCPService().GetStatus(ctx, client, "")
+		},
+		ignored: []string{"hostedClusterCount"},
+	},
+}
+
+// drRelevantFields are the fields whose differences break a failover: workloads expect
+// the same storage and snapshot classes on the cluster they move to
+var drRelevantFields = []string{"storage.storageClasses", "storage.snapshotClasses", "datafoundation.storageClasses"}
+
+// CompareComponents returns the names of the components fusion.compare can diff, sorted
+func CompareComponents() []string {
+	names := make([]string, 0, len(compareChecks))
+	for name := range compareChecks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CompareService diffs the status of components between two clusters
+type CompareService struct {
+	components []string
+}
+
+// NewCompareService creates a compare service for components, every component when empty
+func NewCompareService(components []string) (*CompareService, error) {
+	if len(components) == 0 {
+		return &CompareService{components: CompareComponents()}, nil
+	}
+	for _, component := range components {
+		if _, ok := compareChecks[component]; !ok {
+			return nil, fmt.Errorf("unknown component %q, expected one of %s", component, strings.Join(CompareComponents(), ", "))
+		}
+	}
+	return &CompareService{components: sortedUnique(components)}, nil
+}
+
+// ComponentSnapshot is the status of a component read on one cluster, or why it could not be
+type ComponentSnapshot struct {
+	Status interface{} `json:"status,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// Snapshot reads the status of every component of the service on a cluster concurrently
+func (s *CompareService) Snapshot(ctx context.Context, client *clients.ClusterClient) (map[string]ComponentSnapshot, error) {
+	snapshots := make(map[string]ComponentSnapshot, len(s.components))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, name := range s.components {
+		wg.Add(1)
+		go func(name string, check compareCheck) {
+			defer wg.Done()
+			snapshot := ComponentSnapshot{}
+			status, err := check.status(ctx, client)
+			if err != nil {
+				snapshot.Error = err.Error()
+			} else {
+				if rated, ok := status.(severityRated); ok {
+					rated.ResolveSeverity()
+				}
+				snapshot.Status = status
+			}
+			mu.Lock()
+			snapshots[name] = snapshot
+			mu.Unlock()
+		}(name, compareChecks[name])
+	}
+	wg.Wait()
+	return snapshots, nil
+}
+
+// FieldDifference is a field whose value differs between the two clusters. Items of a
+// named list found on one cluster only are reported under the item's name, with the
+// cluster holding it in OnlyIn. DR marks differences that break a failover.
+type FieldDifference struct {
+	Field  string      `json:"field"`
+	A      interface{} `json:"a"`
+	B      interface{} `json:"b"`
+	OnlyIn string      `json:"onlyIn,omitempty"`
+	DR     bool        `json:"dr,omitempty"`
+}
+
+// ComponentComparison is the diff of one component. Errors holds, by cluster, the
+// checks that failed, the component is then not compared.
+type ComponentComparison struct {
+	Component   string            `json:"component"`
+	Identical   bool              `json:"identical"`
+	Differences []FieldDifference `json:"differences,omitempty"`
+	Errors      map[string]string `json:"errors,omitempty"`
+}
+
+// ComparisonSummary counts the differences. DRDifferences describes the ones that
+// break a failover, such as a storage class missing on one cluster.
+type ComparisonSummary struct {
+	Components    int      `json:"components"`
+	Identical     int      `json:"identical"`
+	Differences   int      `json:"differences"`
+	DRDifferences []string `json:"drDifferences,omitempty"`
+}
+
+// Comparison is the field by field diff of component statuses between cluster A and B.
+// Errors holds the clusters that could not be read at all.
+type Comparison struct {
+	SchemaVersion string                `json:"schemaVersion"`
+	ClusterA      string                `json:"clusterA"`
+	ClusterB      string                `json:"clusterB"`
+	Identical     bool                  `json:"identical"`
+	Components    []ComponentComparison `json:"components"`
+	Summary       ComparisonSummary     `json:"summary"`
+	Errors        map[string]string     `json:"errors,omitempty"`
+}
+
+// Compare diffs the snapshots taken of clusterA and clusterB, read from the result of
+// running Snapshot on both
+func (s *CompareService) Compare(clusterA, clusterB string, result *targeting.Result) *Comparison {
+	comparison := &Comparison{
+		SchemaVersion: targeting.SchemaVersion,
+		ClusterA:      clusterA,
+		ClusterB:      clusterB,
+		Components:    []ComponentComparison{},
+	}
+	if result.Summary.Error != "" {
+		comparison.Errors = map[string]string{clusterA: result.Summary.Error, clusterB: result.Summary.Error}
+		return comparison
+	}
+
+	snapshots := make(map[string]map[string]comparedSnapshot, 2)
+	ForEachClusterData(result, func(clusterName string, data map[string]comparedSnapshot) {
+		snapshots[clusterName] = data
+	})
+	for _, cluster := range []string{clusterA, clusterB} {
+		if _, ok := snapshots[cluster]; ok {
+			continue
+		}
+		if comparison.Errors == nil {
+			comparison.Errors = make(map[string]string)
+		}
+		comparison.Errors[cluster] = "no status read"
+		if clusterResult, ok := result.ClusterResults[cluster]; ok && clusterResult.Error != "" {
+			comparison.Errors[cluster] = clusterResult.Error
+		}
+	}
+	if comparison.Errors != nil {
+		return comparison
+	}
+
+	for _, name := range s.components {
+		a, b := snapshots[clusterA][name], snapshots[clusterB][name]
+		component := ComponentComparison{Component: name}
+		if a.Error != "" || b.Error != "" {
+			component.Errors = make(map[string]string)
+			if a.Error != "" {
+				component.Errors[clusterA] = a.Error
+			}
+			if b.Error != "" {
+				component.Errors[clusterB] = b.Error
+			}
+		} else {
+			differ := &differ{clusterA: clusterA, clusterB: clusterB, ignored: append(slices.Clone(compareIgnoredFields), compareChecks[name].ignored...)}
+			differ.diff(name, a.Status, b.Status)
+			component.Differences = differ.differences
+			component.Identical = len(component.Differences) == 0
+		}
+		comparison.Components = append(comparison.Components, component)
+
+		comparison.Summary.Components++
+		if component.Identical {
+			comparison.Summary.Identical++
+		}
+		comparison.Summary.Differences += len(component.Differences)
+		for _, difference := range component.Differences {
+			if difference.DR {
+				comparison.Summary.DRDifferences = append(comparison.Summary.DRDifferences, difference.describe(clusterA, clusterB))
+			}
+		}
+	}
+	comparison.Identical = comparison.Summary.Identical == comparison.Summary.Components
+	return comparison
+}
+
+// comparedSnapshot is a ComponentSnapshot decoded from a cluster result
+type comparedSnapshot struct {
+	Status map[string]interface{} `json:"status"`
+	Error  string                 `json:"error"`
+}
+
+// describe explains a DR relevant difference in a sentence
+func (d FieldDifference) describe(clusterA, clusterB string) string {
+	if d.OnlyIn != "" {
+		missing := clusterB
+		if d.OnlyIn == clusterB {
+			missing = clusterA
+		}
+		return fmt.Sprintf("%s only on %s, missing on %s", d.Field, d.OnlyIn, missing)
+	}
+	return fmt.Sprintf("%s differs: %v on %s, %v on %s", d.Field, d.A, clusterA, d.B, clusterB)
+}
+
+// differ collects the differences between two decoded JSON values
+type differ struct {
+	clusterA    string
+	clusterB    string
+	ignored     []string
+	differences []FieldDifference
+}
+
+// diff compares a and b found at path. Objects are compared key by key, lists of
+// named objects item by item and other lists as sets.
+func (d *differ) diff(path string, a, b interface{}) {
+	if mapA, ok := a.(map[string]interface{}); ok {
+		if mapB, ok := b.(map[string]interface{}); ok {
+			keys := make([]string, 0, len(mapA)+len(mapB))
+			for key := range mapA {
+				keys = append(keys, key)
+			}
+			for key := range mapB {
+				keys = append(keys, key)
+			}
+			for _, key := range sortedUnique(keys) {
+				if !slices.Contains(d.ignored, key) {
+					d.diff(path+"."+key, mapA[key], mapB[key])
+				}
+			}
+			return
+		}
+	}
+	// A list left out on one cluster is compared as empty
+	listA, okA := a.([]interface{})
+	listB, okB := b.([]interface{})
+	if (okA || okB) && (okA || a == nil) && (okB || b == nil) {
+		d.diffLists(path, listA, listB)
+		return
+	}
+	if !reflect.DeepEqual(a, b) {
+		d.add(FieldDifference{Field: path, A: a, B: b})
+	}
+}
+
+// diffLists compares lists of named objects by name and other lists as sorted sets
+func (d *differ) diffLists(path string, a, b []interface{}) {
+	namedA, okA := namedItems(a)
+	namedB, okB := namedItems(b)
+	if !okA || !okB {
+		if !reflect.DeepEqual(sortedValues(a), sortedValues(b)) {
+			d.add(FieldDifference{Field: path, A: a, B: b})
+		}
+		return
+	}
+
+	names := make([]string, 0, len(namedA)+len(namedB))
+	for name := range namedA {
+		names = append(names, name)
+	}
+	for name := range namedB {
+		names = append(names, name)
+	}
+	for _, name := range sortedUnique(names) {
+		itemPath := fmt.Sprintf("%s[%s]", path, name)
+		itemA, inA := namedA[name]
+		itemB, inB := namedB[name]
+		switch {
+		case !inB:
+			d.add(FieldDifference{Field: itemPath, A: itemA, OnlyIn: d.clusterA})
+		case !inA:
+			d.add(FieldDifference{Field: itemPath, B: itemB, OnlyIn: d.clusterB})
+		default:
+			d.diff(itemPath, itemA, itemB)
+		}
+	}
+}
+
+// add records a difference, marking the ones under a DR relevant field
+func (d *differ) add(difference FieldDifference) {
+	for _, field := range drRelevantFields {
+		if strings.HasPrefix(difference.Field, field) {
+			difference.DR = true
+		}
+	}
+	d.differences = append(d.differences, difference)
+}
+
+// namedItems indexes a list of objects by their name, qualified by their namespace when
+// they have one. It reports false unless every item is a named object.
+func namedItems(list []interface{}) (map[string]interface{}, bool) {
+	items := make(map[string]interface{}, len(list))
+	for _, item := range list {
+		object, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		name, ok := object["name"].(string)
+		if !ok || name == "" {
+			return nil, false
+		}
+		if namespace, ok := object["namespace"].(string); ok && namespace != "" {
+			name = namespace + "/" + name
+		}
+		items[name] = item
+	}
+	return items, true
+}
+
+// sortedValues returns the JSON encodings of the values of a list, sorted, so lists
+// holding the same values in another order compare equal
+func sortedValues(list []interface{}) []string {
+	values := make([]string, 0, len(list))
+	for _, value := range list {
+		encoded, _ := json.Marshal(value)
+		values = append(values, string(encoded))
+	}
+	sort.Strings(values)
+	return values
+}
+
+// Made with Bob
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
+	"github.com/stretchr/testify/suite"
+)
+
+type CompareSuite struct {
+	suite.Suite
+}
+
+// snapshotResult returns the result of a Snapshot run, with the component snapshots of
+// each cluster as its data
+func (s *CompareSuite) snapshotResult(snapshots map[string]map[string]ComponentSnapshot) *targeting.Result {
+	result := targeting.NewResult(targeting.Target{Type: targeting.TargetMulti})
+	for cluster, data := range snapshots {
+		raw, err := json.Marshal(data)
+		s.Require().NoError(err)
+		result.SetClusterResult(targeting.ClusterResult{ClusterName: cluster, Success: true, Data: json.RawMessage(raw)})
+	}
+	result.Finalize()
+	return result
+}
+
+func (s *CompareSuite) TestNewCompareService() {
+	s.Run("compares every component by default", func() {
+		service, err := NewCompareService(nil)
+		s.Require().NoError(err)
+		s.Equal(CompareComponents(), service.components)
+	})
+	s.Run("rejects unknown components", func() {
+		_, err := NewCompareService([]string{"storage", "ceph"})
+		s.ErrorContains(err, `unknown component "ceph"`)
+	})
+}
+
+func (s *CompareSuite) TestCompare() {
+	service, err := NewCompareService([]string{"storage", "datafoundation"})
+	s.Require().NoError(err)
+	primary := map[string]ComponentSnapshot{
+		"storage": {Status: StorageClassInventory{
+			ComponentStatus: InstalledStatus(true, "", "3 storage classes"),
+			StorageClasses: []StorageClassInfo{
+				{Name: "ocs-storagecluster-ceph-rbd", Provisioner: "openshift-storage.rbd.csi.ceph.com", ReclaimPolicy: "Delete"},
+				{Name: "ocs-storagecluster-cephfs", Provisioner: "openshift-storage.cephfs.csi.ceph.com", ReclaimPolicy: "Delete"},
+			},
+			SnapshotClasses: []SnapshotClassInfo{{Name: "ocs-storagecluster-rbdplugin-snapclass", Driver: "openshift-storage.rbd.csi.ceph.com"}},
+		}},
+		"datafoundation": {Status: DataFoundationStatus{ComponentStatus: InstalledStatus(true, "4.16.0", "running"), CephHealth: "HEALTH_OK", UsedPercent: new(float64)}},
+	}
+	standby := map[string]ComponentSnapshot{
+		"storage": {Status: StorageClassInventory{
+			ComponentStatus: InstalledStatus(true, "", "1 storage classes"),
+			StorageClasses: []StorageClassInfo{
+				{Name: "ocs-storagecluster-ceph-rbd", Provisioner: "openshift-storage.rbd.csi.ceph.com", ReclaimPolicy: "Retain"},
+			},
+		}},
+		"datafoundation": {Status: DataFoundationStatus{ComponentStatus: InstalledStatus(true, "4.15.2", "running"), CephHealth: "HEALTH_OK"}},
+	}
+	comparison := service.Compare("prod-1", "dr-1", s.snapshotResult(map[string]map[string]ComponentSnapshot{"prod-1": primary, "dr-1": standby}))
+
+	s.Run("diffs named items by name", func() {
+		storage := comparison.Components[1]
+		s.Equal("storage", storage.Component)
+		s.False(storage.Identical)
+		s.Equal([]string{
+			"storage.snapshotClasses[ocs-storagecluster-rbdplugin-snapclass]",
+			"storage.storageClasses[ocs-storagecluster-ceph-rbd].reclaimPolicy",
+			"storage.storageClasses[ocs-storagecluster-cephfs]",
+		}, fields(storage.Differences))
+		s.Equal("prod-1", storage.Differences[2].OnlyIn)
+		s.Equal("Delete", storage.Differences[1].A)
+		s.Equal("Retain", storage.Differences[1].B)
+	})
+	s.Run("leaves out fields that differ by nature", func() {
+		dataFoundation := comparison.Components[0]
+		s.Equal([]string{"datafoundation.version"}, fields(dataFoundation.Differences))
+		s.False(dataFoundation.Differences[0].DR)
+	})
+	s.Run("summarizes the differences breaking a failover", func() {
+		s.False(comparison.Identical)
+		s.Equal(4, comparison.Summary.Differences)
+		s.Equal([]string{
+			"storage.snapshotClasses[ocs-storagecluster-rbdplugin-snapclass] only on prod-1, missing on dr-1",
+			"storage.storageClasses[ocs-storagecluster-ceph-rbd].reclaimPolicy differs: Delete on prod-1, Retain on dr-1",
+			"storage.storageClasses[ocs-storagecluster-cephfs] only on prod-1, missing on dr-1",
+		}, comparison.Summary.DRDifferences)
+	})
+	s.Run("identical clusters", func() {
+		identical := service.Compare("prod-1", "prod-2", s.snapshotResult(map[string]map[string]ComponentSnapshot{"prod-1": primary, "prod-2": primary}))
+		s.True(identical.Identical)
+		s.Equal(2, identical.Summary.Identical)
+		s.Empty(identical.Summary.DRDifferences)
+	})
+	s.Run("reports failed checks instead of comparing", func() {
+		failing := map[string]ComponentSnapshot{"storage": {Error: "forbidden"}, "datafoundation": primary["datafoundation"]}
+		compared := service.Compare("prod-1", "dr-1", s.snapshotResult(map[string]map[string]ComponentSnapshot{"prod-1": primary, "dr-1": failing}))
+		s.Equal(map[string]string{"dr-1": "forbidden"}, compared.Components[1].Errors)
+		s.False(compared.Identical)
+	})
+	s.Run("reports clusters that could not be read", func() {
+		result := s.snapshotResult(map[string]map[string]ComponentSnapshot{"prod-1": primary})
+		result.SetClusterResult(targeting.ClusterResult{ClusterName: "dr-1", Error: "failed to get client: cluster dr-1 not found"})
+		compared := service.Compare("prod-1", "dr-1", result)
+		s.Equal(map[string]string{"dr-1": "failed to get client: cluster dr-1 not found"}, compared.Errors)
+		s.Empty(compared.Components)
+	})
+}
+
+// fields returns the fields of differences
+func fields(differences []FieldDifference) []string {
+	names := make([]string, 0, len(differences))
+	for _, difference := range differences {
+		names = append(names, difference.Field)
+	}
+	return names
+}
+
+func TestCompareSuite(t *testing.T) {
+	suite.Run(t, new(CompareSuite))
+}
+
+// Made with Bob
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// CountCR counts the objects of a resource in namespace, or in all namespaces when empty,
+// matching the label selector, empty matching every object. Objects are read in pages
+// that are dropped once counted, so memory is bounded by the page size however many
+// objects there are. The allowed namespaces restrict the count like ListInScope.
+func CountCR(ctx context.Context, client *clients.ClusterClient, gvr schema.GroupVersionResource, namespace, selector string) (int, error) {
+	dynamicClient, err := client.DynamicClient()
+	if err != nil {
+		return 0, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+	count, _, err := countInScope(ctx, dynamicClient.Resource(gvr), namespace, metav1.ListOptions{LabelSelector: selector}, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count %s: %w", gvr.GroupResource(), err)
+	}
+	return count, nil
+}
+
+// countInScope counts a namespaced resource in namespace, or in all namespaces when
+// empty, restricted to the allowed namespaces. visit, when set, sees every page before it
+// is dropped. The note is set when the scope was narrowed, like ListInScope.
+func countInScope(ctx context.Context, resource dynamic.NamespaceableResourceInterface, namespace string, opts metav1.ListOptions, visit func(items []unstructured.Unstructured)) (int, string, error) {
+	namespaces, note := ScopeNamespaces(namespace)
+	total := 0
+	for _, ns := range namespaces {
+		count, err := countPages(ctx, resource.Namespace(ns), opts, visit)
+		if err != nil {
+			return 0, note, err
+		}
+		total += count
+	}
+	return total, note, nil
+}
+
+// countPages counts the objects of a resource page by page, following the continue
+// token. visit, when set, sees every page before it is dropped.
+func countPages(ctx context.Context, resource dynamic.ResourceInterface, opts metav1.ListOptions, visit func(items []unstructured.Unstructured)) (int, error) {
+	count := 0
+	err := listPages(ctx, opts, func(opts metav1.ListOptions) (string, error) {
+		list, err := resource.List(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		count += len(list.Items)
+		if visit != nil {
+			visit(list.Items)
+		}
+		return list.GetContinue(), nil
+	})
+	return count, err
+}
+
+// Made with Bob
@@ -3,10 +3,16 @@ package services
 import (
 	"context"
 	"fmt"
+	"math"
+	"sort"
 
 	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/utils/ptr"
 )
 
 // DataFoundationService provides Data Foundation (ODF/OCS) operations
@@ -21,31 +27,88 @@ func NewDataFoundationService(client *clients.KubernetesClient) *DataFoundationS
 	}
 }
 
-// DataFoundationStatus represents the status of Data Foundation
+// CephClusterGVR identifies Rook CephCluster resources
+var CephClusterGVR = schema.GroupVersionResource{
+	Group:    "ceph.rook.io",
+	Version:  "v1",
+	Resource: "cephclusters",
+}
+
+// DataFoundationStatus represents the status of Data Foundation. UsedPercent, NearFull and
+// Critical are computed from the raw used and total capacity, which Capacity keeps so that
+// other thresholds can be applied, and are nil when the usage is unknown. Pods details the
+// pods of the namespace that are not ready, Ready only tells whether the operator runs.
 type DataFoundationStatus struct {
 	ComponentStatus
-	Namespace      string   `json:"namespace,omitempty"`
-	StorageClasses []string `json:"storageClasses,omitempty"`
-	CephHealth     string   `json:"cephHealth,omitempty"`
+	Namespace      string                  `json:"namespace,omitempty"`
+	StorageClasses []StorageClassInfo      `json:"storageClasses,omitempty"`
+	CephHealth     string                  `json:"cephHealth,omitempty"`
+	CephDetails    *CephDetails            `json:"cephDetails,omitempty"`
+	Capacity       *DataFoundationCapacity `json:"capacity,omitempty"`
+	UsedPercent    *float64                `json:"usedPercent,omitempty"`
+	NearFull       *bool                   `json:"nearFull,omitempty"`
+	Critical       *bool                   `json:"critical,omitempty"`
+	Pods           *ODFPodHealth           `json:"pods,omitempty"`
+}
+
+// Capacity thresholds of Data Foundation, in percent of the raw capacity used
+const (
+	CapacityNearFullPercent = 80.0
+	CapacityCriticalPercent = 90.0
+)
+
+// StorageClusterGVR identifies OCS/ODF StorageCluster resources
+var StorageClusterGVR = schema.GroupVersionResource{
+	Group:    "ocs.openshift.io",
+	Version:  "v1",
+	Resource: "storageclusters",
+}
+
+// defaultReplica is the Ceph pool replica count ODF uses when a device set sets none
+const defaultReplica = 3
+
+// DataFoundationCapacity reports raw and usable capacity. Values the cluster does not
+// report are omitted rather than zero, with Message explaining what is unknown.
+type DataFoundationCapacity struct {
+	Source          string         `json:"source,omitempty"`
+	Replica         int64          `json:"replica,omitempty"`
+	RawTotal        *CapacityValue `json:"rawTotal,omitempty"`
+	RawUsed         *CapacityValue `json:"rawUsed,omitempty"`
+	RawAvailable    *CapacityValue `json:"rawAvailable,omitempty"`
+	UsableTotal     *CapacityValue `json:"usableTotal,omitempty"`
+	UsableUsed      *CapacityValue `json:"usableUsed,omitempty"`
+	UsableAvailable *CapacityValue `json:"usableAvailable,omitempty"`
+	Message         string         `json:"message,omitempty"`
+}
+
+// CapacityValue is a byte count with its human-readable form
+type CapacityValue struct {
+	Bytes int64  `json:"bytes"`
+	Human string `json:"human"`
+}
+
+// CephDetails reports the state of the CephCluster backing Data Foundation
+type CephDetails struct {
+	Name     string   `json:"name"`
+	Phase    string   `json:"phase,omitempty"`
+	Health   string   `json:"health,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
 }
 
-// GetStatus retrieves Data Foundation status
-func (s *DataFoundationService) GetStatus(ctx context.Context, clusterClient *clients.ClusterClient) (*DataFoundationStatus, error) {
+// DataFoundationNamespaces are the namespaces ODF is looked for in by default
+var DataFoundationNamespaces = []string{"openshift-storage", "openshift-data-foundation"}
+
+// GetStatus retrieves Data Foundation status, looking for ODF in namespace when set, in
+// DataFoundationNamespaces otherwise
+func (s *DataFoundationService) GetStatus(ctx context.Context, clusterClient *clients.ClusterClient, namespace string) (*DataFoundationStatus, error) {
 	status := &DataFoundationStatus{}
 
 	// Check for ODF namespace
-	odfNamespaces := []string{"openshift-storage", "openshift-data-foundation"}
-	var foundNamespace string
-	for _, ns := range odfNamespaces {
-		if CheckNamespaceExists(ctx, clusterClient, ns) {
-			foundNamespace = ns
-			break
-		}
-	}
+	foundNamespace, reason := findNamespace(ctx, clusterClient, namespace, DataFoundationNamespaces...)
 
 	if foundNamespace == "" {
 		*status = DataFoundationStatus{
-			ComponentStatus: NotInstalledStatus("ODF/OCS namespace not found"),
+			ComponentStatus: NotInstalledStatus(reason, "ODF/OCS namespace not found"),
 		}
 		return status, nil
 	}
@@ -60,39 +123,280 @@ func (s *DataFoundationService) GetStatus(ctx context.Context, clusterClient *cl
 		status.Message = fmt.Sprintf("ODF operator running with %d pods", podCount)
 	} else {
 		status.Ready = false
+		status.Reason = ReasonNoHealthyInstance
+		status.noteForbidden(err)
 		status.Message = "ODF operator not found or not ready"
 	}
 
+	// Health of every pod of the namespace, a crash looping OSD or MON leaves the operator running
+	if pods, err := odfPodHealth(ctx, clusterClient, foundNamespace); err == nil {
+		status.Pods = pods
+		if pods.NotReady > 0 {
+			status.Message += fmt.Sprintf(", %d of %d pods not ready", pods.NotReady, pods.Total)
+		}
+		if pods.CrashLooping > 0 {
+			status.Message += fmt.Sprintf(" (%d in %s)", pods.CrashLooping, crashLoopBackOff)
+			status.RaiseSeverity(SeverityWarning)
+		}
+	} else {
+		logDegraded(ctx, err, "Cannot read the ODF pods", "namespace", foundNamespace)
+	}
+
 	// Get ODF storage classes
 	scList, err := clusterClient.Clientset.StorageV1().StorageClasses().List(ctx, metav1.ListOptions{})
 	if err == nil {
-		odfProvisioners := []string{
-			"openshift-storage.rbd.csi.ceph.com",
-			"openshift-storage.cephfs.csi.ceph.com",
-			"ocs-storagecluster-ceph-rbd",
-			"ocs-storagecluster-cephfs",
+		for i := range scList.Items {
+			if IsODFProvisioner(scList.Items[i].Provisioner) {
+				status.StorageClasses = append(status.StorageClasses, NewStorageClassInfo(&scList.Items[i]))
+			}
 		}
-		for _, sc := range scList.Items {
-			for _, prov := range odfProvisioners {
-				if sc.Provisioner == prov {
-					status.StorageClasses = append(status.StorageClasses, sc.Name)
-					break
+	}
+
+	// Ceph health and capacity are read from the CephCluster and StorageCluster
+	// resources, falling back to CRD presence when they cannot be read
+	if CheckCRDExists(ctx, clusterClient, CephClusterGVR) {
+		status.CephHealth = "CRD exists (detailed health check not implemented)"
+	}
+	status.Capacity = &DataFoundationCapacity{Message: "capacity unknown: no CephCluster or StorageCluster capacity reported"}
+
+	dynamicClient, err := clusterClient.DynamicClient()
+	if err != nil {
+		logDegraded(ctx, err, "Cannot read Ceph health and capacity")
+		status.RaiseSeverity(SeverityUnknown)
+		return status, nil
+	}
+
+	cephCluster, err := getFirst(ctx, dynamicClient, CephClusterGVR, foundNamespace)
+	if err == nil {
+		if details := cephDetailsFrom(cephCluster); details != nil {
+			status.CephHealth = details.Health
+			status.CephDetails = details
+		}
+	} else {
+		logDegraded(ctx, err, "Cannot read the CephCluster", "namespace", foundNamespace)
+	}
+	storageCluster, _ := getFirst(ctx, dynamicClient, StorageClusterGVR, foundNamespace)
+	if storageCluster != nil {
+		// Ready also requires the StorageCluster to be Available and not Degraded
+		conditions := ReadConditions(*storageCluster)
+		status.AddConditions(conditions...)
+		for _, condition := range conditions {
+			if !condition.Healthy() {
+				status.Message += fmt.Sprintf(", StorageCluster %s", condition)
+			}
+		}
+	}
+
+	if capacity := capacityFrom(cephCluster, storageCluster); capacity != nil {
+		status.Capacity = capacity
+	}
+	status.setUsage()
+	status.setSeverity()
+
+	return status, nil
+}
+
+// setSeverity raises the severity from the Ceph health and the capacity thresholds.
+// Ceph health that cannot be read leaves the severity unknown.
+func (s *DataFoundationStatus) setSeverity() {
+	if s.CephDetails == nil {
+		s.RaiseSeverity(SeverityUnknown)
+	} else {
+		s.RaiseSeverity(cephHealthSeverity(s.CephDetails.Health))
+	}
+	switch {
+	case s.Critical != nil && *s.Critical:
+		s.RaiseSeverity(SeverityCritical)
+	case s.NearFull != nil && *s.NearFull:
+		s.RaiseSeverity(SeverityWarning)
+	}
+}
+
+// cephHealthSeverity maps a Ceph health status such as HEALTH_WARN to a severity
+func cephHealthSeverity(health string) string {
+	switch health {
+	case "HEALTH_OK":
+		return SeverityOK
+	case "HEALTH_WARN":
+		return SeverityWarning
+	case "HEALTH_ERR":
+		return SeverityCritical
+	default:
+		return SeverityUnknown
+	}
+}
+
+// setUsage computes the used percent of the raw capacity and flags it against the
+// thresholds, leaving them nil when the used or total capacity is unknown
+func (s *DataFoundationStatus) setUsage() {
+	if s.Capacity == nil || s.Capacity.RawTotal == nil || s.Capacity.RawUsed == nil || s.Capacity.RawTotal.Bytes <= 0 {
+		return
+	}
+	percent := math.Round(float64(s.Capacity.RawUsed.Bytes)/float64(s.Capacity.RawTotal.Bytes)*1000) / 10
+	s.UsedPercent = &percent
+	s.NearFull = ptr.To(percent >= CapacityNearFullPercent)
+	s.Critical = ptr.To(percent >= CapacityCriticalPercent)
+}
+
+// getFirst returns the first resource of a kind in a namespace
+func getFirst(ctx context.Context, dynamicClient dynamic.Interface, gvr schema.GroupVersionResource, namespace string) (*unstructured.Unstructured, error) {
+	list, err := dynamicClient.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", gvr.Resource, err)
+	}
+	if len(list.Items) == 0 {
+		return nil, fmt.Errorf("no %s found in namespace %s", gvr.Resource, namespace)
+	}
+	return &list.Items[0], nil
+}
+
+// cephDetailsFrom reads health, phase and health check messages of a CephCluster
+// It returns nil when the CephCluster does not report health yet
+func cephDetailsFrom(cephCluster *unstructured.Unstructured) *CephDetails {
+	details := &CephDetails{Name: cephCluster.GetName()}
+	details.Phase, _, _ = unstructured.NestedString(cephCluster.Object, "status", "phase")
+	details.Health, _, _ = unstructured.NestedString(cephCluster.Object, "status", "ceph", "health")
+	if details.Health == "" {
+		return nil
+	}
+
+	if details.Health != "HEALTH_OK" {
+		// status.ceph.details maps each failing health check to its severity and message
+		checks, _, _ := unstructured.NestedMap(cephCluster.Object, "status", "ceph", "details")
+		names := make([]string, 0, len(checks))
+		for name := range checks {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			message, _, _ := unstructured.NestedString(cephCluster.Object, "status", "ceph", "details", name, "message")
+			details.Warnings = append(details.Warnings, fmt.Sprintf("%s: %s", name, message))
+		}
+	}
+
+	return details
+}
+
+// capacityFrom derives raw and usable capacity. ODF 4.x reports raw capacity in the
+// CephCluster status.ceph.capacity, older releases only describe the provisioned
+// device sets in the StorageCluster spec, which yields the total but not the usage.
+// Usable capacity divides raw capacity by the device set replica count.
+// It returns nil when neither layout carries capacity.
+func capacityFrom(cephCluster, storageCluster *unstructured.Unstructured) *DataFoundationCapacity {
+	replica := int64(defaultReplica)
+	if storageCluster != nil {
+		if sets, _, _ := unstructured.NestedSlice(storageCluster.Object, "spec", "storageDeviceSets"); len(sets) > 0 {
+			if set, ok := sets[0].(map[string]interface{}); ok {
+				if r, found, _ := unstructured.NestedInt64(set, "replica"); found && r > 0 {
+					replica = r
 				}
 			}
 		}
 	}
 
-	// Try to get Ceph health (best effort)
-	cephGVR := schema.GroupVersionResource{
-		Group:    "ceph.rook.io",
-		Version:  "v1",
-		Resource: "cephclusters",
+	capacity := &DataFoundationCapacity{Replica: replica}
+
+	if cephCluster != nil {
+		total, hasTotal := quantityField(cephCluster.Object, "status", "ceph", "capacity", "bytesTotal")
+		used, hasUsed := quantityField(cephCluster.Object, "status", "ceph", "capacity", "bytesUsed")
+		available, hasAvailable := quantityField(cephCluster.Object, "status", "ceph", "capacity", "bytesAvailable")
+		if hasTotal {
+			capacity.Source = "cephcluster"
+			capacity.RawTotal = newCapacityValue(total)
+			capacity.UsableTotal = newCapacityValue(total / replica)
+			if hasUsed {
+				capacity.RawUsed = newCapacityValue(used)
+				capacity.UsableUsed = newCapacityValue(used / replica)
+			}
+			if hasAvailable {
+				capacity.RawAvailable = newCapacityValue(available)
+				capacity.UsableAvailable = newCapacityValue(available / replica)
+			}
+			return capacity
+		}
+	}
+
+	if storageCluster != nil {
+		var total int64
+		sets, _, _ := unstructured.NestedSlice(storageCluster.Object, "spec", "storageDeviceSets")
+		for _, s := range sets {
+			set, ok := s.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			size, ok := quantityField(set, "dataPVCTemplate", "spec", "resources", "requests", "storage")
+			if !ok {
+				continue
+			}
+			count, found, _ := unstructured.NestedInt64(set, "count")
+			if !found || count < 1 {
+				count = 1
+			}
+			setReplica, found, _ := unstructured.NestedInt64(set, "replica")
+			if !found || setReplica < 1 {
+				setReplica = int64(defaultReplica)
+			}
+			total += size * count * setReplica
+		}
+		if total > 0 {
+			capacity.Source = "storagecluster"
+			capacity.RawTotal = newCapacityValue(total)
+			capacity.UsableTotal = newCapacityValue(total / replica)
+			capacity.Message = "usage not reported by this Data Foundation release, only provisioned capacity is known"
+			return capacity
+		}
 	}
-	if CheckCRDExists(ctx, clusterClient, cephGVR) {
-		status.CephHealth = "CRD exists (detailed health check not implemented)"
+
+	return nil
+}
+
+// quantityField reads a byte quantity that may be stored as a number or as a
+// Kubernetes quantity string such as 512Gi
+func quantityField(obj map[string]interface{}, fields ...string) (int64, bool) {
+	value, found, err := unstructured.NestedFieldNoCopy(obj, fields...)
+	if err != nil || !found {
+		return 0, false
 	}
 
-	return status, nil
+	var quantity *resource.Quantity
+	switch v := value.(type) {
+	case int64:
+		quantity = resource.NewQuantity(v, resource.BinarySI)
+	case float64:
+		quantity = resource.NewQuantity(int64(v), resource.BinarySI)
+	case string:
+		parsed, err := resource.ParseQuantity(v)
+		if err != nil {
+			return 0, false
+		}
+		quantity = &parsed
+	default:
+		return 0, false
+	}
+
+	return quantity.Value(), true
+}
+
+// newCapacityValue pairs a byte count with its human-readable form
+func newCapacityValue(bytes int64) *CapacityValue {
+	return &CapacityValue{
+		Bytes: bytes,
+		Human: FormatBytes(bytes),
+	}
+}
+
+// FormatBytes renders a byte count with binary units, for example 1.5 TiB
+func FormatBytes(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit && exp < 5; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
 }
 
 // Made with Bob
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// maxUnhealthyPods caps the unhealthy pods detailed in ODFPodHealth
+const maxUnhealthyPods = 20
+
+// crashLoopBackOff is the waiting reason of a container restarted over and over
+const crashLoopBackOff = "CrashLoopBackOff"
+
+// ODFPodHealth groups the pods of the ODF namespace by readiness. Completed pods, such as
+// those of finished Jobs, are neither ready nor unhealthy. Unhealthy details the pods
+// that are not ready, crash looping ones first then by restarts, at most 20 of them with
+// Truncated set when more are.
+type ODFPodHealth struct {
+	Total        int            `json:"total"`
+	Ready        int            `json:"ready"`
+	NotReady     int            `json:"notReady"`
+	Completed    int            `json:"completed,omitempty"`
+	CrashLooping int            `json:"crashLooping"`
+	Unhealthy    []UnhealthyPod `json:"unhealthy,omitempty"`
+	Truncated    bool           `json:"truncated,omitempty"`
+}
+
+// UnhealthyPod describes a pod that is not ready. Reason is the waiting reason of its
+// first waiting container, such as CrashLoopBackOff or ImagePullBackOff, the pod status
+// reason, or NotReady. LastTermination is the last exit of its most restarted container.
+type UnhealthyPod struct {
+	Name            string          `json:"name"`
+	Phase           string          `json:"phase"`
+	Reason          string          `json:"reason"`
+	Message         string          `json:"message,omitempty"`
+	Restarts        int32           `json:"restarts"`
+	LastTermination *PodTermination `json:"lastTermination,omitempty"`
+}
+
+// PodTermination is how a container of a pod last exited
+type PodTermination struct {
+	Container  string     `json:"container"`
+	Reason     string     `json:"reason,omitempty"`
+	ExitCode   int32      `json:"exitCode"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+}
+
+// odfPodHealth reads the pods of the ODF namespace page by page, keeping the counts and
+// the unhealthy pods only
+func odfPodHealth(ctx context.Context, clusterClient *clients.ClusterClient, namespace string) (*ODFPodHealth, error) {
+	health := &ODFPodHealth{}
+	err := listPages(ctx, metav1.ListOptions{}, func(opts metav1.ListOptions) (string, error) {
+		pods, err := clusterClient.Clientset.CoreV1().Pods(namespace).List(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		for i := range pods.Items {
+			health.add(&pods.Items[i])
+		}
+		return pods.Continue, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods in %s: %w", namespace, err)
+	}
+
+	sort.Slice(health.Unhealthy, func(i, j int) bool {
+		a, b := health.Unhealthy[i], health.Unhealthy[j]
+		if (a.Reason == crashLoopBackOff) != (b.Reason == crashLoopBackOff) {
+			return a.Reason == crashLoopBackOff
+		}
+		if a.Restarts != b.Restarts {
+			return a.Restarts > b.Restarts
+		}
+		return a.Name < b.Name
+	})
+	if len(health.Unhealthy) > maxUnhealthyPods {
+		health.Unhealthy = health.Unhealthy[:maxUnhealthyPods]
+		health.Truncated = true
+	}
+	return health, nil
+}
+
+// add counts a pod, detailing it when it is not ready
+func (h *ODFPodHealth) add(pod *corev1.Pod) {
+	h.Total++
+	switch {
+	case pod.Status.Phase == corev1.PodSucceeded:
+		h.Completed++
+		return
+	case podReady(pod):
+		h.Ready++
+		return
+	}
+
+	h.NotReady++
+	unhealthy := UnhealthyPod{Name: pod.Name, Phase: string(pod.Status.Phase), Reason: "NotReady", Message: pod.Status.Message}
+	if pod.Status.Reason != "" {
+		unhealthy.Reason = pod.Status.Reason
+	}
+	var mostRestarted *corev1.ContainerStatus
+	waiting := false
+	for i := range pod.Status.ContainerStatuses {
+		container := &pod.Status.ContainerStatuses[i]
+		unhealthy.Restarts += container.RestartCount
+		if mostRestarted == nil || container.RestartCount > mostRestarted.RestartCount {
+			mostRestarted = container
+		}
+		// A crash looping container explains the pod best, otherwise the first waiting one
+		if state := container.State.Waiting; state != nil && (!waiting || state.Reason == crashLoopBackOff) {
+			unhealthy.Reason, unhealthy.Message = state.Reason, state.Message
+			waiting = true
+		}
+	}
+	if unhealthy.Reason == crashLoopBackOff {
+		h.CrashLooping++
+	}
+	if mostRestarted != nil {
+		if terminated := mostRestarted.LastTerminationState.Terminated; terminated != nil {
+			unhealthy.LastTermination = &PodTermination{Container: mostRestarted.Name, Reason: terminated.Reason, ExitCode: terminated.ExitCode}
+			if !terminated.FinishedAt.IsZero() {
+				unhealthy.LastTermination.FinishedAt = &terminated.FinishedAt.Time
+			}
+		}
+	}
+	h.Unhealthy = append(h.Unhealthy, unhealthy)
+}
+
+// podReady reports whether the Ready condition of a pod is true
+func podReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// Made with Bob
@@ -0,0 +1,246 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+)
+
+type DataFoundationSuite struct {
+	suite.Suite
+}
+
+func storageClusterWithDeviceSet(size string, count, replica int64) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"storageDeviceSets": []interface{}{
+				map[string]interface{}{
+					"count":   count,
+					"replica": replica,
+					"dataPVCTemplate": map[string]interface{}{
+						"spec": map[string]interface{}{
+							"resources": map[string]interface{}{
+								"requests": map[string]interface{}{"storage": size},
+							},
+						},
+					},
+				},
+			},
+		},
+	}}
+}
+
+func (s *DataFoundationSuite) TestCapacityFrom() {
+	s.Run("reads raw and usable capacity from the CephCluster", func() {
+		cephCluster := &unstructured.Unstructured{Object: map[string]interface{}{
+			"status": map[string]interface{}{
+				"ceph": map[string]interface{}{
+					"capacity": map[string]interface{}{
+						"bytesTotal":     int64(3 << 40),
+						"bytesUsed":      int64(1 << 40),
+						"bytesAvailable": int64(2 << 40),
+					},
+				},
+			},
+		}}
+		capacity := capacityFrom(cephCluster, storageClusterWithDeviceSet("512Gi", 2, 3))
+		s.Require().NotNil(capacity)
+		s.Equal("cephcluster", capacity.Source)
+		s.Equal(int64(3<<40), capacity.RawTotal.Bytes)
+		s.Equal("3.0 TiB", capacity.RawTotal.Human)
+		s.Equal(int64(1<<40), capacity.UsableTotal.Bytes)
+		s.Equal(int64(2<<40)/3, capacity.UsableAvailable.Bytes)
+	})
+
+	s.Run("falls back to the StorageCluster device sets", func() {
+		capacity := capacityFrom(nil, storageClusterWithDeviceSet("512Gi", 2, 3))
+		s.Require().NotNil(capacity)
+		s.Equal("storagecluster", capacity.Source)
+		s.Equal(int64(3<<40), capacity.RawTotal.Bytes)
+		s.Equal(int64(1<<40), capacity.UsableTotal.Bytes)
+		s.Nil(capacity.RawUsed, "usage is unknown, not zero")
+		s.NotEmpty(capacity.Message)
+	})
+
+	s.Run("returns nil when no capacity is reported", func() {
+		cephCluster := &unstructured.Unstructured{Object: map[string]interface{}{
+			"status": map[string]interface{}{"ceph": map[string]interface{}{"health": "HEALTH_OK"}},
+		}}
+		s.Nil(capacityFrom(cephCluster, nil))
+	})
+}
+
+func (s *DataFoundationSuite) TestSetUsage() {
+	withUsage := func(used, total int64) *DataFoundationStatus {
+		return &DataFoundationStatus{Capacity: &DataFoundationCapacity{RawUsed: newCapacityValue(used), RawTotal: newCapacityValue(total)}}
+	}
+	s.Run("computes the used percent", func() {
+		status := withUsage(1, 3)
+		status.setUsage()
+		s.Require().NotNil(status.UsedPercent)
+		s.Equal(33.3, *status.UsedPercent)
+		s.Equal(ptr.To(false), status.NearFull)
+		s.Equal(ptr.To(false), status.Critical)
+	})
+	s.Run("flags a near full cluster", func() {
+		status := withUsage(80, 100)
+		status.setUsage()
+		s.Equal(ptr.To(true), status.NearFull)
+		s.Equal(ptr.To(false), status.Critical)
+	})
+	s.Run("flags a critical cluster as near full too", func() {
+		status := withUsage(95, 100)
+		status.setUsage()
+		s.Equal(ptr.To(true), status.NearFull)
+		s.Equal(ptr.To(true), status.Critical)
+	})
+	s.Run("leaves the flags nil when the usage is unknown", func() {
+		status := &DataFoundationStatus{Capacity: capacityFrom(nil, storageClusterWithDeviceSet("512Gi", 2, 3))}
+		status.setUsage()
+		s.Nil(status.UsedPercent)
+		s.Nil(status.NearFull)
+		s.Nil(status.Critical)
+	})
+	s.Run("leaves the flags nil without capacity", func() {
+		status := &DataFoundationStatus{}
+		status.setUsage()
+		s.Nil(status.NearFull)
+	})
+}
+
+func (s *DataFoundationSuite) TestSetSeverity() {
+	withCephHealth := func(health string) *DataFoundationStatus {
+		return &DataFoundationStatus{CephDetails: &CephDetails{Health: health}}
+	}
+	s.Run("maps the Ceph health", func() {
+		for health, severity := range map[string]string{
+			"HEALTH_OK":   SeverityOK,
+			"HEALTH_WARN": SeverityWarning,
+			"HEALTH_ERR":  SeverityCritical,
+		} {
+			status := withCephHealth(health)
+			status.setSeverity()
+			s.Equal(severity, status.Severity, health)
+		}
+	})
+	s.Run("is unknown when Ceph health cannot be read", func() {
+		status := &DataFoundationStatus{}
+		status.setSeverity()
+		s.Equal(SeverityUnknown, status.Severity)
+	})
+	s.Run("raises the severity past the capacity thresholds", func() {
+		status := withCephHealth("HEALTH_OK")
+		status.NearFull = ptr.To(true)
+		status.Critical = ptr.To(false)
+		status.setSeverity()
+		s.Equal(SeverityWarning, status.Severity)
+
+		status = withCephHealth("HEALTH_WARN")
+		status.NearFull = ptr.To(true)
+		status.Critical = ptr.To(true)
+		status.setSeverity()
+		s.Equal(SeverityCritical, status.Severity)
+	})
+}
+
+func (s *DataFoundationSuite) TestFormatBytes() {
+	s.Equal("512 B", FormatBytes(512))
+	s.Equal("1.0 KiB", FormatBytes(1024))
+	s.Equal("1.5 GiB", FormatBytes(3<<29))
+	s.Equal("2.0 PiB", FormatBytes(2<<50))
+}
+
+// odfPod returns a pod of the ODF namespace, ready or not, its containers reporting the given statuses
+func odfPod(name string, phase corev1.PodPhase, ready bool, containers ...corev1.ContainerStatus) *corev1.Pod {
+	condition := corev1.ConditionFalse
+	if ready {
+		condition = corev1.ConditionTrue
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "openshift-storage", Name: name, Labels: map[string]string{"app": name}},
+		Status: corev1.PodStatus{
+			Phase:             phase,
+			Conditions:        []corev1.PodCondition{{Type: corev1.PodReady, Status: condition}},
+			ContainerStatuses: containers,
+		},
+	}
+}
+
+func (s *DataFoundationSuite) TestODFPodHealth() {
+	finished := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	objects := []runtime.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "openshift-storage"}},
+		odfPod("odf-operator", corev1.PodRunning, true),
+		odfPod("ocs-metrics-exporter", corev1.PodRunning, true),
+		odfPod("rook-ceph-osd-prepare", corev1.PodSucceeded, false),
+		odfPod("rook-ceph-mgr", corev1.PodPending, false, corev1.ContainerStatus{
+			Name:  "mgr",
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: "image not found"}},
+		}),
+		odfPod("rook-ceph-osd-0", corev1.PodRunning, false,
+			corev1.ContainerStatus{Name: "log-collector", RestartCount: 1},
+			corev1.ContainerStatus{
+				Name:                 "osd",
+				RestartCount:         12,
+				State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Error", ExitCode: 1, FinishedAt: metav1.NewTime(finished)}},
+			}),
+		odfPod("noobaa-db-pg-0", corev1.PodRunning, false, corev1.ContainerStatus{Name: "db", RestartCount: 2}),
+	}
+	client := &clients.ClusterClient{Name: "prod-1", Clientset: fake.NewSimpleClientset(objects...)}
+
+	health, err := odfPodHealth(context.Background(), client, "openshift-storage")
+	s.Require().NoError(err)
+	s.Run("groups the pods by readiness", func() {
+		s.Equal(6, health.Total)
+		s.Equal(2, health.Ready)
+		s.Equal(3, health.NotReady)
+		s.Equal(1, health.Completed)
+		s.Equal(1, health.CrashLooping)
+		s.False(health.Truncated)
+	})
+	s.Run("details the unhealthy pods, crash looping first", func() {
+		s.Require().Len(health.Unhealthy, 3)
+		s.Equal(UnhealthyPod{
+			Name: "rook-ceph-osd-0", Phase: "Running", Reason: "CrashLoopBackOff", Restarts: 13,
+			LastTermination: &PodTermination{Container: "osd", Reason: "Error", ExitCode: 1, FinishedAt: &finished},
+		}, health.Unhealthy[0])
+		s.Equal(UnhealthyPod{Name: "noobaa-db-pg-0", Phase: "Running", Reason: "NotReady", Restarts: 2}, health.Unhealthy[1])
+		s.Equal(UnhealthyPod{Name: "rook-ceph-mgr", Phase: "Pending", Reason: "ImagePullBackOff", Message: "image not found"}, health.Unhealthy[2])
+	})
+	s.Run("caps the unhealthy pods detailed", func() {
+		many := []runtime.Object{}
+		for i := range maxUnhealthyPods + 5 {
+			many = append(many, odfPod(fmt.Sprintf("rook-ceph-osd-%02d", i), corev1.PodRunning, false))
+		}
+		health, err := odfPodHealth(context.Background(), &clients.ClusterClient{Name: "prod-1", Clientset: fake.NewSimpleClientset(many...)}, "openshift-storage")
+		s.Require().NoError(err)
+		s.Equal(maxUnhealthyPods+5, health.NotReady)
+		s.Len(health.Unhealthy, maxUnhealthyPods)
+		s.True(health.Truncated)
+	})
+	s.Run("the status keeps the operator readiness and reports the pods", func() {
+		status, err := NewDataFoundationService(nil).GetStatus(context.Background(), client, "")
+		s.Require().NoError(err)
+		s.True(status.Ready)
+		s.Equal("ODF operator running with 1 pods, 3 of 6 pods not ready (1 in CrashLoopBackOff)", status.Message)
+		s.Require().NotNil(status.Pods)
+		s.Equal(3, status.Pods.NotReady)
+	})
+}
+
+func TestDataFoundationSuite(t *testing.T) {
+	suite.Run(t, new(DataFoundationSuite))
+}
+
+// Made with Bob
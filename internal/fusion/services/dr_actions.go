@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+// DRAction is an action Ramen performs on a protected workload
+type DRAction string
+
+const (
+	DRActionFailover DRAction = "Failover"
+	DRActionRelocate DRAction = "Relocate"
+)
+
+// DRActionRequest selects the DRPlacementControl of a workload to fail over or relocate
+type DRActionRequest struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	// Cluster is the failover cluster of a failover and the preferred cluster of a relocation
+	Cluster string `json:"cluster"`
+	// Force acts even when replication of the workload is not healthy
+	Force  bool `json:"force,omitempty"`
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// DRActionResult reports the action set on a DRPlacementControl. Warnings lists the
+// replication problems that were overridden by force.
+type DRActionResult struct {
+	Name           string   `json:"name"`
+	Namespace      string   `json:"namespace"`
+	DRPolicy       string   `json:"drPolicy,omitempty"`
+	Action         string   `json:"action"`
+	PreviousAction string   `json:"previousAction,omitempty"`
+	Cluster        string   `json:"cluster"`
+	Phase          string   `json:"phase,omitempty"`
+	Forced         bool     `json:"forced,omitempty"`
+	Warnings       []string `json:"warnings,omitempty"`
+	DryRunPreview
+}
+
+// Failover fails a workload over to request.Cluster by setting the action and failover
+// cluster of its DRPlacementControl on the hub
+func (s *DRService) Failover(ctx context.Context, client *clients.ClusterClient, request DRActionRequest) (*DRActionResult, error) {
+	return s.setAction(ctx, client, DRActionFailover, "failoverCluster", request, time.Now())
+}
+
+// Relocate moves a workload back to request.Cluster by setting the action and preferred
+// cluster of its DRPlacementControl on the hub
+func (s *DRService) Relocate(ctx context.Context, client *clients.ClusterClient, request DRActionRequest) (*DRActionResult, error) {
+	return s.setAction(ctx, client, DRActionRelocate, "preferredCluster", request, time.Now())
+}
+
+// setAction patches spec.action and the cluster field of a DRPlacementControl once it
+// is peer ready and, unless forced, its replication is healthy
+func (s *DRService) setAction(ctx context.Context, client *clients.ClusterClient, action DRAction, clusterField string, request DRActionRequest, now time.Time) (*DRActionResult, error) {
+	verb := strings.ToLower(string(action))
+	if request.Name == "" || request.Namespace == "" {
+		return nil, fmt.Errorf("name and namespace of the DRPlacementControl are required")
+	}
+	if request.Cluster == "" {
+		return nil, fmt.Errorf("cluster is required to %s", verb)
+	}
+	if !NamespaceAllowed(request.Namespace) {
+		return nil, fmt.Errorf("namespace %s is outside the allowed namespaces (scope restricted)", request.Namespace)
+	}
+
+	dynamicClient, err := client.DynamicClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+	resource := dynamicClient.Resource(targeting.DRPlacementControlGVR).Namespace(request.Namespace)
+
+	drpc, err := resource.Get(ctx, request.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("DRPlacementControl %s/%s not found", request.Namespace, request.Name)
+		}
+		return nil, fmt.Errorf("failed to get DRPlacementControl %s/%s: %w", request.Namespace, request.Name, err)
+	}
+	if peerReady, message, _ := FindCondition(*drpc, "PeerReady"); peerReady != "True" {
+		if message == "" {
+			message = "PeerReady condition not reported"
+		}
+		return nil, fmt.Errorf("DRPlacementControl %s/%s is not peer ready: %s", request.Namespace, request.Name, message)
+	}
+
+	result := &DRActionResult{
+		Name:      request.Name,
+		Namespace: request.Namespace,
+		Action:    string(action),
+		Cluster:   request.Cluster,
+	}
+	result.DRPolicy, _, _ = unstructured.NestedString(drpc.Object, "spec", "drPolicyRef", "name")
+	result.PreviousAction, _, _ = unstructured.NestedString(drpc.Object, "spec", "action")
+
+	problems, err := s.replicationProblems(ctx, dynamicClient, drpc, result.DRPolicy, request.Cluster, now)
+	if err != nil {
+		return nil, err
+	}
+	if len(problems) > 0 {
+		if !request.Force {
+			return nil, fmt.Errorf("refusing to %s DRPlacementControl %s/%s, replication is not healthy: %s (set force to %s anyway)",
+				verb, request.Namespace, request.Name, strings.Join(problems, "; "), verb)
+		}
+		result.Forced = true
+		result.Warnings = problems
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"action":     string(action),
+			clusterField: request.Cluster,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build patch: %w", err)
+	}
+	patched, err := resource.Patch(ctx, request.Name, types.MergePatchType, patch, metav1.PatchOptions{DryRun: dryRunOption(request.DryRun)})
+	if err != nil {
+		var status apierrors.APIStatus
+		if errors.As(err, &status) {
+			return nil, fmt.Errorf("%s of DRPlacementControl %s/%s rejected: %s", verb, request.Namespace, request.Name, status.Status().Message)
+		}
+		return nil, fmt.Errorf("failed to %s DRPlacementControl %s/%s: %w", verb, request.Namespace, request.Name, err)
+	}
+	result.Phase, _, _ = unstructured.NestedString(patched.Object, "status", "phase")
+	if request.DryRun {
+		result.DryRunPreview = DryRunPreview{DryRun: true, Preview: patched.Object}
+	}
+	return result, nil
+}
+
+// replicationProblems lists why replication of a workload is not healthy: its DRPolicy
+// cannot be read or is not validated, or its last group sync is behind the RPO. Naming
+// a cluster outside the policy is an error that force does not override.
+func (s *DRService) replicationProblems(ctx context.Context, dynamicClient dynamic.Interface, drpc *unstructured.Unstructured, policyName, cluster string, now time.Time) ([]string, error) {
+	if policyName == "" {
+		return []string{"DRPlacementControl references no DRPolicy"}, nil
+	}
+	policy, err := dynamicClient.Resource(DRPolicyGVR).Get(ctx, policyName, metav1.GetOptions{})
+	if err != nil {
+		logDegraded(ctx, err, "Cannot read DRPolicy", "drPolicy", policyName)
+		return []string{fmt.Sprintf("DRPolicy %s cannot be read: %v", policyName, err)}, nil
+	}
+
+	drClusters, _, _ := unstructured.NestedStringSlice(policy.Object, "spec", "drClusters")
+	if len(drClusters) > 0 && !slices.Contains(drClusters, cluster) {
+		return nil, fmt.Errorf("cluster %s is not a DR cluster of DRPolicy %s (%s)", cluster, policyName, strings.Join(drClusters, ", "))
+	}
+
+	var problems []string
+	if validated, message, _ := FindCondition(*policy, "Validated"); validated != "True" {
+		problem := fmt.Sprintf("DRPolicy %s is not validated", policyName)
+		if message != "" {
+			problem += ": " + message
+		}
+		problems = append(problems, problem)
+	}
+
+	workload := DRWorkloadStatus{LagSeconds: -1}
+	workload.LastGroupSyncTime, _, _ = unstructured.NestedString(drpc.Object, "status", "lastGroupSyncTime")
+	interval, _, _ := unstructured.NestedString(policy.Object, "spec", "schedulingInterval")
+	evaluateRPO(&workload, interval, now)
+	if !workload.RPOCompliant {
+		problems = append(problems, workload.Message)
+	}
+	return problems, nil
+}
+
+// Made with Bob
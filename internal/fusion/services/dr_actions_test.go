@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// drPolicy returns a 5m Regional DR policy between prod-1 and prod-2
+func drPolicy(validated string) *unstructured.Unstructured {
+	policy := &unstructured.Unstructured{}
+	policy.SetAPIVersion("ramendr.openshift.io/v1alpha1")
+	policy.SetKind("DRPolicy")
+	policy.SetName("odr-policy-5m")
+	_ = unstructured.SetNestedField(policy.Object, "5m", "spec", "schedulingInterval")
+	_ = unstructured.SetNestedStringSlice(policy.Object, []string{"prod-1", "prod-2"}, "spec", "drClusters")
+	_ = unstructured.SetNestedSlice(policy.Object, []interface{}{
+		map[string]interface{}{"type": "Validated", "status": validated, "message": "drpolicy validated"},
+	}, "status", "conditions")
+	return policy
+}
+
+// drPlacementControl returns the DRPlacementControl of the busybox workload, last synced lag ago
+func drPlacementControl(peerReady string, lag time.Duration) *unstructured.Unstructured {
+	drpc := &unstructured.Unstructured{}
+	drpc.SetAPIVersion("ramendr.openshift.io/v1alpha1")
+	drpc.SetKind("DRPlacementControl")
+	drpc.SetNamespace("busybox")
+	drpc.SetName("busybox-drpc")
+	_ = unstructured.SetNestedField(drpc.Object, "odr-policy-5m", "spec", "drPolicyRef", "name")
+	_ = unstructured.SetNestedField(drpc.Object, "Deployed", "status", "phase")
+	_ = unstructured.SetNestedField(drpc.Object, time.Now().Add(-lag).UTC().Format(time.RFC3339), "status", "lastGroupSyncTime")
+	_ = unstructured.SetNestedSlice(drpc.Object, []interface{}{
+		map[string]interface{}{"type": "PeerReady", "status": peerReady, "message": "waiting for peer"},
+	}, "status", "conditions")
+	return drpc
+}
+
+// drClient returns a hub client serving the given Ramen resources
+func drClient(objects ...runtime.Object) *clients.ClusterClient {
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			DRPolicyGVR:                     "DRPolicyList",
+			targeting.DRPlacementControlGVR: "DRPlacementControlList",
+		}, objects...)
+	return &clients.ClusterClient{Name: "hub", Dynamic: dynamicClient}
+}
+
+func (s *MultidomSuite) TestFailover() {
+	request := DRActionRequest{Name: "busybox-drpc", Namespace: "busybox", Cluster: "prod-2"}
+
+	s.Run("sets the action and failover cluster", func() {
+		client := drClient(drPolicy("True"), drPlacementControl("True", time.Minute))
+		result, err := NewDRService().Failover(context.Background(), client, request)
+		s.Require().NoError(err)
+		s.Equal("Failover", result.Action)
+		s.Equal("odr-policy-5m", result.DRPolicy)
+		s.Equal("Deployed", result.Phase)
+		s.False(result.Forced)
+
+		drpc, err := client.Dynamic.Resource(targeting.DRPlacementControlGVR).Namespace("busybox").Get(context.Background(), "busybox-drpc", metav1.GetOptions{})
+		s.Require().NoError(err)
+		action, _, _ := unstructured.NestedString(drpc.Object, "spec", "action")
+		failoverCluster, _, _ := unstructured.NestedString(drpc.Object, "spec", "failoverCluster")
+		s.Equal("Failover", action)
+		s.Equal("prod-2", failoverCluster)
+	})
+	s.Run("relocation sets the preferred cluster", func() {
+		client := drClient(drPolicy("True"), drPlacementControl("True", time.Minute))
+		result, err := NewDRService().Relocate(context.Background(), client, DRActionRequest{Name: "busybox-drpc", Namespace: "busybox", Cluster: "prod-1"})
+		s.Require().NoError(err)
+		s.Equal("Relocate", result.Action)
+
+		drpc, err := client.Dynamic.Resource(targeting.DRPlacementControlGVR).Namespace("busybox").Get(context.Background(), "busybox-drpc", metav1.GetOptions{})
+		s.Require().NoError(err)
+		preferredCluster, _, _ := unstructured.NestedString(drpc.Object, "spec", "preferredCluster")
+		s.Equal("prod-1", preferredCluster)
+	})
+	s.Run("refuses unhealthy replication unless forced", func() {
+		client := drClient(drPolicy("False"), drPlacementControl("True", time.Hour))
+		_, err := NewDRService().Failover(context.Background(), client, request)
+		s.Require().Error(err)
+		s.Contains(err.Error(), "replication is not healthy")
+		s.Contains(err.Error(), "DRPolicy odr-policy-5m is not validated")
+		s.Contains(err.Error(), "exceeds 2x the 5m scheduling interval")
+
+		forced := request
+		forced.Force = true
+		result, err := NewDRService().Failover(context.Background(), client, forced)
+		s.Require().NoError(err)
+		s.True(result.Forced)
+		s.Len(result.Warnings, 2)
+	})
+	s.Run("refuses a DRPlacementControl that is not peer ready, even when forced", func() {
+		client := drClient(drPolicy("True"), drPlacementControl("False", time.Minute))
+		forced := request
+		forced.Force = true
+		_, err := NewDRService().Failover(context.Background(), client, forced)
+		s.EqualError(err, "DRPlacementControl busybox/busybox-drpc is not peer ready: waiting for peer")
+	})
+	s.Run("refuses a cluster outside the DRPolicy", func() {
+		client := drClient(drPolicy("True"), drPlacementControl("True", time.Minute))
+		outside := request
+		outside.Cluster = "edge-1"
+		_, err := NewDRService().Failover(context.Background(), client, outside)
+		s.EqualError(err, "cluster edge-1 is not a DR cluster of DRPolicy odr-policy-5m (prod-1, prod-2)")
+	})
+	s.Run("a dry run patches with DryRun All and returns the preview", func() {
+		client := drClient(drPolicy("True"), drPlacementControl("True", time.Minute))
+		var options metav1.PatchOptions
+		client.Dynamic.(*dynamicfake.FakeDynamicClient).PrependReactor("patch", "drplacementcontrols", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			options = action.(k8stesting.PatchActionImpl).PatchOptions
+			return false, nil, nil
+		})
+		dryRun := request
+		dryRun.DryRun = true
+		result, err := NewDRService().Failover(context.Background(), client, dryRun)
+		s.Require().NoError(err)
+		s.Equal([]string{metav1.DryRunAll}, options.DryRun)
+		s.True(result.DryRun)
+		s.Equal("prod-2", result.Preview["spec"].(map[string]interface{})["failoverCluster"])
+	})
+	s.Run("reports a missing DRPlacementControl", func() {
+		_, err := NewDRService().Failover(context.Background(), drClient(drPolicy("True")), request)
+		s.EqualError(err, "DRPlacementControl busybox/busybox-drpc not found")
+	})
+}
+
+// Made with Bob
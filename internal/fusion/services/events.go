@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultEventLimit caps the events returned per cluster
+const DefaultEventLimit = 50
+
+// FusionEventNamespaces are the Fusion, ODF, Scale, OADP and virtualization namespaces
+// whose events are listed when no namespace is requested
+var FusionEventNamespaces = []string{
+	"ibm-spectrum-fusion-ns",
+	"isf-operator",
+	"openshift-storage",
+	"ibm-spectrum-scale",
+	"openshift-adp",
+	"openshift-cnv",
+}
+
+// EventsService provides Kubernetes event operations
+type EventsService struct{}
+
+// NewEventsService creates a new events service
+func NewEventsService() *EventsService {
+	return &EventsService{}
+}
+
+// EventsRequest selects the events returned by EventsService.ListWarnings
+// Since is a duration such as 1h; Limit of 0 or less means DefaultEventLimit
+type EventsRequest struct {
+	Namespace string `json:"namespace,omitempty"`
+	Since     string `json:"since,omitempty"`
+	Limit     int    `json:"limit,omitempty"`
+}
+
+// EventInfo describes a Warning event, Age is the time since it was last seen
+type EventInfo struct {
+	Namespace      string    `json:"namespace"`
+	Reason         string    `json:"reason"`
+	Message        string    `json:"message"`
+	InvolvedObject string    `json:"involvedObject"`
+	Count          int32     `json:"count"`
+	LastSeen       time.Time `json:"lastSeen"`
+	Age            string    `json:"age"`
+}
+
+// EventList reports Warning events, newest first. Total counts every matching event,
+// Truncated is set when the limit left some out.
+type EventList struct {
+	Namespaces []string    `json:"namespaces"`
+	Events     []EventInfo `json:"events"`
+	Total      int         `json:"total"`
+	Truncated  bool        `json:"truncated,omitempty"`
+	// Scope notes that the allowed namespaces restricted what was read
+	Scope string `json:"scope,omitempty"`
+}
+
+// ListWarnings lists Warning events of the requested namespace, or of the Fusion
+// related namespaces, seen within the Since window
+func (s *EventsService) ListWarnings(ctx context.Context, client *clients.ClusterClient, request EventsRequest) (*EventList, error) {
+	var since time.Duration
+	if request.Since != "" {
+		var err error
+		if since, err = time.ParseDuration(request.Since); err != nil {
+			return nil, fmt.Errorf("invalid since %q: %w", request.Since, err)
+		}
+	}
+	limit := request.Limit
+	if limit <= 0 {
+		limit = DefaultEventLimit
+	}
+
+	namespaces := FusionEventNamespaces
+	if request.Namespace != "" {
+		namespaces = []string{request.Namespace}
+	}
+
+	// Skip namespaces outside the allowed namespaces
+	var scope string
+	inScope := make([]string, 0, len(namespaces))
+	for _, ns := range namespaces {
+		if NamespaceAllowed(ns) {
+			inScope = append(inScope, ns)
+		} else {
+			_, scope = ScopeNamespaces(ns)
+		}
+	}
+	namespaces = inScope
+
+	result := &EventList{
+		Namespaces: namespaces,
+		Events:     []EventInfo{},
+		Scope:      scope,
+	}
+
+	for _, ns := range namespaces {
+		events, err := client.Clientset.CoreV1().Events(ns).List(ctx, metav1.ListOptions{
+			FieldSelector: "type=" + corev1.EventTypeWarning,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list events in namespace %s: %w", ns, err)
+		}
+
+		for i := range events.Items {
+			info := convertEvent(&events.Items[i])
+			if since > 0 && time.Since(info.LastSeen) > since {
+				continue
+			}
+			result.Events = append(result.Events, info)
+		}
+	}
+
+	sort.SliceStable(result.Events, func(i, j int) bool {
+		return result.Events[i].LastSeen.After(result.Events[j].LastSeen)
+	})
+	result.Total = len(result.Events)
+	if result.Total > limit {
+		result.Events = result.Events[:limit]
+		result.Truncated = true
+	}
+
+	return result, nil
+}
+
+// convertEvent converts an Event to EventInfo, reading the time and count from the
+// legacy fields or from the event series, whichever the emitter populated
+func convertEvent(event *corev1.Event) EventInfo {
+	info := EventInfo{
+		Namespace:      event.Namespace,
+		Reason:         event.Reason,
+		Message:        event.Message,
+		InvolvedObject: event.InvolvedObject.Kind + "/" + event.InvolvedObject.Name,
+		Count:          event.Count,
+		LastSeen:       event.LastTimestamp.Time,
+	}
+
+	if event.Series != nil {
+		if info.Count == 0 {
+			info.Count = event.Series.Count
+		}
+		if info.LastSeen.IsZero() {
+			info.LastSeen = event.Series.LastObservedTime.Time
+		}
+	}
+	if info.LastSeen.IsZero() {
+		info.LastSeen = event.EventTime.Time
+	}
+	if info.LastSeen.IsZero() {
+		info.LastSeen = event.CreationTimestamp.Time
+	}
+	if info.Count == 0 {
+		info.Count = 1
+	}
+	info.Age = HumanizeAge(info.LastSeen)
+
+	return info
+}
+
+// Made with Bob
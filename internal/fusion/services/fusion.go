@@ -0,0 +1,204 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+var (
+	// SpectrumFusionGVR identifies the IBM Fusion SpectrumFusion resource
+	SpectrumFusionGVR = schema.GroupVersionResource{
+		Group:    "prereq.isf.ibm.com",
+		Version:  "v1",
+		Resource: "spectrumfusions",
+	}
+	// FusionServiceInstanceGVR identifies IBM Fusion FusionServiceInstance resources
+	FusionServiceInstanceGVR = schema.GroupVersionResource{
+		Group:    "service.isf.ibm.com",
+		Version:  "v1",
+		Resource: "fusionserviceinstances",
+	}
+	// SubscriptionGVR identifies OLM Subscription resources
+	SubscriptionGVR = schema.GroupVersionResource{
+		Group:    "operators.coreos.com",
+		Version:  "v1alpha1",
+		Resource: "subscriptions",
+	}
+)
+
+// fusionOperatorPrefix is the name prefix of the IBM Fusion operator CSV and subscription
+const fusionOperatorPrefix = "isf-operator"
+
+// FusionService provides operations on IBM Fusion core
+type FusionService struct{}
+
+// NewFusionService creates a new Fusion service
+func NewFusionService() *FusionService {
+	return &FusionService{}
+}
+
+// FusionStatus reports the IBM Fusion installation, its subscription and services
+type FusionStatus struct {
+	ComponentStatus
+	Namespace    string                `json:"namespace,omitempty"`
+	Phase        string                `json:"phase,omitempty"`
+	Subscription *FusionSubscription   `json:"subscription,omitempty"`
+	Services     []FusionServiceStatus `json:"services,omitempty"`
+}
+
+// FusionSubscription describes the OLM subscription of the Fusion operator
+// UpgradeAvailable is set when OLM resolved a newer CSV than the installed one
+type FusionSubscription struct {
+	Name                string `json:"name"`
+	Channel             string `json:"channel,omitempty"`
+	Source              string `json:"source,omitempty"`
+	InstallPlanApproval string `json:"installPlanApproval,omitempty"`
+	InstalledCSV        string `json:"installedCSV,omitempty"`
+	CurrentCSV          string `json:"currentCSV,omitempty"`
+	UpgradeAvailable    bool   `json:"upgradeAvailable"`
+}
+
+// FusionServiceStatus describes a FusionServiceInstance, i.e. an installed Fusion service
+type FusionServiceStatus struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	Phase   string `json:"phase,omitempty"`
+}
+
+// FusionNamespaces are the namespaces the Fusion operator is looked for in by default
+var FusionNamespaces = []string{"ibm-spectrum-fusion-ns", "isf-operator"}
+
+// GetStatus detects the IBM Fusion operator, reads the SpectrumFusion and
+// FusionServiceInstance resources and reports the Fusion version and health. The
+// operator is looked for in namespace when set, in FusionNamespaces otherwise.
+func (s *FusionService) GetStatus(ctx context.Context, client *clients.ClusterClient, namespace string) (*FusionStatus, error) {
+	status := &FusionStatus{}
+
+	var reason NotInstalledReason
+	status.Namespace, reason = findNamespace(ctx, client, namespace, FusionNamespaces...)
+	if status.Namespace == "" {
+		status.ComponentStatus = NotInstalledStatus(reason, "IBM Fusion namespace not found")
+		return status, nil
+	}
+
+	status.Installed = true
+
+	// The operator CSV carries the Fusion version and install phase
+	operators, err := NewOperatorService().GetStatus(ctx, client, OperatorFilter{
+		Namespace: status.Namespace,
+		Prefix:    fusionOperatorPrefix,
+	})
+	operatorPhase := ""
+	if err == nil && len(operators.Operators) > 0 {
+		status.Version = operators.Operators[0].Version
+		operatorPhase = operators.Operators[0].Phase
+	}
+
+	dynamicClient, err := client.DynamicClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
+	status.Subscription = s.getSubscription(ctx, dynamicClient, status.Namespace)
+
+	if CheckCRDExists(ctx, client, SpectrumFusionGVR) {
+		if list, err := dynamicClient.Resource(SpectrumFusionGVR).Namespace(status.Namespace).List(ctx, metav1.ListOptions{}); err == nil && len(list.Items) > 0 {
+			status.Phase = resourcePhase(list.Items[0])
+			if status.Version == "" {
+				status.Version, _, _ = unstructured.NestedString(list.Items[0].Object, "spec", "version")
+			}
+		}
+	}
+
+	if CheckCRDExists(ctx, client, FusionServiceInstanceGVR) {
+		if list, err := dynamicClient.Resource(FusionServiceInstanceGVR).Namespace(status.Namespace).List(ctx, metav1.ListOptions{}); err == nil {
+			for _, item := range list.Items {
+				service := FusionServiceStatus{
+					Name:  item.GetName(),
+					Phase: resourcePhase(item),
+				}
+				service.Version, _, _ = unstructured.NestedString(item.Object, "status", "currentVersion")
+				status.Services = append(status.Services, service)
+			}
+			sort.Slice(status.Services, func(i, j int) bool {
+				return status.Services[i].Name < status.Services[j].Name
+			})
+		}
+	}
+
+	switch {
+	case operatorPhase == "":
+		status.Message = fmt.Sprintf("IBM Fusion namespace %s found but the operator ClusterServiceVersion was not", status.Namespace)
+	case operatorPhase != "Succeeded":
+		status.Message = fmt.Sprintf("IBM Fusion operator is %s", operatorPhase)
+	case status.Phase != "" && !healthyPhase(status.Phase):
+		status.Message = fmt.Sprintf("IBM Fusion operator installed, SpectrumFusion is %s", status.Phase)
+	default:
+		status.Ready = true
+		status.Message = fmt.Sprintf("IBM Fusion %s installed in namespace %s", status.Version, status.Namespace)
+	}
+
+	return status, nil
+}
+
+// getSubscription reads the OLM subscription of the Fusion operator, nil when absent
+func (s *FusionService) getSubscription(ctx context.Context, dynamicClient dynamic.Interface, namespace string) *FusionSubscription {
+	list, err := dynamicClient.Resource(SubscriptionGVR).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		logDegraded(ctx, err, "Cannot list operator Subscriptions", "namespace", namespace)
+		return nil
+	}
+
+	for _, item := range list.Items {
+		pkg, _, _ := unstructured.NestedString(item.Object, "spec", "name")
+		if !strings.HasPrefix(pkg, fusionOperatorPrefix) && !strings.HasPrefix(item.GetName(), fusionOperatorPrefix) {
+			continue
+		}
+
+		subscription := &FusionSubscription{Name: item.GetName()}
+		subscription.Channel, _, _ = unstructured.NestedString(item.Object, "spec", "channel")
+		subscription.Source, _, _ = unstructured.NestedString(item.Object, "spec", "source")
+		subscription.InstallPlanApproval, _, _ = unstructured.NestedString(item.Object, "spec", "installPlanApproval")
+		subscription.InstalledCSV, _, _ = unstructured.NestedString(item.Object, "status", "installedCSV")
+		subscription.CurrentCSV, _, _ = unstructured.NestedString(item.Object, "status", "currentCSV")
+		subscription.UpgradeAvailable = subscription.CurrentCSV != "" && subscription.InstalledCSV != "" &&
+			subscription.CurrentCSV != subscription.InstalledCSV
+		return subscription
+	}
+
+	return nil
+}
+
+// resourcePhase reads the phase of a Fusion custom resource, which depending on the
+// resource and release is reported in status.phase, status.status or status.installStatus.status
+func resourcePhase(obj unstructured.Unstructured) string {
+	for _, fields := range [][]string{
+		{"status", "phase"},
+		{"status", "status"},
+		{"status", "installStatus", "status"},
+	} {
+		if phase, _, _ := unstructured.NestedString(obj.Object, fields...); phase != "" {
+			return phase
+		}
+	}
+	return ""
+}
+
+// healthyPhase reports whether a Fusion resource phase means the resource is healthy
+func healthyPhase(phase string) bool {
+	switch strings.ToLower(phase) {
+	case "completed", "ready", "succeeded", "installed", "healthy", "running":
+		return true
+	}
+	return false
+}
+
+// Made with Bob
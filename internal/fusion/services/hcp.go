@@ -0,0 +1,246 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// NodePoolGVR identifies HyperShift NodePool resources
+var NodePoolGVR = schema.GroupVersionResource{
+	Group:    "hypershift.openshift.io",
+	Version:  "v1beta1",
+	Resource: "nodepools",
+}
+
+// DefaultNodePoolStuckAfter is how long a NodePool may scale without any condition changing
+// before it is flagged as stuck
+const DefaultNodePoolStuckAfter = 30 * time.Minute
+
+// WithStuckAfter sets how long a NodePool may scale without progress before it is flagged
+// as stuck, DefaultNodePoolStuckAfter when 0 or less
+func (s *HCPService) WithStuckAfter(stuckAfter time.Duration) *HCPService {
+	if stuckAfter <= 0 {
+		stuckAfter = DefaultNodePoolStuckAfter
+	}
+	s.stuckAfter = stuckAfter
+	return s
+}
+
+// HostedClusterInfo describes a HostedCluster
+// NodePoolCount is -1 when the NodePools could not be listed
+type HostedClusterInfo struct {
+	Name          string            `json:"name"`
+	Namespace     string            `json:"namespace"`
+	Version       string            `json:"version,omitempty"`
+	NodePoolCount int               `json:"nodePoolCount"`
+	NodePools     []NodePoolStatus  `json:"nodePools,omitempty"`
+	Available     bool              `json:"available"`
+	Progressing   bool              `json:"progressing"`
+	Conditions    []StatusCondition `json:"conditions,omitempty"`
+}
+
+// NodePoolStatus reports the scaling of a NodePool
+// Desired is spec.replicas, unset when the pool is autoscaled between MinReplicas and
+// MaxReplicas. Scaling is set while the current replicas are off the desired ones, and Stuck
+// once no condition of the pool changed for the stuck duration while scaling.
+type NodePoolStatus struct {
+	Name         string     `json:"name"`
+	Desired      *int64     `json:"desired,omitempty"`
+	MinReplicas  *int64     `json:"minReplicas,omitempty"`
+	MaxReplicas  *int64     `json:"maxReplicas,omitempty"`
+	Current      int64      `json:"current"`
+	Ready        bool       `json:"ready"`
+	Scaling      bool       `json:"scaling"`
+	LastProgress *time.Time `json:"lastProgress,omitempty"`
+	Stuck        bool       `json:"stuck"`
+}
+
+// HostedClusterList reports the HostedClusters of a hub
+// Degraded counts the hosted clusters not Available, StuckNodePools the node pools of all
+// of them flagged as stuck
+type HostedClusterList struct {
+	ComponentStatus
+	HostedClusters []HostedClusterInfo `json:"hostedClusters"`
+	Total          int                 `json:"total"`
+	Available      int                 `json:"available"`
+	Degraded       int                 `json:"degraded"`
+	StuckNodePools int                 `json:"stuckNodePools"`
+}
+
+// ListHostedClusters lists the HostedClusters of a hub with their version, node pools and
+// Available/Progressing conditions. Hubs without the HostedCluster CRD are reported as
+// not installed.
+func (s *HCPService) ListHostedClusters(ctx context.Context, client *clients.ClusterClient) (*HostedClusterList, error) {
+	served := CheckCRDsExist(ctx, client, []schema.GroupVersionResource{HostedClusterGVR, NodePoolGVR})
+	if !served[HostedClusterGVR] {
+		return &HostedClusterList{
+			ComponentStatus: NotInstalledStatus(ReasonCRDAbsent, fmt.Sprintf("HostedCluster CRD (%s) not found", HostedClusterGVR.GroupResource())),
+			HostedClusters:  []HostedClusterInfo{},
+		}, nil
+	}
+
+	dynamicClient, err := client.DynamicClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+	return s.listHostedClusters(ctx, dynamicClient, served[NodePoolGVR])
+}
+
+// listHostedClusters lists the HostedClusters and their NodePools once the HostedCluster
+// CRD is known to exist
+func (s *HCPService) listHostedClusters(ctx context.Context, dynamicClient dynamic.Interface, nodePoolsServed bool) (*HostedClusterList, error) {
+	list := &HostedClusterList{
+		HostedClusters: []HostedClusterInfo{},
+	}
+
+	hostedClusters, scope, err := ListInScope(ctx, dynamicClient.Resource(HostedClusterGVR), metav1.NamespaceAll, metav1.ListOptions{})
+	list.Scope = scope
+	if err != nil {
+		return nil, fmt.Errorf("failed to list hosted clusters: %w", err)
+	}
+
+	// NodePools reference their HostedCluster by namespace and spec.clusterName
+	nodePools := map[string][]NodePoolStatus{}
+	nodePoolsMessage := ""
+	if nodePoolsServed {
+		pools, _, err := ListInScope(ctx, dynamicClient.Resource(NodePoolGVR), metav1.NamespaceAll, metav1.ListOptions{})
+		if err != nil {
+			logDegraded(ctx, err, "Cannot list NodePools")
+			nodePools = nil
+			nodePoolsMessage = fmt.Sprintf(", NodePools cannot be listed: %v", err)
+		} else {
+			now := time.Now()
+			for _, pool := range pools.Items {
+				clusterName, _, _ := unstructured.NestedString(pool.Object, "spec", "clusterName")
+				key := pool.GetNamespace() + "/" + clusterName
+				nodePools[key] = append(nodePools[key], convertNodePool(pool, now, s.stuckAfter))
+			}
+		}
+	}
+
+	for _, item := range hostedClusters.Items {
+		info := convertHostedCluster(item)
+		if nodePools == nil {
+			info.NodePoolCount = -1
+		} else {
+			info.NodePools = nodePools[info.Namespace+"/"+info.Name]
+			info.NodePoolCount = len(info.NodePools)
+			sort.Slice(info.NodePools, func(i, j int) bool { return info.NodePools[i].Name < info.NodePools[j].Name })
+			for _, pool := range info.NodePools {
+				if pool.Stuck {
+					list.StuckNodePools++
+				}
+			}
+		}
+		if info.Available {
+			list.Available++
+		} else {
+			list.Degraded++
+		}
+		list.HostedClusters = append(list.HostedClusters, info)
+	}
+
+	sort.SliceStable(list.HostedClusters, func(i, j int) bool {
+		a, b := list.HostedClusters[i], list.HostedClusters[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	list.Total = len(list.HostedClusters)
+
+	message := fmt.Sprintf("%d of %d HostedClusters available", list.Available, list.Total)
+	if list.StuckNodePools > 0 {
+		message += fmt.Sprintf(", %d NodePools stuck scaling for more than %s", list.StuckNodePools, s.stuckAfter)
+	}
+	message += nodePoolsMessage
+	list.ComponentStatus = InstalledStatus(list.Degraded == 0 && list.StuckNodePools == 0, "", message)
+	return list, nil
+}
+
+// convertHostedCluster extracts the reported fields of a HostedCluster
+// Only the Available and Progressing conditions are kept
+func convertHostedCluster(item unstructured.Unstructured) HostedClusterInfo {
+	info := HostedClusterInfo{
+		Name:      item.GetName(),
+		Namespace: item.GetNamespace(),
+		Version:   hostedClusterVersion(item),
+	}
+	for _, condition := range ReadConditions(item) {
+		switch condition.Type {
+		case "Available":
+			info.Available = condition.Status == "True"
+		case "Progressing":
+			info.Progressing = condition.Status == "True"
+		default:
+			continue
+		}
+		info.Conditions = append(info.Conditions, condition)
+	}
+	return info
+}
+
+// convertNodePool extracts the replicas and readiness of a NodePool, missing fields are
+// left unset. The last progress of the pool is the latest transition of its conditions,
+// falling back to its creation.
+func convertNodePool(item unstructured.Unstructured, now time.Time, stuckAfter time.Duration) NodePoolStatus {
+	pool := NodePoolStatus{Name: item.GetName()}
+	if replicas, found, err := unstructured.NestedInt64(item.Object, "spec", "replicas"); found && err == nil {
+		pool.Desired = &replicas
+	}
+	if minReplicas, found, err := unstructured.NestedInt64(item.Object, "spec", "autoScaling", "min"); found && err == nil {
+		pool.MinReplicas = &minReplicas
+	}
+	if maxReplicas, found, err := unstructured.NestedInt64(item.Object, "spec", "autoScaling", "max"); found && err == nil {
+		pool.MaxReplicas = &maxReplicas
+	}
+	pool.Current, _, _ = unstructured.NestedInt64(item.Object, "status", "replicas")
+
+	switch {
+	case pool.Desired != nil:
+		pool.Scaling = pool.Current != *pool.Desired
+	case pool.MinReplicas != nil && pool.MaxReplicas != nil:
+		pool.Scaling = pool.Current < *pool.MinReplicas || pool.Current > *pool.MaxReplicas
+	}
+
+	created := item.GetCreationTimestamp().Time
+	pool.LastProgress = &created
+	for _, condition := range ReadConditions(item) {
+		if condition.Type == "Ready" {
+			pool.Ready = condition.Status == "True"
+		}
+		if transition, err := time.Parse(time.RFC3339, condition.LastTransitionTime); err == nil && transition.After(*pool.LastProgress) {
+			pool.LastProgress = &transition
+		}
+	}
+	pool.Stuck = pool.Scaling && now.Sub(*pool.LastProgress) > stuckAfter
+	return pool
+}
+
+// hostedClusterVersion returns the version of the last completed update in
+// status.version.history, or the desired version while the first rollout is in progress
+func hostedClusterVersion(item unstructured.Unstructured) string {
+	history, _, _ := unstructured.NestedSlice(item.Object, "status", "version", "history")
+	for _, entry := range history {
+		fields, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if state, _, _ := unstructured.NestedString(fields, "state"); state == "Completed" {
+			version, _, _ := unstructured.NestedString(fields, "version")
+			return version
+		}
+	}
+	version, _, _ := unstructured.NestedString(item.Object, "status", "version", "desired", "version")
+	return version
+}
+
+// Made with Bob
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+type HCPSuite struct {
+	suite.Suite
+}
+
+// hostedCluster returns a HostedCluster in the clusters namespace with the given Available status
+func hostedCluster(name, available string, history ...interface{}) *unstructured.Unstructured {
+	hc := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	hc.SetAPIVersion("hypershift.openshift.io/v1beta1")
+	hc.SetKind("HostedCluster")
+	hc.SetNamespace("clusters")
+	hc.SetName(name)
+	_ = unstructured.SetNestedSlice(hc.Object, []interface{}{
+		map[string]interface{}{"type": "Available", "status": available},
+		map[string]interface{}{"type": "Progressing", "status": "False"},
+		map[string]interface{}{"type": "ReconciliationSucceeded", "status": "True"},
+	}, "status", "conditions")
+	if len(history) > 0 {
+		_ = unstructured.SetNestedSlice(hc.Object, history, "status", "version", "history")
+	}
+	return hc
+}
+
+// nodePool returns a NodePool of the named HostedCluster
+func nodePool(name, clusterName string) *unstructured.Unstructured {
+	pool := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	pool.SetAPIVersion("hypershift.openshift.io/v1beta1")
+	pool.SetKind("NodePool")
+	pool.SetNamespace("clusters")
+	pool.SetName(name)
+	_ = unstructured.SetNestedField(pool.Object, clusterName, "spec", "clusterName")
+	return pool
+}
+
+// scalingNodePool returns a NodePool with desired and current replicas whose Ready
+// condition last changed age ago
+func scalingNodePool(desired, current int64, age time.Duration) unstructured.Unstructured {
+	pool := nodePool("workers", "tenant-a")
+	pool.SetCreationTimestamp(metav1.NewTime(time.Now().Add(-24 * time.Hour)))
+	_ = unstructured.SetNestedField(pool.Object, desired, "spec", "replicas")
+	_ = unstructured.SetNestedField(pool.Object, current, "status", "replicas")
+	_ = unstructured.SetNestedSlice(pool.Object, []interface{}{
+		map[string]interface{}{"type": "Ready", "status": "True", "lastTransitionTime": time.Now().Add(-age).UTC().Format(time.RFC3339)},
+	}, "status", "conditions")
+	return *pool
+}
+
+func (s *HCPSuite) TestListHostedClusters() {
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{HostedClusterGVR: "HostedClusterList", NodePoolGVR: "NodePoolList"},
+		hostedCluster("tenant-b", "False"),
+		hostedCluster("tenant-a", "True",
+			map[string]interface{}{"state": "Partial", "version": "4.17.2"},
+			map[string]interface{}{"state": "Completed", "version": "4.17.1"},
+		),
+		nodePool("tenant-a-workers", "tenant-a"),
+		nodePool("tenant-a-gpu", "tenant-a"),
+	)
+	service := NewHCPService()
+
+	list, err := service.listHostedClusters(context.Background(), dynamicClient, true)
+	s.Require().NoError(err)
+
+	s.Run("counts available and degraded hosted clusters", func() {
+		s.Equal(2, list.Total)
+		s.Equal(1, list.Available)
+		s.Equal(1, list.Degraded)
+		s.False(list.Ready)
+		s.Equal("1 of 2 HostedClusters available", list.Message)
+	})
+	s.Run("reports version, node pools and the Available and Progressing conditions", func() {
+		s.Equal("tenant-a", list.HostedClusters[0].Name)
+		s.Equal("4.17.1", list.HostedClusters[0].Version)
+		s.Equal(2, list.HostedClusters[0].NodePoolCount)
+		s.True(list.HostedClusters[0].Available)
+		s.False(list.HostedClusters[0].Progressing)
+		s.Len(list.HostedClusters[0].Conditions, 2)
+		s.Equal("tenant-a-gpu", list.HostedClusters[0].NodePools[0].Name)
+		s.Equal(0, list.HostedClusters[1].NodePoolCount)
+	})
+	s.Run("no node pools are counted without the NodePool CRD", func() {
+		list, err := service.listHostedClusters(context.Background(), dynamicClient, false)
+		s.Require().NoError(err)
+		s.Equal(0, list.HostedClusters[0].NodePoolCount)
+	})
+}
+
+func (s *HCPSuite) TestConvertNodePool() {
+	now := time.Now()
+
+	s.Run("replicas at the desired count are not scaling", func() {
+		pool := convertNodePool(scalingNodePool(3, 3, 2*time.Hour), now, DefaultNodePoolStuckAfter)
+		s.Equal(int64(3), *pool.Desired)
+		s.Equal(int64(3), pool.Current)
+		s.True(pool.Ready)
+		s.False(pool.Scaling)
+		s.False(pool.Stuck)
+	})
+	s.Run("scaling with recent progress is not stuck", func() {
+		pool := convertNodePool(scalingNodePool(5, 3, 5*time.Minute), now, DefaultNodePoolStuckAfter)
+		s.True(pool.Scaling)
+		s.False(pool.Stuck)
+	})
+	s.Run("scaling without progress for the stuck duration is stuck", func() {
+		pool := convertNodePool(scalingNodePool(5, 3, 45*time.Minute), now, DefaultNodePoolStuckAfter)
+		s.True(pool.Stuck)
+		s.False(convertNodePool(scalingNodePool(5, 3, 45*time.Minute), now, time.Hour).Stuck)
+	})
+	s.Run("autoscaled pools scale only outside their bounds", func() {
+		item := *nodePool("autoscaled", "tenant-a")
+		item.SetCreationTimestamp(metav1.NewTime(now.Add(-time.Hour)))
+		_ = unstructured.SetNestedField(item.Object, int64(2), "spec", "autoScaling", "min")
+		_ = unstructured.SetNestedField(item.Object, int64(6), "spec", "autoScaling", "max")
+		_ = unstructured.SetNestedField(item.Object, int64(4), "status", "replicas")
+		pool := convertNodePool(item, now, DefaultNodePoolStuckAfter)
+		s.Nil(pool.Desired)
+		s.False(pool.Scaling)
+
+		_ = unstructured.SetNestedField(item.Object, int64(1), "status", "replicas")
+		s.True(convertNodePool(item, now, DefaultNodePoolStuckAfter).Stuck)
+	})
+	s.Run("missing fields are left unset", func() {
+		pool := convertNodePool(*nodePool("new", "tenant-a"), now, DefaultNodePoolStuckAfter)
+		s.Nil(pool.Desired)
+		s.Equal(int64(0), pool.Current)
+		s.False(pool.Ready)
+		s.False(pool.Scaling)
+	})
+}
+
+func TestHCPSuite(t *testing.T) {
+	suite.Run(t, new(HCPSuite))
+}
+
+// Made with Bob
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	// CreatedByAnnotation marks the resources created by the Fusion tools
+	CreatedByAnnotation = "fusion.mcp.ibm.com/created-by"
+	// CreatedByValue is the value of CreatedByAnnotation
+	CreatedByValue = "fusion-mcp-server"
+	// IdempotencyKeyLabel carries the caller's idempotency key, a label so that retries
+	// find the earlier resource with a label selector
+	IdempotencyKeyLabel = "fusion.mcp.ibm.com/idempotency-key"
+)
+
+// validateIdempotencyKey checks that a key can be stored as a label value
+func validateIdempotencyKey(key string) error {
+	if key == "" {
+		return nil
+	}
+	if errs := validation.IsValidLabelValue(key); len(errs) > 0 {
+		return fmt.Errorf("invalid idempotencyKey %q: %s", key, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// stampCreated annotates a resource as created by the Fusion tools and labels it with
+// the idempotency key when there is one
+func stampCreated(obj *unstructured.Unstructured, idempotencyKey string) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[CreatedByAnnotation] = CreatedByValue
+	obj.SetAnnotations(annotations)
+	if idempotencyKey != "" {
+		labels := obj.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[IdempotencyKeyLabel] = idempotencyKey
+		obj.SetLabels(labels)
+	}
+}
+
+// findIdempotent returns the resource an earlier call created with the idempotency key,
+// nil when there is none or no key. The earlier resource must have the same value for
+// every field of spec, a different one is a conflict. Fields the server filled in are
+// not compared.
+func findIdempotent(ctx context.Context, resource dynamic.ResourceInterface, gvr schema.GroupVersionResource, idempotencyKey string, spec map[string]interface{}) (*unstructured.Unstructured, error) {
+	if idempotencyKey == "" {
+		return nil, nil
+	}
+	list, err := resource.List(ctx, metav1.ListOptions{LabelSelector: IdempotencyKeyLabel + "=" + idempotencyKey})
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up idempotency key %s: %w", idempotencyKey, err)
+	}
+	if len(list.Items) == 0 {
+		return nil, nil
+	}
+	existing := &list.Items[0]
+
+	existingSpec, _, _ := unstructured.NestedMap(existing.Object, "spec")
+	fields := make([]string, 0, len(spec))
+	for field := range spec {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		if !reflect.DeepEqual(spec[field], existingSpec[field]) {
+			return nil, apierrors.NewConflict(gvr.GroupResource(), existing.GetName(),
+				fmt.Errorf("idempotency key %s was already used with a different spec.%s", idempotencyKey, field))
+		}
+	}
+	return existing, nil
+}
+
+// Made with Bob
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+type IdempotencySuite struct {
+	suite.Suite
+}
+
+// keyedBackup returns a Velero Backup of namespace shop created with an idempotency key
+func keyedBackup(name, key string) *unstructured.Unstructured {
+	backup := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "velero.io/v1",
+		"kind":       "Backup",
+		"metadata":   map[string]interface{}{"name": name, "namespace": OADPNamespace},
+		"spec": map[string]interface{}{
+			"includedNamespaces": []interface{}{"shop"},
+			"ttl":                "168h0m0s",
+			// Filled in by Velero, not part of the request
+			"csiSnapshotTimeout": "10m0s",
+		},
+	}}
+	stampCreated(backup, key)
+	return backup
+}
+
+func (s *IdempotencySuite) TestFindIdempotent() {
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{VeleroBackupGVR: "BackupList"},
+		keyedBackup("shop-20260101120000", "nightly-shop"), keyedBackup("other", ""))
+	var selectors []string
+	dynamicClient.PrependReactor("list", "backups", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		selectors = append(selectors, action.(k8stesting.ListActionImpl).GetListRestrictions().Labels.String())
+		return false, nil, nil
+	})
+	backups := dynamicClient.Resource(VeleroBackupGVR).Namespace(OADPNamespace)
+	spec := map[string]interface{}{"includedNamespaces": []interface{}{"shop"}, "ttl": "168h0m0s"}
+
+	s.Run("returns the resource created with the key", func() {
+		existing, err := findIdempotent(context.Background(), backups, VeleroBackupGVR, "nightly-shop", spec)
+		s.Require().NoError(err)
+		s.Require().NotNil(existing)
+		s.Equal("shop-20260101120000", existing.GetName())
+	})
+	s.Run("looks the key up with a label selector", func() {
+		s.Contains(selectors, IdempotencyKeyLabel+"=nightly-shop")
+	})
+	s.Run("an unused key finds nothing", func() {
+		existing, err := findIdempotent(context.Background(), backups, VeleroBackupGVR, "weekly-shop", spec)
+		s.NoError(err)
+		s.Nil(existing)
+	})
+	s.Run("a different spec is a conflict", func() {
+		different := map[string]interface{}{"includedNamespaces": []interface{}{"shop"}, "ttl": "720h0m0s"}
+		_, err := findIdempotent(context.Background(), backups, VeleroBackupGVR, "nightly-shop", different)
+		s.Require().Error(err)
+		s.True(apierrors.IsConflict(err))
+		s.Contains(err.Error(), "idempotency key nightly-shop was already used with a different spec.ttl")
+	})
+	s.Run("without a key nothing is looked up", func() {
+		selectors = nil
+		existing, err := findIdempotent(context.Background(), backups, VeleroBackupGVR, "", spec)
+		s.NoError(err)
+		s.Nil(existing)
+		s.Empty(selectors)
+	})
+}
+
+func (s *IdempotencySuite) TestStampCreated() {
+	backup := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	backup.SetAnnotations(map[string]string{"note": "kept"})
+	stampCreated(backup, "nightly-shop")
+	s.Equal(map[string]string{"note": "kept", CreatedByAnnotation: CreatedByValue}, backup.GetAnnotations())
+	s.Equal(map[string]string{IdempotencyKeyLabel: "nightly-shop"}, backup.GetLabels())
+
+	unkeyed := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	stampCreated(unkeyed, "")
+	s.Empty(unkeyed.GetLabels())
+}
+
+func (s *IdempotencySuite) TestValidateIdempotencyKey() {
+	s.NoError(validateIdempotencyKey(""))
+	s.NoError(validateIdempotencyKey("retry-7f3a"))
+	s.ErrorContains(validateIdempotencyKey("not a label value"), `invalid idempotencyKey "not a label value"`)
+}
+
+func TestIdempotencySuite(t *testing.T) {
+	suite.Run(t, new(IdempotencySuite))
+}
+
+// Made with Bob
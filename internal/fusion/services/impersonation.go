@@ -0,0 +1,42 @@
+package services
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
+)
+
+// impersonationAllowed gates the impersonateUser and impersonateGroups target options,
+// impersonation is privileged so it is off unless the Fusion config allows it
+var impersonationAllowed atomic.Bool
+
+// SetImpersonationAllowed allows or forbids targets that impersonate a user
+func SetImpersonationAllowed(allowed bool) {
+	impersonationAllowed.Store(allowed)
+}
+
+// ImpersonationAllowed reports whether targets may impersonate a user
+func ImpersonationAllowed() bool {
+	return impersonationAllowed.Load()
+}
+
+// checkImpersonation returns an error when the target impersonates while it is not allowed
+func checkImpersonation(target targeting.Target) error {
+	if target.Impersonates() && !ImpersonationAllowed() {
+		return fmt.Errorf("impersonation is disabled, set allowImpersonation in the Fusion config or FUSION_ALLOW_IMPERSONATION=true")
+	}
+	return nil
+}
+
+// clientFor returns the client operations of the target run with: the registered client,
+// or one derived from it acting as the impersonated user
+func clientFor(target targeting.Target, client *clients.ClusterClient) (*clients.ClusterClient, error) {
+	if !target.Impersonates() {
+		return client, nil
+	}
+	return client.Impersonate(target.ImpersonateUser, target.ImpersonateGroups)
+}
+
+// Made with Bob
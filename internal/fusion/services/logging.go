@@ -0,0 +1,24 @@
+package services
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"k8s.io/klog/v2"
+)
+
+// clusterKey is the structured logging key carrying the cluster name
+const clusterKey = "cluster"
+
+// LoggerFrom returns the logger carried by ctx, klog's global logger when it has none.
+// ExecuteOnClusters hands every operation a logger tagged with its cluster name.
+func LoggerFrom(ctx context.Context) logr.Logger {
+	return klog.FromContext(ctx)
+}
+
+// logDegraded logs an error a service reports in its output rather than failing on
+func logDegraded(ctx context.Context, err error, msg string, keysAndValues ...interface{}) {
+	LoggerFrom(ctx).V(2).Info(msg, append(keysAndValues, "err", err)...)
+}
+
+// Made with Bob
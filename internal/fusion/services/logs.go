@@ -0,0 +1,266 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// DefaultLogTailLines is how many lines are read from each container when none is requested
+	DefaultLogTailLines = 100
+	// MaxLogTailLines caps the lines read from each container
+	MaxLogTailLines = 2000
+	// DefaultLogMaxBytes caps the log bytes returned per cluster when no cap is requested
+	DefaultLogMaxBytes = 64 * 1024
+	// MaxLogMaxBytes caps the requested byte cap
+	MaxLogMaxBytes = 1024 * 1024
+	// maxLogContainers caps the containers logs are read from per cluster
+	maxLogContainers = 20
+)
+
+// LogComponent locates the pods of a known component: the first existing namespace of
+// Namespaces and the pods there matching Selector
+type LogComponent struct {
+	Namespaces []string
+	Selector   string
+}
+
+// LogComponents are the components fusion.logs.tail reads the logs of by name
+var LogComponents = map[string]LogComponent{
+	"odf-operator":       {Namespaces: DataFoundationNamespaces, Selector: "app=odf-operator"},
+	"ocs-operator":       {Namespaces: DataFoundationNamespaces, Selector: "name=ocs-operator"},
+	"rook-ceph-operator": {Namespaces: DataFoundationNamespaces, Selector: "app=rook-ceph-operator"},
+	"velero":             {Namespaces: []string{OADPNamespace}, Selector: "component=velero"},
+}
+
+// LogComponentNames returns the names of LogComponents, sorted
+func LogComponentNames() []string {
+	names := make([]string, 0, len(LogComponents))
+	for name := range LogComponents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LogsRequest selects the pods and containers to read the last log lines of: the pods
+// of a known Component, or of a Namespace matching a LabelSelector. Namespace overrides
+// the namespaces a component is looked for in.
+type LogsRequest struct {
+	Component     string `json:"component,omitempty"`
+	Namespace     string `json:"namespace,omitempty"`
+	LabelSelector string `json:"labelSelector,omitempty"`
+	Container     string `json:"container,omitempty"`
+	TailLines     int64  `json:"tailLines,omitempty"`
+	SinceSeconds  int64  `json:"sinceSeconds,omitempty"`
+	MaxBytes      int    `json:"maxBytes,omitempty"`
+}
+
+// Validate checks the request selects pods and its limits are in range, filling the
+// default limits
+func (r *LogsRequest) Validate() error {
+	if r.Component != "" {
+		if _, ok := LogComponents[r.Component]; !ok {
+			return fmt.Errorf("unknown component %s, known components: %s", r.Component, strings.Join(LogComponentNames(), ", "))
+		}
+		if r.LabelSelector != "" {
+			return fmt.Errorf("component and labelSelector cannot both be set")
+		}
+	} else if r.Namespace == "" || r.LabelSelector == "" {
+		return fmt.Errorf("component, or namespace and labelSelector, required")
+	}
+	switch {
+	case r.TailLines < 0:
+		return fmt.Errorf("tailLines cannot be negative: %d", r.TailLines)
+	case r.TailLines > MaxLogTailLines:
+		return fmt.Errorf("tailLines cannot exceed %d: %d", MaxLogTailLines, r.TailLines)
+	case r.SinceSeconds < 0:
+		return fmt.Errorf("sinceSeconds cannot be negative: %d", r.SinceSeconds)
+	case r.MaxBytes < 0:
+		return fmt.Errorf("maxBytes cannot be negative: %d", r.MaxBytes)
+	case r.MaxBytes > MaxLogMaxBytes:
+		return fmt.Errorf("maxBytes cannot exceed %d: %d", MaxLogMaxBytes, r.MaxBytes)
+	}
+	if r.TailLines == 0 {
+		r.TailLines = DefaultLogTailLines
+	}
+	if r.MaxBytes == 0 {
+		r.MaxBytes = DefaultLogMaxBytes
+	}
+	return nil
+}
+
+// ContainerLog holds the last log lines of one container. Truncated is set when the
+// oldest lines were dropped to stay within the byte cap, Error when the log could not
+// be read, e.g. for a container that has not started.
+type ContainerLog struct {
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	Phase     string `json:"phase"`
+	Restarts  int32  `json:"restarts"`
+	Log       string `json:"log,omitempty"`
+	Truncated bool   `json:"truncated,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// PodLogs holds the logs of the pods a LogsRequest selected on a cluster. Pods counts
+// every matching pod, Logs covers at most 20 containers. Bytes is the log returned,
+// at most MaxBytes shared evenly between the containers.
+type PodLogs struct {
+	Component     string         `json:"component,omitempty"`
+	Namespace     string         `json:"namespace"`
+	LabelSelector string         `json:"labelSelector"`
+	Pods          int            `json:"pods"`
+	Logs          []ContainerLog `json:"logs"`
+	Bytes         int            `json:"bytes"`
+	MaxBytes      int            `json:"maxBytes"`
+	Truncated     bool           `json:"truncated"`
+	Message       string         `json:"message,omitempty"`
+}
+
+// TailLogs reads the last lines of the logs of the containers a request selects. The
+// newest lines are kept when a log exceeds its share of the byte cap, so the evidence
+// closest to a failure survives the truncation.
+func (s *ServiceabilityService) TailLogs(ctx context.Context, client *clients.ClusterClient, request LogsRequest) (*PodLogs, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	result := &PodLogs{Component: request.Component, Namespace: request.Namespace, LabelSelector: request.LabelSelector, MaxBytes: request.MaxBytes, Logs: []ContainerLog{}}
+	if component, ok := LogComponents[request.Component]; ok {
+		namespace, reason := findNamespace(ctx, client, request.Namespace, component.Namespaces...)
+		if namespace == "" {
+			return nil, fmt.Errorf("component %s not found, none of namespaces %s is readable (%s)",
+				request.Component, strings.Join(ComponentNamespaces(request.Namespace, component.Namespaces...), ", "), reason)
+		}
+		result.Namespace, result.LabelSelector = namespace, component.Selector
+	}
+	if !NamespaceAllowed(result.Namespace) {
+		return nil, fmt.Errorf("namespace %s is outside the allowed namespaces (scope restricted)", result.Namespace)
+	}
+
+	// Pods in a stable order, so repeated calls read the same containers
+	var pods []corev1.Pod
+	err := listPages(ctx, metav1.ListOptions{LabelSelector: result.LabelSelector}, func(opts metav1.ListOptions) (string, error) {
+		list, err := client.Clientset.CoreV1().Pods(result.Namespace).List(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		pods = append(pods, list.Items...)
+		return list.Continue, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods in %s: %w", result.Namespace, err)
+	}
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+	result.Pods = len(pods)
+
+	for _, pod := range pods {
+		for _, container := range pod.Spec.Containers {
+			if request.Container == "" || container.Name == request.Container {
+				result.Logs = append(result.Logs, ContainerLog{Pod: pod.Name, Container: container.Name, Phase: string(pod.Status.Phase), Restarts: containerRestarts(pod, container.Name)})
+			}
+		}
+	}
+	switch {
+	case len(pods) == 0:
+		result.Message = fmt.Sprintf("no pods match %s in %s", result.LabelSelector, result.Namespace)
+		return result, nil
+	case len(result.Logs) == 0:
+		result.Message = fmt.Sprintf("no container %s in the %d matching pods", request.Container, len(pods))
+		return result, nil
+	case len(result.Logs) > maxLogContainers:
+		result.Message = fmt.Sprintf("logs of the first %d of %d containers", maxLogContainers, len(result.Logs))
+		result.Logs = result.Logs[:maxLogContainers]
+		result.Truncated = true
+	}
+
+	share := request.MaxBytes / len(result.Logs)
+	for i := range result.Logs {
+		log := &result.Logs[i]
+		content, truncated, err := s.readLog(ctx, client, result.Namespace, log.Pod, &corev1.PodLogOptions{
+			Container:    log.Container,
+			TailLines:    ptr.To(request.TailLines),
+			SinceSeconds: sinceSeconds(request.SinceSeconds),
+		}, share)
+		if err != nil {
+			log.Error = err.Error()
+			continue
+		}
+		log.Log, log.Truncated = content, truncated
+		result.Bytes += len(content)
+		result.Truncated = result.Truncated || truncated
+	}
+	if result.Truncated && result.Message == "" {
+		result.Message = fmt.Sprintf("oldest lines dropped to stay within %d bytes, narrow with container, tailLines or sinceSeconds", request.MaxBytes)
+	}
+	return result, nil
+}
+
+// readLog reads the log of a container, keeping its newest limit bytes from a line
+// boundary on. It reports whether older lines were dropped.
+func (s *ServiceabilityService) readLog(ctx context.Context, client *clients.ClusterClient, namespace, pod string, options *corev1.PodLogOptions, limit int) (string, bool, error) {
+	stream, err := client.Clientset.CoreV1().Pods(namespace).GetLogs(pod, options).Stream(ctx)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read logs of %s/%s: %w", pod, options.Container, err)
+	}
+	defer func() { _ = stream.Close() }()
+
+	// Only the newest bytes are held, however long the log is
+	var content []byte
+	truncated := false
+	chunk := make([]byte, 32*1024)
+	for {
+		n, err := stream.Read(chunk)
+		content = append(content, chunk[:n]...)
+		if len(content) > 2*limit {
+			content = append(content[:0], content[len(content)-limit:]...)
+			truncated = true
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", false, fmt.Errorf("failed to read logs of %s/%s: %w", pod, options.Container, err)
+		}
+	}
+	if len(content) > limit {
+		content = content[len(content)-limit:]
+		truncated = true
+	}
+	if truncated {
+		if newline := bytes.IndexByte(content, '\n'); newline >= 0 {
+			content = content[newline+1:]
+		}
+	}
+	return string(content), truncated, nil
+}
+
+// containerRestarts returns the restart count of a container of a pod
+func containerRestarts(pod corev1.Pod, container string) int32 {
+	index := slices.IndexFunc(pod.Status.ContainerStatuses, func(status corev1.ContainerStatus) bool { return status.Name == container })
+	if index < 0 {
+		return 0
+	}
+	return pod.Status.ContainerStatuses[index].RestartCount
+}
+
+// sinceSeconds returns the sinceSeconds log option, nil when unset
+func sinceSeconds(seconds int64) *int64 {
+	if seconds == 0 {
+		return nil
+	}
+	return ptr.To(seconds)
+}
+
+// Made with Bob
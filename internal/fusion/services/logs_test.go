@@ -0,0 +1,126 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type LogsSuite struct {
+	suite.Suite
+}
+
+func (s *LogsSuite) TearDownTest() {
+	SetAllowedNamespaces(nil)
+}
+
+// logPod returns a running pod with the given labels and containers
+func logPod(namespace, name string, labels map[string]string, containers ...string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	for _, container := range containers {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: container})
+		pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, corev1.ContainerStatus{Name: container, RestartCount: 3})
+	}
+	return pod
+}
+
+func (s *LogsSuite) TestValidate() {
+	for _, tc := range []struct {
+		name    string
+		request LogsRequest
+		err     string
+	}{
+		{"nothing selected", LogsRequest{}, "component, or namespace and labelSelector, required"},
+		{"namespace without selector", LogsRequest{Namespace: "apps"}, "component, or namespace and labelSelector, required"},
+		{"unknown component", LogsRequest{Component: "odf"}, "unknown component odf, known components: ocs-operator, odf-operator, rook-ceph-operator, velero"},
+		{"component and selector", LogsRequest{Component: "velero", LabelSelector: "app=web"}, "component and labelSelector cannot both be set"},
+		{"too many lines", LogsRequest{Component: "velero", TailLines: MaxLogTailLines + 1}, "tailLines cannot exceed 2000: 2001"},
+		{"negative since", LogsRequest{Component: "velero", SinceSeconds: -1}, "sinceSeconds cannot be negative: -1"},
+		{"too many bytes", LogsRequest{Component: "velero", MaxBytes: MaxLogMaxBytes + 1}, "maxBytes cannot exceed 1048576: 1048577"},
+	} {
+		s.Run(tc.name, func() {
+			s.EqualError(tc.request.Validate(), tc.err)
+		})
+	}
+	s.Run("fills the default limits", func() {
+		request := LogsRequest{Namespace: "apps", LabelSelector: "app=web"}
+		s.Require().NoError(request.Validate())
+		s.Equal(int64(DefaultLogTailLines), request.TailLines)
+		s.Equal(DefaultLogMaxBytes, request.MaxBytes)
+	})
+}
+
+func (s *LogsSuite) TestTailLogs() {
+	operator := map[string]string{"app": "odf-operator"}
+	client := &clients.ClusterClient{Name: "prod-1", Clientset: fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "openshift-storage"}},
+		logPod("openshift-storage", "odf-operator-b", operator, "manager", "kube-rbac-proxy"),
+		logPod("openshift-storage", "odf-operator-a", operator, "manager"),
+		logPod("openshift-storage", "rook-ceph-operator-a", map[string]string{"app": "rook-ceph-operator"}, "rook-ceph-operator"),
+	)}
+	service := NewServiceabilityService()
+	ctx := context.Background()
+
+	s.Run("reads every container of the component pods", func() {
+		logs, err := service.TailLogs(ctx, client, LogsRequest{Component: "odf-operator"})
+		s.Require().NoError(err)
+		s.Equal("openshift-storage", logs.Namespace)
+		s.Equal("app=odf-operator", logs.LabelSelector)
+		s.Equal(2, logs.Pods)
+		s.Require().Len(logs.Logs, 3)
+		s.Equal(ContainerLog{Pod: "odf-operator-a", Container: "manager", Phase: "Running", Restarts: 3, Log: "fake logs"}, logs.Logs[0])
+		s.Equal("kube-rbac-proxy", logs.Logs[2].Container)
+		s.Equal(27, logs.Bytes)
+		s.False(logs.Truncated)
+	})
+	s.Run("reads one container", func() {
+		logs, err := service.TailLogs(ctx, client, LogsRequest{Namespace: "openshift-storage", LabelSelector: "app=odf-operator", Container: "kube-rbac-proxy"})
+		s.Require().NoError(err)
+		s.Require().Len(logs.Logs, 1)
+		s.Equal("odf-operator-b", logs.Logs[0].Pod)
+	})
+	s.Run("keeps the newest bytes within the cap", func() {
+		logs, err := service.TailLogs(ctx, client, LogsRequest{Component: "odf-operator", MaxBytes: 12})
+		s.Require().NoError(err)
+		s.True(logs.Truncated)
+		s.Equal("logs", logs.Logs[0].Log, "each container gets a third of the cap")
+		s.True(logs.Logs[0].Truncated)
+		s.Equal(12, logs.Bytes)
+		s.Contains(logs.Message, "oldest lines dropped to stay within 12 bytes")
+	})
+	s.Run("reports a container no pod has", func() {
+		logs, err := service.TailLogs(ctx, client, LogsRequest{Component: "odf-operator", Container: "sidecar"})
+		s.Require().NoError(err)
+		s.Empty(logs.Logs)
+		s.Equal("no container sidecar in the 2 matching pods", logs.Message)
+	})
+	s.Run("reports a selector matching no pod", func() {
+		logs, err := service.TailLogs(ctx, client, LogsRequest{Namespace: "openshift-storage", LabelSelector: "app=noobaa"})
+		s.Require().NoError(err)
+		s.Zero(logs.Pods)
+		s.Equal("no pods match app=noobaa in openshift-storage", logs.Message)
+	})
+	s.Run("fails for a component that is not installed", func() {
+		_, err := service.TailLogs(ctx, client, LogsRequest{Component: "velero"})
+		s.EqualError(err, "component velero not found, none of namespaces openshift-adp is readable (namespace-absent)")
+	})
+	s.Run("stays within the allowed namespaces", func() {
+		SetAllowedNamespaces([]string{"openshift-adp"})
+		_, err := service.TailLogs(ctx, client, LogsRequest{Namespace: "openshift-storage", LabelSelector: "app=odf-operator"})
+		s.ErrorContains(err, "scope restricted")
+	})
+}
+
+func TestLogsSuite(t *testing.T) {
+	suite.Run(t, new(LogsSuite))
+}
+
+// Made with Bob
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+type MigrationsSuite struct {
+	suite.Suite
+}
+
+// liveMigration returns a VirtualMachineInstanceMigration created and started age ago from
+// source to target
+func liveMigration(name, phase, source, target string, age time.Duration) *unstructured.Unstructured {
+	migration := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	migration.SetAPIVersion("kubevirt.io/v1")
+	migration.SetKind("VirtualMachineInstanceMigration")
+	migration.SetNamespace("vms")
+	migration.SetName(name)
+	migration.SetCreationTimestamp(metav1.NewTime(time.Now().Add(-age)))
+	_ = unstructured.SetNestedField(migration.Object, name+"-vmi", "spec", "vmiName")
+	if phase != "" {
+		_ = unstructured.SetNestedField(migration.Object, phase, "status", "phase")
+	}
+	if source != "" {
+		_ = unstructured.SetNestedField(migration.Object, source, "status", "migrationState", "sourceNode")
+		_ = unstructured.SetNestedField(migration.Object, target, "status", "migrationState", "targetNode")
+		_ = unstructured.SetNestedField(migration.Object, time.Now().Add(-age).UTC().Format(time.RFC3339), "status", "migrationState", "startTimestamp")
+	}
+	return migration
+}
+
+func (s *MigrationsSuite) TestListMigrations() {
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{VirtualMachineInstanceMigrationGVR: "VirtualMachineInstanceMigrationList"},
+		liveMigration("db", "Running", "worker-1", "worker-2", time.Minute),
+		liveMigration("web", "Scheduling", "worker-3", "worker-1", 2*time.Minute),
+		liveMigration("queued", "", "", "", 0),
+		liveMigration("cache", "Succeeded", "worker-1", "worker-3", time.Hour),
+	)
+	service := NewVirtualizationService()
+
+	s.Run("lists migrations in progress, newest first", func() {
+		list, err := service.listMigrations(context.Background(), dynamicClient, MigrationFilter{})
+		s.Require().NoError(err)
+		s.Equal(3, list.Total)
+		s.Equal(3, list.Active)
+		s.Equal("db", list.Migrations[1].Name)
+		s.Equal("worker-2", list.Migrations[1].TargetNode)
+		s.Equal("db-vmi", list.Migrations[1].VMI)
+	})
+	s.Run("a migration without status is pending on no node yet", func() {
+		list, err := service.listMigrations(context.Background(), dynamicClient, MigrationFilter{})
+		s.Require().NoError(err)
+		s.Equal("queued", list.Migrations[0].Name)
+		s.Equal("Pending", list.Migrations[0].Phase)
+		s.Empty(list.Migrations[0].SourceNode)
+	})
+	s.Run("filters by source or target node", func() {
+		list, err := service.listMigrations(context.Background(), dynamicClient, MigrationFilter{Node: "worker-1"})
+		s.Require().NoError(err)
+		s.Equal(2, list.Active)
+		s.Contains(list.Message, "involving node worker-1")
+	})
+	s.Run("includes completed migrations on request", func() {
+		list, err := service.listMigrations(context.Background(), dynamicClient, MigrationFilter{Node: "worker-1", IncludeCompleted: true})
+		s.Require().NoError(err)
+		s.Equal(3, list.Total)
+		s.Equal(2, list.Active)
+		s.False(list.Migrations[2].Active)
+	})
+}
+
+func TestMigrationsSuite(t *testing.T) {
+	suite.Run(t, new(MigrationsSuite))
+}
+
+// Made with Bob
@@ -3,9 +3,19 @@ package services
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/yaml"
 )
 
 // GDPService provides Global Data Platform operations
@@ -13,71 +23,532 @@ type GDPService struct{}
 
 func NewGDPService() *GDPService { return &GDPService{} }
 
-func (s *GDPService) GetStatus(ctx context.Context, client *clients.ClusterClient) (*ComponentStatus, error) {
-	status := &ComponentStatus{}
+var (
+	// ScaleFilesystemGVR identifies IBM Storage Scale container native Filesystem resources
+	ScaleFilesystemGVR = schema.GroupVersionResource{
+		Group:    "scale.spectrum.ibm.com",
+		Version:  "v1beta1",
+		Resource: "filesystems",
+	}
+	// ScaleClusterGVR identifies IBM Storage Scale container native Cluster resources
+	ScaleClusterGVR = schema.GroupVersionResource{
+		Group:    "scale.spectrum.ibm.com",
+		Version:  "v1beta1",
+		Resource: "clusters",
+	}
+	// ClusterServiceVersionGVR identifies OLM ClusterServiceVersion resources
+	ClusterServiceVersionGVR = schema.GroupVersionResource{
+		Group:    "operators.coreos.com",
+		Version:  "v1alpha1",
+		Resource: "clusterserviceversions",
+	}
+)
+
+// GDPStatus reports IBM Storage Scale installation and filesystem health
+type GDPStatus struct {
+	ComponentStatus
+	Namespace    string          `json:"namespace,omitempty"`
+	ScaleCluster string          `json:"scaleCluster,omitempty"`
+	Filesystems  []GDPFilesystem `json:"filesystems,omitempty"`
+}
+
+// GDPFilesystem reports the health of a Storage Scale Filesystem
+type GDPFilesystem struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Healthy   bool   `json:"healthy"`
+	Message   string `json:"message,omitempty"`
+}
+
+// GDPNamespaces are the namespaces IBM Storage Scale is looked for in by default
+var GDPNamespaces = []string{"ibm-spectrum-scale", "ibm-gdp"}
+
+// GetStatus reports Storage Scale and its filesystems, looking for it in namespace when
+// set, in GDPNamespaces otherwise
+func (s *GDPService) GetStatus(ctx context.Context, client *clients.ClusterClient, namespace string) (*GDPStatus, error) {
+	status := &GDPStatus{}
 
 	// Check for IBM Spectrum Scale/GDP namespaces
-	gdpNamespaces := []string{"ibm-spectrum-scale", "ibm-gdp"}
-	for _, ns := range gdpNamespaces {
-		if CheckNamespaceExists(ctx, client, ns) {
-			status.Installed = true
-			status.Ready = true
-			status.Message = fmt.Sprintf("GDP found in namespace: %s", ns)
-			return status, nil
+	var reason NotInstalledReason
+	status.Namespace, reason = findNamespace(ctx, client, namespace, GDPNamespaces...)
+	if status.Namespace == "" {
+		status.ComponentStatus = NotInstalledStatus(reason, "GDP/Spectrum Scale not found")
+		return status, nil
+	}
+
+	status.Installed = true
+	status.Message = fmt.Sprintf("GDP found in namespace %s but no healthy filesystem found", status.Namespace)
+
+	if !CheckCRDExists(ctx, client, ScaleFilesystemGVR) {
+		status.Reason = ReasonCRDAbsent
+		status.Message = fmt.Sprintf("GDP found in namespace %s but Filesystem CRD not detected", status.Namespace)
+		return status, nil
+	}
+
+	dynamicClient, err := client.DynamicClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
+	if clusters, err := dynamicClient.Resource(ScaleClusterGVR).List(ctx, metav1.ListOptions{}); err == nil && len(clusters.Items) > 0 {
+		status.ScaleCluster = clusters.Items[0].GetName()
+	}
+
+	filesystems, scope, err := ListInScope(ctx, dynamicClient.Resource(ScaleFilesystemGVR), metav1.NamespaceAll, metav1.ListOptions{})
+	status.Scope = scope
+	if err != nil {
+		logDegraded(ctx, err, "Cannot list GDP Filesystems")
+		status.noteForbidden(err)
+		status.Message = fmt.Sprintf("GDP found in namespace %s but Filesystems cannot be listed: %v", status.Namespace, err)
+		return status, nil
+	}
+	if len(filesystems.Items) == 0 {
+		status.Reason = ReasonNoHealthyInstance
+		status.Message = fmt.Sprintf("GDP found in namespace %s but no Filesystem is defined", status.Namespace)
+		return status, nil
+	}
+
+	healthy := 0
+	for _, fs := range filesystems.Items {
+		filesystem := filesystemHealth(fs)
+		if filesystem.Healthy {
+			healthy++
 		}
+		if status.Version == "" {
+			status.Version, _, _ = unstructured.NestedString(fs.Object, "status", "version")
+		}
+		status.Filesystems = append(status.Filesystems, filesystem)
+	}
+
+	if version := s.operatorVersion(ctx, dynamicClient, status.Namespace); version != "" {
+		status.Version = version
+	}
+
+	status.Ready = healthy == len(status.Filesystems)
+	if healthy == 0 {
+		status.Reason = ReasonNoHealthyInstance
+	}
+	if status.Ready {
+		status.Message = fmt.Sprintf("GDP found in namespace %s with %d healthy filesystem(s)", status.Namespace, healthy)
+	} else {
+		status.Message = fmt.Sprintf("GDP found in namespace %s, %d of %d filesystem(s) healthy", status.Namespace, healthy, len(status.Filesystems))
 	}
 
-	*status = NotInstalledStatus("GDP/Spectrum Scale not found")
 	return status, nil
 }
 
+// filesystemHealth derives Filesystem health from its status conditions. A Filesystem
+// is healthy when it reports Healthy (or, on older operators, Success) and is not
+// reported as unmounted.
+func filesystemHealth(fs unstructured.Unstructured) GDPFilesystem {
+	filesystem := GDPFilesystem{
+		Name:      fs.GetName(),
+		Namespace: fs.GetNamespace(),
+	}
+
+	healthStatus, message, found := FindCondition(fs, "Healthy")
+	if !found {
+		healthStatus, message, found = FindCondition(fs, "Success")
+	}
+	if !found {
+		filesystem.Message = "no health condition reported"
+		return filesystem
+	}
+	filesystem.Healthy = healthStatus == "True"
+	filesystem.Message = message
+
+	if mounted, mountMessage, found := FindCondition(fs, "Mounted"); found && mounted != "True" {
+		filesystem.Healthy = false
+		filesystem.Message = mountMessage
+	}
+
+	return filesystem
+}
+
+// operatorVersion reads the Storage Scale operator version from its ClusterServiceVersion
+func (s *GDPService) operatorVersion(ctx context.Context, dynamicClient dynamic.Interface, namespace string) string {
+	return CSVVersion(ctx, dynamicClient, []string{"ibm-spectrum-scale-operator", namespace}, "ibm-spectrum-scale", "ibm-storage-scale")
+}
+
 // DRService provides Disaster Recovery operations
 type DRService struct{}
 
 func NewDRService() *DRService { return &DRService{} }
 
-func (s *DRService) GetStatus(ctx context.Context, client *clients.ClusterClient) (*ComponentStatus, error) {
-	status := &ComponentStatus{}
+// DRPolicyGVR identifies Ramen DRPolicy resources
+var DRPolicyGVR = schema.GroupVersionResource{
+	Group:    "ramendr.openshift.io",
+	Version:  "v1alpha1",
+	Resource: "drpolicies",
+}
+
+// RPOToleranceFactor is how many scheduling intervals a workload may lag behind
+// before it is reported as violating its RPO, matching the ODF DR sync delay warning
+const RPOToleranceFactor = 2
+
+// DRStatus reports Ramen DR installation and the DRPolicies visible on a cluster
+type DRStatus struct {
+	ComponentStatus
+	Policies  []DRPolicyStatus   `json:"policies,omitempty"`
+	Workloads []DRWorkloadStatus `json:"workloads,omitempty"`
+}
+
+// DRWorkloadStatus describes the replication lag of a workload protected by a
+// DRPlacementControl. LagSeconds is -1 when no sync has been recorded yet.
+type DRWorkloadStatus struct {
+	Name              string `json:"name"`
+	Namespace         string `json:"namespace"`
+	DRPolicy          string `json:"drPolicy"`
+	LastGroupSyncTime string `json:"lastGroupSyncTime,omitempty"`
+	LagSeconds        int64  `json:"lagSeconds"`
+	RPOCompliant      bool   `json:"rpoCompliant"`
+	Message           string `json:"message,omitempty"`
+}
+
+// DRPolicyStatus describes a DRPolicy and its current validation/sync condition
+type DRPolicyStatus struct {
+	Name               string   `json:"name"`
+	SchedulingInterval string   `json:"schedulingInterval,omitempty"`
+	DRClusters         []string `json:"drClusters"`
+	Validated          bool     `json:"validated"`
+	Condition          string   `json:"condition,omitempty"`
+	// Conditions are all the conditions the DRPolicy reports
+	Conditions []StatusCondition `json:"conditions,omitempty"`
+}
+
+// Key identifies a DRPolicy independently of the cluster it was read from, so the
+// same policy reported by several clusters can be counted once
+func (p DRPolicyStatus) Key() string {
+	clusters := append([]string(nil), p.DRClusters...)
+	sort.Strings(clusters)
+	return p.Name + "/" + strings.Join(clusters, ",")
+}
+
+func (s *DRService) GetStatus(ctx context.Context, client *clients.ClusterClient) (*DRStatus, error) {
+	status := &DRStatus{}
 
 	// Check for Metro DR or Regional DR CRDs
-	drGVRs := []schema.GroupVersionResource{
-		{Group: "ramendr.openshift.io", Version: "v1alpha1", Resource: "drpolicies"},
-		{Group: "ramendr.openshift.io", Version: "v1alpha1", Resource: "drclusters"},
+	drGVRs := CheckCRDsExist(ctx, client, []schema.GroupVersionResource{DRPolicyGVR, targeting.DRClusterGVR})
+	if drGVRs[DRPolicyGVR] || drGVRs[targeting.DRClusterGVR] {
+		status.Installed = true
+		status.Ready = true
+		status.Message = "DR CRDs found (Ramen DR)"
+	}
+
+	if !status.Installed {
+		status.ComponentStatus = NotInstalledStatus(ReasonCRDAbsent, "DR components not found")
+		return status, nil
+	}
+
+	// DRPolicies only exist on the hub, managed clusters just report the CRDs
+	if !drGVRs[DRPolicyGVR] {
+		return status, nil
+	}
+
+	policies, err := s.listPolicies(ctx, client)
+	if err != nil {
+		logDegraded(ctx, err, "Cannot list DRPolicies")
+		status.Message = fmt.Sprintf("DR CRDs found (Ramen DR) but DRPolicies cannot be listed: %v", err)
+		status.RaiseSeverity(SeverityUnknown)
+		return status, nil
+	}
+	status.Policies = policies
+
+	validated := 0
+	for _, policy := range policies {
+		if policy.Validated {
+			validated++
+		}
+	}
+	status.Message = fmt.Sprintf("DR CRDs found (Ramen DR), %d of %d DRPolicies validated", validated, len(policies))
+	if len(policies) > 0 {
+		status.AddConditions(policiesValidatedCondition(policies, validated))
+	}
+
+	workloads, scope, err := s.listWorkloads(ctx, client, policies, time.Now())
+	status.Scope = scope
+	if err != nil {
+		logDegraded(ctx, err, "Cannot list DRPlacementControls")
+		status.Message += fmt.Sprintf(", DRPlacementControls cannot be listed: %v", err)
+		status.RaiseSeverity(SeverityUnknown)
+		return status, nil
 	}
+	status.Workloads = workloads
 
-	for _, gvr := range drGVRs {
-		if CheckCRDExists(ctx, client, gvr) {
-			status.Installed = true
-			status.Ready = true
-			status.Message = "DR CRDs found (Ramen DR)"
-			return status, nil
+	violations := 0
+	for _, workload := range workloads {
+		if !workload.RPOCompliant {
+			violations++
 		}
 	}
+	if violations > 0 {
+		status.Message += fmt.Sprintf(", %d of %d protected workloads behind their RPO", violations, len(workloads))
+		status.RaiseSeverity(SeverityWarning)
+	}
 
-	*status = NotInstalledStatus("DR components not found")
 	return status, nil
 }
 
+// policiesValidatedCondition summarizes the Validated conditions of the DRPolicies
+func policiesValidatedCondition(policies []DRPolicyStatus, validated int) StatusCondition {
+	condition := StatusCondition{
+		Type:    "Validated",
+		Status:  "True",
+		Reason:  "AllDRPoliciesValidated",
+		Message: fmt.Sprintf("%d of %d DRPolicies validated", validated, len(policies)),
+	}
+	var invalid []string
+	for _, policy := range policies {
+		if !policy.Validated {
+			invalid = append(invalid, policy.Name)
+		}
+	}
+	if len(invalid) > 0 {
+		condition.Status = "False"
+		condition.Reason = "DRPolicyNotValidated"
+		condition.Message += fmt.Sprintf(", not validated: %s", strings.Join(invalid, ", "))
+	}
+	return condition
+}
+
+// listWorkloads reads the DRPlacementControls and measures how far each protected
+// workload's last group sync lags behind now, against its policy's scheduling interval
+func (s *DRService) listWorkloads(ctx context.Context, client *clients.ClusterClient, policies []DRPolicyStatus, now time.Time) ([]DRWorkloadStatus, string, error) {
+	dynamicClient, err := client.DynamicClient()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
+	list, scope, err := ListInScope(ctx, dynamicClient.Resource(targeting.DRPlacementControlGVR), metav1.NamespaceAll, metav1.ListOptions{})
+	if err != nil {
+		return nil, scope, err
+	}
+
+	intervals := make(map[string]string, len(policies))
+	for _, policy := range policies {
+		intervals[policy.Name] = policy.SchedulingInterval
+	}
+
+	workloads := make([]DRWorkloadStatus, 0, len(list.Items))
+	for _, item := range list.Items {
+		workload := DRWorkloadStatus{
+			Name:       item.GetName(),
+			Namespace:  item.GetNamespace(),
+			LagSeconds: -1,
+		}
+		workload.DRPolicy, _, _ = unstructured.NestedString(item.Object, "spec", "drPolicyRef", "name")
+		workload.LastGroupSyncTime, _, _ = unstructured.NestedString(item.Object, "status", "lastGroupSyncTime")
+		evaluateRPO(&workload, intervals[workload.DRPolicy], now)
+		workloads = append(workloads, workload)
+	}
+
+	sort.Slice(workloads, func(i, j int) bool {
+		if workloads[i].Namespace != workloads[j].Namespace {
+			return workloads[i].Namespace < workloads[j].Namespace
+		}
+		return workloads[i].Name < workloads[j].Name
+	})
+	return workloads, scope, nil
+}
+
+// evaluateRPO sets the lag and RPO compliance of a workload given its policy's
+// scheduling interval
+func evaluateRPO(workload *DRWorkloadStatus, schedulingInterval string, now time.Time) {
+	interval, err := parseSchedulingInterval(schedulingInterval)
+	if err != nil {
+		workload.Message = fmt.Sprintf("cannot evaluate RPO: %v", err)
+		return
+	}
+	if interval == 0 {
+		// Metro DR replicates synchronously, there is no group sync to lag behind
+		workload.LagSeconds = 0
+		workload.RPOCompliant = true
+		workload.Message = "synchronous replication"
+		return
+	}
+
+	if workload.LastGroupSyncTime == "" {
+		workload.Message = "no group sync recorded yet"
+		return
+	}
+	lastSync, err := time.Parse(time.RFC3339, workload.LastGroupSyncTime)
+	if err != nil {
+		workload.Message = fmt.Sprintf("invalid lastGroupSyncTime: %v", err)
+		return
+	}
+
+	lag := now.Sub(lastSync)
+	if lag < 0 {
+		lag = 0
+	}
+	workload.LagSeconds = int64(lag.Seconds())
+	workload.RPOCompliant = lag <= RPOToleranceFactor*interval
+	if !workload.RPOCompliant {
+		workload.Message = fmt.Sprintf("last sync %s ago exceeds %dx the %s scheduling interval",
+			lag.Round(time.Second), RPOToleranceFactor, schedulingInterval)
+	}
+}
+
+// parseSchedulingInterval parses a Ramen scheduling interval such as 5m, 1h or 1d
+// An empty interval is treated as synchronous replication
+func parseSchedulingInterval(interval string) (time.Duration, error) {
+	if interval == "" {
+		return 0, nil
+	}
+	if days, ok := strings.CutSuffix(interval, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid scheduling interval %q", interval)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(interval)
+	if err != nil {
+		return 0, fmt.Errorf("invalid scheduling interval %q", interval)
+	}
+	return d, nil
+}
+
+// listPolicies lists DRPolicies with their scheduling interval, member clusters and
+// Validated condition
+func (s *DRService) listPolicies(ctx context.Context, client *clients.ClusterClient) ([]DRPolicyStatus, error) {
+	dynamicClient, err := client.DynamicClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
+	list, err := dynamicClient.Resource(DRPolicyGVR).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	policies := make([]DRPolicyStatus, 0, len(list.Items))
+	for _, item := range list.Items {
+		policy := DRPolicyStatus{
+			Name:       item.GetName(),
+			DRClusters: []string{},
+		}
+		policy.SchedulingInterval, _, _ = unstructured.NestedString(item.Object, "spec", "schedulingInterval")
+		if clusters, _, _ := unstructured.NestedStringSlice(item.Object, "spec", "drClusters"); clusters != nil {
+			policy.DRClusters = clusters
+		}
+
+		policy.Conditions = ReadConditions(item)
+		conditionStatus, message, found := FindCondition(item, "Validated")
+		policy.Validated = conditionStatus == "True"
+		switch {
+		case !found:
+			policy.Condition = "Validated condition not reported"
+		case message != "":
+			policy.Condition = fmt.Sprintf("Validated=%s: %s", conditionStatus, message)
+		default:
+			policy.Condition = fmt.Sprintf("Validated=%s", conditionStatus)
+		}
+
+		policies = append(policies, policy)
+	}
+
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].Name < policies[j].Name
+	})
+	return policies, nil
+}
+
 // CatalogService provides Data Cataloging operations
 type CatalogService struct{}
 
 func NewCatalogService() *CatalogService { return &CatalogService{} }
 
-func (s *CatalogService) GetStatus(ctx context.Context, client *clients.ClusterClient) (*ComponentStatus, error) {
-	status := &ComponentStatus{}
+// CatalogConnectionGVR identifies the data source connections configured in Data Cataloging
+var CatalogConnectionGVR = schema.GroupVersionResource{
+	Group:    "dcs.isf.ibm.com",
+	Version:  "v1",
+	Resource: "connections",
+}
+
+// CatalogStatus reports Data Cataloging installation and its configured connections
+type CatalogStatus struct {
+	ComponentStatus
+	Namespace   string              `json:"namespace,omitempty"`
+	Connections []CatalogConnection `json:"connections"`
+}
+
+// CatalogConnection describes a data source connection of the catalog
+type CatalogConnection struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Type      string `json:"type,omitempty"`
+	Healthy   bool   `json:"healthy"`
+	Message   string `json:"message,omitempty"`
+}
+
+// CatalogNamespaces are the namespaces the Data Catalog is looked for in by default
+var CatalogNamespaces = []string{"ibm-data-catalog", "openshift-data-catalog"}
+
+// GetStatus reports the Data Catalog and its connections, looking for it in namespace
+// when set, in CatalogNamespaces otherwise
+func (s *CatalogService) GetStatus(ctx context.Context, client *clients.ClusterClient, namespace string) (*CatalogStatus, error) {
+	status := &CatalogStatus{
+		Connections: []CatalogConnection{},
+	}
 
 	// Check for catalog namespaces
-	catalogNamespaces := []string{"ibm-data-catalog", "openshift-data-catalog"}
-	for _, ns := range catalogNamespaces {
-		if CheckNamespaceExists(ctx, client, ns) {
-			status.Installed = true
-			status.Ready = true
-			status.Message = fmt.Sprintf("Data Catalog found in namespace: %s", ns)
-			return status, nil
+	var reason NotInstalledReason
+	status.Namespace, reason = findNamespace(ctx, client, namespace, CatalogNamespaces...)
+	if status.Namespace == "" {
+		status.ComponentStatus = NotInstalledStatus(reason, "Data Catalog not found")
+		return status, nil
+	}
+	status.Installed = true
+
+	// The namespace alone doesn't make a working catalog
+	if !CheckCRDExists(ctx, client, CatalogConnectionGVR) {
+		status.Reason = ReasonCRDAbsent
+		status.Message = fmt.Sprintf("Data Catalog namespace %s found but the catalog CRDs (%s) are not installed", status.Namespace, CatalogConnectionGVR.GroupResource())
+		return status, nil
+	}
+
+	dynamicClient, err := client.DynamicClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+	status.Version = CSVVersion(ctx, dynamicClient, []string{status.Namespace}, "ibm-data-catalog", "ibm-dcs", "data-catalog")
+
+	connections, scope, err := ListInScope(ctx, dynamicClient.Resource(CatalogConnectionGVR), metav1.NamespaceAll, metav1.ListOptions{})
+	status.Scope = scope
+	if err != nil {
+		return nil, fmt.Errorf("failed to list catalog connections: %w", err)
+	}
+
+	healthy := 0
+	for _, item := range connections.Items {
+		connection := CatalogConnection{
+			Name:      item.GetName(),
+			Namespace: item.GetNamespace(),
+		}
+		connection.Type, _, _ = unstructured.NestedString(item.Object, "spec", "type")
+		if connected, message, found := FindCondition(item, "Connected"); found {
+			connection.Healthy = connected == "True"
+			connection.Message = message
+		} else {
+			phase, _, _ := unstructured.NestedString(item.Object, "status", "phase")
+			connection.Healthy = healthyPhase(phase) || strings.EqualFold(phase, "connected")
+			connection.Message = phase
+		}
+		if connection.Healthy {
+			healthy++
 		}
+		status.Connections = append(status.Connections, connection)
 	}
+	sort.Slice(status.Connections, func(i, j int) bool {
+		return status.Connections[i].Namespace+"/"+status.Connections[i].Name < status.Connections[j].Namespace+"/"+status.Connections[j].Name
+	})
 
-	*status = NotInstalledStatus("Data Catalog not found")
+	status.Ready = healthy == len(status.Connections)
+	if healthy == 0 && len(status.Connections) > 0 {
+		status.Reason = ReasonNoHealthyInstance
+	}
+	status.Message = fmt.Sprintf("Data Catalog found in namespace %s with %d of %d connection(s) healthy", status.Namespace, healthy, len(status.Connections))
 	return status, nil
 }
 
@@ -86,21 +557,124 @@ type CASService struct{}
 
 func NewCASService() *CASService { return &CASService{} }
 
-func (s *CASService) GetStatus(ctx context.Context, client *clients.ClusterClient) (*ComponentStatus, error) {
-	status := &ComponentStatus{}
+// CASNamespace is the namespace Content Aware Storage is installed in
+const CASNamespace = "ibm-cas"
+
+var (
+	// CASGVR identifies Content Aware Storage instances
+	CASGVR = schema.GroupVersionResource{
+		Group:    "cas.isf.ibm.com",
+		Version:  "v1beta1",
+		Resource: "cas",
+	}
+	// CASDataSourceGVR identifies the data sources connected to Content Aware Storage
+	CASDataSourceGVR = schema.GroupVersionResource{
+		Group:    "cas.isf.ibm.com",
+		Version:  "v1beta1",
+		Resource: "datasources",
+	}
+)
+
+// CASStatus reports Content Aware Storage instances, data source connections and indexing
+// IndexBacklog is only set when the CAS instance exposes it in its status
+type CASStatus struct {
+	ComponentStatus
+	Namespace       string `json:"namespace,omitempty"`
+	Instances       int    `json:"instances"`
+	DataSources     int    `json:"dataSources"`
+	ConnectionCount int    `json:"connectionCount"`
+	IndexHealthy    bool   `json:"indexHealthy"`
+	IndexBacklog    *int64 `json:"indexBacklog,omitempty"`
+}
+
+// GetStatus reports Content Aware Storage and its instances, looking for it in namespace
+// when set, in CASNamespace otherwise
+func (s *CASService) GetStatus(ctx context.Context, client *clients.ClusterClient, namespace string) (*CASStatus, error) {
+	status := &CASStatus{}
 
 	// Check for CAS namespace
-	if CheckNamespaceExists(ctx, client, "ibm-cas") {
-		status.Installed = true
-		status.Ready = true
-		status.Message = "CAS found in namespace: ibm-cas"
+	var reason NotInstalledReason
+	status.Namespace, reason = findNamespace(ctx, client, namespace, CASNamespace)
+	if status.Namespace == "" {
+		status.ComponentStatus = NotInstalledStatus(reason, "Content Aware Storage not found")
 		return status, nil
 	}
+	status.Installed = true
+
+	casGVRs := CheckCRDsExist(ctx, client, []schema.GroupVersionResource{CASGVR, CASDataSourceGVR})
+	if !casGVRs[CASGVR] {
+		status.Reason = ReasonCRDAbsent
+		status.Message = fmt.Sprintf("CAS namespace %s found but the CAS CRD (%s) is not installed", status.Namespace, CASGVR.GroupResource())
+		return status, nil
+	}
+
+	dynamicClient, err := client.DynamicClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+	status.Version = CSVVersion(ctx, dynamicClient, []string{status.Namespace}, "ibm-cas", "cas-operator")
+
+	instances, scope, err := ListInScope(ctx, dynamicClient.Resource(CASGVR), metav1.NamespaceAll, metav1.ListOptions{})
+	status.Scope = scope
+	if err != nil {
+		return nil, fmt.Errorf("failed to list CAS instances: %w", err)
+	}
+	status.Instances = len(instances.Items)
+
+	healthyInstances := 0
+	for _, instance := range instances.Items {
+		ready := casInstanceReady(instance)
+		if ready {
+			healthyInstances++
+		}
+
+		// Without a dedicated condition the index is considered healthy with its instance
+		if indexHealthy, _, found := FindCondition(instance, "IndexHealthy"); found {
+			status.IndexHealthy = indexHealthy == "True"
+		} else if ready {
+			status.IndexHealthy = true
+		}
+		if backlog, found, _ := unstructured.NestedInt64(instance.Object, "status", "indexing", "backlog"); found {
+			status.IndexBacklog = &backlog
+		}
+	}
+
+	if casGVRs[CASDataSourceGVR] {
+		dataSources, _, err := ListInScope(ctx, dynamicClient.Resource(CASDataSourceGVR), metav1.NamespaceAll, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list CAS data sources: %w", err)
+		}
+		status.DataSources = len(dataSources.Items)
+		for _, dataSource := range dataSources.Items {
+			if connected, _, found := FindCondition(dataSource, "Connected"); found && connected == "True" {
+				status.ConnectionCount++
+			}
+		}
+	}
+
+	switch {
+	case healthyInstances == 0:
+		status.Reason = ReasonNoHealthyInstance
+		status.Message = fmt.Sprintf("CAS namespace %s found but no healthy CAS instance (%d instance(s) found)", status.Namespace, status.Instances)
+	case !status.IndexHealthy:
+		status.Message = fmt.Sprintf("CAS running with %d of %d data source(s) connected, indexing unhealthy", status.ConnectionCount, status.DataSources)
+	default:
+		status.Ready = true
+		status.Message = fmt.Sprintf("CAS running with %d of %d data source(s) connected", status.ConnectionCount, status.DataSources)
+	}
 
-	*status = NotInstalledStatus("Content Aware Storage not found")
 	return status, nil
 }
 
+// casInstanceReady reports whether a CAS instance is Ready, falling back to its phase
+func casInstanceReady(instance unstructured.Unstructured) bool {
+	if ready, _, found := FindCondition(instance, "Ready"); found {
+		return ready == "True"
+	}
+	phase, _, _ := unstructured.NestedString(instance.Object, "status", "phase")
+	return healthyPhase(phase)
+}
+
 // ServiceabilityService provides serviceability operations
 type ServiceabilityService struct{}
 
@@ -132,7 +706,7 @@ func (s *ServiceabilityService) GetSummary(ctx context.Context, client *clients.
 	summary.Message = "Serviceability components detected"
 
 	if !summary.Installed {
-		summary.ComponentStatus = NotInstalledStatus("No serviceability components found")
+		summary.ComponentStatus = NotInstalledStatus(ReasonNamespaceAbsent, "No serviceability components found")
 	}
 
 	return summary, nil
@@ -145,10 +719,40 @@ func NewObservabilityService() *ObservabilityService { return &ObservabilityServ
 
 type ObservabilitySummary struct {
 	ComponentStatus
-	PrometheusInstalled bool   `json:"prometheusInstalled"`
-	GrafanaInstalled    bool   `json:"grafanaInstalled"`
-	OtelInstalled       bool   `json:"otelInstalled"`
-	Namespace           string `json:"namespace,omitempty"`
+	PrometheusInstalled bool                    `json:"prometheusInstalled"`
+	GrafanaInstalled    bool                    `json:"grafanaInstalled"`
+	OtelInstalled       bool                    `json:"otelInstalled"`
+	Namespace           string                  `json:"namespace,omitempty"`
+	Endpoints           []ObservabilityEndpoint `json:"endpoints,omitempty"`
+	// FiringAlerts and DownTargets are null when the Prometheus API could not be queried
+	FiringAlerts *int `json:"firingAlerts"`
+	DownTargets  *int `json:"downTargets"`
+}
+
+// Endpoint sources reported in ObservabilityEndpoint.Source
+const (
+	EndpointSourceRoute   = "route"
+	EndpointSourceService = "service"
+)
+
+// ObservabilityEndpoint is how to reach an observability component. URL is the external
+// Route URL, or the in-cluster ClusterIP:port of the Service when no Route exists.
+type ObservabilityEndpoint struct {
+	Component string `json:"component"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Source    string `json:"source"`
+	URL       string `json:"url"`
+}
+
+// observabilityEndpoints lists the Route/Service names looked up per component
+var observabilityEndpoints = []struct {
+	component string
+	names     []string
+}{
+	{"prometheus", []string{"prometheus-k8s"}},
+	{"alertmanager", []string{"alertmanager-main"}},
+	{"grafana", []string{"grafana", "grafana-route", "grafana-service"}},
 }
 
 func (s *ObservabilityService) GetSummary(ctx context.Context, client *clients.ClusterClient) (*ObservabilitySummary, error) {
@@ -175,44 +779,215 @@ func (s *ObservabilityService) GetSummary(ctx context.Context, client *clients.C
 		summary.OtelInstalled = true
 	}
 
+	// Look up how to reach the detected components
+	var namespaces []string
+	if summary.PrometheusInstalled {
+		namespaces = append(namespaces, "openshift-monitoring")
+	}
+	if summary.GrafanaInstalled {
+		namespaces = append(namespaces, "openshift-grafana")
+	}
+	if len(namespaces) > 0 {
+		summary.Endpoints = s.findEndpoints(ctx, client, namespaces)
+	}
+
 	summary.Installed = summary.PrometheusInstalled || summary.GrafanaInstalled || summary.OtelInstalled
 	summary.Ready = summary.Installed
 	summary.Message = "Observability stack detected"
 
+	// Quick health signal of monitoring itself
+	if summary.PrometheusInstalled {
+		if err := s.checkPrometheusHealth(ctx, client, summary); err != nil {
+			logDegraded(ctx, err, "Cannot check Prometheus health")
+			summary.Message += fmt.Sprintf("; firing alerts and scrape target health unknown: %v", err)
+		}
+	}
+
 	if !summary.Installed {
-		summary.ComponentStatus = NotInstalledStatus("No observability components found")
+		summary.ComponentStatus = NotInstalledStatus(ReasonNamespaceAbsent, "No observability components found")
 	}
 
 	return summary, nil
 }
 
+// findEndpoints resolves the external Route URL of each observability component in the
+// given namespaces, falling back to the Service ClusterIP:port when no Route exists
+func (s *ObservabilityService) findEndpoints(ctx context.Context, client *clients.ClusterClient, namespaces []string) []ObservabilityEndpoint {
+	// Without a dynamic client only Services can be looked up
+	var dynamicClient dynamic.Interface
+	if dyn, err := client.DynamicClient(); err == nil {
+		dynamicClient = dyn
+	}
+
+	var endpoints []ObservabilityEndpoint
+	for _, candidate := range observabilityEndpoints {
+		if endpoint := s.findEndpoint(ctx, client, dynamicClient, namespaces, candidate.component, candidate.names); endpoint != nil {
+			endpoints = append(endpoints, *endpoint)
+		}
+	}
+	return endpoints
+}
+
+// findEndpoint returns the first Route, or otherwise Service, matching one of names
+func (s *ObservabilityService) findEndpoint(ctx context.Context, client *clients.ClusterClient, dynamicClient dynamic.Interface, namespaces []string, component string, names []string) *ObservabilityEndpoint {
+	if dynamicClient != nil {
+		for _, ns := range namespaces {
+			for _, name := range names {
+				route, err := dynamicClient.Resource(RouteGVR).Namespace(ns).Get(ctx, name, metav1.GetOptions{})
+				if err != nil {
+					continue
+				}
+				if url := routeURL(route); url != "" {
+					return &ObservabilityEndpoint{Component: component, Namespace: ns, Name: name, Source: EndpointSourceRoute, URL: url}
+				}
+			}
+		}
+	}
+
+	for _, ns := range namespaces {
+		for _, name := range names {
+			service, err := client.Clientset.CoreV1().Services(ns).Get(ctx, name, metav1.GetOptions{})
+			if err != nil || service.Spec.ClusterIP == "" || service.Spec.ClusterIP == "None" || len(service.Spec.Ports) == 0 {
+				continue
+			}
+			return &ObservabilityEndpoint{
+				Component: component,
+				Namespace: ns,
+				Name:      name,
+				Source:    EndpointSourceService,
+				URL:       fmt.Sprintf("%s:%d", service.Spec.ClusterIP, service.Spec.Ports[0].Port),
+			}
+		}
+	}
+	return nil
+}
+
+// prometheusService is the platform Prometheus queried for the health signal
+var prometheusService = monitoringService{name: "prometheus-k8s", port: "web"}
+
+// checkPrometheusHealth counts firing alerts and down scrape targets through the Prometheus
+// HTTP API of the prometheus-k8s Service. Both counts stay unknown when user workload
+// monitoring is disabled or the API is not reachable.
+func (s *ObservabilityService) checkPrometheusHealth(ctx context.Context, client *clients.ClusterClient, summary *ObservabilitySummary) error {
+	if enabled, err := userWorkloadMonitoringEnabled(ctx, client); err != nil {
+		// Unknown, the Prometheus API tells whether it can be queried
+		logDegraded(ctx, err, "Cannot read the cluster monitoring config")
+	} else if !enabled {
+		return fmt.Errorf("user workload monitoring is disabled")
+	}
+
+	var alerts struct {
+		Data struct {
+			Alerts []struct {
+				State string `json:"state"`
+			} `json:"alerts"`
+		} `json:"data"`
+	}
+	if err := prometheusService.getJSON(ctx, client.Clientset, "/api/v1/alerts", nil, &alerts); err != nil {
+		return err
+	}
+
+	var targets struct {
+		Data struct {
+			ActiveTargets []struct {
+				Health string `json:"health"`
+			} `json:"activeTargets"`
+		} `json:"data"`
+	}
+	if err := prometheusService.getJSON(ctx, client.Clientset, "/api/v1/targets", map[string]string{"state": "active"}, &targets); err != nil {
+		return err
+	}
+
+	firing := 0
+	for _, alert := range alerts.Data.Alerts {
+		if alert.State == "firing" {
+			firing++
+		}
+	}
+	down := 0
+	for _, target := range targets.Data.ActiveTargets {
+		if target.Health == "down" {
+			down++
+		}
+	}
+	summary.FiringAlerts = &firing
+	summary.DownTargets = &down
+	return nil
+}
+
+// ClusterMonitoringConfigMap is the ConfigMap configuring the platform monitoring stack
+const ClusterMonitoringConfigMap = "cluster-monitoring-config"
+
+// userWorkloadMonitoringEnabled reports whether enableUserWorkload is set in the cluster
+// monitoring config. A missing ConfigMap leaves it disabled, the OpenShift default.
+func userWorkloadMonitoringEnabled(ctx context.Context, client *clients.ClusterClient) (bool, error) {
+	configMap, err := client.Clientset.CoreV1().ConfigMaps(MonitoringNamespace).Get(ctx, ClusterMonitoringConfigMap, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get configmap %s/%s: %w", MonitoringNamespace, ClusterMonitoringConfigMap, err)
+	}
+	var config struct {
+		EnableUserWorkload bool `json:"enableUserWorkload"`
+	}
+	if err := yaml.Unmarshal([]byte(configMap.Data["config.yaml"]), &config); err != nil {
+		return false, fmt.Errorf("failed to parse configmap %s/%s: %w", MonitoringNamespace, ClusterMonitoringConfigMap, err)
+	}
+	return config.EnableUserWorkload, nil
+}
+
+// routeURL builds the external URL of an OpenShift Route
+func routeURL(route *unstructured.Unstructured) string {
+	host, _, _ := unstructured.NestedString(route.Object, "spec", "host")
+	if host == "" {
+		return ""
+	}
+	scheme := "http"
+	if _, found, _ := unstructured.NestedMap(route.Object, "spec", "tls"); found {
+		scheme = "https"
+	}
+	path, _, _ := unstructured.NestedString(route.Object, "spec", "path")
+	return scheme + "://" + host + path
+}
+
 // VirtualizationService provides virtualization operations
 type VirtualizationService struct{}
 
 func NewVirtualizationService() *VirtualizationService { return &VirtualizationService{} }
 
+// VirtualMachineGVR identifies KubeVirt VirtualMachine resources
+var VirtualMachineGVR = schema.GroupVersionResource{
+	Group:    "kubevirt.io",
+	Version:  "v1",
+	Resource: "virtualmachines",
+}
+
+// VirtualizationStatus reports KubeVirt installation and VM counts
+// VMCount and RunningVMCount are -1 when VirtualMachines could not be listed
 type VirtualizationStatus struct {
 	ComponentStatus
 	KubeVirtInstalled bool   `json:"kubevirtInstalled"`
 	VMCount           int    `json:"vmCount"`
+	RunningVMCount    int    `json:"runningVmCount"`
 	Namespace         string `json:"namespace,omitempty"`
 }
 
-func (s *VirtualizationService) GetStatus(ctx context.Context, client *clients.ClusterClient) (*VirtualizationStatus, error) {
+// VirtualizationNamespaces are the namespaces KubeVirt is looked for in by default
+var VirtualizationNamespaces = []string{"openshift-cnv", "kubevirt"}
+
+// GetStatus reports KubeVirt and its VirtualMachines, looking for it in namespace when
+// set, in VirtualizationNamespaces otherwise
+func (s *VirtualizationService) GetStatus(ctx context.Context, client *clients.ClusterClient, namespace string) (*VirtualizationStatus, error) {
 	status := &VirtualizationStatus{}
 
 	// Check for KubeVirt/OpenShift Virtualization
-	virtNamespaces := []string{"openshift-cnv", "kubevirt"}
-	for _, ns := range virtNamespaces {
-		if CheckNamespaceExists(ctx, client, ns) {
-			status.KubeVirtInstalled = true
-			status.Namespace = ns
-			break
-		}
-	}
+	var reason NotInstalledReason
+	status.Namespace, reason = findNamespace(ctx, client, namespace, VirtualizationNamespaces...)
+	status.KubeVirtInstalled = status.Namespace != ""
 
 	if !status.KubeVirtInstalled {
-		status.ComponentStatus = NotInstalledStatus("KubeVirt/OpenShift Virtualization not found")
+		status.ComponentStatus = NotInstalledStatus(reason, "KubeVirt/OpenShift Virtualization not found")
 		return status, nil
 	}
 
@@ -220,25 +995,60 @@ func (s *VirtualizationService) GetStatus(ctx context.Context, client *clients.C
 	status.Ready = true
 
 	// Check for VM CRD
-	vmGVR := schema.GroupVersionResource{
-		Group:    "kubevirt.io",
-		Version:  "v1",
-		Resource: "virtualmachines",
-	}
-	if CheckCRDExists(ctx, client, vmGVR) {
-		status.Message = "KubeVirt installed with VM CRDs"
-	} else {
+	if !CheckCRDExists(ctx, client, VirtualMachineGVR) {
+		status.Reason = ReasonCRDAbsent
 		status.Message = "KubeVirt namespace found but CRDs not detected"
 		status.Ready = false
+		return status, nil
+	}
+
+	status.Message = "KubeVirt installed with VM CRDs"
+	if err := s.countVMs(ctx, client, status); err != nil {
+		status.VMCount = -1
+		status.RunningVMCount = -1
+		if apierrors.IsForbidden(err) {
+			status.Reason = ReasonForbidden
+			status.Message = fmt.Sprintf("KubeVirt installed with VM CRDs, but VirtualMachines cannot be listed: permission denied: %v", err)
+		} else {
+			status.Message = fmt.Sprintf("KubeVirt installed with VM CRDs, but VirtualMachines cannot be listed: %v", err)
+		}
 	}
 
 	return status, nil
 }
 
+// countVMs counts VirtualMachines across all namespaces, and those currently running,
+// page by page
+func (s *VirtualizationService) countVMs(ctx context.Context, client *clients.ClusterClient, status *VirtualizationStatus) error {
+	dynamicClient, err := client.DynamicClient()
+	if err != nil {
+		return fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
+	running := 0
+	count, scope, err := countInScope(ctx, dynamicClient.Resource(VirtualMachineGVR), metav1.NamespaceAll, metav1.ListOptions{}, func(vms []unstructured.Unstructured) {
+		for _, vm := range vms {
+			if printableStatus, _, _ := unstructured.NestedString(vm.Object, "status", "printableStatus"); printableStatus == "Running" {
+				running++
+			}
+		}
+	})
+	status.Scope = scope
+	if err != nil {
+		return err
+	}
+
+	status.VMCount = count
+	status.RunningVMCount = running
+	return nil
+}
+
 // HCPService provides Hosted Control Planes operations
-type HCPService struct{}
+type HCPService struct {
+	stuckAfter time.Duration
+}
 
-func NewHCPService() *HCPService { return &HCPService{} }
+func NewHCPService() *HCPService { return &HCPService{stuckAfter: DefaultNodePoolStuckAfter} }
 
 type HCPStatus struct {
 	ComponentStatus
@@ -247,17 +1057,21 @@ type HCPStatus struct {
 	Namespace           string `json:"namespace,omitempty"`
 }
 
-func (s *HCPService) GetStatus(ctx context.Context, client *clients.ClusterClient) (*HCPStatus, error) {
+// HyperShiftNamespace is the namespace the HyperShift operator is looked for in by default
+const HyperShiftNamespace = "hypershift"
+
+// GetStatus reports HyperShift and its HostedClusters, looking for the operator in
+// namespace when set, in HyperShiftNamespace otherwise
+func (s *HCPService) GetStatus(ctx context.Context, client *clients.ClusterClient, namespace string) (*HCPStatus, error) {
 	status := &HCPStatus{}
 
 	// Check for HyperShift namespace
-	if CheckNamespaceExists(ctx, client, "hypershift") {
-		status.HyperShiftInstalled = true
-		status.Namespace = "hypershift"
-	}
+	var reason NotInstalledReason
+	status.Namespace, reason = findNamespace(ctx, client, namespace, HyperShiftNamespace)
+	status.HyperShiftInstalled = status.Namespace != ""
 
 	if !status.HyperShiftInstalled {
-		status.ComponentStatus = NotInstalledStatus("HyperShift/HCP not found")
+		status.ComponentStatus = NotInstalledStatus(reason, "HyperShift/HCP not found")
 		return status, nil
 	}
 
@@ -265,19 +1079,71 @@ func (s *HCPService) GetStatus(ctx context.Context, client *clients.ClusterClien
 	status.Ready = true
 
 	// Check for HostedCluster CRD
-	hcGVR := schema.GroupVersionResource{
-		Group:    "hypershift.openshift.io",
-		Version:  "v1beta1",
-		Resource: "hostedclusters",
-	}
-	if CheckCRDExists(ctx, client, hcGVR) {
-		status.Message = "HyperShift installed with HostedCluster CRDs"
-	} else {
+	if !CheckCRDExists(ctx, client, HostedClusterGVR) {
+		status.Reason = ReasonCRDAbsent
 		status.Message = "HyperShift namespace found but CRDs not detected"
 		status.Ready = false
+		return status, nil
+	}
+	status.Message = "HyperShift installed with HostedCluster CRDs"
+
+	dynamicClient, err := client.DynamicClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+	var unavailable []string
+	count, scope, err := countInScope(ctx, dynamicClient.Resource(HostedClusterGVR), metav1.NamespaceAll, metav1.ListOptions{}, func(hostedClusters []unstructured.Unstructured) {
+		unavailable = append(unavailable, unavailableHostedClusters(hostedClusters)...)
+	})
+	status.Scope = scope
+	if err != nil {
+		logDegraded(ctx, err, "Cannot list HostedClusters")
+		status.Message += fmt.Sprintf(", HostedClusters cannot be listed: %v", err)
+		return status, nil
+	}
+	status.HostedClusterCount = count
+	if status.HostedClusterCount > 0 {
+		condition := hostedClustersAvailableCondition(count, unavailable)
+		status.AddConditions(condition)
+		status.Message += ", " + condition.Message
 	}
 
 	return status, nil
 }
 
+// HostedClusterGVR identifies HyperShift HostedCluster resources
+var HostedClusterGVR = schema.GroupVersionResource{
+	Group:    "hypershift.openshift.io",
+	Version:  "v1beta1",
+	Resource: "hostedclusters",
+}
+
+// unavailableHostedClusters returns the namespace/name of the HostedClusters not Available
+func unavailableHostedClusters(hostedClusters []unstructured.Unstructured) []string {
+	var unavailable []string
+	for _, hostedCluster := range hostedClusters {
+		if available, _, _ := FindCondition(hostedCluster, "Available"); available != "True" {
+			unavailable = append(unavailable, hostedCluster.GetNamespace()+"/"+hostedCluster.GetName())
+		}
+	}
+	return unavailable
+}
+
+// hostedClustersAvailableCondition summarizes the availability of total HostedClusters,
+// naming the unavailable ones
+func hostedClustersAvailableCondition(total int, unavailable []string) StatusCondition {
+	condition := StatusCondition{
+		Type:    "Available",
+		Status:  "True",
+		Reason:  "AllHostedClustersAvailable",
+		Message: fmt.Sprintf("%d of %d HostedClusters available", total-len(unavailable), total),
+	}
+	if len(unavailable) > 0 {
+		condition.Status = "False"
+		condition.Reason = "HostedClusterUnavailable"
+		condition.Message += fmt.Sprintf(", unavailable: %s", strings.Join(unavailable, ", "))
+	}
+	return condition
+}
+
 // Made with Bob
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type MultidomSuite struct {
+	suite.Suite
+}
+
+func (s *MultidomSuite) TestEvaluateRPO() {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	s.Run("within twice the interval is compliant", func() {
+		workload := DRWorkloadStatus{LagSeconds: -1, LastGroupSyncTime: now.Add(-8 * time.Minute).Format(time.RFC3339)}
+		evaluateRPO(&workload, "5m", now)
+		s.True(workload.RPOCompliant)
+		s.Equal(int64(480), workload.LagSeconds)
+	})
+
+	s.Run("beyond twice the interval violates the RPO", func() {
+		workload := DRWorkloadStatus{LagSeconds: -1, LastGroupSyncTime: now.Add(-11 * time.Minute).Format(time.RFC3339)}
+		evaluateRPO(&workload, "5m", now)
+		s.False(workload.RPOCompliant)
+		s.Equal(int64(660), workload.LagSeconds)
+		s.Contains(workload.Message, "exceeds")
+	})
+
+	s.Run("day intervals are supported", func() {
+		workload := DRWorkloadStatus{LagSeconds: -1, LastGroupSyncTime: now.Add(-30 * time.Hour).Format(time.RFC3339)}
+		evaluateRPO(&workload, "1d", now)
+		s.True(workload.RPOCompliant)
+	})
+
+	s.Run("missing sync time is not compliant", func() {
+		workload := DRWorkloadStatus{LagSeconds: -1}
+		evaluateRPO(&workload, "5m", now)
+		s.False(workload.RPOCompliant)
+		s.Equal(int64(-1), workload.LagSeconds)
+	})
+
+	s.Run("synchronous policies are always compliant", func() {
+		workload := DRWorkloadStatus{LagSeconds: -1}
+		evaluateRPO(&workload, "0m", now)
+		s.True(workload.RPOCompliant)
+		s.Equal(int64(0), workload.LagSeconds)
+	})
+
+	s.Run("invalid interval is reported", func() {
+		workload := DRWorkloadStatus{LagSeconds: -1}
+		evaluateRPO(&workload, "often", now)
+		s.False(workload.RPOCompliant)
+		s.Contains(workload.Message, "invalid scheduling interval")
+	})
+}
+
+func (s *MultidomSuite) TestPoliciesValidatedCondition() {
+	s.Run("all policies validated", func() {
+		condition := policiesValidatedCondition([]DRPolicyStatus{{Name: "metro", Validated: true}}, 1)
+		s.True(condition.Healthy())
+		s.Equal("1 of 1 DRPolicies validated", condition.Message)
+	})
+	s.Run("a policy not validated", func() {
+		condition := policiesValidatedCondition([]DRPolicyStatus{{Name: "metro", Validated: true}, {Name: "regional"}}, 1)
+		s.False(condition.Healthy())
+		s.Equal("DRPolicyNotValidated", condition.Reason)
+		s.Equal("1 of 2 DRPolicies validated, not validated: regional", condition.Message)
+	})
+}
+
+func (s *MultidomSuite) TestHostedClustersAvailableCondition() {
+	hostedCluster := func(name, available string) unstructured.Unstructured {
+		hc := unstructured.Unstructured{Object: map[string]interface{}{}}
+		hc.SetNamespace("clusters")
+		hc.SetName(name)
+		_ = unstructured.SetNestedSlice(hc.Object, []interface{}{
+			map[string]interface{}{"type": "Available", "status": available},
+		}, "status", "conditions")
+		return hc
+	}
+	unavailable := unavailableHostedClusters([]unstructured.Unstructured{hostedCluster("tenant-a", "True"), hostedCluster("tenant-b", "False")})
+	condition := hostedClustersAvailableCondition(2, unavailable)
+	s.False(condition.Healthy())
+	s.Equal("1 of 2 HostedClusters available, unavailable: clusters/tenant-b", condition.Message)
+}
+
+// monitoringServer serves the cluster monitoring config, unless monitoringConfig is empty,
+// and the Prometheus alerts and targets APIs through the prometheus-k8s service proxy.
+// Every other request is not found.
+func monitoringServer(monitoringConfig string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/api/v1/namespaces/openshift-monitoring/configmaps/cluster-monitoring-config" && monitoringConfig != "":
+			_, _ = fmt.Fprintf(w, `{"kind":"ConfigMap","apiVersion":"v1","metadata":{"name":"cluster-monitoring-config"},"data":{"config.yaml":%q}}`, monitoringConfig)
+		case r.URL.Path == prometheusService.proxyPath("/api/v1/alerts"):
+			_, _ = fmt.Fprint(w, `{"status":"success","data":{"alerts":[{"state":"firing"},{"state":"pending"},{"state":"firing"}]}}`)
+		case r.URL.Path == prometheusService.proxyPath("/api/v1/targets") && r.URL.Query().Get("state") == "active":
+			_, _ = fmt.Fprint(w, `{"status":"success","data":{"activeTargets":[{"health":"up"},{"health":"down"}]}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprint(w, `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`)
+		}
+	}))
+}
+
+func (s *MultidomSuite) TestCheckPrometheusHealth() {
+	service := NewObservabilityService()
+
+	s.Run("counts firing alerts and down targets through the service proxy", func() {
+		server := monitoringServer("enableUserWorkload: true\n")
+		defer server.Close()
+		summary := &ObservabilitySummary{}
+		s.Require().NoError(service.checkPrometheusHealth(context.Background(), probeClient(s.T(), server), summary))
+		s.Require().NotNil(summary.FiringAlerts)
+		s.Require().NotNil(summary.DownTargets)
+		s.Equal(2, *summary.FiringAlerts)
+		s.Equal(1, *summary.DownTargets)
+	})
+	s.Run("is unknown when user workload monitoring is disabled", func() {
+		for _, config := range []string{"", "enableUserWorkload: false\n"} {
+			server := monitoringServer(config)
+			summary := &ObservabilitySummary{}
+			err := service.checkPrometheusHealth(context.Background(), probeClient(s.T(), server), summary)
+			server.Close()
+			s.EqualError(err, "user workload monitoring is disabled")
+			s.Nil(summary.FiringAlerts)
+			s.Nil(summary.DownTargets)
+		}
+	})
+	s.Run("is unknown when the Prometheus API is not reachable", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/api/v1/namespaces/openshift-monitoring/configmaps/cluster-monitoring-config" {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = fmt.Fprint(w, `{"kind":"ConfigMap","apiVersion":"v1","data":{"config.yaml":"enableUserWorkload: true"}}`)
+				return
+			}
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		summary := &ObservabilitySummary{}
+		err := service.checkPrometheusHealth(context.Background(), probeClient(s.T(), server), summary)
+		s.ErrorContains(err, "failed to query /api/v1/alerts of service openshift-monitoring/prometheus-k8s")
+		s.Nil(summary.FiringAlerts)
+		s.Nil(summary.DownTargets)
+	})
+}
+
+func (s *MultidomSuite) TestRouteURL() {
+	route := func(spec map[string]interface{}) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	}
+	s.Equal("https://prometheus-k8s.apps.example.com", routeURL(route(map[string]interface{}{
+		"host": "prometheus-k8s.apps.example.com",
+		"tls":  map[string]interface{}{"termination": "reencrypt"},
+	})))
+	s.Equal("http://grafana.apps.example.com/dashboards", routeURL(route(map[string]interface{}{
+		"host": "grafana.apps.example.com",
+		"path": "/dashboards",
+	})))
+	s.Empty(routeURL(route(map[string]interface{}{})))
+}
+
+func TestMultidomSuite(t *testing.T) {
+	suite.Run(t, new(MultidomSuite))
+}
+
+// Made with Bob
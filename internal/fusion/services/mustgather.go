@@ -0,0 +1,248 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// DefaultMustGatherImage is the collection image used when none is requested
+	DefaultMustGatherImage = "quay.io/openshift/origin-must-gather:latest"
+	// MustGatherRequestLabel identifies Jobs created for the same must-gather request
+	MustGatherRequestLabel = "fusion.ibm.com/must-gather-request"
+	// MustGatherOutputDir is where the collected data is kept inside the pod
+	MustGatherOutputDir = "/must-gather"
+	// MustGatherContainer is the init container running the collection
+	MustGatherContainer = "gather"
+
+	mustGatherActiveDeadline = 2 * time.Hour
+	mustGatherRetention      = time.Hour
+	mustGatherPodWait        = 10 * time.Second
+)
+
+// MustGatherRequest describes a must-gather collection to start
+type MustGatherRequest struct {
+	Namespace      string `json:"namespace,omitempty"`
+	Image          string `json:"image,omitempty"`
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+	DryRun         bool   `json:"dryRun,omitempty"`
+}
+
+// StartedMustGather describes a must-gather Job created by TriggerMustGather
+type StartedMustGather struct {
+	JobName   string `json:"jobName"`
+	PodName   string `json:"podName,omitempty"`
+	Namespace string `json:"namespace"`
+	Image     string `json:"image"`
+	OutputDir string `json:"outputDir"`
+	Message   string `json:"message"`
+	DryRunPreview
+}
+
+// TriggerMustGather creates a Job running the must-gather image and returns the pod to follow.
+// The collection runs in an init container writing to a shared volume; the main container then
+// keeps the pod alive for an hour so the output can be copied with oc rsync. The namespace and
+// the ServiceAccount the collection runs as must exist: a default ServiceAccount cannot read
+// cluster resources, so one with read access has to be named. Only one collection per namespace
+// and image may run at a time; one whose output is only kept for copying does not count.
+func (s *ServiceabilityService) TriggerMustGather(ctx context.Context, client *clients.ClusterClient, request MustGatherRequest) (*StartedMustGather, error) {
+	if request.Namespace == "" {
+		return nil, fmt.Errorf("namespace is required")
+	}
+	if request.ServiceAccount == "" {
+		return nil, fmt.Errorf("serviceAccount is required: the default ServiceAccount cannot read cluster resources, name one bound to the cluster-reader or cluster-admin ClusterRole")
+	}
+	if request.Image == "" {
+		request.Image = DefaultMustGatherImage
+	}
+
+	if _, err := client.Clientset.CoreV1().Namespaces().Get(ctx, request.Namespace, metav1.GetOptions{}); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("namespace %s does not exist", request.Namespace)
+		}
+		return nil, fmt.Errorf("failed to get namespace %s: %w", request.Namespace, err)
+	}
+	if _, err := client.Clientset.CoreV1().ServiceAccounts(request.Namespace).Get(ctx, request.ServiceAccount, metav1.GetOptions{}); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("serviceaccount %s does not exist in %s", request.ServiceAccount, request.Namespace)
+		}
+		return nil, fmt.Errorf("failed to get serviceaccount %s/%s: %w", request.Namespace, request.ServiceAccount, err)
+	}
+
+	requestID := mustGatherRequestID(request)
+	jobs := client.Clientset.BatchV1().Jobs(request.Namespace)
+	existing, err := jobs.List(ctx, metav1.ListOptions{LabelSelector: MustGatherRequestLabel + "=" + requestID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list must-gather jobs in %s: %w", request.Namespace, err)
+	}
+	for _, job := range existing.Items {
+		if jobFinished(&job) {
+			continue
+		}
+		collecting, err := mustGatherCollecting(ctx, client, &job)
+		if err != nil {
+			return nil, err
+		}
+		if collecting {
+			return nil, fmt.Errorf("must-gather job %s for image %s is already running in %s", job.Name, request.Image, request.Namespace)
+		}
+	}
+
+	created, err := jobs.Create(ctx, newMustGatherJob(request, requestID), metav1.CreateOptions{DryRun: dryRunOption(request.DryRun)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create must-gather job: %w", err)
+	}
+
+	started := &StartedMustGather{
+		JobName:   created.Name,
+		Namespace: created.Namespace,
+		Image:     request.Image,
+		OutputDir: MustGatherOutputDir,
+	}
+	if request.DryRun {
+		preview, err := runtime.DefaultUnstructuredConverter.ToUnstructured(created)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert must-gather job: %w", err)
+		}
+		started.DryRunPreview = DryRunPreview{DryRun: true, Preview: preview}
+		started.Message = fmt.Sprintf("dry run: must-gather job %s would be created in %s", created.Name, created.Namespace)
+		return started, nil
+	}
+
+	// The pod is created asynchronously by the Job controller, give it a moment to appear
+	_ = wait.PollUntilContextTimeout(ctx, time.Second, mustGatherPodWait, true, func(ctx context.Context) (bool, error) {
+		pods, err := client.Clientset.CoreV1().Pods(created.Namespace).List(ctx, metav1.ListOptions{LabelSelector: "job-name=" + created.Name})
+		if err != nil || len(pods.Items) == 0 {
+			return false, nil
+		}
+		started.PodName = pods.Items[0].Name
+		return true, nil
+	})
+
+	if started.PodName == "" {
+		started.Message = fmt.Sprintf("must-gather job %s created, its pod has not been scheduled yet", created.Name)
+	} else {
+		started.Message = fmt.Sprintf("follow with: oc logs -n %s %s -c %s -f; copy the output with: oc rsync -n %s %s:%s .",
+			created.Namespace, started.PodName, MustGatherContainer, created.Namespace, started.PodName, MustGatherOutputDir)
+	}
+	return started, nil
+}
+
+// newMustGatherJob builds the Job running a must-gather collection
+func newMustGatherJob(request MustGatherRequest, requestID string) *batchv1.Job {
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("100m"),
+			corev1.ResourceMemory: resource.MustParse("256Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("1"),
+			corev1.ResourceMemory: resource.MustParse("1Gi"),
+		},
+	}
+	output := corev1.VolumeMount{Name: "must-gather-output", MountPath: MustGatherOutputDir}
+	labels := map[string]string{
+		"app.kubernetes.io/name":       "fusion-must-gather",
+		"app.kubernetes.io/managed-by": "fusion-mcp-server",
+		MustGatherRequestLabel:         requestID,
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "fusion-must-gather-",
+			Namespace:    request.Namespace,
+			Labels:       labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            ptr.To(int32(0)),
+			ActiveDeadlineSeconds:   ptr.To(int64((mustGatherActiveDeadline + mustGatherRetention).Seconds())),
+			TTLSecondsAfterFinished: ptr.To(int32(mustGatherRetention.Seconds())),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ServiceAccountName: request.ServiceAccount,
+					InitContainers: []corev1.Container{{
+						Name:         MustGatherContainer,
+						Image:        request.Image,
+						Command:      []string{"/usr/bin/gather"},
+						Env:          []corev1.EnvVar{{Name: "BASE_COLLECTION_PATH", Value: MustGatherOutputDir}},
+						Resources:    resources,
+						VolumeMounts: []corev1.VolumeMount{output},
+					}},
+					Containers: []corev1.Container{{
+						Name:         "output",
+						Image:        request.Image,
+						Command:      []string{"sleep", fmt.Sprintf("%d", int64(mustGatherRetention.Seconds()))},
+						Resources:    resources,
+						VolumeMounts: []corev1.VolumeMount{output},
+					}},
+					Volumes: []corev1.Volume{{
+						Name:         output.Name,
+						VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+					}},
+				},
+			},
+		},
+	}
+}
+
+// mustGatherRequestID derives a label-safe identifier of a must-gather request
+func mustGatherRequestID(request MustGatherRequest) string {
+	sum := sha256.Sum256([]byte(request.Namespace + "/" + request.Image))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// jobFinished reports whether a Job has completed or failed
+func jobFinished(job *batchv1.Job) bool {
+	for _, condition := range job.Status.Conditions {
+		if (condition.Type == batchv1.JobComplete || condition.Type == batchv1.JobFailed) && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// mustGatherCollecting reports whether the collection of an unfinished must-gather Job is
+// still running: its pod is not created yet or its gather container has not terminated.
+// Once it has, the pod only keeps the output for copying.
+func mustGatherCollecting(ctx context.Context, client *clients.ClusterClient, job *batchv1.Job) (bool, error) {
+	pods, err := client.Clientset.CoreV1().Pods(job.Namespace).List(ctx, metav1.ListOptions{LabelSelector: "job-name=" + job.Name})
+	if err != nil {
+		return false, fmt.Errorf("failed to list pods of must-gather job %s: %w", job.Name, err)
+	}
+	if len(pods.Items) == 0 {
+		return true, nil
+	}
+	for _, pod := range pods.Items {
+		if !gatherTerminated(&pod) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// gatherTerminated reports whether the gather container of a must-gather pod has terminated
+func gatherTerminated(pod *corev1.Pod) bool {
+	for _, status := range pod.Status.InitContainerStatuses {
+		if status.Name == MustGatherContainer {
+			return status.State.Terminated != nil
+		}
+	}
+	return false
+}
+
+// Made with Bob
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/stretchr/testify/suite"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+type MustGatherSuite struct {
+	suite.Suite
+}
+
+// mustGatherRequest is a valid request for the support namespace
+var mustGatherRequest = MustGatherRequest{Namespace: "support", ServiceAccount: "must-gather", Image: "icr.io/cpopen/fusion-must-gather:2.9"}
+
+// mustGatherCluster is a cluster with the support namespace and its must-gather ServiceAccount.
+// The Job controller is stood in for by naming created Jobs and creating their pod at once.
+func mustGatherCluster(objects ...runtime.Object) *clients.ClusterClient {
+	objects = append(objects,
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "support"}},
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "must-gather", Namespace: "support"}},
+	)
+	clientset := fake.NewSimpleClientset(objects...)
+	clientset.PrependReactor("create", "jobs", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		job := action.(k8stesting.CreateAction).GetObject().(*batchv1.Job)
+		if job.Name == "" {
+			job.Name = job.GenerateName + "x7k2p"
+		}
+		if len(action.(k8stesting.CreateActionImpl).CreateOptions.DryRun) == 0 {
+			_ = clientset.Tracker().Add(mustGatherPod(job, nil))
+		}
+		return false, nil, nil
+	})
+	return &clients.ClusterClient{Name: "prod-1", Clientset: clientset}
+}
+
+// existingMustGatherJob is a Job of an earlier mustGatherRequest, finished when a condition is given
+func existingMustGatherJob(name string, conditions ...batchv1.JobConditionType) *batchv1.Job {
+	job := newMustGatherJob(mustGatherRequest, mustGatherRequestID(mustGatherRequest))
+	job.Name = name
+	for _, condition := range conditions {
+		job.Status.Conditions = append(job.Status.Conditions, batchv1.JobCondition{Type: condition, Status: corev1.ConditionTrue})
+	}
+	return job
+}
+
+// mustGatherPod is the pod of a must-gather Job, whose gather container is in state
+func mustGatherPod(job *batchv1.Job, state *corev1.ContainerState) *corev1.Pod {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:      job.Name + "-pod",
+		Namespace: job.Namespace,
+		Labels:    map[string]string{"job-name": job.Name},
+	}}
+	if state != nil {
+		pod.Status.InitContainerStatuses = []corev1.ContainerStatus{{Name: MustGatherContainer, State: *state}}
+	}
+	return pod
+}
+
+func (s *MustGatherSuite) TestTriggerMustGather() {
+	service := NewServiceabilityService()
+	running := &corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+	terminated := &corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}}
+
+	s.Run("requires a namespace and a ServiceAccount", func() {
+		_, err := service.TriggerMustGather(context.Background(), mustGatherCluster(), MustGatherRequest{ServiceAccount: "must-gather"})
+		s.EqualError(err, "namespace is required")
+		_, err = service.TriggerMustGather(context.Background(), mustGatherCluster(), MustGatherRequest{Namespace: "support"})
+		s.ErrorContains(err, "serviceAccount is required")
+	})
+	s.Run("fails when the namespace is missing", func() {
+		request := mustGatherRequest
+		request.Namespace = "missing"
+		_, err := service.TriggerMustGather(context.Background(), mustGatherCluster(), request)
+		s.EqualError(err, "namespace missing does not exist")
+	})
+	s.Run("fails when the ServiceAccount is missing", func() {
+		request := mustGatherRequest
+		request.ServiceAccount = "default"
+		_, err := service.TriggerMustGather(context.Background(), mustGatherCluster(), request)
+		s.EqualError(err, "serviceaccount default does not exist in support")
+	})
+	s.Run("refuses while a collection is running", func() {
+		job := existingMustGatherJob("fusion-must-gather-abcde")
+		_, err := service.TriggerMustGather(context.Background(), mustGatherCluster(job, mustGatherPod(job, running)), mustGatherRequest)
+		s.EqualError(err, "must-gather job fusion-must-gather-abcde for image icr.io/cpopen/fusion-must-gather:2.9 is already running in support")
+	})
+	s.Run("refuses while the pod of a collection is not created yet", func() {
+		_, err := service.TriggerMustGather(context.Background(), mustGatherCluster(existingMustGatherJob("fusion-must-gather-abcde")), mustGatherRequest)
+		s.ErrorContains(err, "is already running in support")
+	})
+	s.Run("starts a new collection once the gather container terminated", func() {
+		job := existingMustGatherJob("fusion-must-gather-abcde")
+		started, err := service.TriggerMustGather(context.Background(), mustGatherCluster(job, mustGatherPod(job, terminated)), mustGatherRequest)
+		s.Require().NoError(err)
+		s.Equal("fusion-must-gather-x7k2p", started.JobName)
+		s.Equal("fusion-must-gather-x7k2p-pod", started.PodName)
+		s.Contains(started.Message, "oc logs -n support fusion-must-gather-x7k2p-pod -c gather -f")
+	})
+	s.Run("starts a new collection once earlier jobs finished", func() {
+		started, err := service.TriggerMustGather(context.Background(), mustGatherCluster(
+			existingMustGatherJob("fusion-must-gather-abcde", batchv1.JobComplete),
+			existingMustGatherJob("fusion-must-gather-fghij", batchv1.JobFailed),
+		), mustGatherRequest)
+		s.Require().NoError(err)
+		s.Equal("fusion-must-gather-x7k2p", started.JobName)
+	})
+	s.Run("ignores collections of another image", func() {
+		other := mustGatherRequest
+		other.Image = DefaultMustGatherImage
+		job := existingMustGatherJob("fusion-must-gather-abcde")
+		started, err := service.TriggerMustGather(context.Background(), mustGatherCluster(job, mustGatherPod(job, running)), other)
+		s.Require().NoError(err)
+		s.Equal(DefaultMustGatherImage, started.Image)
+	})
+	s.Run("previews the job of a dry run", func() {
+		client := mustGatherCluster()
+		request := mustGatherRequest
+		request.DryRun = true
+		started, err := service.TriggerMustGather(context.Background(), client, request)
+		s.Require().NoError(err)
+		s.True(started.DryRun)
+		s.Empty(started.PodName)
+		s.Equal("dry run: must-gather job fusion-must-gather-x7k2p would be created in support", started.Message)
+		s.Equal("fusion-must-gather-", started.Preview["metadata"].(map[string]interface{})["generateName"])
+		var dryRun bool
+		for _, action := range client.Clientset.(*fake.Clientset).Actions() {
+			if create, ok := action.(k8stesting.CreateActionImpl); ok {
+				dryRun = len(create.CreateOptions.DryRun) > 0
+			}
+		}
+		s.True(dryRun, "the job is created with the dry run option")
+	})
+}
+
+func (s *MustGatherSuite) TestNewMustGatherJob() {
+	job := newMustGatherJob(mustGatherRequest, "0123456789abcdef")
+	s.Equal("support", job.Namespace)
+	s.Equal("0123456789abcdef", job.Labels[MustGatherRequestLabel])
+	s.Equal(int32(3600), *job.Spec.TTLSecondsAfterFinished)
+	s.Equal(int32(0), *job.Spec.BackoffLimit)
+	pod := job.Spec.Template.Spec
+	s.Equal("must-gather", pod.ServiceAccountName)
+	s.Require().Len(pod.InitContainers, 1)
+	s.Equal(MustGatherContainer, pod.InitContainers[0].Name)
+	s.Equal("icr.io/cpopen/fusion-must-gather:2.9", pod.InitContainers[0].Image)
+	s.Equal("1Gi", pod.InitContainers[0].Resources.Limits.Memory().String())
+	s.Require().Len(pod.Containers, 1)
+	s.Equal([]string{"sleep", "3600"}, pod.Containers[0].Command)
+}
+
+func (s *MustGatherSuite) TestMustGatherRequestID() {
+	id := mustGatherRequestID(mustGatherRequest)
+	s.Len(id, 16)
+	s.Equal(id, mustGatherRequestID(MustGatherRequest{Namespace: "support", Image: mustGatherRequest.Image, DryRun: true}), "the ServiceAccount and dry run do not matter")
+	s.NotEqual(id, mustGatherRequestID(MustGatherRequest{Namespace: "support", Image: DefaultMustGatherImage}))
+	s.NotEqual(id, mustGatherRequestID(MustGatherRequest{Namespace: "other", Image: mustGatherRequest.Image}))
+}
+
+func (s *MustGatherSuite) TestJobFinished() {
+	s.False(jobFinished(existingMustGatherJob("running")))
+	s.True(jobFinished(existingMustGatherJob("complete", batchv1.JobComplete)))
+	s.True(jobFinished(existingMustGatherJob("failed", batchv1.JobFailed)))
+	suspended := existingMustGatherJob("suspended")
+	suspended.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionFalse}}
+	s.False(jobFinished(suspended))
+}
+
+func TestMustGatherSuite(t *testing.T) {
+	suite.Run(t, new(MustGatherSuite))
+}
+
+// Made with Bob
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultMaxNodeDetails caps the nodes returned in detail per cluster
+	DefaultMaxNodeDetails = 100
+
+	// nodeRoleLabelPrefix prefixes the labels carrying node roles
+	nodeRoleLabelPrefix = "node-role.kubernetes.io/"
+)
+
+// NodeService provides node operations
+type NodeService struct{}
+
+// NewNodeService creates a new node service
+func NewNodeService() *NodeService {
+	return &NodeService{}
+}
+
+// NodeResource compares the capacity of a node resource with what is allocatable to pods
+type NodeResource struct {
+	Capacity    string `json:"capacity"`
+	Allocatable string `json:"allocatable"`
+}
+
+// NodeInfo describes a node
+type NodeInfo struct {
+	Name           string       `json:"name"`
+	Ready          bool         `json:"ready"`
+	Roles          []string     `json:"roles"`
+	KubeletVersion string       `json:"kubeletVersion"`
+	CPU            NodeResource `json:"cpu"`
+	Memory         NodeResource `json:"memory"`
+	Message        string       `json:"message,omitempty"`
+}
+
+// NodeStatusList reports the nodes of a cluster. The counts always cover every node,
+// Nodes is capped and lists NotReady nodes first; Truncated is set when nodes were left out.
+type NodeStatusList struct {
+	Nodes     []NodeInfo     `json:"nodes"`
+	Total     int            `json:"total"`
+	Ready     int            `json:"ready"`
+	NotReady  int            `json:"notReady"`
+	ByRole    map[string]int `json:"byRole"`
+	Truncated bool           `json:"truncated,omitempty"`
+}
+
+// GetStatus lists the nodes page by page, counting readiness and roles of every node
+// and returning details for at most maxNodes of them (DefaultMaxNodeDetails when 0 or less)
+func (s *NodeService) GetStatus(ctx context.Context, client *clients.ClusterClient, maxNodes int) (*NodeStatusList, error) {
+	if maxNodes <= 0 {
+		maxNodes = DefaultMaxNodeDetails
+	}
+
+	result := &NodeStatusList{
+		Nodes:  []NodeInfo{},
+		ByRole: map[string]int{},
+	}
+
+	var notReady, ready []NodeInfo
+	err := listPages(ctx, metav1.ListOptions{}, func(opts metav1.ListOptions) (string, error) {
+		page, err := client.Clientset.CoreV1().Nodes().List(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+
+		for i := range page.Items {
+			info := s.convertNode(&page.Items[i])
+			result.Total++
+			for _, role := range info.Roles {
+				result.ByRole[role]++
+			}
+			// Keep only as many details as can be returned, NotReady nodes take precedence
+			if info.Ready {
+				result.Ready++
+				if len(ready) < maxNodes {
+					ready = append(ready, info)
+				}
+			} else {
+				result.NotReady++
+				if len(notReady) < maxNodes {
+					notReady = append(notReady, info)
+				}
+			}
+		}
+		return page.Continue, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	sortNodes := func(nodes []NodeInfo) {
+		sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+	}
+	sortNodes(notReady)
+	sortNodes(ready)
+	result.Nodes = append(result.Nodes, notReady...)
+	result.Nodes = append(result.Nodes, ready...)
+	if len(result.Nodes) > maxNodes {
+		result.Nodes = result.Nodes[:maxNodes]
+	}
+	result.Truncated = len(result.Nodes) < result.Total
+
+	return result, nil
+}
+
+// convertNode converts a Node to NodeInfo
+func (s *NodeService) convertNode(node *corev1.Node) NodeInfo {
+	info := NodeInfo{
+		Name:           node.Name,
+		Roles:          []string{},
+		KubeletVersion: node.Status.NodeInfo.KubeletVersion,
+		CPU: NodeResource{
+			Capacity:    node.Status.Capacity.Cpu().String(),
+			Allocatable: node.Status.Allocatable.Cpu().String(),
+		},
+		Memory: NodeResource{
+			Capacity:    FormatBytes(node.Status.Capacity.Memory().Value()),
+			Allocatable: FormatBytes(node.Status.Allocatable.Memory().Value()),
+		},
+	}
+
+	for label := range node.Labels {
+		if role, ok := strings.CutPrefix(label, nodeRoleLabelPrefix); ok && role != "" {
+			info.Roles = append(info.Roles, role)
+		}
+	}
+	sort.Strings(info.Roles)
+
+	info.Message = "Ready condition not reported"
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			info.Ready = condition.Status == corev1.ConditionTrue
+			info.Message = ""
+			if !info.Ready {
+				info.Message = condition.Message
+			}
+			break
+		}
+	}
+	if node.Spec.Unschedulable {
+		info.Message = strings.TrimPrefix(info.Message+"; cordoned", "; ")
+	}
+
+	return info
+}
+
+// Made with Bob
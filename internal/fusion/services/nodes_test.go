@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type NodesSuite struct {
+	suite.Suite
+}
+
+func node(name string, ready bool, roles ...string) *corev1.Node {
+	status := corev1.ConditionTrue
+	if !ready {
+		status = corev1.ConditionFalse
+	}
+	labels := map[string]string{}
+	for _, role := range roles {
+		labels["node-role.kubernetes.io/"+role] = ""
+	}
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Status: corev1.NodeStatus{
+			Conditions:  []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status, Message: "kubelet stopped posting status"}},
+			Capacity:    corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8"), corev1.ResourceMemory: resource.MustParse("32Gi")},
+			Allocatable: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("7500m"), corev1.ResourceMemory: resource.MustParse("30Gi")},
+			NodeInfo:    corev1.NodeSystemInfo{KubeletVersion: "v1.31.4"},
+		},
+	}
+}
+
+func (s *NodesSuite) TestGetStatus() {
+	objects := []runtime.Object{
+		node("master-0", true, "master", "control-plane"),
+		node("worker-2", false, "worker"),
+	}
+	for i := 0; i < 5; i++ {
+		objects = append(objects, node(fmt.Sprintf("worker-%d", 10+i), true, "worker"))
+	}
+	client := &clients.ClusterClient{Name: "prod-1", Clientset: fake.NewSimpleClientset(objects...)}
+
+	s.Run("counts every node", func() {
+		status, err := NewNodeService().GetStatus(context.Background(), client, 0)
+		s.Require().NoError(err)
+		s.Equal(7, status.Total)
+		s.Equal(6, status.Ready)
+		s.Equal(1, status.NotReady)
+		s.Equal(6, status.ByRole["worker"])
+		s.Equal(1, status.ByRole["master"])
+		s.False(status.Truncated)
+	})
+
+	s.Run("caps details with NotReady nodes first", func() {
+		status, err := NewNodeService().GetStatus(context.Background(), client, 3)
+		s.Require().NoError(err)
+		s.Equal(7, status.Total, "counts are not capped")
+		s.Require().Len(status.Nodes, 3)
+		s.True(status.Truncated)
+		s.Equal("worker-2", status.Nodes[0].Name)
+		s.False(status.Nodes[0].Ready)
+		s.Equal("kubelet stopped posting status", status.Nodes[0].Message)
+	})
+
+	s.Run("reports roles and resources", func() {
+		status, err := NewNodeService().GetStatus(context.Background(), client, 0)
+		s.Require().NoError(err)
+		var master NodeInfo
+		for _, n := range status.Nodes {
+			if n.Name == "master-0" {
+				master = n
+			}
+		}
+		s.Equal([]string{"control-plane", "master"}, master.Roles)
+		s.Equal("v1.31.4", master.KubeletVersion)
+		s.Equal("8", master.CPU.Capacity)
+		s.Equal("7500m", master.CPU.Allocatable)
+		s.Equal("32.0 GiB", master.Memory.Capacity)
+	})
+}
+
+func TestNodesSuite(t *testing.T) {
+	suite.Run(t, new(NodesSuite))
+}
+
+// Made with Bob
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// copiedCSVLabel marks CSVs OLM copies into every namespace for all-namespace operators
+const copiedCSVLabel = "olm.copiedFrom"
+
+// OperatorService provides operator (OLM ClusterServiceVersion) operations
+type OperatorService struct{}
+
+// NewOperatorService creates a new operator service
+func NewOperatorService() *OperatorService {
+	return &OperatorService{}
+}
+
+// OperatorFilter narrows the operators reported by OperatorService.GetStatus
+type OperatorFilter struct {
+	Namespace string `json:"namespace,omitempty"`
+	Prefix    string `json:"prefix,omitempty"`
+}
+
+// OperatorInfo describes an installed operator from its ClusterServiceVersion
+type OperatorInfo struct {
+	Name        string `json:"name"`
+	Namespace   string `json:"namespace"`
+	DisplayName string `json:"displayName,omitempty"`
+	Version     string `json:"version,omitempty"`
+	Phase       string `json:"phase"`
+	Reason      string `json:"reason,omitempty"`
+	Message     string `json:"message,omitempty"`
+}
+
+// OperatorStatusList reports the operators installed on a cluster
+type OperatorStatusList struct {
+	Operators []OperatorInfo `json:"operators"`
+	Total     int            `json:"total"`
+	Succeeded int            `json:"succeeded"`
+	Failed    int            `json:"failed"`
+	// Scope notes that the allowed namespaces restricted what was read
+	Scope string `json:"scope,omitempty"`
+}
+
+// GetStatus lists ClusterServiceVersions, cluster-wide unless a namespace is given,
+// keeping those whose name starts with the prefix. Copies OLM places in every
+// namespace for all-namespace operators are skipped so each operator appears once.
+func (s *OperatorService) GetStatus(ctx context.Context, client *clients.ClusterClient, filter OperatorFilter) (*OperatorStatusList, error) {
+	result := &OperatorStatusList{
+		Operators: []OperatorInfo{},
+	}
+
+	if !CheckCRDExists(ctx, client, ClusterServiceVersionGVR) {
+		return nil, fmt.Errorf("OLM is not installed: %s CRD not found", ClusterServiceVersionGVR.GroupResource().String())
+	}
+
+	dynamicClient, err := client.DynamicClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
+	csvs, scope, err := ListInScope(ctx, dynamicClient.Resource(ClusterServiceVersionGVR), filter.Namespace, metav1.ListOptions{})
+	result.Scope = scope
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ClusterServiceVersions: %w", err)
+	}
+
+	for _, csv := range csvs.Items {
+		if _, copied := csv.GetLabels()[copiedCSVLabel]; copied {
+			continue
+		}
+		if filter.Prefix != "" && !strings.HasPrefix(csv.GetName(), filter.Prefix) {
+			continue
+		}
+
+		operator := OperatorInfo{
+			Name:      csv.GetName(),
+			Namespace: csv.GetNamespace(),
+		}
+		operator.DisplayName, _, _ = unstructured.NestedString(csv.Object, "spec", "displayName")
+		operator.Version, _, _ = unstructured.NestedString(csv.Object, "spec", "version")
+		operator.Phase, _, _ = unstructured.NestedString(csv.Object, "status", "phase")
+		operator.Reason, _, _ = unstructured.NestedString(csv.Object, "status", "reason")
+		if operator.Phase != "Succeeded" {
+			operator.Message, _, _ = unstructured.NestedString(csv.Object, "status", "message")
+		}
+
+		switch operator.Phase {
+		case "Succeeded":
+			result.Succeeded++
+		case "Failed":
+			result.Failed++
+		}
+		result.Operators = append(result.Operators, operator)
+	}
+
+	sort.Slice(result.Operators, func(i, j int) bool {
+		if result.Operators[i].Namespace != result.Operators[j].Namespace {
+			return result.Operators[i].Namespace < result.Operators[j].Namespace
+		}
+		return result.Operators[i].Name < result.Operators[j].Name
+	})
+	result.Total = len(result.Operators)
+
+	return result, nil
+}
+
+// Made with Bob
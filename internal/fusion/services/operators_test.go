@@ -0,0 +1,102 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+type OperatorSuite struct {
+	suite.Suite
+}
+
+// clusterServiceVersion returns a CSV in the given phase, a copy OLM placed in the
+// namespace when copiedFrom is set
+func clusterServiceVersion(namespace, name, version, phase, copiedFrom string) *unstructured.Unstructured {
+	csv := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec":   map[string]interface{}{"displayName": name + " operator", "version": version},
+		"status": map[string]interface{}{"phase": phase, "reason": "InstallSucceeded", "message": "install strategy completed"},
+	}}
+	csv.SetAPIVersion(ClusterServiceVersionGVR.GroupVersion().String())
+	csv.SetKind("ClusterServiceVersion")
+	csv.SetNamespace(namespace)
+	csv.SetName(name + ".v" + version)
+	if copiedFrom != "" {
+		csv.SetLabels(map[string]string{copiedCSVLabel: copiedFrom})
+	}
+	return csv
+}
+
+// operatorCluster is a cluster with OLM serving the CSVs
+func operatorCluster(csvs ...runtime.Object) *clients.ClusterClient {
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{ClusterServiceVersionGVR: "ClusterServiceVersionList"}, csvs...)
+	return &clients.ClusterClient{Name: "prod-1", Dynamic: dynamicClient}
+}
+
+func (s *OperatorSuite) TestGetStatus() {
+	service := NewOperatorService()
+	client := operatorCluster(
+		clusterServiceVersion("ibm-spectrum-fusion-ns", "isf-operator", "2.9.0", "Succeeded", ""),
+		clusterServiceVersion("openshift-storage", "odf-operator", "4.16.2", "Succeeded", ""),
+		clusterServiceVersion("openshift-storage", "ocs-operator", "4.16.2", "Failed", ""),
+		clusterServiceVersion("openshift-adp", "oadp-operator", "1.4.1", "Installing", ""),
+		clusterServiceVersion("shop", "odf-operator", "4.16.2", "Succeeded", "openshift-storage"),
+	)
+	ctx := discoveredContext("prod-1", ClusterServiceVersionGVR)
+
+	s.Run("lists the operators of every namespace", func() {
+		list, err := service.GetStatus(ctx, client, OperatorFilter{})
+		s.Require().NoError(err)
+		s.Equal(4, list.Total, "copied CSVs are skipped")
+		s.Equal(2, list.Succeeded)
+		s.Equal(1, list.Failed)
+		s.Empty(list.Scope)
+		s.Equal(OperatorInfo{
+			Name:        "isf-operator.v2.9.0",
+			Namespace:   "ibm-spectrum-fusion-ns",
+			DisplayName: "isf-operator operator",
+			Version:     "2.9.0",
+			Phase:       "Succeeded",
+			Reason:      "InstallSucceeded",
+		}, list.Operators[0], "the message of a Succeeded operator is dropped")
+		s.Equal("openshift-adp", list.Operators[1].Namespace)
+		s.Equal("install strategy completed", list.Operators[1].Message)
+		s.Equal("ocs-operator.v4.16.2", list.Operators[2].Name, "sorted by namespace, then by name")
+	})
+	s.Run("filters by namespace", func() {
+		list, err := service.GetStatus(ctx, client, OperatorFilter{Namespace: "openshift-storage"})
+		s.Require().NoError(err)
+		s.Equal(2, list.Total)
+		for _, operator := range list.Operators {
+			s.Equal("openshift-storage", operator.Namespace)
+		}
+	})
+	s.Run("filters by name prefix", func() {
+		list, err := service.GetStatus(ctx, client, OperatorFilter{Prefix: "odf-"})
+		s.Require().NoError(err)
+		s.Require().Equal(1, list.Total)
+		s.Equal("odf-operator.v4.16.2", list.Operators[0].Name)
+	})
+	s.Run("combines both filters", func() {
+		list, err := service.GetStatus(ctx, client, OperatorFilter{Namespace: "openshift-adp", Prefix: "odf-"})
+		s.Require().NoError(err)
+		s.Zero(list.Total)
+		s.NotNil(list.Operators)
+	})
+	s.Run("fails when OLM is not installed", func() {
+		_, err := service.GetStatus(discoveredContext("prod-1"), client, OperatorFilter{})
+		s.EqualError(err, "OLM is not installed: clusterserviceversions.operators.coreos.com CRD not found")
+	})
+}
+
+func TestOperatorSuite(t *testing.T) {
+	suite.Run(t, new(OperatorSuite))
+}
+
+// Made with Bob
@@ -0,0 +1,218 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+)
+
+// Overview verdicts
+const (
+	VerdictHealthy      = "healthy"
+	VerdictDegraded     = "degraded"
+	VerdictNotInstalled = "not-installed"
+)
+
+// OverviewService rolls up the status of every Fusion component of a cluster
+type OverviewService struct {
+	cache  *clients.StatusCache
+	maxAge time.Duration
+}
+
+// NewOverviewService creates a new overview service
+func NewOverviewService() *OverviewService {
+	return &OverviewService{}
+}
+
+// WithStatusCache makes the overview reuse component statuses at most maxAge old from
+// cache, and store the ones it checks. A maxAge of 0 checks every component and only
+// refreshes the cache.
+func (s *OverviewService) WithStatusCache(cache *clients.StatusCache, maxAge time.Duration) *OverviewService {
+	s.cache = cache
+	s.maxAge = maxAge
+	return s
+}
+
+// ComponentHealth is the rollup entry of a single component
+// Cached marks a status reused from the status cache, AgeMs is then how old it is.
+// A failed check has an unknown severity.
+type ComponentHealth struct {
+	Installed bool               `json:"installed"`
+	Ready     bool               `json:"ready"`
+	Severity  string             `json:"severity,omitempty"`
+	Reason    NotInstalledReason `json:"reason,omitempty"`
+	Message   string             `json:"message,omitempty"`
+	Error     string             `json:"error,omitempty"`
+	Cached    bool               `json:"cached,omitempty"`
+	AgeMs     int64              `json:"ageMs,omitempty"`
+}
+
+// Overview is the health rollup of a cluster. Unhealthy lists the components that
+// failed their check or are installed but not ready; components that aren't installed
+// don't affect the verdict. Severity is the worst severity of the components, a cluster
+// may be healthy and still warn.
+type Overview struct {
+	Verdict    string                     `json:"verdict"`
+	Healthy    bool                       `json:"healthy"`
+	Severity   string                     `json:"severity,omitempty"`
+	Components map[string]ComponentHealth `json:"components"`
+	Unhealthy  []string                   `json:"unhealthy,omitempty"`
+}
+
+// statusCheck returns the ComponentStatus of a component
+type statusCheck func(ctx context.Context, client *clients.ClusterClient) (ComponentStatus, error)
+
+// overviewChecks are the components covered by the overview, keyed by component name
+var overviewChecks = map[string]statusCheck{
+	"gdp": func(ctx context.Context, client *clients.ClusterClient) (ComponentStatus, error) {
+		status, err := NewGDPService().GetStatus(ctx, client, "")
+		if err != nil {
+			return ComponentStatus{}, err
+		}
+		return status.ComponentStatus, nil
+	},
+	"dr": func(ctx context.Context, client *clients.ClusterClient) (ComponentStatus, error) {
+		status, err := NewDRService().GetStatus(ctx, client)
+		if err != nil {
+			return ComponentStatus{}, err
+		}
+		return status.ComponentStatus, nil
+	},
+	"datafoundation": func(ctx context.Context, client *clients.ClusterClient) (ComponentStatus, error) {
+		status, err := NewDataFoundationService(nil).GetStatus(ctx, client, "")
+		if err != nil {
+			return ComponentStatus{}, err
+		}
+		return status.ComponentStatus, nil
+	},
+	"backup": func(ctx context.Context, client *clients.ClusterClient) (ComponentStatus, error) {
+		status, err := NewBackupService(nil).ListJobs(ctx, client, "", 0)
+		if err != nil {
+			return ComponentStatus{}, err
+		}
+		return status.ComponentStatus, nil
+	},
+	"observability": func(ctx context.Context, client *clients.ClusterClient) (ComponentStatus, error) {
+		status, err := NewObservabilityService().GetSummary(ctx, client)
+		if err != nil {
+			return ComponentStatus{}, err
+		}
+		return status.ComponentStatus, nil
+	},
+	"serviceability": func(ctx context.Context, client *clients.ClusterClient) (ComponentStatus, error) {
+		status, err := NewServiceabilityService().GetSummary(ctx, client)
+		if err != nil {
+			return ComponentStatus{}, err
+		}
+		return status.ComponentStatus, nil
+	},
+	"virtualization": func(ctx context.Context, client *clients.ClusterClient) (ComponentStatus, error) {
+		status, err := NewVirtualizationService().GetStatus(ctx, client, "")
+		if err != nil {
+			return ComponentStatus{}, err
+		}
+		return status.ComponentStatus, nil
+	},
+	"hcp": func(ctx context.Context, client *clients.ClusterClient) (ComponentStatus, error) {
+		status, err := NewHCPService().GetStatus(ctx, client, "")
+		if err != nil {
+			return ComponentStatus{}, err
+		}
+		return status.ComponentStatus, nil
+	},
+}
+
+// OverviewComponents returns the names of the components covered by the overview, sorted
+func OverviewComponents() []string {
+	names := make([]string, 0, len(overviewChecks))
+	for name := range overviewChecks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetOverview runs every component check concurrently and rolls them up into a verdict
+func (s *OverviewService) GetOverview(ctx context.Context, client *clients.ClusterClient) (*Overview, error) {
+	overview := &Overview{
+		Components: make(map[string]ComponentHealth, len(overviewChecks)),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range overviewChecks {
+		wg.Add(1)
+		go func(name string, check statusCheck) {
+			defer wg.Done()
+			health := ComponentHealth{}
+			status, age, cached, err := s.checkComponent(ctx, client, name, check)
+			health.Cached = cached
+			health.AgeMs = age.Milliseconds()
+			if err != nil {
+				health.Error = err.Error()
+				health.Severity = SeverityUnknown
+			} else {
+				status.ResolveSeverity()
+				health.Installed = status.Installed
+				health.Ready = status.IsHealthy()
+				health.Severity = status.Severity
+				health.Reason = status.Reason
+				health.Message = status.Message
+			}
+			mu.Lock()
+			overview.Components[name] = health
+			mu.Unlock()
+		}(name, check)
+	}
+	wg.Wait()
+
+	installed := 0
+	for _, name := range OverviewComponents() {
+		health := overview.Components[name]
+		if health.Installed {
+			installed++
+		}
+		overview.Severity = WorstSeverity(overview.Severity, health.Severity)
+		if health.Error != "" || (health.Installed && !health.Ready) {
+			overview.Unhealthy = append(overview.Unhealthy, name)
+		}
+	}
+
+	switch {
+	case len(overview.Unhealthy) > 0:
+		overview.Verdict = VerdictDegraded
+	case installed == 0:
+		overview.Verdict = VerdictNotInstalled
+	default:
+		overview.Verdict = VerdictHealthy
+		overview.Healthy = true
+	}
+
+	return overview, nil
+}
+
+// checkComponent runs the check of a component, answering from the status cache when it
+// holds a recent enough status. Failed checks are not cached.
+func (s *OverviewService) checkComponent(ctx context.Context, client *clients.ClusterClient, name string, check statusCheck) (ComponentStatus, time.Duration, bool, error) {
+	if s.cache == nil {
+		status, err := check(ctx, client)
+		return status, 0, false, err
+	}
+	if s.maxAge > 0 {
+		if cached, age, ok := s.cache.Get(client.Name, name, s.maxAge); ok {
+			if status, ok := cached.(ComponentStatus); ok {
+				return status, age, true, nil
+			}
+		}
+	}
+	status, err := check(ctx, client)
+	if err == nil {
+		s.cache.Put(client.Name, name, status)
+	}
+	return status, 0, false, err
+}
+
+// Made with Bob
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/stretchr/testify/suite"
+)
+
+type OverviewSuite struct {
+	suite.Suite
+	originalChecks map[string]statusCheck
+}
+
+func (s *OverviewSuite) SetupTest() {
+	s.originalChecks = overviewChecks
+}
+
+func (s *OverviewSuite) TearDownTest() {
+	overviewChecks = s.originalChecks
+}
+
+func (s *OverviewSuite) TestStatusCache() {
+	checks := 0
+	failing := false
+	overviewChecks = map[string]statusCheck{
+		"dr": func(ctx context.Context, client *clients.ClusterClient) (ComponentStatus, error) {
+			checks++
+			if failing {
+				return ComponentStatus{}, errors.New("connection refused")
+			}
+			return ComponentStatus{Installed: true, Ready: true}, nil
+		},
+	}
+	client := &clients.ClusterClient{Name: "prod-1"}
+	cache := clients.NewStatusCache()
+
+	s.Run("reuses a recent status and reports its age", func() {
+		first, err := NewOverviewService().WithStatusCache(cache, time.Minute).GetOverview(context.Background(), client)
+		s.Require().NoError(err)
+		s.False(first.Components["dr"].Cached)
+
+		second, err := NewOverviewService().WithStatusCache(cache, time.Minute).GetOverview(context.Background(), client)
+		s.Require().NoError(err)
+		s.True(second.Components["dr"].Cached)
+		s.True(second.Healthy)
+		s.Equal(1, checks)
+	})
+	s.Run("a maxAge of 0 checks again and refreshes the cache", func() {
+		overview, err := NewOverviewService().WithStatusCache(cache, 0).GetOverview(context.Background(), client)
+		s.Require().NoError(err)
+		s.False(overview.Components["dr"].Cached)
+		s.Equal(2, checks)
+	})
+	s.Run("failed checks are not cached", func() {
+		failing = true
+		fresh := clients.NewStatusCache()
+		overview, err := NewOverviewService().WithStatusCache(fresh, time.Minute).GetOverview(context.Background(), client)
+		s.Require().NoError(err)
+		s.Equal("connection refused", overview.Components["dr"].Error)
+		_, _, ok := fresh.Get("prod-1", "dr", time.Minute)
+		s.False(ok)
+	})
+}
+
+func (s *OverviewSuite) TestSeverity() {
+	overviewChecks = map[string]statusCheck{
+		"datafoundation": func(ctx context.Context, client *clients.ClusterClient) (ComponentStatus, error) {
+			status := InstalledStatus(true, "", "Ceph HEALTH_WARN")
+			status.RaiseSeverity(SeverityWarning)
+			return status, nil
+		},
+		"dr": func(ctx context.Context, client *clients.ClusterClient) (ComponentStatus, error) {
+			return InstalledStatus(true, "", ""), nil
+		},
+		"hcp": func(ctx context.Context, client *clients.ClusterClient) (ComponentStatus, error) {
+			return NotInstalledStatus(ReasonNamespaceAbsent, "not found"), nil
+		},
+	}
+	overview, err := NewOverviewService().GetOverview(context.Background(), &clients.ClusterClient{Name: "prod-1"})
+	s.Require().NoError(err)
+	s.Equal(SeverityWarning, overview.Components["datafoundation"].Severity)
+	s.Equal(SeverityOK, overview.Components["dr"].Severity)
+	s.Empty(overview.Components["hcp"].Severity)
+	s.Equal(ReasonNamespaceAbsent, overview.Components["hcp"].Reason, "the reason a component is not installed is kept")
+	s.Equal(SeverityWarning, overview.Severity)
+	s.True(overview.Healthy, "warnings don't change the verdict")
+}
+
+func TestOverviewSuite(t *testing.T) {
+	suite.Run(t, new(OverviewSuite))
+}
+
+// Made with Bob
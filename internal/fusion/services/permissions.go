@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// permissionCheck is an access the Fusion tools need, Namespace empty meaning all namespaces
+type permissionCheck struct {
+	verb      string
+	gvr       schema.GroupVersionResource
+	namespace string
+	purpose   string
+}
+
+// permissionChecks are the key accesses of the Fusion tools, checked by CheckPermissions
+var permissionChecks = []permissionCheck{
+	{verb: "get", gvr: schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}, purpose: "detect installed components by their namespace"},
+	{verb: "list", gvr: schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}, purpose: "inspect installed CRDs"},
+	{verb: "list", gvr: schema.GroupVersionResource{Version: "v1", Resource: "pods"}, purpose: "check the pods of components"},
+	{verb: "list", gvr: schema.GroupVersionResource{Version: "v1", Resource: "nodes"}, purpose: "report node readiness and capacity"},
+	{verb: "list", gvr: schema.GroupVersionResource{Version: "v1", Resource: "events"}, purpose: "list Warning events"},
+	{verb: "list", gvr: schema.GroupVersionResource{Version: "v1", Resource: "persistentvolumeclaims"}, purpose: "report PVCs"},
+	{verb: "list", gvr: schema.GroupVersionResource{Group: "storage.k8s.io", Version: "v1", Resource: "storageclasses"}, purpose: "report storage classes"},
+	{verb: "list", gvr: ClusterServiceVersionGVR, purpose: "read operator versions"},
+	{verb: "list", gvr: VeleroBackupGVR, namespace: OADPNamespace, purpose: "read Velero backups"},
+	{verb: "create", gvr: VeleroBackupGVR, namespace: OADPNamespace, purpose: "create on-demand backups"},
+	{verb: "list", gvr: targeting.DRPlacementControlGVR, purpose: "read DR placements of workloads"},
+	{verb: "list", gvr: VirtualMachineGVR, purpose: "count virtual machines"},
+	{verb: "list", gvr: HostedClusterGVR, purpose: "list hosted clusters"},
+}
+
+// PermissionResult reports whether the credentials of the server are allowed an access
+// Error is set when the access could not be reviewed, Allowed is then false
+type PermissionResult struct {
+	Verb      string `json:"verb"`
+	Group     string `json:"group,omitempty"`
+	Resource  string `json:"resource"`
+	Namespace string `json:"namespace,omitempty"`
+	Purpose   string `json:"purpose"`
+	Allowed   bool   `json:"allowed"`
+	Reason    string `json:"reason,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// PermissionReport reports who the server is on a cluster and what it may do there
+// Username and Groups are empty when the cluster does not serve SelfSubjectReviews
+type PermissionReport struct {
+	Username    string             `json:"username,omitempty"`
+	Groups      []string           `json:"groups,omitempty"`
+	Permissions []PermissionResult `json:"permissions"`
+	Allowed     int                `json:"allowed"`
+	Denied      int                `json:"denied"`
+}
+
+// CheckPermissions reviews the key accesses of the Fusion tools with
+// SelfSubjectAccessReviews, so missing RBAC is known before a tool fails on it
+func (s *ClusterService) CheckPermissions(ctx context.Context, client *clients.ClusterClient) (*PermissionReport, error) {
+	report := &PermissionReport{
+		Permissions: make([]PermissionResult, 0, len(permissionChecks)),
+	}
+
+	// Older API servers don't serve SelfSubjectReviews, the accesses are still reviewed
+	if review, err := client.Clientset.AuthenticationV1().SelfSubjectReviews().Create(ctx, &authenticationv1.SelfSubjectReview{}, metav1.CreateOptions{}); err == nil {
+		report.Username = review.Status.UserInfo.Username
+		report.Groups = review.Status.UserInfo.Groups
+	} else {
+		logDegraded(ctx, err, "Cannot review the server identity")
+	}
+
+	reviewed := 0
+	for _, check := range permissionChecks {
+		result := PermissionResult{
+			Verb:      check.verb,
+			Group:     check.gvr.Group,
+			Resource:  check.gvr.Resource,
+			Namespace: check.namespace,
+			Purpose:   check.purpose,
+		}
+		review, err := client.Clientset.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Verb:      check.verb,
+					Group:     check.gvr.Group,
+					Resource:  check.gvr.Resource,
+					Namespace: check.namespace,
+				},
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			reviewed++
+			result.Allowed = review.Status.Allowed
+			result.Reason = review.Status.Reason
+		}
+		if result.Allowed {
+			report.Allowed++
+		} else {
+			report.Denied++
+		}
+		report.Permissions = append(report.Permissions, result)
+	}
+
+	if reviewed == 0 && len(permissionChecks) > 0 {
+		return nil, fmt.Errorf("failed to review permissions: %s", report.Permissions[0].Error)
+	}
+	return report, nil
+}
+
+// Made with Bob
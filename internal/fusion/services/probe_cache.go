@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"sync"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterProbeCache memoizes namespace and CRD existence lookups for the duration of
+// one tool invocation, so the services a tool combines don't repeat them per cluster.
+// Unlike clients.DiscoveryCache it also remembers a failed discovery, which otherwise
+// re-runs on every CRD probe of a cluster whose aggregated APIs are unavailable.
+type ClusterProbeCache struct {
+	mu         sync.Mutex
+	namespaces map[probeKey]*namespaceProbe
+	discovery  map[string]*discoveryProbe
+}
+
+// probeKey identifies a namespace of a cluster
+type probeKey struct {
+	cluster string
+	name    string
+}
+
+// namespaceProbe holds the outcome of one namespace lookup
+type namespaceProbe struct {
+	mu     sync.Mutex
+	done   bool
+	exists bool
+}
+
+// discoveryProbe holds the discovered resources of one cluster
+type discoveryProbe struct {
+	mu        sync.Mutex
+	done      bool
+	resources []*metav1.APIResourceList
+	err       error
+}
+
+// NewClusterProbeCache creates an empty probe cache
+func NewClusterProbeCache() *ClusterProbeCache {
+	return &ClusterProbeCache{
+		namespaces: make(map[probeKey]*namespaceProbe),
+		discovery:  make(map[string]*discoveryProbe),
+	}
+}
+
+type probeCacheKey struct{}
+
+// WithProbeCache returns a context carrying the probe cache
+func WithProbeCache(ctx context.Context, cache *ClusterProbeCache) context.Context {
+	return context.WithValue(ctx, probeCacheKey{}, cache)
+}
+
+// ProbeCacheFromContext returns the probe cache of the context, nil when it has none
+func ProbeCacheFromContext(ctx context.Context) *ClusterProbeCache {
+	cache, _ := ctx.Value(probeCacheKey{}).(*ClusterProbeCache)
+	return cache
+}
+
+// namespaceExists looks a namespace up once per cluster. Only a found or not-found answer
+// is remembered, other errors report the namespace as missing and are retried next time.
+func (c *ClusterProbeCache) namespaceExists(ctx context.Context, client *clients.ClusterClient, namespace string) (bool, error) {
+	key := probeKey{cluster: client.Name, name: namespace}
+	c.mu.Lock()
+	probe, ok := c.namespaces[key]
+	if !ok {
+		probe = &namespaceProbe{}
+		c.namespaces[key] = probe
+	}
+	c.mu.Unlock()
+
+	// Concurrent probes of the same namespace wait for the first one
+	probe.mu.Lock()
+	defer probe.mu.Unlock()
+	if probe.done {
+		return probe.exists, nil
+	}
+	exists, err := getNamespace(ctx, client, namespace)
+	if err == nil || apierrors.IsNotFound(err) {
+		probe.done = true
+		probe.exists = exists
+		return exists, nil
+	}
+	return false, err
+}
+
+// serverResources discovers the resources of a cluster once. A discovery cut short by
+// the caller's context is not remembered.
+func (c *ClusterProbeCache) serverResources(ctx context.Context, client *clients.ClusterClient) ([]*metav1.APIResourceList, error) {
+	c.mu.Lock()
+	probe, ok := c.discovery[client.Name]
+	if !ok {
+		probe = &discoveryProbe{}
+		c.discovery[client.Name] = probe
+	}
+	c.mu.Unlock()
+
+	probe.mu.Lock()
+	defer probe.mu.Unlock()
+	if probe.done {
+		return probe.resources, probe.err
+	}
+	resources, err := clients.ServerResources(ctx, client)
+	if ctx.Err() == nil {
+		probe.done = true
+		probe.resources, probe.err = resources, err
+	}
+	return resources, err
+}
+
+// serverResources returns the API resources of a cluster through the probe cache of the
+// context when it has one
+func serverResources(ctx context.Context, client *clients.ClusterClient) ([]*metav1.APIResourceList, error) {
+	if cache := ProbeCacheFromContext(ctx); cache != nil {
+		return cache.serverResources(ctx, client)
+	}
+	// Discovery is cached per API server, see clients.DiscoveryCache
+	return clients.ServerResources(ctx, client)
+}
+
+// getNamespace reads a namespace, reporting whether it exists
+func getNamespace(ctx context.Context, client *clients.ClusterClient, namespace string) (bool, error) {
+	_, err := client.Clientset.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	return err == nil, err
+}
+
+// Made with Bob
@@ -0,0 +1,251 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+type ProbeCacheSuite struct {
+	suite.Suite
+}
+
+// namespaceGets counts the namespace reads of a fake clientset
+func namespaceGets(clientset *fake.Clientset) int {
+	gets := 0
+	for _, action := range clientset.Actions() {
+		if action.GetVerb() == "get" && action.GetResource().Resource == "namespaces" {
+			gets++
+		}
+	}
+	return gets
+}
+
+func (s *ProbeCacheSuite) TestNamespaceExists() {
+	s.Run("concurrent probes of a namespace share one read", func() {
+		clientset := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "openshift-storage"}})
+		client := &clients.ClusterClient{Name: "prod-1", Clientset: clientset}
+		ctx := WithProbeCache(context.Background(), NewClusterProbeCache())
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				s.True(CheckNamespaceExists(ctx, client, "openshift-storage"))
+			}()
+		}
+		wg.Wait()
+		s.False(CheckNamespaceExists(ctx, client, "openshift-adp"))
+		s.False(CheckNamespaceExists(ctx, client, "openshift-adp"))
+		s.Equal(2, namespaceGets(clientset))
+	})
+	s.Run("clusters are probed separately", func() {
+		ctx := WithProbeCache(context.Background(), NewClusterProbeCache())
+		prod := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "openshift-storage"}})
+		edge := fake.NewSimpleClientset()
+		s.True(CheckNamespaceExists(ctx, &clients.ClusterClient{Name: "prod-1", Clientset: prod}, "openshift-storage"))
+		s.False(CheckNamespaceExists(ctx, &clients.ClusterClient{Name: "edge-1", Clientset: edge}, "openshift-storage"))
+	})
+	s.Run("transient errors are not remembered", func() {
+		clientset := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "openshift-storage"}})
+		failures := 1
+		clientset.PrependReactor("get", "namespaces", func(k8stesting.Action) (bool, runtime.Object, error) {
+			if failures > 0 {
+				failures--
+				return true, nil, errors.New("connection reset by peer")
+			}
+			return false, nil, nil
+		})
+		client := &clients.ClusterClient{Name: "prod-1", Clientset: clientset}
+		ctx := WithProbeCache(context.Background(), NewClusterProbeCache())
+
+		s.False(CheckNamespaceExists(ctx, client, "openshift-storage"))
+		s.True(CheckNamespaceExists(ctx, client, "openshift-storage"))
+	})
+	s.Run("without a cache every probe reads the namespace", func() {
+		clientset := fake.NewSimpleClientset()
+		client := &clients.ClusterClient{Name: "prod-1", Clientset: clientset}
+		CheckNamespaceExists(context.Background(), client, "openshift-adp")
+		CheckNamespaceExists(context.Background(), client, "openshift-adp")
+		s.Equal(2, namespaceGets(clientset))
+	})
+}
+
+func (s *ProbeCacheSuite) TestProbeCacheFromContext() {
+	s.Nil(ProbeCacheFromContext(context.Background()))
+	cache := NewClusterProbeCache()
+	s.Same(cache, ProbeCacheFromContext(WithProbeCache(context.Background(), cache)))
+}
+
+func (s *ProbeCacheSuite) TestCheckCRDsExist() {
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api":
+			_, _ = fmt.Fprint(w, `{"kind":"APIVersions","versions":["v1"]}`)
+		case "/api/v1":
+			_, _ = fmt.Fprint(w, `{"kind":"APIResourceList","groupVersion":"v1","resources":[{"name":"namespaces","namespaced":false,"kind":"Namespace","verbs":["get","list"]}]}`)
+		case "/apis":
+			_, _ = fmt.Fprint(w, `{"kind":"APIGroupList","groups":[{"name":"ramendr.openshift.io","versions":[{"groupVersion":"ramendr.openshift.io/v1alpha1","version":"v1alpha1"}],"preferredVersion":{"groupVersion":"ramendr.openshift.io/v1alpha1","version":"v1alpha1"}}]}`)
+		case "/apis/ramendr.openshift.io/v1alpha1":
+			_, _ = fmt.Fprint(w, `{"kind":"APIResourceList","groupVersion":"ramendr.openshift.io/v1alpha1","resources":[{"name":"drpolicies","namespaced":false,"kind":"DRPolicy","verbs":["get","list"]}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client := probeClient(s.T(), server)
+	namespacesGVR := schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+
+	exists := CheckCRDsExist(context.Background(), client, []schema.GroupVersionResource{DRPolicyGVR, targeting.DRClusterGVR, namespacesGVR})
+	s.Equal(map[schema.GroupVersionResource]bool{
+		DRPolicyGVR:            true,
+		targeting.DRClusterGVR: false,
+		namespacesGVR:          true,
+	}, exists)
+
+	s.Run("answers every resource from one discovery", func() {
+		discovered := requests.Load()
+		s.True(CheckCRDExists(context.Background(), client, DRPolicyGVR))
+		s.False(CheckCRDExists(context.Background(), client, targeting.DRClusterGVR))
+		s.Equal(discovered, requests.Load())
+	})
+}
+
+func (s *ProbeCacheSuite) TestOverviewRequests() {
+	uncached := overviewRequests(s.T(), false)
+	cached := overviewRequests(s.T(), true)
+	s.Less(cached, uncached, "the probe cache cuts the requests of the overviews")
+}
+
+func TestProbeCacheSuite(t *testing.T) {
+	suite.Run(t, new(ProbeCacheSuite))
+}
+
+// probeServer serves discovery where the aggregated metrics API is unavailable, the
+// common case where discovery fails and the shared discovery cache can't keep it.
+// Everything else is not found. Every request is counted.
+func probeServer(requests *atomic.Int64) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api":
+			_, _ = fmt.Fprint(w, `{"kind":"APIVersions","versions":["v1"]}`)
+		case "/api/v1":
+			_, _ = fmt.Fprint(w, `{"kind":"APIResourceList","groupVersion":"v1","resources":[{"name":"namespaces","namespaced":false,"kind":"Namespace","verbs":["get","list"]}]}`)
+		case "/apis":
+			_, _ = fmt.Fprint(w, `{"kind":"APIGroupList","groups":[{"name":"metrics.k8s.io","versions":[{"groupVersion":"metrics.k8s.io/v1beta1","version":"v1beta1"}],"preferredVersion":{"groupVersion":"metrics.k8s.io/v1beta1","version":"v1beta1"}}]}`)
+		case "/apis/metrics.k8s.io/v1beta1":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprint(w, `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`)
+		}
+	}))
+}
+
+// overviewRequests returns the API requests of two overviews of a cluster in one
+// invocation. Within a single overview concurrent probes may already share an in-flight
+// discovery, so only the second overview tells reliably what the probe cache saves.
+func overviewRequests(tb testing.TB, withCache bool) int64 {
+	var requests atomic.Int64
+	server := probeServer(&requests)
+	defer server.Close()
+	client := probeClient(tb, server)
+
+	ctx := context.Background()
+	if withCache {
+		ctx = WithProbeCache(ctx, NewClusterProbeCache())
+	}
+	for range 2 {
+		if _, err := NewOverviewService().GetOverview(ctx, client); err != nil {
+			tb.Fatal(err)
+		}
+	}
+	return requests.Load()
+}
+
+// discoveredContext returns a context whose probe cache has already discovered the
+// resources on the cluster, so CRD probes of clients without a REST config answer
+func discoveredContext(cluster string, gvrs ...schema.GroupVersionResource) context.Context {
+	lists := map[string]*metav1.APIResourceList{}
+	var resources []*metav1.APIResourceList
+	for _, gvr := range gvrs {
+		groupVersion := gvr.GroupVersion().String()
+		list, ok := lists[groupVersion]
+		if !ok {
+			list = &metav1.APIResourceList{GroupVersion: groupVersion}
+			lists[groupVersion] = list
+			resources = append(resources, list)
+		}
+		list.APIResources = append(list.APIResources, metav1.APIResource{Name: gvr.Resource, Namespaced: true})
+	}
+	cache := NewClusterProbeCache()
+	cache.discovery[cluster] = &discoveryProbe{done: true, resources: resources}
+	return WithProbeCache(context.Background(), cache)
+}
+
+// probeClient returns a cluster client talking to server
+func probeClient(tb testing.TB, server *httptest.Server) *clients.ClusterClient {
+	config := &rest.Config{Host: server.URL}
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return &clients.ClusterClient{Name: "prod-1", Config: config, Clientset: clientset}
+}
+
+// BenchmarkOverviewProbes reports the API requests of an overview with and without the
+// probe cache, see requests/op
+func BenchmarkOverviewProbes(b *testing.B) {
+	for _, bc := range []struct {
+		name      string
+		withCache bool
+	}{
+		{name: "uncached", withCache: false},
+		{name: "probe-cache", withCache: true},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			var requests atomic.Int64
+			server := probeServer(&requests)
+			defer server.Close()
+			client := probeClient(b, server)
+			service := NewOverviewService()
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				ctx := context.Background()
+				if bc.withCache {
+					ctx = WithProbeCache(ctx, NewClusterProbeCache())
+				}
+				if _, err := service.GetOverview(ctx, client); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(requests.Load())/float64(b.N), "requests/op")
+		})
+	}
+}
+
+// Made with Bob
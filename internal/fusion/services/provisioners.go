@@ -0,0 +1,66 @@
+package services
+
+// Storage class categories, by what backs the volumes of their provisioner
+const (
+	// StorageCategoryODF is OpenShift Data Foundation (Ceph) storage
+	StorageCategoryODF = "odf"
+	// StorageCategoryCloudBlock is block storage of a cloud or hypervisor platform
+	StorageCategoryCloudBlock = "cloud-block"
+	// StorageCategoryCloudFile is shared file storage of a cloud platform
+	StorageCategoryCloudFile = "cloud-file"
+	// StorageCategoryLocal is storage on the disks of a node, lost with the node
+	StorageCategoryLocal = "local"
+	// StorageCategoryOther is any provisioner not known here
+	StorageCategoryOther = "other"
+)
+
+// provisionerCategories maps the known provisioners, CSI and in-tree, to their category
+var provisionerCategories = map[string]string{
+	"openshift-storage.rbd.csi.ceph.com":    StorageCategoryODF,
+	"openshift-storage.cephfs.csi.ceph.com": StorageCategoryODF,
+	"ocs-storagecluster-ceph-rbd":           StorageCategoryODF,
+	"ocs-storagecluster-cephfs":             StorageCategoryODF,
+
+	"ebs.csi.aws.com":              StorageCategoryCloudBlock,
+	"kubernetes.io/aws-ebs":        StorageCategoryCloudBlock,
+	"pd.csi.storage.gke.io":        StorageCategoryCloudBlock,
+	"kubernetes.io/gce-pd":         StorageCategoryCloudBlock,
+	"disk.csi.azure.com":           StorageCategoryCloudBlock,
+	"kubernetes.io/azure-disk":     StorageCategoryCloudBlock,
+	"vpc.block.csi.ibm.io":         StorageCategoryCloudBlock,
+	"cinder.csi.openstack.org":     StorageCategoryCloudBlock,
+	"kubernetes.io/cinder":         StorageCategoryCloudBlock,
+	"csi.vsphere.vmware.com":       StorageCategoryCloudBlock,
+	"kubernetes.io/vsphere-volume": StorageCategoryCloudBlock,
+
+	"efs.csi.aws.com":              StorageCategoryCloudFile,
+	"filestore.csi.storage.gke.io": StorageCategoryCloudFile,
+	"file.csi.azure.com":           StorageCategoryCloudFile,
+	"kubernetes.io/azure-file":     StorageCategoryCloudFile,
+	"vpc.file.csi.ibm.io":          StorageCategoryCloudFile,
+	"manila.csi.openstack.org":     StorageCategoryCloudFile,
+
+	"kubernetes.io/no-provisioner":      StorageCategoryLocal,
+	"topolvm.io":                        StorageCategoryLocal,
+	"topolvm.cybozu.com":                StorageCategoryLocal,
+	"kubevirt.io.hostpath-provisioner":  StorageCategoryLocal,
+	"kubevirt.io/hostpath-provisioner":  StorageCategoryLocal,
+	"rancher.io/local-path":             StorageCategoryLocal,
+	"local.storage.openshift.io/device": StorageCategoryLocal,
+}
+
+// StorageCategory returns the category of the storage classes of a provisioner,
+// StorageCategoryOther when it is not known
+func StorageCategory(provisioner string) string {
+	if category, ok := provisionerCategories[provisioner]; ok {
+		return category
+	}
+	return StorageCategoryOther
+}
+
+// IsODFProvisioner reports whether a provisioner is one of OpenShift Data Foundation
+func IsODFProvisioner(provisioner string) bool {
+	return StorageCategory(provisioner) == StorageCategoryODF
+}
+
+// Made with Bob
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// QuotaNearExhaustedPercent flags a quota resource consumed beyond this percent of its hard limit
+const QuotaNearExhaustedPercent = 90.0
+
+// quotaResources are the CPU, memory and storage quota resources reported. Storage
+// requests of a single storage class, <class>.storageclass.storage.k8s.io/requests.storage,
+// are reported too.
+var quotaResources = []corev1.ResourceName{
+	corev1.ResourceCPU,
+	corev1.ResourceRequestsCPU,
+	corev1.ResourceLimitsCPU,
+	corev1.ResourceMemory,
+	corev1.ResourceRequestsMemory,
+	corev1.ResourceLimitsMemory,
+	corev1.ResourceRequestsStorage,
+	corev1.ResourceEphemeralStorage,
+	corev1.ResourceRequestsEphemeralStorage,
+	corev1.ResourceLimitsEphemeralStorage,
+}
+
+// storageClassQuotaSuffix ends the quota resources limiting the storage of one storage class
+const storageClassQuotaSuffix = ".storageclass.storage.k8s.io/requests.storage"
+
+// QuotaService provides ResourceQuota operations
+type QuotaService struct{}
+
+// NewQuotaService creates a new quota service
+func NewQuotaService() *QuotaService {
+	return &QuotaService{}
+}
+
+// QuotaUsage compares the use of a quota resource with its hard limit. UsedPercent is
+// nil when the quota controller has not reported the use yet; a hard limit of 0 is
+// always exhausted.
+type QuotaUsage struct {
+	Resource      string   `json:"resource"`
+	Used          string   `json:"used"`
+	Hard          string   `json:"hard"`
+	UsedPercent   *float64 `json:"usedPercent,omitempty"`
+	NearExhausted bool     `json:"nearExhausted"`
+}
+
+// QuotaInfo describes the CPU, memory and storage resources of a ResourceQuota.
+// NearExhausted is set when any of them is consumed beyond QuotaNearExhaustedPercent.
+type QuotaInfo struct {
+	Name          string       `json:"name"`
+	Namespace     string       `json:"namespace"`
+	Resources     []QuotaUsage `json:"resources"`
+	NearExhausted bool         `json:"nearExhausted"`
+}
+
+// QuotaSummary reports the ResourceQuotas of a cluster. Total and NearExhausted count
+// every quota, Quotas is capped and lists the near-exhausted quotas first; Truncated is
+// set when quotas were left out.
+type QuotaSummary struct {
+	Quotas        []QuotaInfo `json:"quotas"`
+	Total         int         `json:"total"`
+	NearExhausted int         `json:"nearExhausted"`
+	Truncated     bool        `json:"truncated,omitempty"`
+	// Scope notes that the allowed namespaces restricted the quotas listed
+	Scope string `json:"scope,omitempty"`
+}
+
+// GetSummary lists the ResourceQuotas of a namespace, or of all namespaces, page by page,
+// returning at most maxItems of them (DefaultMaxItems when 0 or less)
+func (s *QuotaService) GetSummary(ctx context.Context, client *clients.ClusterClient, namespace string, maxItems int) (*QuotaSummary, error) {
+	maxItems = effectiveMaxItems(maxItems)
+
+	namespaces, scope := ScopeNamespaces(namespace)
+	result := &QuotaSummary{Quotas: []QuotaInfo{}, Scope: scope}
+	var quotas []QuotaInfo
+	for _, ns := range namespaces {
+		err := listPages(ctx, metav1.ListOptions{}, func(opts metav1.ListOptions) (string, error) {
+			list, err := client.Clientset.CoreV1().ResourceQuotas(ns).List(ctx, opts)
+			if err != nil {
+				return "", err
+			}
+			for i := range list.Items {
+				quotas = append(quotas, convertQuota(&list.Items[i]))
+			}
+			return list.Continue, nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list resource quotas: %w", err)
+		}
+	}
+
+	sort.SliceStable(quotas, func(i, j int) bool {
+		if quotas[i].NearExhausted != quotas[j].NearExhausted {
+			return quotas[i].NearExhausted
+		}
+		if quotas[i].Namespace != quotas[j].Namespace {
+			return quotas[i].Namespace < quotas[j].Namespace
+		}
+		return quotas[i].Name < quotas[j].Name
+	})
+	result.Total = len(quotas)
+	for _, quota := range quotas {
+		if quota.NearExhausted {
+			result.NearExhausted++
+		}
+	}
+	if len(quotas) > maxItems {
+		quotas = quotas[:maxItems]
+		result.Truncated = true
+	}
+	result.Quotas = append(result.Quotas, quotas...)
+
+	return result, nil
+}
+
+// convertQuota reads the use of the CPU, memory and storage resources of a ResourceQuota.
+// The hard limits are read from the status, the ones the quota controller enforces,
+// falling back to the spec until the controller has seen the quota.
+func convertQuota(quota *corev1.ResourceQuota) QuotaInfo {
+	info := QuotaInfo{Name: quota.Name, Namespace: quota.Namespace, Resources: []QuotaUsage{}}
+	hardLimits := quota.Status.Hard
+	if len(hardLimits) == 0 {
+		hardLimits = quota.Spec.Hard
+	}
+
+	names := make([]string, 0, len(hardLimits))
+	for name := range hardLimits {
+		if isQuotaResource(name) {
+			names = append(names, string(name))
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		hard := hardLimits[corev1.ResourceName(name)]
+		usage := QuotaUsage{Resource: name, Hard: hard.String()}
+		if used, ok := quota.Status.Used[corev1.ResourceName(name)]; ok {
+			usage.Used = used.String()
+			percent := 100.0
+			if !hard.IsZero() {
+				percent = math.Round(used.AsApproximateFloat64()/hard.AsApproximateFloat64()*1000) / 10
+			}
+			usage.UsedPercent = ptr.To(percent)
+			usage.NearExhausted = percent > QuotaNearExhaustedPercent
+		}
+		info.NearExhausted = info.NearExhausted || usage.NearExhausted
+		info.Resources = append(info.Resources, usage)
+	}
+	return info
+}
+
+// isQuotaResource reports whether a quota resource limits CPU, memory or storage
+func isQuotaResource(name corev1.ResourceName) bool {
+	for _, known := range quotaResources {
+		if name == known {
+			return true
+		}
+	}
+	return strings.HasSuffix(string(name), storageClassQuotaSuffix)
+}
+
+// Made with Bob
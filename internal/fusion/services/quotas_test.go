@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type QuotaSuite struct {
+	suite.Suite
+}
+
+func (s *QuotaSuite) TearDownTest() {
+	SetAllowedNamespaces(nil)
+}
+
+func resourceQuota(namespace, name string, hard, used map[string]string) *corev1.ResourceQuota {
+	toList := func(values map[string]string) corev1.ResourceList {
+		list := corev1.ResourceList{}
+		for name, value := range values {
+			list[corev1.ResourceName(name)] = resource.MustParse(value)
+		}
+		return list
+	}
+	return &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       corev1.ResourceQuotaSpec{Hard: toList(hard)},
+		Status:     corev1.ResourceQuotaStatus{Hard: toList(hard), Used: toList(used)},
+	}
+}
+
+func (s *QuotaSuite) TestGetSummary() {
+	pending := resourceQuota("team-c", "compute", map[string]string{"limits.cpu": "4"}, nil)
+	pending.Status = corev1.ResourceQuotaStatus{}
+	client := &clients.ClusterClient{Name: "prod-1", Clientset: fake.NewSimpleClientset(
+		resourceQuota("team-a", "compute",
+			map[string]string{"requests.cpu": "4", "requests.memory": "8Gi", "pods": "10"},
+			map[string]string{"requests.cpu": "3800m", "requests.memory": "4Gi", "pods": "10"}),
+		resourceQuota("team-a", "storage",
+			map[string]string{"requests.storage": "100Gi", "gold.storageclass.storage.k8s.io/requests.storage": "10Gi"},
+			map[string]string{"requests.storage": "50Gi", "gold.storageclass.storage.k8s.io/requests.storage": "9Gi"}),
+		resourceQuota("team-b", "compute",
+			map[string]string{"limits.memory": "0"},
+			map[string]string{"limits.memory": "0"}),
+		pending,
+	)}
+
+	s.Run("reports used versus hard CPU, memory and storage", func() {
+		summary, err := NewQuotaService().GetSummary(context.Background(), client, "team-a", 0)
+		s.Require().NoError(err)
+		s.Require().Len(summary.Quotas, 2)
+		compute := summary.Quotas[0]
+		s.Equal("compute", compute.Name)
+		s.Require().Len(compute.Resources, 2, "object count quotas are left out")
+		s.Equal("requests.cpu", compute.Resources[0].Resource)
+		s.Equal("3800m", compute.Resources[0].Used)
+		s.Equal("4", compute.Resources[0].Hard)
+		s.Equal(95.0, *compute.Resources[0].UsedPercent)
+		s.True(compute.Resources[0].NearExhausted)
+		s.Equal(50.0, *compute.Resources[1].UsedPercent)
+		s.False(compute.Resources[1].NearExhausted)
+		s.True(compute.NearExhausted)
+
+		storage := summary.Quotas[1]
+		s.Require().Len(storage.Resources, 2)
+		s.Equal("gold.storageclass.storage.k8s.io/requests.storage", storage.Resources[0].Resource)
+		s.Equal(90.0, *storage.Resources[0].UsedPercent)
+		s.False(storage.NearExhausted, "90% is not beyond the threshold")
+	})
+
+	s.Run("counts near-exhausted quotas first", func() {
+		summary, err := NewQuotaService().GetSummary(context.Background(), client, "", 0)
+		s.Require().NoError(err)
+		s.Equal(4, summary.Total)
+		s.Equal(2, summary.NearExhausted)
+		s.Equal("team-a", summary.Quotas[0].Namespace)
+		s.Equal("team-b", summary.Quotas[1].Namespace)
+		s.True(summary.Quotas[1].NearExhausted, "a hard limit of 0 is exhausted")
+	})
+
+	s.Run("reports quotas not yet seen by the controller from the spec", func() {
+		summary, err := NewQuotaService().GetSummary(context.Background(), client, "team-c", 0)
+		s.Require().NoError(err)
+		s.Require().Len(summary.Quotas, 1)
+		s.Require().Len(summary.Quotas[0].Resources, 1)
+		s.Equal("4", summary.Quotas[0].Resources[0].Hard)
+		s.Nil(summary.Quotas[0].Resources[0].UsedPercent)
+		s.False(summary.Quotas[0].NearExhausted)
+	})
+
+	s.Run("caps quotas but not counts", func() {
+		summary, err := NewQuotaService().GetSummary(context.Background(), client, "", 1)
+		s.Require().NoError(err)
+		s.Equal(4, summary.Total)
+		s.Len(summary.Quotas, 1)
+		s.True(summary.Truncated)
+	})
+
+	s.Run("lists the allowed namespaces only", func() {
+		SetAllowedNamespaces([]string{"team-b"})
+		summary, err := NewQuotaService().GetSummary(context.Background(), client, "", 0)
+		s.Require().NoError(err)
+		s.Equal(1, summary.Total)
+		s.NotEmpty(summary.Scope)
+	})
+}
+
+func TestQuotaSuite(t *testing.T) {
+	suite.Run(t, new(QuotaSuite))
+}
+
+// Made with Bob
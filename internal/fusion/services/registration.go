@@ -0,0 +1,160 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+// registrationAllowed gates fusion.clusters.register and unregister. Registering builds
+// clients from kubeconfigs the caller names, so it is off unless the Fusion config allows it.
+var registrationAllowed atomic.Bool
+
+// authPluginsAllowed lets runtime kubeconfigs authenticate through exec plugins and auth
+// providers, whose commands run on the server host
+var authPluginsAllowed atomic.Bool
+
+// kubeconfigPaths are the kubeconfig files, or directories of them, callers may name
+var (
+	kubeconfigPaths   []string
+	kubeconfigPathsMu sync.RWMutex
+)
+
+// SetClusterRegistrationAllowed allows or forbids registering clusters at runtime
+func SetClusterRegistrationAllowed(allowed bool) {
+	registrationAllowed.Store(allowed)
+}
+
+// ClusterRegistrationAllowed reports whether clusters may be registered at runtime
+func ClusterRegistrationAllowed() bool {
+	return registrationAllowed.Load()
+}
+
+// SetAuthPluginsAllowed allows or forbids exec plugins and auth providers in runtime kubeconfigs
+func SetAuthPluginsAllowed(allowed bool) {
+	authPluginsAllowed.Store(allowed)
+}
+
+// SetKubeconfigPaths sets the kubeconfig files, or directories of them, callers may name.
+// An empty list allows the kubeconfig files the server loads its clusters from only.
+func SetKubeconfigPaths(paths []string) {
+	kubeconfigPathsMu.Lock()
+	defer kubeconfigPathsMu.Unlock()
+	kubeconfigPaths = append([]string(nil), paths...)
+}
+
+// checkRegistration returns an error when clusters may not be registered at runtime
+func checkRegistration() error {
+	if !ClusterRegistrationAllowed() {
+		return fmt.Errorf("cluster registration is disabled, set allowClusterRegistration in the Fusion config or FUSION_ALLOW_CLUSTER_REGISTRATION=true")
+	}
+	return nil
+}
+
+// checkAuthPlugin returns an error when a context of a runtime kubeconfig authenticates
+// through an exec plugin or auth provider while they are not allowed
+func checkAuthPlugin(config *api.Config, contextName string) error {
+	if authPluginsAllowed.Load() {
+		return nil
+	}
+	kubeContext, ok := config.Contexts[contextName]
+	if !ok {
+		return fmt.Errorf("context %s not found in kubeconfig", contextName)
+	}
+	authInfo := config.AuthInfos[kubeContext.AuthInfo]
+	switch {
+	case authInfo == nil:
+		return nil
+	case authInfo.Exec != nil:
+		return fmt.Errorf("context %s authenticates with the exec plugin %q, which would run on the server; set allowAuthPlugins in the Fusion config or FUSION_ALLOW_AUTH_PLUGINS=true to allow it", contextName, authInfo.Exec.Command)
+	case authInfo.AuthProvider != nil:
+		return fmt.Errorf("context %s authenticates with the %s auth provider; set allowAuthPlugins in the Fusion config or FUSION_ALLOW_AUTH_PLUGINS=true to allow it", contextName, authInfo.AuthProvider.Name)
+	}
+	return nil
+}
+
+// checkInlineFileReferences returns an error when the context of an inline kubeconfig
+// names files for its credentials or CA. client-go would read them on the server host, so
+// inline content could send, say, the server's service account token to a server of the
+// caller's choosing. Inline content must embed them with the *-data fields or token.
+func checkInlineFileReferences(config *api.Config, contextName string) error {
+	kubeContext, ok := config.Contexts[contextName]
+	if !ok {
+		return fmt.Errorf("context %s not found in kubeconfig", contextName)
+	}
+	var fields []string
+	if cluster := config.Clusters[kubeContext.Cluster]; cluster != nil && cluster.CertificateAuthority != "" {
+		fields = append(fields, "certificate-authority")
+	}
+	if authInfo := config.AuthInfos[kubeContext.AuthInfo]; authInfo != nil {
+		if authInfo.ClientCertificate != "" {
+			fields = append(fields, "client-certificate")
+		}
+		if authInfo.ClientKey != "" {
+			fields = append(fields, "client-key")
+		}
+		if authInfo.TokenFile != "" {
+			fields = append(fields, "tokenFile")
+		}
+	}
+	if len(fields) > 0 {
+		return fmt.Errorf("context %s of the inline kubeconfig references files on the server (%s); embed them with certificate-authority-data, client-certificate-data, client-key-data or token instead", contextName, strings.Join(fields, ", "))
+	}
+	return nil
+}
+
+// CheckKubeconfigPath returns the absolute path of a kubeconfig file a caller named, or an
+// error when it is outside the allowed kubeconfig paths. Symbolic links are resolved first,
+// so a link cannot point outside of them.
+func CheckKubeconfigPath(path string) (string, error) {
+	resolved, exists := resolvePath(path)
+
+	kubeconfigPathsMu.RLock()
+	allowed := kubeconfigPaths
+	kubeconfigPathsMu.RUnlock()
+	if len(allowed) == 0 {
+		allowed = defaultKubeconfigPaths()
+	}
+	for _, entry := range allowed {
+		base, _ := resolvePath(entry)
+		if resolved != base && !strings.HasPrefix(resolved, base+string(filepath.Separator)) {
+			continue
+		}
+		if !exists {
+			return "", fmt.Errorf("kubeconfig %s not found", path)
+		}
+		return resolved, nil
+	}
+	return "", fmt.Errorf("kubeconfig %s is outside the allowed kubeconfig paths, add it to kubeconfigPaths in the Fusion config or FUSION_KUBECONFIG_PATHS", path)
+}
+
+// defaultKubeconfigPaths are the kubeconfig files the server loads its clusters from:
+// those listed in KUBECONFIG, otherwise ~/.kube/config
+func defaultKubeconfigPaths() []string {
+	if paths := filepath.SplitList(os.Getenv(clientcmd.RecommendedConfigPathEnvVar)); len(paths) > 0 {
+		return paths
+	}
+	return []string{clientcmd.RecommendedHomeFile}
+}
+
+// resolvePath returns the absolute, cleaned path of a file or directory with symbolic
+// links resolved, and whether it exists. A missing path is only made absolute.
+func resolvePath(path string) (string, bool) {
+	absolute, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.Clean(path), false
+	}
+	resolved, err := filepath.EvalSymlinks(absolute)
+	if err != nil {
+		return absolute, false
+	}
+	return resolved, true
+}
+
+// Made with Bob
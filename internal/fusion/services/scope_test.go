@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+type ScopeSuite struct {
+	suite.Suite
+}
+
+func (s *ScopeSuite) TearDownTest() {
+	SetAllowedNamespaces(nil)
+}
+
+func (s *ScopeSuite) TestScopeNamespaces() {
+	s.Run("unrestricted by default", func() {
+		namespaces, note := ScopeNamespaces("")
+		s.Equal([]string{""}, namespaces)
+		s.Empty(note)
+		s.True(NamespaceAllowed("default"))
+	})
+
+	SetAllowedNamespaces([]string{"ibm-spectrum-fusion-ns", "openshift-storage"})
+	s.Run("all namespaces become the allowed namespaces", func() {
+		namespaces, note := ScopeNamespaces("")
+		s.Equal([]string{"ibm-spectrum-fusion-ns", "openshift-storage"}, namespaces)
+		s.Contains(note, "scope restricted")
+	})
+	s.Run("an allowed namespace is kept", func() {
+		namespaces, note := ScopeNamespaces("openshift-storage")
+		s.Equal([]string{"openshift-storage"}, namespaces)
+		s.Empty(note)
+	})
+	s.Run("a namespace outside the allowed namespaces is dropped", func() {
+		namespaces, note := ScopeNamespaces("default")
+		s.Empty(namespaces)
+		s.Contains(note, "scope restricted")
+		s.False(NamespaceAllowed("default"))
+	})
+}
+
+func (s *ScopeSuite) TestListInScope() {
+	gvr := schema.GroupVersionResource{Group: "snapshot.storage.k8s.io", Version: "v1", Resource: "volumesnapshots"}
+	snapshot := func(namespace, name string) runtime.Object {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("snapshot.storage.k8s.io/v1")
+		obj.SetKind("VolumeSnapshot")
+		obj.SetNamespace(namespace)
+		obj.SetName(name)
+		return obj
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gvr: "VolumeSnapshotList"},
+		snapshot("openshift-storage", "db-1"), snapshot("apps", "web-1"), snapshot("ibm-spectrum-fusion-ns", "fusion-1"))
+
+	s.Run("lists every namespace when unrestricted", func() {
+		list, note, err := ListInScope(context.Background(), dynamicClient.Resource(gvr), "", metav1.ListOptions{})
+		s.Require().NoError(err)
+		s.Len(list.Items, 3)
+		s.Empty(note)
+	})
+
+	SetAllowedNamespaces([]string{"ibm-spectrum-fusion-ns", "openshift-storage"})
+	s.Run("lists only the allowed namespaces", func() {
+		list, note, err := ListInScope(context.Background(), dynamicClient.Resource(gvr), "", metav1.ListOptions{})
+		s.Require().NoError(err)
+		s.Len(list.Items, 2)
+		s.NotEmpty(note)
+	})
+	s.Run("returns nothing for a namespace outside the allowed namespaces", func() {
+		list, note, err := ListInScope(context.Background(), dynamicClient.Resource(gvr), "apps", metav1.ListOptions{})
+		s.Require().NoError(err)
+		s.Empty(list.Items)
+		s.NotEmpty(note)
+	})
+}
+
+func (s *ScopeSuite) TestNamespaceChecks() {
+	client := &clients.ClusterClient{Name: "prod-1", Clientset: fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "apps"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "apps"}},
+	)}
+	ctx := context.Background()
+
+	s.True(CheckNamespaceExists(ctx, client, "apps"))
+	count, err := CheckPodsInNamespace(ctx, client, "apps", "")
+	s.NoError(err)
+	s.Equal(1, count)
+
+	SetAllowedNamespaces([]string{"openshift-storage"})
+	s.False(CheckNamespaceExists(ctx, client, "apps"), "namespaces outside the allowed namespaces read as missing")
+	_, err = CheckPodsInNamespace(ctx, client, "apps", "")
+	s.ErrorContains(err, "scope restricted")
+}
+
+func (s *ScopeSuite) TestEventsInScope() {
+	SetAllowedNamespaces([]string{"openshift-storage"})
+	client := &clients.ClusterClient{Name: "prod-1", Clientset: fake.NewSimpleClientset()}
+
+	events, err := NewEventsService().ListWarnings(context.Background(), client, EventsRequest{})
+	s.Require().NoError(err)
+	s.Equal([]string{"openshift-storage"}, events.Namespaces)
+	s.Contains(events.Scope, "scope restricted")
+}
+
+func (s *ScopeSuite) TestComponentNamespaces() {
+	client := &clients.ClusterClient{Name: "prod-1", Clientset: fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ibm-gdp"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "scale-prod"}},
+	)}
+	ctx := context.Background()
+
+	s.Run("the defaults are probed in order", func() {
+		s.Equal(GDPNamespaces, ComponentNamespaces("", GDPNamespaces...))
+		namespace, reason := findNamespace(ctx, client, "", GDPNamespaces...)
+		s.Equal("ibm-gdp", namespace)
+		s.Empty(reason)
+	})
+	s.Run("an override replaces the defaults", func() {
+		s.Equal([]string{"scale-prod"}, ComponentNamespaces("scale-prod", GDPNamespaces...))
+		namespace, _ := findNamespace(ctx, client, "scale-prod", GDPNamespaces...)
+		s.Equal("scale-prod", namespace)
+		namespace, reason := findNamespace(ctx, client, "scale-test", GDPNamespaces...)
+		s.Empty(namespace, "defaults are not a fallback")
+		s.Equal(ReasonNamespaceAbsent, reason)
+	})
+	s.Run("services report the overridden namespace", func() {
+		status, err := NewCASService().GetStatus(ctx, client, "scale-prod")
+		s.Require().NoError(err)
+		s.True(status.Installed)
+		s.Equal("scale-prod", status.Namespace)
+		s.Equal(ReasonCRDAbsent, status.Reason)
+
+		status, err = NewCASService().GetStatus(ctx, client, "")
+		s.Require().NoError(err)
+		s.False(status.Installed, "ibm-cas does not exist")
+		s.Equal(ReasonNamespaceAbsent, status.Reason)
+	})
+	s.Run("a denied lookup is forbidden, not absent", func() {
+		clientset := fake.NewSimpleClientset()
+		clientset.PrependReactor("get", "namespaces", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, "ibm-cas", fmt.Errorf("RBAC denied"))
+		})
+		status, err := NewCASService().GetStatus(ctx, &clients.ClusterClient{Name: "prod-1", Clientset: clientset}, "")
+		s.Require().NoError(err)
+		s.False(status.Installed)
+		s.Equal(ReasonForbidden, status.Reason)
+	})
+	s.Run("an override outside the allowed namespaces reads as missing", func() {
+		SetAllowedNamespaces([]string{"ibm-gdp"})
+		namespace, reason := findNamespace(ctx, client, "scale-prod", GDPNamespaces...)
+		s.Empty(namespace)
+		s.Equal(ReasonNamespaceAbsent, reason)
+	})
+}
+
+func TestScopeSuite(t *testing.T) {
+	suite.Run(t, new(ScopeSuite))
+}
+
+// Made with Bob
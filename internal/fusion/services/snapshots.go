@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// VolumeSnapshotGVR identifies CSI VolumeSnapshot resources
+var VolumeSnapshotGVR = schema.GroupVersionResource{
+	Group:    "snapshot.storage.k8s.io",
+	Version:  "v1",
+	Resource: "volumesnapshots",
+}
+
+// VolumeSnapshotClassGVR identifies CSI VolumeSnapshotClass resources
+var VolumeSnapshotClassGVR = schema.GroupVersionResource{
+	Group:    "snapshot.storage.k8s.io",
+	Version:  "v1",
+	Resource: "volumesnapshotclasses",
+}
+
+// defaultSnapshotClassAnnotation marks the default VolumeSnapshotClass of a driver
+const defaultSnapshotClassAnnotation = "snapshot.storage.kubernetes.io/is-default-class"
+
+// SnapshotService provides VolumeSnapshot operations
+type SnapshotService struct{}
+
+// NewSnapshotService creates a new snapshot service
+func NewSnapshotService() *SnapshotService {
+	return &SnapshotService{}
+}
+
+// SnapshotFilter narrows the snapshots returned by ListSnapshots
+type SnapshotFilter struct {
+	Namespace     string `json:"namespace,omitempty"`
+	SnapshotClass string `json:"snapshotClass,omitempty"`
+}
+
+// SnapshotInfo describes a VolumeSnapshot
+type SnapshotInfo struct {
+	Name          string     `json:"name"`
+	Namespace     string     `json:"namespace"`
+	SnapshotClass string     `json:"snapshotClass,omitempty"`
+	SourcePVC     string     `json:"sourcePvc,omitempty"`
+	RestoreSize   string     `json:"restoreSize,omitempty"`
+	ReadyToUse    bool       `json:"readyToUse"`
+	CreationTime  *time.Time `json:"creationTime,omitempty"`
+	Age           string     `json:"age,omitempty"`
+	Error         string     `json:"error,omitempty"`
+}
+
+// SnapshotList reports the VolumeSnapshots of a cluster
+type SnapshotList struct {
+	ComponentStatus
+	Snapshots []SnapshotInfo `json:"snapshots"`
+	Total     int            `json:"total"`
+	Ready     int            `json:"ready"`
+	NotReady  int            `json:"notReady"`
+}
+
+// ListSnapshots lists VolumeSnapshots, newest first, optionally filtered by namespace and
+// snapshot class. Clusters without the snapshot CRD are reported as not installed.
+func (s *SnapshotService) ListSnapshots(ctx context.Context, client *clients.ClusterClient, filter SnapshotFilter) (*SnapshotList, error) {
+	list := &SnapshotList{
+		Snapshots: []SnapshotInfo{},
+	}
+
+	if !CheckCRDExists(ctx, client, VolumeSnapshotGVR) {
+		list.ComponentStatus = NotInstalledStatus(ReasonCRDAbsent, "VolumeSnapshot CRD (snapshot.storage.k8s.io) not found")
+		return list, nil
+	}
+
+	dynamicClient, err := client.DynamicClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
+	// Pages are converted as they are read, only the reported fields are kept
+	_, scope, err := countInScope(ctx, dynamicClient.Resource(VolumeSnapshotGVR), filter.Namespace, metav1.ListOptions{}, func(snapshots []unstructured.Unstructured) {
+		for _, item := range snapshots {
+			info := convertSnapshot(item)
+			if filter.SnapshotClass != "" && info.SnapshotClass != filter.SnapshotClass {
+				continue
+			}
+			if info.ReadyToUse {
+				list.Ready++
+			} else {
+				list.NotReady++
+			}
+			list.Snapshots = append(list.Snapshots, info)
+		}
+	})
+	list.Scope = scope
+	if err != nil {
+		return nil, fmt.Errorf("failed to list volume snapshots: %w", err)
+	}
+
+	sort.SliceStable(list.Snapshots, func(i, j int) bool {
+		a, b := list.Snapshots[i].CreationTime, list.Snapshots[j].CreationTime
+		if a == nil || b == nil {
+			return a != nil
+		}
+		return a.After(*b)
+	})
+	list.Total = len(list.Snapshots)
+
+	list.ComponentStatus = InstalledStatus(list.NotReady == 0, "", fmt.Sprintf("%d of %d snapshots ready to use", list.Ready, list.Total))
+	return list, nil
+}
+
+// convertSnapshot extracts the reported fields of a VolumeSnapshot
+func convertSnapshot(item unstructured.Unstructured) SnapshotInfo {
+	info := SnapshotInfo{
+		Name:      item.GetName(),
+		Namespace: item.GetNamespace(),
+	}
+	info.SnapshotClass, _, _ = unstructured.NestedString(item.Object, "spec", "volumeSnapshotClassName")
+	info.SourcePVC, _, _ = unstructured.NestedString(item.Object, "spec", "source", "persistentVolumeClaimName")
+	info.RestoreSize, _, _ = unstructured.NestedString(item.Object, "status", "restoreSize")
+	info.ReadyToUse, _, _ = unstructured.NestedBool(item.Object, "status", "readyToUse")
+	info.CreationTime = nestedTime(item.Object, "status", "creationTime")
+	if info.CreationTime == nil {
+		created := item.GetCreationTimestamp().Time
+		info.CreationTime = &created
+	}
+	info.Age = HumanizeAge(*info.CreationTime)
+	info.Error, _, _ = unstructured.NestedString(item.Object, "status", "error", "message")
+	return info
+}
+
+// SnapshotClassInfo describes a VolumeSnapshotClass
+type SnapshotClassInfo struct {
+	Name           string `json:"name"`
+	Driver         string `json:"driver"`
+	DeletionPolicy string `json:"deletionPolicy"`
+	IsDefault      bool   `json:"isDefault"`
+}
+
+// StorageClassInventory lists the storage and snapshot classes of a cluster, the classes
+// workloads must find under the same name when they fail over to another cluster
+type StorageClassInventory struct {
+	ComponentStatus
+	StorageClasses  []StorageClassInfo  `json:"storageClasses"`
+	SnapshotClasses []SnapshotClassInfo `json:"snapshotClasses,omitempty"`
+}
+
+// GetClassInventory reads the storage classes and, when the snapshot CRDs are served, the
+// VolumeSnapshotClasses of a cluster, both sorted by name
+func (s *SnapshotService) GetClassInventory(ctx context.Context, client *clients.ClusterClient) (*StorageClassInventory, error) {
+	scList, err := client.Clientset.StorageV1().StorageClasses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list storage classes: %w", err)
+	}
+	inventory := &StorageClassInventory{StorageClasses: make([]StorageClassInfo, 0, len(scList.Items))}
+	for i := range scList.Items {
+		inventory.StorageClasses = append(inventory.StorageClasses, NewStorageClassInfo(&scList.Items[i]))
+	}
+	sort.Slice(inventory.StorageClasses, func(i, j int) bool {
+		return inventory.StorageClasses[i].Name < inventory.StorageClasses[j].Name
+	})
+	message := fmt.Sprintf("%d storage classes", len(inventory.StorageClasses))
+
+	if CheckCRDExists(ctx, client, VolumeSnapshotClassGVR) {
+		dynamicClient, err := client.DynamicClient()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+		}
+		classes, err := dynamicClient.Resource(VolumeSnapshotClassGVR).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list volume snapshot classes: %w", err)
+		}
+		for _, item := range classes.Items {
+			info := SnapshotClassInfo{
+				Name:      item.GetName(),
+				IsDefault: item.GetAnnotations()[defaultSnapshotClassAnnotation] == "true",
+			}
+			info.Driver, _, _ = unstructured.NestedString(item.Object, "driver")
+			info.DeletionPolicy, _, _ = unstructured.NestedString(item.Object, "deletionPolicy")
+			inventory.SnapshotClasses = append(inventory.SnapshotClasses, info)
+		}
+		sort.Slice(inventory.SnapshotClasses, func(i, j int) bool {
+			return inventory.SnapshotClasses[i].Name < inventory.SnapshotClasses[j].Name
+		})
+		message += fmt.Sprintf(", %d snapshot classes", len(inventory.SnapshotClasses))
+	} else {
+		message += ", snapshot CRDs not found"
+	}
+
+	inventory.ComponentStatus = InstalledStatus(true, "", message)
+	return inventory, nil
+}
+
+// Made with Bob
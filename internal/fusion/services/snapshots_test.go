@@ -0,0 +1,102 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+type SnapshotSuite struct {
+	suite.Suite
+}
+
+// volumeSnapshot returns a VolumeSnapshot of a PVC in the apps namespace taken age ago,
+// failed with errorMessage when it is set
+func volumeSnapshot(name, snapshotClass string, ready bool, age time.Duration, errorMessage string) *unstructured.Unstructured {
+	status := map[string]interface{}{
+		"readyToUse":   ready,
+		"restoreSize":  "1Gi",
+		"creationTime": time.Now().Add(-age).UTC().Format(time.RFC3339),
+	}
+	if errorMessage != "" {
+		status["error"] = map[string]interface{}{"message": errorMessage}
+	}
+	snapshot := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"volumeSnapshotClassName": snapshotClass,
+			"source":                  map[string]interface{}{"persistentVolumeClaimName": name + "-data"},
+		},
+		"status": status,
+	}}
+	snapshot.SetAPIVersion(VolumeSnapshotGVR.GroupVersion().String())
+	snapshot.SetKind("VolumeSnapshot")
+	snapshot.SetNamespace("apps")
+	snapshot.SetName(name)
+	return snapshot
+}
+
+func (s *SnapshotSuite) TestListSnapshots() {
+	service := NewSnapshotService()
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{VolumeSnapshotGVR: "VolumeSnapshotList"},
+		volumeSnapshot("db", "ocs-storagecluster-rbdplugin-snapclass", true, 2*time.Hour, ""),
+		volumeSnapshot("web", "ocs-storagecluster-rbdplugin-snapclass", false, time.Minute, "failed to take snapshot: timed out"),
+		volumeSnapshot("shared", "ocs-storagecluster-cephfsplugin-snapclass", true, time.Hour, ""),
+	)
+	client := &clients.ClusterClient{Name: "prod-1", Dynamic: dynamicClient}
+	ctx := discoveredContext("prod-1", VolumeSnapshotGVR)
+
+	s.Run("counts ready and not ready snapshots, newest first", func() {
+		list, err := service.ListSnapshots(ctx, client, SnapshotFilter{})
+		s.Require().NoError(err)
+		s.True(list.Installed)
+		s.False(list.ComponentStatus.Ready, "a snapshot is not ready to use")
+		s.Equal(3, list.Total)
+		s.Equal(2, list.Ready)
+		s.Equal(1, list.NotReady)
+		s.Equal("2 of 3 snapshots ready to use", list.Message)
+		s.Require().Len(list.Snapshots, 3)
+		s.Equal([]string{"web", "shared", "db"}, []string{list.Snapshots[0].Name, list.Snapshots[1].Name, list.Snapshots[2].Name})
+		s.Equal("web-data", list.Snapshots[0].SourcePVC)
+		s.Equal("1Gi", list.Snapshots[0].RestoreSize)
+		s.Equal("failed to take snapshot: timed out", list.Snapshots[0].Error)
+	})
+	s.Run("filters by snapshot class", func() {
+		list, err := service.ListSnapshots(ctx, client, SnapshotFilter{SnapshotClass: "ocs-storagecluster-rbdplugin-snapclass"})
+		s.Require().NoError(err)
+		s.Equal(2, list.Total)
+		s.Equal(1, list.Ready)
+		s.Equal(1, list.NotReady)
+		for _, snapshot := range list.Snapshots {
+			s.Equal("ocs-storagecluster-rbdplugin-snapclass", snapshot.SnapshotClass)
+		}
+	})
+	s.Run("is ready when every snapshot of the class is", func() {
+		list, err := service.ListSnapshots(ctx, client, SnapshotFilter{SnapshotClass: "ocs-storagecluster-cephfsplugin-snapclass"})
+		s.Require().NoError(err)
+		s.Equal(1, list.Total)
+		s.True(list.ComponentStatus.Ready)
+		s.Zero(list.NotReady)
+	})
+	s.Run("reports a cluster without the snapshot CRD as not installed", func() {
+		list, err := service.ListSnapshots(discoveredContext("prod-1"), client, SnapshotFilter{})
+		s.Require().NoError(err)
+		s.False(list.Installed)
+		s.Equal(ReasonCRDAbsent, list.Reason)
+		s.Equal("VolumeSnapshot CRD (snapshot.storage.k8s.io) not found", list.Message)
+		s.Empty(list.Snapshots)
+		s.NotNil(list.Snapshots)
+	})
+}
+
+func TestSnapshotSuite(t *testing.T) {
+	suite.Run(t, new(SnapshotSuite))
+}
+
+// Made with Bob
@@ -3,10 +3,13 @@ package services
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -22,30 +25,101 @@ func NewStorageService(client *clients.KubernetesClient) *StorageService {
 	}
 }
 
+// Annotations marking the default storage class, the beta one is still honoured by Kubernetes
+const (
+	defaultStorageClassAnnotation     = "storageclass.kubernetes.io/is-default-class"
+	betaDefaultStorageClassAnnotation = "storageclass.beta.kubernetes.io/is-default-class"
+)
+
 // StorageClassInfo contains information about a storage class
+// Category tells what backs its volumes, see StorageCategory
 type StorageClassInfo struct {
-	Name        string `json:"name"`
-	Provisioner string `json:"provisioner"`
-	IsDefault   bool   `json:"isDefault"`
+	Name              string `json:"name"`
+	Provisioner       string `json:"provisioner"`
+	Category          string `json:"category"`
+	IsDefault         bool   `json:"isDefault"`
+	ReclaimPolicy     string `json:"reclaimPolicy"`
+	VolumeBindingMode string `json:"volumeBindingMode"`
+}
+
+// NewStorageClassInfo describes a storage class, applying the Kubernetes defaults for
+// unset reclaim policy and volume binding mode
+func NewStorageClassInfo(sc *storagev1.StorageClass) StorageClassInfo {
+	info := StorageClassInfo{
+		Name:              sc.Name,
+		Provisioner:       sc.Provisioner,
+		Category:          StorageCategory(sc.Provisioner),
+		IsDefault:         IsDefaultStorageClass(sc),
+		ReclaimPolicy:     string(corev1.PersistentVolumeReclaimDelete),
+		VolumeBindingMode: string(storagev1.VolumeBindingImmediate),
+	}
+	if sc.ReclaimPolicy != nil {
+		info.ReclaimPolicy = string(*sc.ReclaimPolicy)
+	}
+	if sc.VolumeBindingMode != nil {
+		info.VolumeBindingMode = string(*sc.VolumeBindingMode)
+	}
+	return info
+}
+
+// IsDefaultStorageClass reports whether a storage class is annotated as the cluster default
+func IsDefaultStorageClass(sc *storagev1.StorageClass) bool {
+	return sc.Annotations[defaultStorageClassAnnotation] == "true" ||
+		sc.Annotations[betaDefaultStorageClassAnnotation] == "true"
 }
 
-// PVCStats contains statistics about PVCs
+// StuckPendingThreshold is how long a PVC may stay Pending before it is flagged as stuck
+const StuckPendingThreshold = 10 * time.Minute
+
+// PVCStats contains statistics about PVCs. The counts cover every PVC, StuckPending is
+// capped and Truncated is set when stuck PVCs were left out; StuckPendingCount counts them all.
 type PVCStats struct {
-	Bound   int `json:"bound"`
-	Pending int `json:"pending"`
-	Lost    int `json:"lost"`
-	Total   int `json:"total"`
+	Bound             int                          `json:"bound"`
+	Pending           int                          `json:"pending"`
+	Lost              int                          `json:"lost"`
+	Total             int                          `json:"total"`
+	RequestedBytes    int64                        `json:"requestedBytes"`
+	Requested         string                       `json:"requested"`
+	ByStorageClass    map[string]*StorageClassPVCs `json:"byStorageClass"`
+	StuckPending      []StuckPVC                   `json:"stuckPending,omitempty"`
+	StuckPendingCount int                          `json:"stuckPendingCount,omitempty"`
+	Truncated         bool                         `json:"truncated,omitempty"`
+}
+
+// StorageClassPVCs contains PVC statistics of a single storage class
+type StorageClassPVCs struct {
+	Bound          int    `json:"bound"`
+	Pending        int    `json:"pending"`
+	Lost           int    `json:"lost"`
+	Total          int    `json:"total"`
+	RequestedBytes int64  `json:"requestedBytes"`
+	Requested      string `json:"requested"`
+}
+
+// StuckPVC identifies a PVC that has been Pending longer than StuckPendingThreshold
+type StuckPVC struct {
+	Name         string    `json:"name"`
+	Namespace    string    `json:"namespace"`
+	StorageClass string    `json:"storageClass"`
+	CreatedAt    time.Time `json:"createdAt"`
+	PendingFor   string    `json:"pendingFor"`
 }
 
 // StorageSummary contains a summary of storage status
+// Errors lists misconfigurations such as several default storage classes
 type StorageSummary struct {
-	StorageClasses []StorageClassInfo `json:"storageClasses"`
-	PVCStats       PVCStats           `json:"pvcStats"`
-	ODFInstalled   bool               `json:"odfInstalled"`
+	StorageClasses      []StorageClassInfo `json:"storageClasses"`
+	DefaultStorageClass string             `json:"defaultStorageClass,omitempty"`
+	PVCStats            PVCStats           `json:"pvcStats"`
+	ODFInstalled        bool               `json:"odfInstalled"`
+	Errors              []string           `json:"errors,omitempty"`
+	// Scope notes that the allowed namespaces restricted the PVCs counted
+	Scope string `json:"scope,omitempty"`
 }
 
-// GetStorageSummary retrieves a comprehensive storage summary
-func (s *StorageService) GetStorageSummary(ctx context.Context) (*StorageSummary, error) {
+// GetStorageSummary retrieves a comprehensive storage summary. PVCs are read page by page,
+// listing at most maxItems stuck PVCs (DefaultMaxItems when 0 or less).
+func (s *StorageService) GetStorageSummary(ctx context.Context, maxItems int) (*StorageSummary, error) {
 	summary := &StorageSummary{
 		StorageClasses: []StorageClassInfo{},
 		PVCStats:       PVCStats{},
@@ -59,14 +133,28 @@ func (s *StorageService) GetStorageSummary(ctx context.Context) (*StorageSummary
 	}
 
 	summary.StorageClasses = s.extractStorageClassInfo(scList)
+	var defaults []string
+	for _, sc := range summary.StorageClasses {
+		if sc.IsDefault {
+			defaults = append(defaults, sc.Name)
+		}
+	}
+	switch len(defaults) {
+	case 0:
+	case 1:
+		summary.DefaultStorageClass = defaults[0]
+	default:
+		summary.Errors = append(summary.Errors, fmt.Sprintf("multiple default storage classes: %s, PVCs without a storage class get an unpredictable one",
+			strings.Join(defaults, ", ")))
+	}
 
 	// Get PVC statistics
-	pvcList, err := s.client.ListPVCs(ctx, metav1.NamespaceAll)
+	pvcStats, scope, err := s.collectPVCStats(ctx, effectiveMaxItems(maxItems))
 	if err != nil {
 		return nil, fmt.Errorf("failed to list PVCs: %w", err)
 	}
-
-	summary.PVCStats = s.calculatePVCStats(pvcList)
+	summary.Scope = scope
+	summary.PVCStats = pvcStats
 
 	// Check for ODF/OCS installation (non-failing check)
 	summary.ODFInstalled = s.checkODFInstalled(scList)
@@ -74,61 +162,236 @@ func (s *StorageService) GetStorageSummary(ctx context.Context) (*StorageSummary
 	return summary, nil
 }
 
+// collectPVCStats counts the PVCs of all namespaces, or of the allowed namespaces when
+// restricted, one page at a time
+func (s *StorageService) collectPVCStats(ctx context.Context, maxStuck int) (PVCStats, string, error) {
+	namespaces, scope := ScopeNamespaces(metav1.NamespaceAll)
+	builder := newPVCStatsBuilder(maxStuck)
+	for _, ns := range namespaces {
+		err := listPages(ctx, metav1.ListOptions{}, func(opts metav1.ListOptions) (string, error) {
+			list, err := s.client.ListPVCs(ctx, ns, opts)
+			if err != nil {
+				return "", err
+			}
+			for i := range list.Items {
+				builder.add(&list.Items[i])
+			}
+			return list.Continue, nil
+		})
+		if err != nil {
+			return PVCStats{}, scope, err
+		}
+	}
+	return builder.finish(), scope, nil
+}
+
 // extractStorageClassInfo extracts relevant info from storage classes
 func (s *StorageService) extractStorageClassInfo(scList *storagev1.StorageClassList) []StorageClassInfo {
 	info := make([]StorageClassInfo, 0, len(scList.Items))
-	for _, sc := range scList.Items {
-		isDefault := false
-		if sc.Annotations != nil {
-			if val, ok := sc.Annotations["storageclass.kubernetes.io/is-default-class"]; ok && val == "true" {
-				isDefault = true
-			}
-		}
-		info = append(info, StorageClassInfo{
-			Name:        sc.Name,
-			Provisioner: sc.Provisioner,
-			IsDefault:   isDefault,
-		})
+	for i := range scList.Items {
+		info = append(info, NewStorageClassInfo(&scList.Items[i]))
 	}
 	return info
 }
 
-// calculatePVCStats calculates statistics from PVC list
+// calculatePVCStats calculates statistics from PVC list, including the requested
+// capacity per storage class and Pending PVCs that look stuck
 func (s *StorageService) calculatePVCStats(pvcList interface{}) PVCStats {
-	stats := PVCStats{}
-
-	// Type assert to PVC list
+	builder := newPVCStatsBuilder(DefaultMaxItems)
 	if list, ok := pvcList.(*corev1.PersistentVolumeClaimList); ok {
-		stats.Total = len(list.Items)
-		for _, pvc := range list.Items {
-			switch pvc.Status.Phase {
-			case corev1.ClaimBound:
-				stats.Bound++
-			case corev1.ClaimPending:
-				stats.Pending++
-			case corev1.ClaimLost:
-				stats.Lost++
+		for i := range list.Items {
+			builder.add(&list.Items[i])
+		}
+	}
+	return builder.finish()
+}
+
+// pvcStatsBuilder accumulates PVCStats one PVC at a time, keeping at most maxStuck stuck PVCs
+type pvcStatsBuilder struct {
+	stats    PVCStats
+	total    resource.Quantity
+	perClass map[string]*resource.Quantity
+	maxStuck int
+}
+
+// newPVCStatsBuilder creates an empty builder
+func newPVCStatsBuilder(maxStuck int) *pvcStatsBuilder {
+	return &pvcStatsBuilder{
+		stats:    PVCStats{ByStorageClass: map[string]*StorageClassPVCs{}},
+		perClass: map[string]*resource.Quantity{},
+		maxStuck: maxStuck,
+	}
+}
+
+// add counts a PVC
+func (b *pvcStatsBuilder) add(pvc *corev1.PersistentVolumeClaim) {
+	stats := &b.stats
+	stats.Total++
+	className := "<none>"
+	if pvc.Spec.StorageClassName != nil && *pvc.Spec.StorageClassName != "" {
+		className = *pvc.Spec.StorageClassName
+	}
+	class, exists := stats.ByStorageClass[className]
+	if !exists {
+		class = &StorageClassPVCs{}
+		stats.ByStorageClass[className] = class
+		b.perClass[className] = &resource.Quantity{}
+	}
+	class.Total++
+
+	switch pvc.Status.Phase {
+	case corev1.ClaimBound:
+		stats.Bound++
+		class.Bound++
+	case corev1.ClaimPending:
+		stats.Pending++
+		class.Pending++
+		if pendingFor := time.Since(pvc.CreationTimestamp.Time); pendingFor > StuckPendingThreshold {
+			stats.StuckPendingCount++
+			if len(stats.StuckPending) < b.maxStuck {
+				stats.StuckPending = append(stats.StuckPending, StuckPVC{
+					Name:         pvc.Name,
+					Namespace:    pvc.Namespace,
+					StorageClass: className,
+					CreatedAt:    pvc.CreationTimestamp.Time,
+					PendingFor:   HumanizeDuration(pendingFor),
+				})
 			}
 		}
+	case corev1.ClaimLost:
+		stats.Lost++
+		class.Lost++
+	}
+
+	if request, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+		b.total.Add(request)
+		b.perClass[className].Add(request)
 	}
+}
 
+// finish returns the statistics with the requested capacities filled in
+func (b *pvcStatsBuilder) finish() PVCStats {
+	stats := b.stats
+	stats.RequestedBytes = b.total.Value()
+	stats.Requested = FormatBytes(stats.RequestedBytes)
+	for className, class := range stats.ByStorageClass {
+		class.RequestedBytes = b.perClass[className].Value()
+		class.Requested = FormatBytes(class.RequestedBytes)
+	}
+	stats.Truncated = len(stats.StuckPending) < stats.StuckPendingCount
 	return stats
 }
 
-// checkODFInstalled checks if ODF/OCS is installed by looking for known provisioners
-func (s *StorageService) checkODFInstalled(scList *storagev1.StorageClassList) bool {
-	odfProvisioners := []string{
-		"openshift-storage.rbd.csi.ceph.com",
-		"openshift-storage.cephfs.csi.ceph.com",
-		"ocs-storagecluster-ceph-rbd",
-		"ocs-storagecluster-cephfs",
+// PVCFilter narrows the PVCs returned by ListPVCs
+type PVCFilter struct {
+	Namespace string `json:"namespace,omitempty"`
+	Phase     string `json:"phase,omitempty"`
+}
+
+// PVCInfo describes a PVC
+type PVCInfo struct {
+	Name           string    `json:"name"`
+	Namespace      string    `json:"namespace"`
+	Phase          string    `json:"phase"`
+	StorageClass   string    `json:"storageClass"`
+	VolumeName     string    `json:"volumeName,omitempty"`
+	RequestedBytes int64     `json:"requestedBytes"`
+	Requested      string    `json:"requested"`
+	CreatedAt      time.Time `json:"createdAt"`
+	Age            string    `json:"age"`
+}
+
+// PVCList reports the PVCs of a cluster matching a filter. Total and ByPhase count every
+// match, PVCs is capped and Truncated is set when matches were left out.
+type PVCList struct {
+	PVCs      []PVCInfo      `json:"pvcs"`
+	Total     int            `json:"total"`
+	ByPhase   map[string]int `json:"byPhase"`
+	Truncated bool           `json:"truncated,omitempty"`
+	// Scope notes that the allowed namespaces restricted the PVCs listed
+	Scope string `json:"scope,omitempty"`
+}
+
+// pvcPhases are the phases a PVC filter accepts
+var pvcPhases = []corev1.PersistentVolumeClaimPhase{corev1.ClaimPending, corev1.ClaimBound, corev1.ClaimLost}
+
+// ListPVCs lists the PVCs of a namespace, or of all namespaces, in phase when one is set.
+// PVCs are read page by page in API order, returning at most maxItems of them
+// (DefaultMaxItems when 0 or less).
+func (s *StorageService) ListPVCs(ctx context.Context, filter PVCFilter, maxItems int) (*PVCList, error) {
+	var phase corev1.PersistentVolumeClaimPhase
+	if filter.Phase != "" {
+		for _, known := range pvcPhases {
+			if strings.EqualFold(filter.Phase, string(known)) {
+				phase = known
+			}
+		}
+		if phase == "" {
+			return nil, fmt.Errorf("invalid phase %q: must be Pending, Bound or Lost", filter.Phase)
+		}
 	}
+	maxItems = effectiveMaxItems(maxItems)
 
-	for _, sc := range scList.Items {
-		for _, odfProv := range odfProvisioners {
-			if sc.Provisioner == odfProv {
-				return true
+	namespaces, scope := ScopeNamespaces(filter.Namespace)
+	result := &PVCList{
+		PVCs:    []PVCInfo{},
+		ByPhase: map[string]int{},
+		Scope:   scope,
+	}
+	for _, ns := range namespaces {
+		err := listPages(ctx, metav1.ListOptions{}, func(opts metav1.ListOptions) (string, error) {
+			list, err := s.client.ListPVCs(ctx, ns, opts)
+			if err != nil {
+				return "", err
 			}
+			for i := range list.Items {
+				pvc := &list.Items[i]
+				if phase != "" && pvc.Status.Phase != phase {
+					continue
+				}
+				result.Total++
+				result.ByPhase[string(pvc.Status.Phase)]++
+				if len(result.PVCs) < maxItems {
+					result.PVCs = append(result.PVCs, convertPVC(pvc))
+				}
+			}
+			return list.Continue, nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list PVCs: %w", err)
+		}
+	}
+	result.Truncated = len(result.PVCs) < result.Total
+
+	return result, nil
+}
+
+// convertPVC extracts the reported fields of a PVC
+func convertPVC(pvc *corev1.PersistentVolumeClaim) PVCInfo {
+	info := PVCInfo{
+		Name:         pvc.Name,
+		Namespace:    pvc.Namespace,
+		Phase:        string(pvc.Status.Phase),
+		StorageClass: "<none>",
+		VolumeName:   pvc.Spec.VolumeName,
+		CreatedAt:    pvc.CreationTimestamp.Time,
+		Age:          HumanizeAge(pvc.CreationTimestamp.Time),
+	}
+	if pvc.Spec.StorageClassName != nil && *pvc.Spec.StorageClassName != "" {
+		info.StorageClass = *pvc.Spec.StorageClassName
+	}
+	if request, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+		info.RequestedBytes = request.Value()
+	}
+	info.Requested = FormatBytes(info.RequestedBytes)
+	return info
+}
+
+// checkODFInstalled checks if ODF/OCS is installed by looking for known provisioners
+func (s *StorageService) checkODFInstalled(scList *storagev1.StorageClassList) bool {
+	for _, sc := range scList.Items {
+		if IsODFProvisioner(sc.Provisioner) {
+			return true
 		}
 	}
 	return false
@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+)
+
+type StorageSuite struct {
+	suite.Suite
+}
+
+func pvc(name, storageClass, size string, phase corev1.PersistentVolumeClaimPhase, age time.Duration) corev1.PersistentVolumeClaim {
+	return corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "apps",
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-age)),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			StorageClassName: ptr.To(storageClass),
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)},
+			},
+		},
+		Status: corev1.PersistentVolumeClaimStatus{Phase: phase},
+	}
+}
+
+func (s *StorageSuite) TestCalculatePVCStats() {
+	list := &corev1.PersistentVolumeClaimList{Items: []corev1.PersistentVolumeClaim{
+		pvc("db", "ceph-rbd", "10Gi", corev1.ClaimBound, time.Hour),
+		pvc("cache", "ceph-rbd", "512Mi", corev1.ClaimPending, time.Minute),
+		pvc("shared", "cephfs", "1Gi", corev1.ClaimPending, time.Hour),
+		pvc("old", "cephfs", "2Gi", corev1.ClaimLost, time.Hour),
+	}}
+
+	stats := NewStorageService(nil).calculatePVCStats(list)
+
+	s.Run("counts by phase", func() {
+		s.Equal(4, stats.Total)
+		s.Equal(1, stats.Bound)
+		s.Equal(2, stats.Pending)
+		s.Equal(1, stats.Lost)
+	})
+
+	s.Run("sums requested capacity", func() {
+		s.Equal(int64(13<<30+512<<20), stats.RequestedBytes)
+		s.Equal("13.5 GiB", stats.Requested)
+	})
+
+	s.Run("breaks down by storage class", func() {
+		s.Require().Contains(stats.ByStorageClass, "ceph-rbd")
+		s.Equal(2, stats.ByStorageClass["ceph-rbd"].Total)
+		s.Equal(int64(10<<30+512<<20), stats.ByStorageClass["ceph-rbd"].RequestedBytes)
+		s.Equal(1, stats.ByStorageClass["cephfs"].Lost)
+	})
+
+	s.Run("flags only old pending PVCs as stuck", func() {
+		s.Require().Len(stats.StuckPending, 1)
+		s.Equal("shared", stats.StuckPending[0].Name)
+	})
+}
+
+func (s *StorageSuite) TestPVCStatsBuilder() {
+	builder := newPVCStatsBuilder(2)
+	pages := [][]corev1.PersistentVolumeClaim{
+		{pvc("a", "ceph-rbd", "1Gi", corev1.ClaimPending, time.Hour), pvc("b", "ceph-rbd", "1Gi", corev1.ClaimBound, time.Hour)},
+		{pvc("c", "cephfs", "1Gi", corev1.ClaimPending, time.Hour), pvc("d", "cephfs", "1Gi", corev1.ClaimPending, time.Hour)},
+	}
+	for _, page := range pages {
+		for i := range page {
+			builder.add(&page[i])
+		}
+	}
+	stats := builder.finish()
+
+	s.Run("counts stay exact across pages", func() {
+		s.Equal(4, stats.Total)
+		s.Equal(3, stats.Pending)
+		s.Equal(int64(4<<30), stats.RequestedBytes)
+		s.Equal(3, stats.StuckPendingCount)
+	})
+	s.Run("stuck PVC details are capped", func() {
+		s.Len(stats.StuckPending, 2)
+		s.True(stats.Truncated)
+	})
+}
+
+func (s *StorageSuite) TestStorageClassInfo() {
+	s.Run("applies Kubernetes defaults", func() {
+		info := NewStorageClassInfo(&storagev1.StorageClass{
+			ObjectMeta:  metav1.ObjectMeta{Name: "gp3"},
+			Provisioner: "ebs.csi.aws.com",
+		})
+		s.False(info.IsDefault)
+		s.Equal(StorageCategoryCloudBlock, info.Category)
+		s.Equal("Delete", info.ReclaimPolicy)
+		s.Equal("Immediate", info.VolumeBindingMode)
+	})
+
+	s.Run("detects default and beta default annotations", func() {
+		s.True(IsDefaultStorageClass(&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{"storageclass.kubernetes.io/is-default-class": "true"},
+		}}))
+		s.True(IsDefaultStorageClass(&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{"storageclass.beta.kubernetes.io/is-default-class": "true"},
+		}}))
+		s.False(IsDefaultStorageClass(&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{"storageclass.kubernetes.io/is-default-class": "false"},
+		}}))
+	})
+
+	s.Run("reports reclaim policy and binding mode", func() {
+		info := NewStorageClassInfo(&storagev1.StorageClass{
+			ObjectMeta:        metav1.ObjectMeta{Name: "ceph-rbd"},
+			ReclaimPolicy:     ptr.To(corev1.PersistentVolumeReclaimRetain),
+			VolumeBindingMode: ptr.To(storagev1.VolumeBindingWaitForFirstConsumer),
+		})
+		s.Equal("Retain", info.ReclaimPolicy)
+		s.Equal("WaitForFirstConsumer", info.VolumeBindingMode)
+	})
+}
+
+func (s *StorageSuite) TestStorageCategory() {
+	s.Equal(StorageCategoryODF, StorageCategory("openshift-storage.rbd.csi.ceph.com"))
+	s.Equal(StorageCategoryCloudBlock, StorageCategory("kubernetes.io/aws-ebs"))
+	s.Equal(StorageCategoryCloudFile, StorageCategory("efs.csi.aws.com"))
+	s.Equal(StorageCategoryLocal, StorageCategory("kubernetes.io/no-provisioner"))
+	s.Equal(StorageCategoryOther, StorageCategory("spectrumscale.csi.ibm.com"))
+
+	s.True(IsODFProvisioner("ocs-storagecluster-cephfs"))
+	s.False(IsODFProvisioner("ebs.csi.aws.com"))
+}
+
+func (s *StorageSuite) TestListPVCs() {
+	db := pvc("db", "ceph-rbd", "10Gi", corev1.ClaimBound, time.Hour)
+	cache := pvc("cache", "ceph-rbd", "512Mi", corev1.ClaimPending, time.Minute)
+	shared := pvc("shared", "", "1Gi", corev1.ClaimPending, time.Hour)
+	shared.Namespace = "shop"
+	client := &clients.ClusterClient{Name: "prod-1", Clientset: fake.NewSimpleClientset(&db, &cache, &shared)}
+	service := NewStorageService(clients.NewClusterKubernetesClient(client))
+
+	s.Run("filters by phase", func() {
+		list, err := service.ListPVCs(context.Background(), PVCFilter{Phase: "pending"}, 0)
+		s.Require().NoError(err)
+		s.Equal(2, list.Total)
+		s.Equal(map[string]int{"Pending": 2}, list.ByPhase)
+		s.Require().Len(list.PVCs, 2)
+		for _, info := range list.PVCs {
+			s.Equal("Pending", info.Phase)
+		}
+	})
+	s.Run("filters by namespace and projects the fields", func() {
+		list, err := service.ListPVCs(context.Background(), PVCFilter{Namespace: "shop"}, 0)
+		s.Require().NoError(err)
+		s.Require().Len(list.PVCs, 1)
+		info := list.PVCs[0]
+		s.Equal("shared", info.Name)
+		s.Equal("<none>", info.StorageClass)
+		s.Equal(int64(1<<30), info.RequestedBytes)
+		s.Equal("1.0 GiB", info.Requested)
+		s.Equal("1h", info.Age)
+	})
+	s.Run("caps the PVCs and keeps counting", func() {
+		list, err := service.ListPVCs(context.Background(), PVCFilter{}, 1)
+		s.Require().NoError(err)
+		s.Len(list.PVCs, 1)
+		s.Equal(3, list.Total)
+		s.Equal(map[string]int{"Bound": 1, "Pending": 2}, list.ByPhase)
+		s.True(list.Truncated)
+	})
+	s.Run("rejects an unknown phase", func() {
+		_, err := service.ListPVCs(context.Background(), PVCFilter{Phase: "Failed"}, 0)
+		s.EqualError(err, `invalid phase "Failed": must be Pending, Bound or Lost`)
+	})
+}
+
+func TestStorageSuite(t *testing.T) {
+	suite.Run(t, new(StorageSuite))
+}
+
+// Made with Bob
@@ -0,0 +1,94 @@
+package targeting
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+)
+
+// collapseIgnoredFields are top-level fields of cluster data that differ between clusters
+// in the same state. They are left out of fingerprints and of the status of a group.
+var collapseIgnoredFields = []string{"namespace"}
+
+// ResultGroup is a set of clusters that reported the same data, Status, once the
+// cluster-specific fields are removed. Fingerprint is a stable hash of Status.
+// Status holds the canonical JSON of the data, typed like ClusterResult.Data.
+type ResultGroup struct {
+	Fingerprint string      `json:"fingerprint"`
+	Status      interface{} `json:"status"`
+	Clusters    []string    `json:"clusters"`
+}
+
+// Collapse moves the successful cluster results into Groups of clusters reporting the
+// same data, so a homogeneous fleet is reported once instead of once per cluster.
+// Failed and skipped clusters stay in ClusterResults. Like ApplyFilter it must run after
+// the summary and any aggregates are computed, which keep counting every cluster.
+func (r *Result) Collapse() {
+	groups := make(map[string]*ResultGroup)
+	for name, result := range r.ClusterResults {
+		if !result.Success {
+			continue
+		}
+		status, err := canonicalStatus(result.Data)
+		if err != nil {
+			continue
+		}
+		fingerprint := Fingerprint(status)
+		group, ok := groups[fingerprint]
+		if !ok {
+			group = &ResultGroup{Fingerprint: fingerprint, Status: status}
+			groups[fingerprint] = group
+		}
+		group.Clusters = append(group.Clusters, name)
+		delete(r.ClusterResults, name)
+	}
+
+	r.Groups = make([]ResultGroup, 0, len(groups))
+	for _, group := range groups {
+		sort.Strings(group.Clusters)
+		r.Groups = append(r.Groups, *group)
+	}
+	// Largest groups first, ties ordered by their first cluster for stable output
+	sort.Slice(r.Groups, func(i, j int) bool {
+		if len(r.Groups[i].Clusters) != len(r.Groups[j].Clusters) {
+			return len(r.Groups[i].Clusters) > len(r.Groups[j].Clusters)
+		}
+		return r.Groups[i].Clusters[0] < r.Groups[j].Clusters[0]
+	})
+}
+
+// Fingerprint returns a stable hash of a canonical status, see canonicalStatus
+func Fingerprint(status json.RawMessage) string {
+	sum := sha256.Sum256(status)
+	return hex.EncodeToString(sum[:8])
+}
+
+// canonicalStatus encodes cluster data without the cluster-specific fields and with
+// object keys sorted, so that equal statuses encode to the same bytes. Numbers are kept
+// verbatim rather than going through float64.
+func canonicalStatus(data interface{}) (json.RawMessage, error) {
+	raw, ok := data.(json.RawMessage)
+	if !ok {
+		var err error
+		if raw, err = json.Marshal(data); err != nil {
+			return nil, err
+		}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if object, ok := value.(map[string]interface{}); ok {
+		for _, field := range collapseIgnoredFields {
+			delete(object, field)
+		}
+	}
+	return json.Marshal(value)
+}
+
+// Made with Bob
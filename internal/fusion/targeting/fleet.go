@@ -0,0 +1,230 @@
+package targeting
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ClusterSetLabel is the label OCM/ACM uses to record ManagedCluster clusterset membership
+const ClusterSetLabel = "cluster.open-cluster-management.io/clusterset"
+
+// ManagedClusterGVR identifies the OCM/ACM ManagedCluster resource on a hub cluster
+var ManagedClusterGVR = schema.GroupVersionResource{
+	Group:    "cluster.open-cluster-management.io",
+	Version:  "v1",
+	Resource: "managedclusters",
+}
+
+// ResolveFleet resolves the registered clusters belonging to a fleet.
+// Membership is read from ManagedCluster objects on the hub: the clusterset label
+// must match the fleet name, and each ManagedCluster is mapped to a registered
+// cluster either by name or by API server URL. When hub is empty every registered
+// cluster is probed concurrently and the first in name order serving the
+// ManagedCluster CRD is the hub. The cluster name prefix heuristic is used only when
+// every probed cluster reports the CRD absent: a cluster that could not be probed,
+// unreachable or forbidden, might be the hub, so the fleet cannot be resolved.
+func ResolveFleet(ctx context.Context, registry *clients.Registry, fleet, hub string) ([]string, ResolutionMethod, error) {
+	if errs := validation.IsValidLabelValue(fleet); len(errs) > 0 {
+		return nil, "", fmt.Errorf("invalid fleet name %q, it must be a valid clusterset label value: %s", fleet, strings.Join(errs, "; "))
+	}
+	if hub != "" {
+		if _, err := registry.GetClient(hub); err != nil {
+			return nil, "", fmt.Errorf("hub cluster %s: %w", hub, err)
+		}
+	}
+
+	candidates := hubCandidates(registry, hub)
+	probes := probeHubs(ctx, registry, candidates, func(ctx context.Context, hubClient *clients.ClusterClient) ([]unstructured.Unstructured, error) {
+		return listManagedClusters(ctx, hubClient, fleet)
+	})
+	var failed []string
+	for i, candidate := range candidates {
+		if err := probes[i].err; err != nil {
+			if !crdAbsent(err) {
+				if hub != "" {
+					return nil, "", fmt.Errorf("failed to list ManagedClusters on hub %s: %w", candidate, err)
+				}
+				failed = append(failed, fmt.Sprintf("%s: %v", candidate, err))
+			}
+			// Not the hub, or not known to be: try the next candidate
+			continue
+		}
+
+		members := fleetMembers(probes[i].value, fleet, registry.GetAllClients())
+		if len(members) == 0 {
+			return nil, "", fmt.Errorf("no registered clusters found in clusterset %s on hub %s", fleet, candidate)
+		}
+		return members, ResolvedManagedCluster, nil
+	}
+	if len(failed) > 0 {
+		return nil, "", fmt.Errorf("cannot resolve fleet %s: no cluster serves the ManagedCluster CRD and these could not be probed for it, set hub to name the hub cluster: %s", fleet, strings.Join(failed, "; "))
+	}
+
+	// No hub serves the ManagedCluster CRD, fall back to the naming heuristic
+	t := Target{Type: TargetFleet, Fleet: fleet}
+	names, err := t.GetClusterNames(registry.ListClusterNames())
+	if err != nil {
+		return nil, "", err
+	}
+	return names, ResolvedPrefix, nil
+}
+
+// crdAbsent reports whether a hub probe failed because the cluster does not serve the
+// resource, rather than because it could not be asked
+func crdAbsent(err error) bool {
+	return apierrors.IsNotFound(err) || meta.IsNoMatchError(err)
+}
+
+// hubCandidates returns the clusters to probe for hub resources: the explicit hub
+// when given, otherwise every registered cluster in name order
+func hubCandidates(registry *clients.Registry, hub string) []string {
+	if hub != "" {
+		return []string{hub}
+	}
+	candidates := registry.ListClusterNames()
+	sort.Strings(candidates)
+	return candidates
+}
+
+// hubProbe is the outcome of probing one hub candidate
+type hubProbe[T any] struct {
+	value T
+	err   error
+}
+
+// probeHubs runs probe on the hub candidates concurrently, at most the registry's
+// concurrency at a time and each within the registry timeout, so an unreachable
+// cluster delays the resolution by one timeout at most. Outcomes are returned in
+// candidate order.
+func probeHubs[T any](ctx context.Context, registry *clients.Registry, candidates []string, probe func(context.Context, *clients.ClusterClient) (T, error)) []hubProbe[T] {
+	probes := make([]hubProbe[T], len(candidates))
+	limit := registry.MaxConcurrency()
+	if limit <= 0 || limit > len(candidates) {
+		limit = len(candidates)
+	}
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i, candidate := range candidates {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			probes[i] = probeHub(ctx, registry, candidate, probe)
+		}()
+	}
+	wg.Wait()
+	return probes
+}
+
+// probeHub runs probe on one hub candidate, giving up when the registry timeout expires
+// even if the probe does not honor its context
+func probeHub[T any](ctx context.Context, registry *clients.Registry, candidate string, probe func(context.Context, *clients.ClusterClient) (T, error)) hubProbe[T] {
+	hubClient, err := registry.GetClient(candidate)
+	if err != nil {
+		return hubProbe[T]{err: err}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, registry.Timeout())
+	defer cancel()
+	done := make(chan hubProbe[T], 1)
+	go func() {
+		value, err := probe(ctx, hubClient)
+		done <- hubProbe[T]{value: value, err: err}
+	}()
+	select {
+	case outcome := <-done:
+		return outcome
+	case <-ctx.Done():
+		return hubProbe[T]{err: fmt.Errorf("probe of hub cluster %s timed out: %w", candidate, ctx.Err())}
+	}
+}
+
+// listManagedClusters lists the ManagedCluster objects of a clusterset on a hub cluster
+func listManagedClusters(ctx context.Context, hubClient *clients.ClusterClient, fleet string) ([]unstructured.Unstructured, error) {
+	dynamicClient, err := hubClient.DynamicClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
+	list, err := dynamicClient.Resource(ManagedClusterGVR).List(ctx, metav1.ListOptions{
+		LabelSelector: ClusterSetLabel + "=" + fleet,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// fleetMembers maps the ManagedClusters of a clusterset to registered cluster names
+func fleetMembers(managedClusters []unstructured.Unstructured, fleet string, registered map[string]*clients.ClusterClient) []string {
+	// Index registered clusters by API server URL to match ManagedClusters whose
+	// name differs from the kubeconfig context name
+	byHost := registeredHosts(registered)
+
+	seen := make(map[string]bool)
+	var members []string
+	for _, mc := range managedClusters {
+		if mc.GetLabels()[ClusterSetLabel] != fleet {
+			continue
+		}
+
+		name := registeredName(mc, registered, byHost)
+		if name != "" && !seen[name] {
+			seen[name] = true
+			members = append(members, name)
+		}
+	}
+
+	sort.Strings(members)
+	return members
+}
+
+// registeredHosts indexes registered clusters by normalized API server URL
+func registeredHosts(registered map[string]*clients.ClusterClient) map[string]string {
+	byHost := make(map[string]string, len(registered))
+	for name, client := range registered {
+		if client.Config != nil {
+			byHost[normalizeHost(client.Config.Host)] = name
+		}
+	}
+	return byHost
+}
+
+// registeredName returns the registered cluster a ManagedCluster refers to, matching
+// by name first and then by the API server URLs in its client configs
+func registeredName(mc unstructured.Unstructured, registered map[string]*clients.ClusterClient, byHost map[string]string) string {
+	if _, ok := registered[mc.GetName()]; ok {
+		return mc.GetName()
+	}
+	clientConfigs, _, _ := unstructured.NestedSlice(mc.Object, "spec", "managedClusterClientConfigs")
+	for _, cc := range clientConfigs {
+		ccMap, ok := cc.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		url, _ := ccMap["url"].(string)
+		if name, ok := byHost[normalizeHost(url)]; ok {
+			return name
+		}
+	}
+	return ""
+}
+
+// normalizeHost normalizes an API server URL for comparison
+func normalizeHost(host string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(host)), "/")
+}
+
+// Made with Bob
@@ -0,0 +1,181 @@
+package targeting
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/stretchr/testify/suite"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+type FleetSuite struct {
+	suite.Suite
+}
+
+func managedCluster(name, clusterset, url string) *unstructured.Unstructured {
+	mc := &unstructured.Unstructured{}
+	mc.SetAPIVersion("cluster.open-cluster-management.io/v1")
+	mc.SetKind("ManagedCluster")
+	mc.SetName(name)
+	mc.SetLabels(map[string]string{ClusterSetLabel: clusterset})
+	if url != "" {
+		_ = unstructured.SetNestedSlice(mc.Object, []interface{}{map[string]interface{}{"url": url}}, "spec", "managedClusterClientConfigs")
+	}
+	return mc
+}
+
+// hubClient is a cluster serving the ManagedCluster and Ramen hub resources
+func hubClient(name string, objects ...runtime.Object) *clients.ClusterClient {
+	return &clients.ClusterClient{
+		Name:   name,
+		Config: &rest.Config{Host: "https://api." + name + ".example.com:6443"},
+		Dynamic: dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+			ManagedClusterGVR:     "ManagedClusterList",
+			DRPlacementControlGVR: "DRPlacementControlList",
+			DRClusterGVR:          "DRClusterList",
+		}, objects...),
+	}
+}
+
+// spokeClient is a cluster that serves none of the hub resources
+func spokeClient(name string) *clients.ClusterClient {
+	client := hubClient(name)
+	client.Dynamic.(*dynamicfake.FakeDynamicClient).PrependReactor("*", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewNotFound(action.GetResource().GroupResource(), "")
+	})
+	return client
+}
+
+// unreachableClient is a cluster whose requests hang until release is closed
+func unreachableClient(name string, release chan struct{}) *clients.ClusterClient {
+	client := hubClient(name)
+	client.Dynamic.(*dynamicfake.FakeDynamicClient).PrependReactor("*", "*", func(k8stesting.Action) (bool, runtime.Object, error) {
+		<-release
+		return true, nil, context.DeadlineExceeded
+	})
+	return client
+}
+
+func newRegistry(clusters ...*clients.ClusterClient) *clients.Registry {
+	registry := clients.NewRegistry()
+	for _, client := range clusters {
+		registry.AddClient(client)
+	}
+	return registry
+}
+
+func (s *FleetSuite) TestResolveFleet() {
+	s.Run("resolves clusterset members from the ManagedClusters of the hub", func() {
+		registry := newRegistry(
+			hubClient("hub", managedCluster("prod-1", "prod", ""), managedCluster("prod-2", "prod", ""), managedCluster("dev-1", "dev", "")),
+			spokeClient("prod-1"), spokeClient("prod-2"), spokeClient("dev-1"),
+		)
+		names, method, err := ResolveFleet(context.Background(), registry, "prod", "")
+		s.Require().NoError(err)
+		s.Equal([]string{"prod-1", "prod-2"}, names)
+		s.Equal(ResolvedManagedCluster, method)
+	})
+
+	s.Run("maps ManagedClusters to registered clusters by API server URL", func() {
+		east := spokeClient("ctx-east")
+		registry := newRegistry(
+			hubClient("hub", managedCluster("edge-east", "edge", east.Config.Host+"/")),
+			east, spokeClient("ctx-west"),
+		)
+		names, method, err := ResolveFleet(context.Background(), registry, "edge", "hub")
+		s.Require().NoError(err)
+		s.Equal([]string{"ctx-east"}, names)
+		s.Equal(ResolvedManagedCluster, method)
+	})
+
+	s.Run("fails on a clusterset without registered members", func() {
+		registry := newRegistry(hubClient("hub", managedCluster("edge-1", "edge", "")), spokeClient("prod-1"))
+		_, _, err := ResolveFleet(context.Background(), registry, "edge", "")
+		s.ErrorContains(err, "no registered clusters found in clusterset edge on hub hub")
+	})
+
+	s.Run("falls back to the name prefix without a hub", func() {
+		registry := newRegistry(spokeClient("prod-1"), spokeClient("prod-2"), spokeClient("dev-1"))
+		names, method, err := ResolveFleet(context.Background(), registry, "prod", "")
+		s.Require().NoError(err)
+		s.ElementsMatch([]string{"prod-1", "prod-2"}, names)
+		s.Equal(ResolvedPrefix, method)
+	})
+
+	s.Run("falls back to the name prefix when the kind is not served", func() {
+		noMatch := hubClient("prod-2")
+		noMatch.Dynamic.(*dynamicfake.FakeDynamicClient).PrependReactor("*", "*", func(k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, &meta.NoKindMatchError{GroupKind: schema.GroupKind{Group: ManagedClusterGVR.Group, Kind: "ManagedCluster"}}
+		})
+		names, method, err := ResolveFleet(context.Background(), newRegistry(spokeClient("prod-1"), noMatch), "prod", "")
+		s.Require().NoError(err)
+		s.ElementsMatch([]string{"prod-1", "prod-2"}, names)
+		s.Equal(ResolvedPrefix, method)
+	})
+
+	s.Run("does not fall back when a cluster could not be probed", func() {
+		forbidden := hubClient("acm")
+		forbidden.Dynamic.(*dynamicfake.FakeDynamicClient).PrependReactor("*", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, apierrors.NewForbidden(action.GetResource().GroupResource(), "", errors.New("cannot list"))
+		})
+		release := make(chan struct{})
+		defer close(release)
+		registry := newRegistry(forbidden, unreachableClient("down", release), spokeClient("prod-1"), spokeClient("prod-2"))
+		registry.SetTimeout(50 * time.Millisecond)
+		_, _, err := ResolveFleet(context.Background(), registry, "prod", "")
+		s.ErrorContains(err, "cannot resolve fleet prod: no cluster serves the ManagedCluster CRD and these could not be probed for it")
+		s.ErrorContains(err, "acm: managedclusters.cluster.open-cluster-management.io is forbidden")
+		s.ErrorContains(err, "down: probe of hub cluster down timed out")
+	})
+
+	s.Run("fails on a fleet name that is not a label value", func() {
+		_, _, err := ResolveFleet(context.Background(), newRegistry(spokeClient("prod-1")), "prod,env!=dev", "")
+		s.ErrorContains(err, `invalid fleet name "prod,env!=dev"`)
+	})
+
+	s.Run("fails on an explicit hub that cannot list ManagedClusters", func() {
+		release := make(chan struct{})
+		defer close(release)
+		registry := newRegistry(unreachableClient("hub", release), spokeClient("prod-1"))
+		registry.SetTimeout(50 * time.Millisecond)
+		_, _, err := ResolveFleet(context.Background(), registry, "prod", "hub")
+		s.ErrorContains(err, "failed to list ManagedClusters on hub hub")
+	})
+
+	s.Run("fails on an unregistered explicit hub", func() {
+		_, _, err := ResolveFleet(context.Background(), newRegistry(spokeClient("prod-1")), "prod", "hub")
+		s.ErrorContains(err, "hub cluster hub")
+	})
+
+	s.Run("bounds the wait on unreachable clusters by one timeout", func() {
+		release := make(chan struct{})
+		defer close(release)
+		registry := newRegistry(
+			unreachableClient("a-down", release), unreachableClient("b-down", release), unreachableClient("c-down", release),
+			hubClient("hub", managedCluster("prod-1", "prod", "")), spokeClient("prod-1"),
+		)
+		registry.SetTimeout(200 * time.Millisecond)
+		start := time.Now()
+		names, method, err := ResolveFleet(context.Background(), registry, "prod", "")
+		s.Require().NoError(err)
+		s.Equal([]string{"prod-1"}, names)
+		s.Equal(ResolvedManagedCluster, method)
+		s.Less(time.Since(start), 500*time.Millisecond, "the unreachable clusters are probed concurrently")
+	})
+}
+
+func TestFleetSuite(t *testing.T) {
+	suite.Run(t, new(FleetSuite))
+}
+
+// Made with Bob
@@ -0,0 +1,86 @@
+package targeting
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Group is a named set of clusters, listed explicitly or matched by a selector
+type Group struct {
+	// Clusters names the clusters of the group
+	Clusters []string `json:"clusters,omitempty"`
+
+	// Selector matches registered clusters like a selector target, format: key1=value1
+	Selector string `json:"selector,omitempty"`
+}
+
+var (
+	groups   map[string]Group
+	groupsMu sync.RWMutex
+)
+
+// SetGroups sets the named groups group targets resolve against, nil removes them all
+// Each group must either list clusters or have a selector
+func SetGroups(named map[string]Group) error {
+	var errs []error
+	for _, name := range slices.Sorted(maps.Keys(named)) {
+		group := named[name]
+		switch {
+		case name == "":
+			errs = append(errs, fmt.Errorf("group names cannot be empty"))
+		case len(group.Clusters) > 0 && group.Selector != "":
+			errs = append(errs, fmt.Errorf("group %s cannot have both clusters and a selector", name))
+		case len(group.Clusters) == 0 && group.Selector == "":
+			errs = append(errs, fmt.Errorf("group %s requires clusters or a selector", name))
+		case slices.Contains(group.Clusters, ""):
+			errs = append(errs, fmt.Errorf("cluster names of group %s cannot be empty", name))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	groupsMu.Lock()
+	defer groupsMu.Unlock()
+	groups = maps.Clone(named)
+	return nil
+}
+
+// lookupGroup returns the named group, with an error listing the known groups when
+// there is none of that name
+func lookupGroup(name string) (Group, error) {
+	groupsMu.RLock()
+	defer groupsMu.RUnlock()
+	group, ok := groups[name]
+	if !ok {
+		if len(groups) == 0 {
+			return Group{}, fmt.Errorf("unknown group %s: no groups are configured", name)
+		}
+		return Group{}, fmt.Errorf("unknown group %s, configured groups: %s", name, strings.Join(slices.Sorted(maps.Keys(groups)), ", "))
+	}
+	return group, nil
+}
+
+// groupClusterNames returns the clusters of the named group, matching its selector
+// against availableClusters like a selector target
+func groupClusterNames(name string, availableClusters []string, labelsOf func(clusterName string) map[string]string) ([]string, error) {
+	group, err := lookupGroup(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(group.Clusters) > 0 {
+		return slices.Clone(group.Clusters), nil
+	}
+
+	selected := selectClusters(group.Selector, availableClusters, labelsOf)
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no clusters match the selector %s of group %s", group.Selector, name)
+	}
+	return selected, nil
+}
+
+// Made with Bob
@@ -0,0 +1,154 @@
+package targeting
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+var (
+	// DRPlacementControlGVR identifies Ramen DRPlacementControl resources on the hub
+	DRPlacementControlGVR = schema.GroupVersionResource{
+		Group:    "ramendr.openshift.io",
+		Version:  "v1alpha1",
+		Resource: "drplacementcontrols",
+	}
+	// DRClusterGVR identifies Ramen DRCluster resources on the hub
+	DRClusterGVR = schema.GroupVersionResource{
+		Group:    "ramendr.openshift.io",
+		Version:  "v1alpha1",
+		Resource: "drclusters",
+	}
+)
+
+// ResolvePrimary resolves the registered cluster currently holding the primary role
+// for a DRPolicy. The hub's DRPlacementControls referencing the policy are inspected
+// and the cluster their placement decision points at is the primary. An error is
+// returned when no primary can be determined, or when workloads of the policy are
+// primary on different clusters, rather than fanning out to every cluster. Without a
+// hub the registered clusters are probed concurrently, as in ResolveFleet.
+func ResolvePrimary(ctx context.Context, registry *clients.Registry, drPolicy, hub string) ([]string, ResolutionMethod, error) {
+	if hub != "" {
+		if _, err := registry.GetClient(hub); err != nil {
+			return nil, "", fmt.Errorf("hub cluster %s: %w", hub, err)
+		}
+	}
+
+	candidates := hubCandidates(registry, hub)
+	probes := probeHubs(ctx, registry, candidates, func(ctx context.Context, hubClient *clients.ClusterClient) ([]unstructured.Unstructured, error) {
+		dynamicClient, err := hubClient.DynamicClient()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+		}
+		drpcs, err := dynamicClient.Resource(DRPlacementControlGVR).Namespace(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return drpcs.Items, nil
+	})
+	for i, candidate := range candidates {
+		if err := probes[i].err; err != nil {
+			if hub != "" {
+				return nil, "", fmt.Errorf("failed to list DRPlacementControls on hub %s: %w", candidate, err)
+			}
+			// Not a Ramen hub (or unreachable while auto-detecting): try the next candidate
+			continue
+		}
+
+		primaries := primaryClusters(probes[i].value, drPolicy)
+		if len(primaries) == 0 {
+			return nil, "", fmt.Errorf("no primary cluster found for DRPolicy %s on hub %s", drPolicy, candidate)
+		}
+		if len(primaries) > 1 {
+			return nil, "", fmt.Errorf("workloads of DRPolicy %s are primary on multiple clusters (%s), target them explicitly",
+				drPolicy, strings.Join(primaries, ", "))
+		}
+
+		hubClient, err := registry.GetClient(candidate)
+		if err != nil {
+			return nil, "", fmt.Errorf("hub cluster %s: %w", candidate, err)
+		}
+		dynamicClient, err := hubClient.DynamicClient()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to create dynamic client for hub %s: %w", candidate, err)
+		}
+		name, err := registeredPrimary(ctx, dynamicClient, primaries[0], registry.GetAllClients())
+		if err != nil {
+			return nil, "", err
+		}
+		return []string{name}, ResolvedDRPlacement, nil
+	}
+
+	return nil, "", fmt.Errorf("no hub cluster with DRPlacementControls found to resolve the primary of DRPolicy %s", drPolicy)
+}
+
+// primaryClusters returns the distinct DR cluster names currently primary for the
+// DRPlacementControls referencing the given policy
+func primaryClusters(drpcs []unstructured.Unstructured, drPolicy string) []string {
+	seen := make(map[string]bool)
+	var primaries []string
+	for _, drpc := range drpcs {
+		policy, _, _ := unstructured.NestedString(drpc.Object, "spec", "drPolicyRef", "name")
+		if policy != drPolicy {
+			continue
+		}
+
+		// The placement decision reflects where the workload runs now, the spec
+		// fields only express intent and are used when no decision was recorded yet
+		primary, _, _ := unstructured.NestedString(drpc.Object, "status", "preferredDecision", "clusterName")
+		if primary == "" {
+			action, _, _ := unstructured.NestedString(drpc.Object, "spec", "action")
+			if action == "Failover" {
+				primary, _, _ = unstructured.NestedString(drpc.Object, "spec", "failoverCluster")
+			} else {
+				primary, _, _ = unstructured.NestedString(drpc.Object, "spec", "preferredCluster")
+			}
+		}
+
+		if primary != "" && !seen[primary] {
+			seen[primary] = true
+			primaries = append(primaries, primary)
+		}
+	}
+	sort.Strings(primaries)
+	return primaries
+}
+
+// registeredPrimary maps a DR cluster name to a registered cluster. The DRCluster
+// must exist and must not be fenced, and the match falls back to the ManagedCluster
+// API server URLs when the names differ.
+func registeredPrimary(ctx context.Context, hubClient dynamic.Interface, drClusterName string, registered map[string]*clients.ClusterClient) (string, error) {
+	drCluster, err := hubClient.Resource(DRClusterGVR).Get(ctx, drClusterName, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get DRCluster %s: %w", drClusterName, err)
+	}
+	if phase, _, _ := unstructured.NestedString(drCluster.Object, "status", "phase"); phase == "Fenced" {
+		return "", fmt.Errorf("primary DRCluster %s is fenced", drClusterName)
+	}
+
+	if _, ok := registered[drClusterName]; ok {
+		return drClusterName, nil
+	}
+
+	managedCluster, err := hubClient.Resource(ManagedClusterGVR).Get(ctx, drClusterName, metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return "", fmt.Errorf("failed to get ManagedCluster %s: %w", drClusterName, err)
+	}
+	if err == nil {
+		if name := registeredName(*managedCluster, registered, registeredHosts(registered)); name != "" {
+			return name, nil
+		}
+	}
+
+	return "", fmt.Errorf("primary cluster %s is not registered", drClusterName)
+}
+
+// Made with Bob
@@ -0,0 +1,98 @@
+package targeting
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type PrimarySuite struct {
+	suite.Suite
+}
+
+func drpc(name, policy, decision string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("ramendr.openshift.io/v1alpha1")
+	obj.SetKind("DRPlacementControl")
+	obj.SetNamespace("shop")
+	obj.SetName(name)
+	_ = unstructured.SetNestedField(obj.Object, policy, "spec", "drPolicyRef", "name")
+	_ = unstructured.SetNestedField(obj.Object, "prod-2", "spec", "preferredCluster")
+	if decision != "" {
+		_ = unstructured.SetNestedField(obj.Object, decision, "status", "preferredDecision", "clusterName")
+	}
+	return obj
+}
+
+func drCluster(name, phase string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("ramendr.openshift.io/v1alpha1")
+	obj.SetKind("DRCluster")
+	obj.SetName(name)
+	_ = unstructured.SetNestedField(obj.Object, phase, "status", "phase")
+	return obj
+}
+
+func (s *PrimarySuite) TestResolvePrimary() {
+	clusters := []runtime.Object{drCluster("prod-1", "Available"), drCluster("prod-2", "Available")}
+
+	s.Run("resolves the cluster the placement decision points at", func() {
+		registry := newRegistry(
+			hubClient("hub", append(clusters, drpc("web", "metro", "prod-1"), drpc("db", "metro", "prod-1"), drpc("batch", "other", "prod-2"))...),
+			spokeClient("prod-1"), spokeClient("prod-2"),
+		)
+		names, method, err := ResolvePrimary(context.Background(), registry, "metro", "")
+		s.Require().NoError(err)
+		s.Equal([]string{"prod-1"}, names)
+		s.Equal(ResolvedDRPlacement, method)
+	})
+
+	s.Run("falls back to the preferred cluster before a decision is recorded", func() {
+		registry := newRegistry(hubClient("hub", append(clusters, drpc("web", "metro", ""))...), spokeClient("prod-1"), spokeClient("prod-2"))
+		names, _, err := ResolvePrimary(context.Background(), registry, "metro", "hub")
+		s.Require().NoError(err)
+		s.Equal([]string{"prod-2"}, names)
+	})
+
+	s.Run("fails without a DRPlacementControl of the policy", func() {
+		registry := newRegistry(hubClient("hub", append(clusters, drpc("batch", "other", "prod-2"))...), spokeClient("prod-1"))
+		_, _, err := ResolvePrimary(context.Background(), registry, "metro", "")
+		s.ErrorContains(err, "no primary cluster found for DRPolicy metro on hub hub")
+	})
+
+	s.Run("fails without a Ramen hub", func() {
+		registry := newRegistry(spokeClient("prod-1"), spokeClient("prod-2"))
+		_, _, err := ResolvePrimary(context.Background(), registry, "metro", "")
+		s.ErrorContains(err, "no hub cluster with DRPlacementControls found to resolve the primary of DRPolicy metro")
+	})
+
+	s.Run("fails on workloads primary on different clusters", func() {
+		registry := newRegistry(
+			hubClient("hub", append(clusters, drpc("web", "metro", "prod-1"), drpc("db", "metro", "prod-2"))...),
+			spokeClient("prod-1"), spokeClient("prod-2"),
+		)
+		_, _, err := ResolvePrimary(context.Background(), registry, "metro", "")
+		s.ErrorContains(err, "workloads of DRPolicy metro are primary on multiple clusters (prod-1, prod-2)")
+	})
+
+	s.Run("fails on a fenced primary", func() {
+		registry := newRegistry(hubClient("hub", drCluster("prod-1", "Fenced"), drpc("web", "metro", "prod-1")), spokeClient("prod-1"))
+		_, _, err := ResolvePrimary(context.Background(), registry, "metro", "")
+		s.ErrorContains(err, "primary DRCluster prod-1 is fenced")
+	})
+
+	s.Run("fails on a primary that is not registered", func() {
+		registry := newRegistry(hubClient("hub", append(clusters, drpc("web", "metro", "prod-2"))...), spokeClient("prod-1"))
+		_, _, err := ResolvePrimary(context.Background(), registry, "metro", "")
+		s.ErrorContains(err, "primary cluster prod-2 is not registered")
+	})
+}
+
+func TestPrimarySuite(t *testing.T) {
+	suite.Run(t, new(PrimarySuite))
+}
+
+// Made with Bob
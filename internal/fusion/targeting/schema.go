@@ -0,0 +1,45 @@
+package targeting
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+)
+
+// ResultSchemaFor returns the JSON schema of a Result whose cluster results carry data of
+// type T. Both schemas are inferred from the Go structs so they can't drift apart.
+func ResultSchemaFor[T any]() (*jsonschema.Schema, error) {
+	resultSchema, err := jsonschema.For[Result](nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to infer result schema: %w", err)
+	}
+	dataSchema, err := jsonschema.For[T](nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to infer cluster data schema: %w", err)
+	}
+
+	clusterResults, ok := resultSchema.Properties["clusterResults"]
+	if !ok || clusterResults.AdditionalProperties == nil {
+		return nil, fmt.Errorf("result schema has no clusterResults map")
+	}
+	clusterResults.AdditionalProperties.Properties["data"] = dataSchema
+
+	// Collapsed results group the cluster data
+	groups, ok := resultSchema.Properties["groups"]
+	if !ok || groups.Items == nil {
+		return nil, fmt.Errorf("result schema has no groups list")
+	}
+	groups.Items.Properties["status"] = dataSchema
+	return resultSchema, nil
+}
+
+// MustResultSchemaFor is like ResultSchemaFor but panics on error, for use in package init
+func MustResultSchemaFor[T any]() *jsonschema.Schema {
+	schema, err := ResultSchemaFor[T]()
+	if err != nil {
+		panic(err)
+	}
+	return schema
+}
+
+// Made with Bob
@@ -1,10 +1,19 @@
 package targeting
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
 	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
 )
 
 // TargetType defines how clusters are targeted
@@ -21,6 +30,30 @@ const (
 	TargetSelector TargetType = "selector"
 	// TargetAll targets all registered clusters
 	TargetAll TargetType = "all"
+	// TargetPrimary targets the cluster currently primary for a DRPolicy
+	TargetPrimary TargetType = "primary"
+	// TargetGroup targets the clusters of a named group of the Fusion config
+	TargetGroup TargetType = "group"
+)
+
+// ResolutionMethod records which strategy resolved a target to cluster names
+type ResolutionMethod string
+
+const (
+	// ResolvedExplicit means the cluster names were given explicitly in the target
+	ResolvedExplicit ResolutionMethod = "explicit"
+	// ResolvedRegistry means every registered cluster was targeted
+	ResolvedRegistry ResolutionMethod = "registry"
+	// ResolvedSelector means registered clusters were matched against a selector
+	ResolvedSelector ResolutionMethod = "selector"
+	// ResolvedManagedCluster means fleet membership was read from hub ManagedCluster objects
+	ResolvedManagedCluster ResolutionMethod = "managedcluster"
+	// ResolvedPrefix means fleet membership was inferred from cluster name prefixes
+	ResolvedPrefix ResolutionMethod = "prefix"
+	// ResolvedDRPlacement means the primary was read from hub DRPlacementControls
+	ResolvedDRPlacement ResolutionMethod = "drplacementcontrol"
+	// ResolvedGroup means the clusters were read from a configured group
+	ResolvedGroup ResolutionMethod = "group"
 )
 
 // Target defines how to target clusters for an operation
@@ -37,52 +70,196 @@ type Target struct {
 	// Fleet specifies a fleet/hub name (for TargetFleet)
 	Fleet string `json:"fleet,omitempty"`
 
+	// Hub specifies the registered hub cluster used to resolve fleet membership
+	// or the DR primary (for TargetFleet and TargetPrimary)
+	// When empty, registered clusters are probed for the hub resources
+	Hub string `json:"hub,omitempty"`
+
+	// DRPolicy specifies the Ramen DRPolicy whose primary cluster is targeted (for TargetPrimary)
+	DRPolicy string `json:"drPolicy,omitempty"`
+
 	// Selector specifies label selectors (for TargetSelector)
 	// Format: "key1=value1,key2=value2"
 	Selector string `json:"selector,omitempty"`
 
+	// Group specifies a named group of the Fusion config (for TargetGroup), see SetGroups
+	Group string `json:"group,omitempty"`
+
+	// ImpersonateUser and ImpersonateGroups make the operation act as this user and groups
+	// instead of the server's identity (optional, only when the Fusion config allows it)
+	ImpersonateUser   string   `json:"impersonateUser,omitempty"`
+	ImpersonateGroups []string `json:"impersonateGroups,omitempty"`
+
 	// Timeout specifies operation timeout in seconds (optional)
 	Timeout int `json:"timeout,omitempty"`
+
+	// ClusterTimeouts overrides Timeout for individual clusters, in seconds (optional)
+	ClusterTimeouts map[string]int `json:"clusterTimeouts,omitempty"`
+
+	// FailFast cancels the remaining clusters as soon as one fails (optional)
+	// Clusters that had not started are reported as skipped
+	FailFast bool `json:"failFast,omitempty"`
+
+	// MaxConcurrency caps how many clusters are operated on at once (optional)
+	// Defaults to clients.DefaultMaxConcurrency when omitted, 0 means unbounded
+	MaxConcurrency *int `json:"maxConcurrency,omitempty"`
+
+	// Retry overrides the registry retry policy for transient errors (optional)
+	// Unset fields keep the registry value, maxAttempts 1 disables retries
+	Retry *clients.RetryPolicy `json:"retry,omitempty"`
+
+	// OnlyFailures, OnlyReady and OnlyNotInstalled trim the returned cluster results (optional)
+	// When several are set a cluster result is kept if it matches any of them
+	// The summary still counts every targeted cluster
+	OnlyFailures     bool `json:"onlyFailures,omitempty"`
+	OnlyReady        bool `json:"onlyReady,omitempty"`
+	OnlyNotInstalled bool `json:"onlyNotInstalled,omitempty"`
+
+	// Collapse groups the clusters reporting the same status instead of returning one
+	// cluster result each (optional), see Result.Collapse
+	Collapse bool `json:"collapse,omitempty"`
 }
 
 // Validate checks if the target configuration is valid
+// Every problem found is reported, joined into a single error
 func (t *Target) Validate() error {
 	if t == nil {
 		return fmt.Errorf("target cannot be nil")
 	}
 
+	var errs []error
+	// targeted holds the clusters named by single and multi targets, nil when they
+	// are only known once the target is resolved
+	var targeted []string
 	switch t.Type {
 	case TargetSingle:
 		if t.Cluster == "" {
-			return fmt.Errorf("cluster name required for single target")
+			errs = append(errs, fmt.Errorf("cluster name required for single target"))
+		} else {
+			targeted = []string{t.Cluster}
 		}
 	case TargetMulti:
 		if len(t.Clusters) == 0 {
-			return fmt.Errorf("at least one cluster required for multi target")
+			errs = append(errs, fmt.Errorf("at least one cluster required for multi target"))
 		}
+		if slices.Contains(t.Clusters, "") {
+			errs = append(errs, fmt.Errorf("cluster names of a multi target cannot be empty"))
+		}
+		// An empty multi target includes none of the clusters
+		targeted = append([]string{}, t.Clusters...)
 	case TargetFleet:
 		if t.Fleet == "" {
-			return fmt.Errorf("fleet name required for fleet target")
+			errs = append(errs, fmt.Errorf("fleet name required for fleet target"))
 		}
 	case TargetSelector:
 		if t.Selector == "" {
-			return fmt.Errorf("selector required for selector target")
+			errs = append(errs, fmt.Errorf("selector required for selector target"))
+		}
+	case TargetPrimary:
+		if t.DRPolicy == "" {
+			errs = append(errs, fmt.Errorf("DR policy required for primary target"))
+		}
+	case TargetGroup:
+		if t.Group == "" {
+			errs = append(errs, fmt.Errorf("group name required for group target"))
 		}
 	case TargetAll:
 		// No additional validation needed
 	case "":
 		// Default to single cluster if not specified
 		t.Type = TargetSingle
+		if t.Cluster != "" {
+			targeted = []string{t.Cluster}
+		}
 	default:
-		return fmt.Errorf("invalid target type: %s", t.Type)
+		errs = append(errs, fmt.Errorf("invalid target type: %s", t.Type))
 	}
 
-	return nil
+	// Timeouts past the request timeout ceiling would be cut short by it
+	maxSeconds := int(clients.MaxRequestTimeout / time.Second)
+	if t.Timeout < 0 {
+		errs = append(errs, fmt.Errorf("timeout cannot be negative: %d", t.Timeout))
+	} else if t.Timeout > maxSeconds {
+		errs = append(errs, fmt.Errorf("timeout cannot exceed %d: %d", maxSeconds, t.Timeout))
+	}
+	for _, cluster := range slices.Sorted(maps.Keys(t.ClusterTimeouts)) {
+		if seconds := t.ClusterTimeouts[cluster]; seconds < 0 {
+			errs = append(errs, fmt.Errorf("timeout for cluster %s cannot be negative: %d", cluster, seconds))
+		} else if seconds > maxSeconds {
+			errs = append(errs, fmt.Errorf("timeout for cluster %s cannot exceed %d: %d", cluster, maxSeconds, seconds))
+		}
+		if targeted != nil && !slices.Contains(targeted, cluster) {
+			errs = append(errs, fmt.Errorf("clusterTimeouts names cluster %s, which the target does not include", cluster))
+		}
+	}
+	if len(t.ImpersonateGroups) > 0 && t.ImpersonateUser == "" {
+		errs = append(errs, fmt.Errorf("impersonateUser required with impersonateGroups"))
+	}
+	if t.MaxConcurrency != nil && *t.MaxConcurrency < 0 {
+		errs = append(errs, fmt.Errorf("maxConcurrency cannot be negative: %d", *t.MaxConcurrency))
+	}
+	if t.Retry != nil {
+		if t.Retry.MaxAttempts < 0 {
+			errs = append(errs, fmt.Errorf("retry maxAttempts cannot be negative: %d", t.Retry.MaxAttempts))
+		}
+		if t.Retry.BackoffMs < 0 {
+			errs = append(errs, fmt.Errorf("retry backoffMs cannot be negative: %d", t.Retry.BackoffMs))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// TimeoutFor returns the operation timeout for a cluster: the ClusterTimeouts
+// override when present, then Timeout, then the given default
+func (t *Target) TimeoutFor(clusterName string, defaultTimeout time.Duration) time.Duration {
+	if seconds, ok := t.ClusterTimeouts[clusterName]; ok && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if t.Timeout > 0 {
+		return time.Duration(t.Timeout) * time.Second
+	}
+	return defaultTimeout
+}
+
+// Impersonates reports whether the operation acts as an impersonated user
+func (t *Target) Impersonates() bool {
+	return t.ImpersonateUser != ""
+}
+
+// EffectiveMaxConcurrency returns the fan-out limit for the target, 0 meaning unbounded
+func (t *Target) EffectiveMaxConcurrency() int {
+	if t.MaxConcurrency == nil {
+		return clients.DefaultMaxConcurrency
+	}
+	return *t.MaxConcurrency
+}
+
+// RetryPolicyFor returns the retry policy for the target, applying its overrides to defaultPolicy
+func (t *Target) RetryPolicyFor(defaultPolicy clients.RetryPolicy) clients.RetryPolicy {
+	policy := defaultPolicy
+	if t.Retry == nil {
+		return policy
+	}
+	if t.Retry.MaxAttempts > 0 {
+		policy.MaxAttempts = t.Retry.MaxAttempts
+	}
+	if t.Retry.BackoffMs > 0 {
+		policy.BackoffMs = t.Retry.BackoffMs
+	}
+	return policy
 }
 
 // GetClusterNames returns the list of cluster names to target
 // This is a helper that resolves the target to actual cluster names
+// Selectors are matched on cluster names, see ResolveClusterNames for labels
 func (t *Target) GetClusterNames(availableClusters []string) ([]string, error) {
+	return t.getClusterNames(availableClusters, nil)
+}
+
+// getClusterNames resolves the target against availableClusters, matching selectors on
+// the labels labelsOf returns for a cluster, see selectClusters
+func (t *Target) getClusterNames(availableClusters []string, labelsOf func(clusterName string) map[string]string) ([]string, error) {
 	if err := t.Validate(); err != nil {
 		return nil, err
 	}
@@ -98,8 +275,7 @@ func (t *Target) GetClusterNames(availableClusters []string) ([]string, error) {
 		return availableClusters, nil
 
 	case TargetFleet:
-		// Filter clusters by fleet prefix or label
-		// For now, simple prefix matching
+		// Prefix heuristic, used by ResolveFleet only when no hub serves ManagedClusters
 		var fleetClusters []string
 		fleetPrefix := t.Fleet + "-"
 		for _, cluster := range availableClusters {
@@ -113,23 +289,15 @@ func (t *Target) GetClusterNames(availableClusters []string) ([]string, error) {
 		return fleetClusters, nil
 
 	case TargetSelector:
-		// Parse selector and match clusters
-		// For now, simple key=value matching
-		// In production, this would use proper label matching
-		var selectedClusters []string
-		selectors := parseSelector(t.Selector)
-
-		for _, cluster := range availableClusters {
-			if matchesSelector(cluster, selectors) {
-				selectedClusters = append(selectedClusters, cluster)
-			}
-		}
-
+		selectedClusters := selectClusters(t.Selector, availableClusters, labelsOf)
 		if len(selectedClusters) == 0 {
 			return nil, fmt.Errorf("no clusters match selector: %s", t.Selector)
 		}
 		return selectedClusters, nil
 
+	case TargetGroup:
+		return groupClusterNames(t.Group, availableClusters, labelsOf)
+
 	default:
 		return nil, fmt.Errorf("unsupported target type: %s", t.Type)
 	}
@@ -150,8 +318,42 @@ func parseSelector(selector string) map[string]string {
 	return selectors
 }
 
+// selectClusters returns the availableClusters matching a selector. A cluster with
+// labels, looked up with labelsOf when set, matches when it carries every label of the
+// selector. A cluster without labels is matched on its name, see matchesSelector.
+func selectClusters(selector string, availableClusters []string, labelsOf func(clusterName string) map[string]string) []string {
+	var selected []string
+	selectors := parseSelector(selector)
+	for _, cluster := range availableClusters {
+		var labels map[string]string
+		if labelsOf != nil {
+			labels = labelsOf(cluster)
+		}
+		if len(labels) > 0 {
+			if matchesLabels(labels, selectors) {
+				selected = append(selected, cluster)
+			}
+			continue
+		}
+		if matchesSelector(cluster, selectors) {
+			selected = append(selected, cluster)
+		}
+	}
+	return selected
+}
+
+// matchesLabels checks if cluster labels carry every key=value of selectors
+func matchesLabels(labels, selectors map[string]string) bool {
+	for key, value := range selectors {
+		if labelValue, ok := labels[key]; !ok || labelValue != value {
+			return false
+		}
+	}
+	return true
+}
+
 // matchesSelector checks if a cluster name matches selectors
-// This is a simplified implementation
+// This is a simplified implementation for clusters without labels
 func matchesSelector(clusterName string, selectors map[string]string) bool {
 	// In a real implementation, this would check cluster labels
 	// For now, we do simple string matching on cluster name
@@ -169,39 +371,78 @@ func matchesSelector(clusterName string, selectors map[string]string) bool {
 	return true
 }
 
+// SchemaVersion is the version of the output shape of the Fusion tools, reported as the
+// schemaVersion of every result and component status. Bump it when a change can break
+// parsers: a field removed, renamed or changing type or meaning. Added fields don't bump it.
+const SchemaVersion = "v1"
+
 // Result represents the result of an operation across clusters
 type Result struct {
+	// SchemaVersion is the output shape version, see the SchemaVersion constant
+	SchemaVersion string `json:"schemaVersion"`
+
 	// Target describes how clusters were targeted
 	Target Target `json:"target"`
 
 	// ClusterResults contains per-cluster results
+	// encoding/json and sigs.k8s.io/yaml write map keys sorted, so the output is ordered by cluster name
 	ClusterResults map[string]ClusterResult `json:"clusterResults"`
 
+	// Groups holds the successful clusters grouped by identical status when the target
+	// asks to collapse them, they are then left out of ClusterResults
+	Groups []ResultGroup `json:"groups,omitempty"`
+
 	// Summary provides an aggregated summary
-	Summary interface{} `json:"summary,omitempty"`
+	Summary ResultSummary `json:"summary"`
 
 	// Errors contains any cluster-level errors
 	Errors map[string]string `json:"errors,omitempty"`
+
+	// DryRun marks the result of a write that the API servers validated without persisting
+	DryRun bool `json:"dryRun,omitempty"`
 }
 
-// ClusterResult represents the result from a single cluster
-type ClusterResult struct {
-	// ClusterName identifies the cluster
-	ClusterName string `json:"clusterName"`
+// ResultSummary aggregates the outcome of an operation across clusters
+type ResultSummary struct {
+	// Total is the number of clusters the operation ran on
+	Total int `json:"total"`
+
+	// Succeeded is the number of clusters where the operation succeeded
+	Succeeded int `json:"succeeded"`
 
-	// Data contains the cluster-specific result
-	Data interface{} `json:"data,omitempty"`
+	// Failed is the number of clusters where the operation failed
+	Failed int `json:"failed"`
 
-	// Error contains any error that occurred
+	// Skipped is the number of clusters never attempted because fail-fast tripped
+	Skipped int `json:"skipped,omitempty"`
+
+	// Error contains a target-level error that prevented the fan-out
 	Error string `json:"error,omitempty"`
 
-	// Success indicates if the operation succeeded
-	Success bool `json:"success"`
+	// DurationMs is the wall-clock duration of the whole operation in milliseconds
+	DurationMs int64 `json:"durationMs"`
+
+	// Resolution records how the target was resolved to cluster names
+	Resolution ResolutionMethod `json:"resolution,omitempty"`
+
+	// Aggregates holds tool-specific counts across the targeted clusters
+	Aggregates map[string]int `json:"aggregates,omitempty"`
+
+	// ErrorKinds counts the failed clusters by ErrorKind, e.g. {"timeout": 3, "forbidden": 1}
+	ErrorKinds map[clients.ErrorKind]int `json:"errorKinds,omitempty"`
+
+	// Omitted is the number of cluster results dropped by the target's result filter
+	Omitted int `json:"omitted,omitempty"`
 }
 
+// ClusterResult represents the result from a single cluster
+// It aliases clients.ClusterResult so the registry and the fan-out share one shape
+type ClusterResult = clients.ClusterResult
+
 // NewResult creates a new Result with the given target
 func NewResult(target Target) *Result {
 	return &Result{
+		SchemaVersion:  SchemaVersion,
 		Target:         target,
 		ClusterResults: make(map[string]ClusterResult),
 		Errors:         make(map[string]string),
@@ -218,12 +459,115 @@ func (r *Result) AddClusterResult(clusterName string, data interface{}, err erro
 
 	if err != nil {
 		result.Error = err.Error()
+		result.ErrorKind = clients.ClassifyError(err)
 		r.Errors[clusterName] = err.Error()
 	}
 
 	r.ClusterResults[clusterName] = result
 }
 
+// SetClusterResult records an already built cluster result
+func (r *Result) SetClusterResult(result ClusterResult) {
+	if !result.Success && result.Error != "" {
+		r.Errors[result.ClusterName] = result.Error
+	}
+	r.ClusterResults[result.ClusterName] = result
+}
+
+// AddSkippedCluster records a cluster the operation was never attempted on
+func (r *Result) AddSkippedCluster(clusterName, reason string) {
+	r.ClusterResults[clusterName] = ClusterResult{
+		ClusterName: clusterName,
+		Error:       reason,
+		Skipped:     true,
+	}
+}
+
+// SetAggregate records a tool-specific count in the summary
+func (r *Result) SetAggregate(name string, value int) {
+	if r.Summary.Aggregates == nil {
+		r.Summary.Aggregates = make(map[string]int)
+	}
+	r.Summary.Aggregates[name] = value
+}
+
+// Finalize recomputes the summary counts from ClusterResults
+// Error, DurationMs, Resolution and Aggregates are left untouched
+func (r *Result) Finalize() {
+	r.Summary.Total = len(r.ClusterResults)
+	r.Summary.Succeeded = 0
+	r.Summary.Failed = 0
+	r.Summary.Skipped = 0
+	r.Summary.ErrorKinds = nil
+	for _, result := range r.ClusterResults {
+		switch {
+		case result.Success:
+			r.Summary.Succeeded++
+		case result.Skipped:
+			r.Summary.Skipped++
+		default:
+			r.Summary.Failed++
+			kind := result.ErrorKind
+			if kind == "" {
+				kind = clients.ErrorKindUnknown
+			}
+			if r.Summary.ErrorKinds == nil {
+				r.Summary.ErrorKinds = make(map[clients.ErrorKind]int)
+			}
+			r.Summary.ErrorKinds[kind]++
+		}
+	}
+}
+
+// ApplyFilter drops the cluster results not matching the target's OnlyFailures,
+// OnlyReady and OnlyNotInstalled filters, then collapses the kept ones when the target
+// sets Collapse. It must run after the summary and any aggregates are computed, which
+// keep describing the full set of clusters.
+func (r *Result) ApplyFilter() {
+	if r.Target.OnlyFailures || r.Target.OnlyReady || r.Target.OnlyNotInstalled {
+		for name, result := range r.ClusterResults {
+			if r.Target.Keeps(result) {
+				continue
+			}
+			delete(r.ClusterResults, name)
+			r.Summary.Omitted++
+		}
+	}
+	if r.Target.Collapse {
+		r.Collapse()
+	}
+}
+
+// Keeps reports whether ApplyFilter keeps a cluster result, so results can be filtered
+// as they are produced
+func (t *Target) Keeps(result ClusterResult) bool {
+	if !t.OnlyFailures && !t.OnlyReady && !t.OnlyNotInstalled {
+		return true
+	}
+	return t.matchesFilter(result)
+}
+
+// matchesFilter reports whether a cluster result is kept by any of the result filters
+func (t *Target) matchesFilter(result ClusterResult) bool {
+	if !result.Success {
+		return t.OnlyFailures
+	}
+	var status struct {
+		Installed *bool `json:"installed"`
+		Ready     *bool `json:"ready"`
+	}
+	if raw, ok := result.Data.(json.RawMessage); ok {
+		_ = json.Unmarshal(raw, &status)
+	}
+	if t.OnlyReady && status.Ready != nil && *status.Ready {
+		return true
+	}
+	if t.OnlyNotInstalled && status.Installed != nil && !*status.Installed {
+		return true
+	}
+	return false
+}
+
 // HasErrors returns true if any cluster operation failed
 func (r *Result) HasErrors() bool {
 	return len(r.Errors) > 0
@@ -257,8 +601,8 @@ func TargetSchema() *jsonschema.Schema {
 		Properties: map[string]*jsonschema.Schema{
 			"type": {
 				Type:        "string",
-				Enum:        []interface{}{"single", "multi", "fleet", "selector", "all"},
-				Description: "Targeting strategy: single (one cluster), multi (specific clusters), fleet (all in fleet), selector (label-based), all (all registered)",
+				Enum:        []interface{}{"single", "multi", "fleet", "selector", "all", "primary", "group"},
+				Description: "Targeting strategy: single (one cluster), multi (specific clusters), fleet (all in fleet), selector (label-based), all (all registered), primary (current DR primary of a DRPolicy), group (named group of the Fusion config)",
 			},
 			"cluster": {
 				Type:        "string",
@@ -273,40 +617,204 @@ func TargetSchema() *jsonschema.Schema {
 			},
 			"fleet": {
 				Type:        "string",
-				Description: "Fleet name (for type=fleet)",
+				Description: "Fleet name (for type=fleet), matched against the ACM/OCM clusterset of ManagedClusters",
+			},
+			"hub": {
+				Type:        "string",
+				Description: "Registered hub cluster used to resolve fleet membership or the DR primary (for type=fleet or type=primary, optional)",
+			},
+			"drPolicy": {
+				Type:        "string",
+				Description: "Ramen DRPolicy name whose current primary cluster is targeted (for type=primary)",
 			},
 			"selector": {
 				Type:        "string",
 				Description: "Label selector (for type=selector), format: key1=value1,key2=value2",
 			},
+			"group": {
+				Type:        "string",
+				Description: "Name of a cluster group defined in the Fusion config, e.g. payments-prod (for type=group)",
+			},
 			"timeout": {
 				Type:        "integer",
 				Description: "Operation timeout in seconds (default: 30)",
 			},
+			"clusterTimeouts": {
+				Type:                 "object",
+				AdditionalProperties: &jsonschema.Schema{Type: "integer", Minimum: ptr.To(0.0)},
+				Description:          "Per-cluster timeout overrides in seconds, keyed by cluster name (falls back to timeout)",
+			},
+			"failFast": {
+				Type:        "boolean",
+				Description: "Cancel remaining clusters on the first failure; clusters not yet started are reported as skipped (default: false, run all clusters)",
+			},
+			"maxConcurrency": {
+				Type:        "integer",
+				Description: "Maximum number of clusters operated on at once (default: 16, 0 means unbounded)",
+			},
+			"retry": {
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"maxAttempts": {Type: "integer", Minimum: ptr.To(1.0), Description: "Total attempts per cluster, 1 disables retries (default: 3)"},
+					"backoffMs":   {Type: "integer", Minimum: ptr.To(1.0), Description: "Delay before the first retry in milliseconds, doubled on each retry (default: 500)"},
+				},
+				Description: "Retry policy for transient errors (timeouts, connection failures, 5xx). Forbidden and NotFound are never retried. Retries share the per-cluster timeout. Tools that write to the clusters never retry",
+			},
+			"impersonateUser": {
+				Type:        "string",
+				Description: "Act as this user or service account (system:serviceaccount:<namespace>:<name>) on every targeted cluster instead of the server's identity. Only available when the server allows impersonation",
+			},
+			"impersonateGroups": {
+				Type:        "array",
+				Items:       &jsonschema.Schema{Type: "string"},
+				Description: "Groups of the impersonated user (requires impersonateUser)",
+			},
+			"onlyFailures": {
+				Type:        "boolean",
+				Description: "Only return failed or skipped clusters in clusterResults; the summary still counts every cluster (default: false)",
+			},
+			"onlyReady": {
+				Type:        "boolean",
+				Description: "Only return clusters whose component reports ready=true in clusterResults (default: false)",
+			},
+			"onlyNotInstalled": {
+				Type:        "boolean",
+				Description: "Only return clusters whose component reports installed=false in clusterResults (default: false)",
+			},
+			"collapse": {
+				Type:        "boolean",
+				Description: "Group the clusters reporting the same status, ignoring cluster-specific fields such as namespace, into groups of {fingerprint, status, clusters} instead of one clusterResults entry each. Failed clusters stay in clusterResults and the summary still counts every cluster (default: false)",
+			},
 		},
 	}
 }
 
+// DryRunSchema returns the JSON schema for the dryRun input parameter of write tools
+func DryRunSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:        "boolean",
+		Description: "Preview the write: the API server validates it, admission webhooks included, and returns the object without persisting anything (default: false)",
+	}
+}
+
+// NamespaceSchema returns the JSON schema for the namespace input parameter of status
+// tools, overriding the namespaces a component is looked for in
+func NamespaceSchema(defaults ...string) *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:        "string",
+		Description: fmt.Sprintf("Namespace the component is installed in, for custom installs. Replaces the default namespaces (%s) rather than adding to them", strings.Join(defaults, ", ")),
+	}
+}
+
+var (
+	defaultTarget   *Target
+	defaultTargetMu sync.RWMutex
+)
+
+// SetDefaultTarget sets the clusters targeted when a caller omits the target type
+// nil restores the built-in default of the single "default" cluster
+func SetDefaultTarget(target *Target) error {
+	if target != nil {
+		if target.Type == "" {
+			return fmt.Errorf("default target requires a type")
+		}
+		validated := *target
+		if err := validated.Validate(); err != nil {
+			return fmt.Errorf("invalid default target: %w", err)
+		}
+		target = &validated
+	}
+	defaultTargetMu.Lock()
+	defer defaultTargetMu.Unlock()
+	defaultTarget = target
+	return nil
+}
+
+// applyDefaultTarget fills the cluster selection of a target whose type was omitted from
+// the configured default target. The caller's timeouts, retry and filters are kept.
+func (t *Target) applyDefaultTarget() {
+	if t.Type != "" {
+		return
+	}
+	defaultTargetMu.RLock()
+	defer defaultTargetMu.RUnlock()
+	if defaultTarget == nil {
+		return
+	}
+	t.Type = defaultTarget.Type
+	t.Cluster = defaultTarget.Cluster
+	t.Clusters = defaultTarget.Clusters
+	t.Fleet = defaultTarget.Fleet
+	t.Hub = defaultTarget.Hub
+	t.DRPolicy = defaultTarget.DRPolicy
+	t.Selector = defaultTarget.Selector
+	t.Group = defaultTarget.Group
+}
+
+// ValidateAgainstRegistry checks the clusters a target names, its cluster, clusters or
+// hub, are registered, so a mistyped target fails before any cluster is called with the
+// registered names closest to it, see clients.UnknownClusterError.
+// A multi target naming at least one registered cluster passes: its unknown names fail as
+// cluster results with the same suggestions, and the registered clusters still report.
+// A target without a type is checked as the default target it resolves to.
+func (t *Target) ValidateAgainstRegistry(registry *clients.Registry) error {
+	t.applyDefaultTarget()
+	registered := registry.ListClusterNames()
+
+	var named []string
+	switch t.Type {
+	case TargetSingle, "":
+		if t.Cluster != "" {
+			named = []string{t.Cluster}
+		}
+	case TargetMulti:
+		named = t.Clusters
+	}
+	var errs []error
+	for _, name := range named {
+		if !registry.HasCluster(name) {
+			errs = append(errs, clients.UnknownClusterError(name, registered))
+		}
+	}
+	if len(errs) < len(named) {
+		errs = nil
+	}
+	if (t.Type == TargetFleet || t.Type == TargetPrimary) && t.Hub != "" && !registry.HasCluster(t.Hub) {
+		errs = append(errs, fmt.Errorf("hub %w", clients.UnknownClusterError(t.Hub, registered)))
+	}
+	return errors.Join(errs...)
+}
+
 // ResolveClusterNames resolves the target to actual cluster names using the registry
-func (t *Target) ResolveClusterNames(registry interface{}) ([]string, error) {
-	// This is a placeholder - actual implementation would query the registry
-	// For now, return based on target type
+// It also reports which resolution strategy was used
+// A target without a type resolves through the default target, see SetDefaultTarget
+func (t *Target) ResolveClusterNames(ctx context.Context, registry *clients.Registry) ([]string, ResolutionMethod, error) {
+	t.applyDefaultTarget()
 	if err := t.Validate(); err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	switch t.Type {
 	case TargetSingle:
 		if t.Cluster == "" {
-			return []string{"default"}, nil
+			return []string{"default"}, ResolvedExplicit, nil
 		}
-		return []string{t.Cluster}, nil
+		return []string{t.Cluster}, ResolvedExplicit, nil
 	case TargetMulti:
-		return t.Clusters, nil
-	case TargetAll, TargetFleet:
-		// Would query registry for all clusters
-		return []string{"default"}, nil
+		return t.Clusters, ResolvedExplicit, nil
+	case TargetAll:
+		return registry.ListClusterNames(), ResolvedRegistry, nil
+	case TargetFleet:
+		return ResolveFleet(ctx, registry, t.Fleet, t.Hub)
+	case TargetPrimary:
+		return ResolvePrimary(ctx, registry, t.DRPolicy, t.Hub)
+	case TargetSelector:
+		names, err := t.getClusterNames(registry.ListClusterNames(), registry.ClusterLabels)
+		return names, ResolvedSelector, err
+	case TargetGroup:
+		names, err := t.getClusterNames(registry.ListClusterNames(), registry.ClusterLabels)
+		return names, ResolvedGroup, err
 	default:
-		return []string{"default"}, nil
+		return []string{"default"}, ResolvedExplicit, nil
 	}
 }
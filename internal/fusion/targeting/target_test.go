@@ -0,0 +1,270 @@
+package targeting
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/utils/ptr"
+)
+
+type TargetSuite struct {
+	suite.Suite
+}
+
+func (s *TargetSuite) TestValidate() {
+	for _, tc := range []struct {
+		name   string
+		target Target
+		errs   []string
+	}{
+		{
+			name:   "single target without a cluster",
+			target: Target{Type: TargetSingle},
+			errs:   []string{"cluster name required for single target"},
+		},
+		{
+			name:   "multi target without clusters",
+			target: Target{Type: TargetMulti},
+			errs:   []string{"at least one cluster required for multi target"},
+		},
+		{
+			name:   "multi target with an empty cluster name",
+			target: Target{Type: TargetMulti, Clusters: []string{"prod-1", ""}},
+			errs:   []string{"cluster names of a multi target cannot be empty"},
+		},
+		{
+			name:   "fleet target without a fleet",
+			target: Target{Type: TargetFleet},
+			errs:   []string{"fleet name required for fleet target"},
+		},
+		{
+			name:   "selector target without a selector",
+			target: Target{Type: TargetSelector},
+			errs:   []string{"selector required for selector target"},
+		},
+		{
+			name:   "primary target without a DR policy",
+			target: Target{Type: TargetPrimary},
+			errs:   []string{"DR policy required for primary target"},
+		},
+		{
+			name:   "group target without a group",
+			target: Target{Type: TargetGroup},
+			errs:   []string{"group name required for group target"},
+		},
+		{
+			name:   "impersonated groups without a user",
+			target: Target{Type: TargetAll, ImpersonateGroups: []string{"payments-admins"}},
+			errs:   []string{"impersonateUser required with impersonateGroups"},
+		},
+		{
+			name:   "unknown target type",
+			target: Target{Type: "everything"},
+			errs:   []string{"invalid target type: everything"},
+		},
+		{
+			name:   "negative timeout",
+			target: Target{Type: TargetAll, Timeout: -1},
+			errs:   []string{"timeout cannot be negative: -1"},
+		},
+		{
+			name:   "negative cluster timeout",
+			target: Target{Type: TargetAll, ClusterTimeouts: map[string]int{"edge-1": -5}},
+			errs:   []string{"timeout for cluster edge-1 cannot be negative: -5"},
+		},
+		{
+			name:   "cluster timeout past the request timeout ceiling",
+			target: Target{Type: TargetAll, Timeout: 3601, ClusterTimeouts: map[string]int{"edge-1": 7200}},
+			errs:   []string{"timeout cannot exceed 3600: 3601", "timeout for cluster edge-1 cannot exceed 3600: 7200"},
+		},
+		{
+			name:   "cluster timeout for a cluster outside a single target",
+			target: Target{Type: TargetSingle, Cluster: "prod-1", ClusterTimeouts: map[string]int{"prod-2": 60}},
+			errs:   []string{"clusterTimeouts names cluster prod-2, which the target does not include"},
+		},
+		{
+			name:   "cluster timeout for a cluster outside a multi target",
+			target: Target{Type: TargetMulti, Clusters: []string{"prod-1", "prod-2"}, ClusterTimeouts: map[string]int{"prod-1": 60, "edge-1": 60}},
+			errs:   []string{"clusterTimeouts names cluster edge-1, which the target does not include"},
+		},
+		{
+			name:   "negative max concurrency",
+			target: Target{Type: TargetAll, MaxConcurrency: ptr.To(-2)},
+			errs:   []string{"maxConcurrency cannot be negative: -2"},
+		},
+		{
+			name:   "negative retry settings",
+			target: Target{Type: TargetAll, Retry: &clients.RetryPolicy{MaxAttempts: -1, BackoffMs: -100}},
+			errs: []string{
+				"retry maxAttempts cannot be negative: -1",
+				"retry backoffMs cannot be negative: -100",
+			},
+		},
+		{
+			name: "every problem is reported at once",
+			target: Target{
+				Type:            TargetMulti,
+				Timeout:         -1,
+				ClusterTimeouts: map[string]int{"edge-1": -1},
+				MaxConcurrency:  ptr.To(-1),
+			},
+			errs: []string{
+				"at least one cluster required for multi target",
+				"timeout cannot be negative: -1",
+				"timeout for cluster edge-1 cannot be negative: -1",
+				"clusterTimeouts names cluster edge-1, which the target does not include",
+				"maxConcurrency cannot be negative: -1",
+			},
+		},
+	} {
+		s.Run(tc.name, func() {
+			err := tc.target.Validate()
+			s.Require().Error(err)
+			s.Equal(tc.errs, strings.Split(err.Error(), "\n"))
+		})
+	}
+}
+
+func (s *TargetSuite) TestValidateAccepts() {
+	for _, tc := range []struct {
+		name   string
+		target Target
+	}{
+		{name: "single target", target: Target{Type: TargetSingle, Cluster: "prod-1", ClusterTimeouts: map[string]int{"prod-1": 60}}},
+		{name: "multi target", target: Target{Type: TargetMulti, Clusters: []string{"prod-1", "edge-1"}, ClusterTimeouts: map[string]int{"edge-1": 120}}},
+		{name: "cluster timeouts are only checked on resolution for other types", target: Target{Type: TargetAll, ClusterTimeouts: map[string]int{"edge-1": 120}}},
+		{name: "unbounded concurrency", target: Target{Type: TargetAll, MaxConcurrency: ptr.To(0)}},
+		{name: "fail-fast fleet", target: Target{Type: TargetFleet, Fleet: "prod", FailFast: true}},
+	} {
+		s.Run(tc.name, func() {
+			s.NoError(tc.target.Validate())
+		})
+	}
+
+	s.Run("omitted type defaults to single", func() {
+		target := Target{}
+		s.NoError(target.Validate())
+		s.Equal(TargetSingle, target.Type)
+	})
+}
+
+func (s *TargetSuite) TestGroupTarget() {
+	s.Require().NoError(SetGroups(map[string]Group{
+		"payments-prod": {Clusters: []string{"east-7", "west-2"}},
+		"analytics-dev": {Selector: "name=dev"},
+	}))
+	s.T().Cleanup(func() { _ = SetGroups(nil) })
+	registered := []string{"dev-analytics-1", "east-7", "qa-1", "west-2"}
+
+	s.Run("resolves the clusters listed by the group", func() {
+		target := Target{Type: TargetGroup, Group: "payments-prod"}
+		names, err := target.GetClusterNames(registered)
+		s.Require().NoError(err)
+		s.Equal([]string{"east-7", "west-2"}, names)
+	})
+	s.Run("matches the selector of the group against registered clusters", func() {
+		target := Target{Type: TargetGroup, Group: "analytics-dev"}
+		names, err := target.GetClusterNames(registered)
+		s.Require().NoError(err)
+		s.Equal([]string{"dev-analytics-1"}, names)
+	})
+	s.Run("unknown groups list the configured ones", func() {
+		target := Target{Type: TargetGroup, Group: "payments"}
+		_, err := target.GetClusterNames(registered)
+		s.EqualError(err, "unknown group payments, configured groups: analytics-dev, payments-prod")
+	})
+	s.Run("groups need either clusters or a selector", func() {
+		err := SetGroups(map[string]Group{
+			"empty": {},
+			"both":  {Clusters: []string{"east-7"}, Selector: "env=prod"},
+		})
+		s.EqualError(err, "group both cannot have both clusters and a selector\ngroup empty requires clusters or a selector")
+		target := Target{Type: TargetGroup, Group: "payments-prod"}
+		_, err = target.GetClusterNames(registered)
+		s.NoError(err, "invalid groups leave the configured ones in place")
+	})
+}
+
+func (s *TargetSuite) TestSelectorLabels() {
+	registry := clients.NewRegistry()
+	for _, name := range []string{"east-7", "west-2", "dev-analytics-1", "qa-1"} {
+		registry.AddClient(&clients.ClusterClient{Name: name})
+	}
+	prod, us := "prod", "us"
+	_, err := registry.PatchClusterLabels("east-7", map[string]*string{"env": &prod, "region": &us})
+	s.Require().NoError(err)
+	_, err = registry.PatchClusterLabels("west-2", map[string]*string{"env": &prod})
+	s.Require().NoError(err)
+
+	s.Run("matches labeled clusters on their labels", func() {
+		target := Target{Type: TargetSelector, Selector: "env=prod,region=us"}
+		names, method, err := target.ResolveClusterNames(context.Background(), registry)
+		s.Require().NoError(err)
+		s.Equal(ResolvedSelector, method)
+		s.Equal([]string{"east-7"}, names)
+	})
+	s.Run("matches clusters without labels on their name", func() {
+		target := Target{Type: TargetSelector, Selector: "env=dev"}
+		names, _, err := target.ResolveClusterNames(context.Background(), registry)
+		s.Require().NoError(err)
+		s.Equal([]string{"dev-analytics-1"}, names)
+	})
+	s.Run("matches labeled and unlabeled clusters together", func() {
+		target := Target{Type: TargetSelector, Selector: "env=prod"}
+		names, _, err := target.ResolveClusterNames(context.Background(), registry)
+		s.Require().NoError(err)
+		sort.Strings(names)
+		s.Equal([]string{"east-7", "west-2"}, names)
+	})
+	s.Run("matches group selectors on labels", func() {
+		s.Require().NoError(SetGroups(map[string]Group{"us-prod": {Selector: "env=prod,region=us"}}))
+		s.T().Cleanup(func() { _ = SetGroups(nil) })
+		target := Target{Type: TargetGroup, Group: "us-prod"}
+		names, _, err := target.ResolveClusterNames(context.Background(), registry)
+		s.Require().NoError(err)
+		s.Equal([]string{"east-7"}, names)
+	})
+}
+
+func (s *TargetSuite) TestValidateAgainstRegistry() {
+	registry := clients.NewRegistry()
+	for _, name := range []string{"prod-1", "prod-2", "hub"} {
+		registry.AddClient(&clients.ClusterClient{Name: name})
+	}
+
+	s.Run("registered names pass", func() {
+		s.NoError((&Target{Type: TargetSingle, Cluster: "prod-1"}).ValidateAgainstRegistry(registry))
+		s.NoError((&Target{Type: TargetMulti, Clusters: []string{"prod-1", "prod-2"}}).ValidateAgainstRegistry(registry))
+		s.NoError((&Target{Type: TargetFleet, Fleet: "prod", Hub: "hub"}).ValidateAgainstRegistry(registry))
+	})
+	s.Run("an unknown single cluster fails with suggestions", func() {
+		err := (&Target{Type: TargetSingle, Cluster: "prod-3"}).ValidateAgainstRegistry(registry)
+		s.EqualError(err, "cluster prod-3 not found in registry, did you mean prod-1 or prod-2?")
+	})
+	s.Run("a multi target naming no registered cluster fails for each name", func() {
+		err := (&Target{Type: TargetMulti, Clusters: []string{"prod1", "staging"}}).ValidateAgainstRegistry(registry)
+		s.EqualError(err, "cluster prod1 not found in registry, did you mean prod-1 or prod-2?\n"+
+			"cluster staging not found in registry, registered clusters: hub, prod-1, prod-2")
+	})
+	s.Run("a multi target naming a registered cluster passes", func() {
+		s.NoError((&Target{Type: TargetMulti, Clusters: []string{"prod-1", "prod-3"}}).ValidateAgainstRegistry(registry))
+	})
+	s.Run("an unknown hub fails", func() {
+		err := (&Target{Type: TargetPrimary, DRPolicy: "metro", Hub: "hub-1"}).ValidateAgainstRegistry(registry)
+		s.EqualError(err, "hub cluster hub-1 not found in registry, did you mean hub?")
+	})
+	s.Run("targets resolved from the registry pass", func() {
+		s.NoError((&Target{Type: TargetAll}).ValidateAgainstRegistry(registry))
+		s.NoError((&Target{Type: TargetSelector, Selector: "env=prod"}).ValidateAgainstRegistry(registry))
+	})
+}
+
+func TestTargetSuite(t *testing.T) {
+	suite.Run(t, new(TargetSuite))
+}
+
+// Made with Bob
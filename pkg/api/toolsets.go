@@ -57,6 +57,9 @@ type ToolCallResult struct {
 	Content string
 	// Error (non-protocol) to send back to the LLM.
 	Error error
+	// StructuredContent is the result of a tool with an output schema, sent as JSON
+	// structured content whatever format Content is in. Content is used when nil.
+	StructuredContent any
 }
 
 func NewToolCallResult(content string, err error) *ToolCallResult {
@@ -90,6 +93,10 @@ type Tool struct {
 	Annotations ToolAnnotations `json:"annotations"`
 	// A JSON Schema object defining the expected parameters for the tool.
 	InputSchema *jsonschema.Schema
+	// An optional JSON Schema object defining the structure of the tool's output.
+	// When set, the tool's text content must be a JSON object matching this schema,
+	// it is also returned as structured content.
+	OutputSchema *jsonschema.Schema
 }
 
 type ToolAnnotations struct {
@@ -0,0 +1,75 @@
+package mcp
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/suite"
+	"sigs.k8s.io/yaml"
+)
+
+type FusionSuite struct {
+	BaseMcpSuite
+}
+
+func (s *FusionSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.Cfg.Toolsets = []string{"fusion"}
+}
+
+func (s *FusionSuite) TestStructuredContentInEveryFormat() {
+	s.InitMcpClient()
+	for _, format := range []string{"json", "yaml", "ndjson"} {
+		s.Run("fusion.datafoundation.status format="+format, func() {
+			toolResult, err := s.CallTool("fusion.datafoundation.status", map[string]interface{}{"format": format})
+			s.Require().Nilf(err, "call tool failed %v", err)
+			s.Require().Falsef(toolResult.IsError, "call tool failed")
+			s.Run("returns structured content", func() {
+				s.Require().NotNil(toolResult.StructuredContent)
+				structured, err := json.Marshal(toolResult.StructuredContent)
+				s.Require().NoError(err)
+				var decoded struct {
+					Summary struct {
+						Total int `json:"total"`
+					} `json:"summary"`
+				}
+				s.Require().NoError(json.Unmarshal(structured, &decoded))
+				s.Equal(1, decoded.Summary.Total)
+			})
+			s.Run("keeps the requested text format", func() {
+				text := toolResult.Content[0].(mcp.TextContent).Text
+				if format == "yaml" {
+					var decoded map[string]interface{}
+					s.Require().NoError(yaml.Unmarshal([]byte(text), &decoded))
+					s.Contains(decoded, "summary")
+					s.False(json.Valid([]byte(text)), "yaml is not JSON")
+				}
+			})
+		})
+	}
+}
+
+func (s *FusionSuite) TestNDJSONClusterLinesAsLogNotifications() {
+	s.InitMcpClient()
+	capture := s.StartCapturingLogNotifications()
+	toolResult, err := s.CallTool("fusion.clusters.health", map[string]interface{}{"format": "ndjson"})
+	s.Require().Nilf(err, "call tool failed %v", err)
+	s.Require().Falsef(toolResult.IsError, "call tool failed")
+	s.Run("sends the cluster line as an info log notification", func() {
+		logNotification := capture.RequireLogNotification(s.T(), 2*time.Second)
+		s.Equal("info", logNotification.Level)
+		s.True(json.Valid([]byte(logNotification.Data)), "the notification is a whole JSON line: %s", logNotification.Data)
+		s.Contains(logNotification.Data, `"clusterName"`)
+	})
+	s.Run("returns every line in the result", func() {
+		lines := strings.Split(strings.TrimSuffix(toolResult.Content[0].(mcp.TextContent).Text, "\n"), "\n")
+		s.Len(lines, 2, "the cluster line and the summary line")
+	})
+}
+
+func TestFusion(t *testing.T) {
+	suite.Run(t, new(FusionSuite))
+}
@@ -32,6 +32,9 @@ func ServerToolToGoSdkTool(s *Server, tool api.ServerTool) (*mcp.Tool, mcp.ToolH
 		},
 		InputSchema: inputSchema,
 	}
+	if tool.Tool.OutputSchema != nil {
+		goSdkTool.OutputSchema = tool.Tool.OutputSchema
+	}
 	goSdkHandler := func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		toolCallRequest, err := GoSdkToolCallRequestToToolCallRequest(request)
 		if err != nil {
@@ -54,11 +57,35 @@ func ServerToolToGoSdkTool(s *Server, tool api.ServerTool) (*mcp.Tool, mcp.ToolH
 		if err != nil {
 			return nil, err
 		}
-		return NewTextResult(result.Content, result.Error), nil
+		callToolResult := NewTextResult(result.Content, result.Error)
+		if tool.Tool.OutputSchema != nil && result.Error == nil {
+			structuredContent, err := toStructuredContent(result)
+			if err != nil {
+				return nil, fmt.Errorf("%v for tool %s", err, tool.Tool.Name)
+			}
+			callToolResult.StructuredContent = structuredContent
+		}
+		return callToolResult, nil
 	}
 	return goSdkTool, goSdkHandler, nil
 }
 
+// toStructuredContent returns the JSON structured content of a tool result: its
+// StructuredContent when set, otherwise its Content when that is JSON
+func toStructuredContent(result *api.ToolCallResult) (json.RawMessage, error) {
+	if result.StructuredContent != nil {
+		structuredContent, err := json.Marshal(result.StructuredContent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal structured content: %w", err)
+		}
+		return structuredContent, nil
+	}
+	if json.Valid([]byte(result.Content)) {
+		return json.RawMessage(result.Content), nil
+	}
+	return nil, nil
+}
+
 type ToolCallRequest struct {
 	Name      string
 	arguments map[string]any
@@ -0,0 +1,69 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/render"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/services"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+)
+
+// InitListTool creates the fusion.alerts.list tool
+func InitListTool() api.ServerTool {
+	return api.ServerTool{
+		Tool: api.Tool{
+			Name:        "fusion.alerts.list",
+			Description: "List firing alerts across clusters from the in-cluster Alertmanager (or Thanos Querier) in openshift-monitoring, with severity, summary, and active-since time. By default only storage, DR, backup, and Fusion related alerts (Ceph, ODF, NooBaa, PersistentVolume, Ramen, Velero, ...) are returned",
+			Annotations: api.ToolAnnotations{
+				Title:        "Firing Alerts",
+				ReadOnlyHint: ptr.To(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"target": targeting.TargetSchema(),
+					"format": render.FormatSchema(),
+					"all": {
+						Type:        "boolean",
+						Description: "Return every firing alert instead of only storage, DR, and Fusion related ones (default: false)",
+					},
+				},
+			},
+		},
+		Handler: handleAlertsList,
+	}
+}
+
+// handleAlertsList implements the alerts list tool handler
+func handleAlertsList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	// Parse target
+	var input struct {
+		Target targeting.Target `json:"target"`
+		All    bool             `json:"all"`
+	}
+	argBytes, _ := json.Marshal(params.GetArguments())
+	if err := json.Unmarshal(argBytes, &input); err != nil {
+		input.Target = targeting.Target{Type: targeting.TargetSingle}
+	}
+
+	// Get or create registry
+	registry := clients.GetOrCreateRegistry(params.KubernetesClient)
+
+	// Execute on clusters
+	result := services.ExecuteOnClusters(params.Context, registry, input.Target, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
+		return services.NewAlertsService().ListFiring(ctx, client, input.All)
+	})
+
+	// Trim cluster results to the requested filter, the summary keeps the full counts
+	result.ApplyFilter()
+
+	// Marshal result in the requested format
+	return render.ToolCallResult(params, result), nil
+}
+
+// Made with Bob
@@ -0,0 +1,36 @@
+package alltools
+
+import (
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/services"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
+	"github.com/google/jsonschema-go/jsonschema"
+)
+
+// Output schemas of the tools in this package, inferred once from the result structs
+var (
+	fusionStatusOutputSchema          *jsonschema.Schema
+	overviewOutputSchema              *jsonschema.Schema
+	gdpStatusOutputSchema             *jsonschema.Schema
+	drStatusOutputSchema              *jsonschema.Schema
+	catalogStatusOutputSchema         *jsonschema.Schema
+	casStatusOutputSchema             *jsonschema.Schema
+	serviceabilitySummaryOutputSchema *jsonschema.Schema
+	observabilitySummaryOutputSchema  *jsonschema.Schema
+	virtualizationStatusOutputSchema  *jsonschema.Schema
+	hcpStatusOutputSchema             *jsonschema.Schema
+)
+
+func init() {
+	fusionStatusOutputSchema = targeting.MustResultSchemaFor[services.FusionStatus]()
+	overviewOutputSchema = targeting.MustResultSchemaFor[services.Overview]()
+	gdpStatusOutputSchema = targeting.MustResultSchemaFor[services.GDPStatus]()
+	drStatusOutputSchema = targeting.MustResultSchemaFor[services.DRStatus]()
+	catalogStatusOutputSchema = targeting.MustResultSchemaFor[services.CatalogStatus]()
+	casStatusOutputSchema = targeting.MustResultSchemaFor[services.CASStatus]()
+	serviceabilitySummaryOutputSchema = targeting.MustResultSchemaFor[services.ServiceabilitySummary]()
+	observabilitySummaryOutputSchema = targeting.MustResultSchemaFor[services.ObservabilitySummary]()
+	virtualizationStatusOutputSchema = targeting.MustResultSchemaFor[services.VirtualizationStatus]()
+	hcpStatusOutputSchema = targeting.MustResultSchemaFor[services.HCPStatus]()
+}
+
+// Made with Bob
@@ -3,8 +3,11 @@ package alltools
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"time"
 
 	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/render"
 	"github.com/containers/kubernetes-mcp-server/internal/fusion/services"
 	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
 	"github.com/containers/kubernetes-mcp-server/pkg/api"
@@ -12,37 +15,178 @@ import (
 	"k8s.io/utils/ptr"
 )
 
+// InitFusionStatusTool creates the fusion.status tool
+func InitFusionStatusTool() api.ServerTool {
+	return api.ServerTool{
+		Tool: api.Tool{
+			Name:        "fusion.status",
+			Description: "Get IBM Fusion core status across clusters: whether the Fusion operator is installed, its version and install health, the installed Fusion services, and the operator subscription channel with upgrade availability. Use this first to confirm Fusion is installed before querying its sub-components",
+			Annotations: api.ToolAnnotations{
+				Title:        "IBM Fusion Status",
+				ReadOnlyHint: ptr.To(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: map[string]*jsonschema.Schema{"target": targeting.TargetSchema(), "format": render.FormatSchema(), "namespace": targeting.NamespaceSchema(services.FusionNamespaces...)},
+			},
+			OutputSchema: fusionStatusOutputSchema,
+		},
+		Handler: handleFusionStatus,
+	}
+}
+
+func handleFusionStatus(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	var input struct {
+		Target    targeting.Target
+		Namespace string
+	}
+	argBytes, _ := json.Marshal(params.GetArguments())
+	if err := json.Unmarshal(argBytes, &input); err != nil {
+		input.Target = targeting.Target{Type: targeting.TargetSingle}
+	}
+	registry := clients.GetOrCreateRegistry(params.KubernetesClient)
+	return render.StreamToolCallResult(params, input.Target, func(onResult func(targeting.ClusterResult)) *targeting.Result {
+		result := services.ExecuteOnClustersStream(params.Context, registry, input.Target, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
+			return services.NewFusionService().GetStatus(ctx, client, input.Namespace)
+		}, onResult)
+		result.ApplyFilter()
+		return result
+	}), nil
+}
+
+// InitOverviewTool creates the fusion.overview tool
+func InitOverviewTool() api.ServerTool {
+	return api.ServerTool{
+		Tool: api.Tool{
+			Name:        "fusion.overview",
+			Description: "Get a single health rollup of the IBM Fusion stack across clusters. Checks GDP, DR, Data Foundation, Backup, Observability, Serviceability, Virtualization, and HCP concurrently and reports per-component installed/ready flags with an overall verdict (healthy, degraded, not-installed). Components that are not installed don't make a cluster unhealthy. The summary counts unhealthy clusters and, per component, how many clusters report it unhealthy so you can drill down with the component tool. Component statuses checked in the last maxAge seconds are reused and marked cached with their age; set noCache to check everything now",
+			Annotations: api.ToolAnnotations{
+				Title:        "IBM Fusion Overview",
+				ReadOnlyHint: ptr.To(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"target": targeting.TargetSchema(),
+					"format": render.FormatSchema(),
+					"maxAge": {
+						Type:        "integer",
+						Description: fmt.Sprintf("Oldest component status in seconds to reuse from the previous checks of a cluster (default: %d)", int(clients.DefaultStatusMaxAge.Seconds())),
+						Minimum:     ptr.To(0.0),
+					},
+					"noCache": {
+						Type:        "boolean",
+						Description: "Check every component now instead of reusing recent statuses (default: false)",
+					},
+				},
+			},
+			OutputSchema: overviewOutputSchema,
+		},
+		Handler: handleOverview,
+	}
+}
+
+func handleOverview(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	var input struct {
+		Target  targeting.Target
+		MaxAge  *int `json:"maxAge"`
+		NoCache bool `json:"noCache"`
+	}
+	argBytes, _ := json.Marshal(params.GetArguments())
+	if err := json.Unmarshal(argBytes, &input); err != nil {
+		input.Target = targeting.Target{Type: targeting.TargetSingle}
+	}
+	maxAge := clients.DefaultStatusMaxAge
+	if input.MaxAge != nil {
+		maxAge = time.Duration(*input.MaxAge) * time.Second
+	}
+	if input.NoCache {
+		maxAge = 0
+	}
+	registry := clients.GetOrCreateRegistry(params.KubernetesClient)
+	result := services.ExecuteOnClusters(params.Context, registry, input.Target, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
+		service := services.NewOverviewService()
+		// Cached statuses were checked as the server, never reuse them for another identity
+		if !input.Target.Impersonates() {
+			service.WithStatusCache(registry.StatusCache(), maxAge)
+		}
+		return service.GetOverview(ctx, client)
+	})
+
+	// Count unhealthy clusters overall and per component, clusters per severity, and the
+	// component statuses reused from cache with the age of the oldest
+	unhealthyClusters := 0
+	unhealthyComponents := make(map[string]int)
+	severities := make(map[string]int)
+	cacheHits, cacheMisses, oldestMs := 0, 0, int64(0)
+	services.ForEachClusterData(result, func(_ string, overview services.Overview) {
+		if len(overview.Unhealthy) > 0 {
+			unhealthyClusters++
+		}
+		for _, component := range overview.Unhealthy {
+			unhealthyComponents[component]++
+		}
+		if overview.Severity != "" {
+			severities[overview.Severity]++
+		}
+		for _, health := range overview.Components {
+			if !health.Cached {
+				cacheMisses++
+				continue
+			}
+			cacheHits++
+			oldestMs = max(oldestMs, health.AgeMs)
+		}
+	})
+	result.SetAggregate("unhealthyClusters", unhealthyClusters)
+	result.SetAggregate("cache.hits", cacheHits)
+	result.SetAggregate("cache.misses", cacheMisses)
+	result.SetAggregate("cache.oldestMs", int(oldestMs))
+	for component, count := range unhealthyComponents {
+		result.SetAggregate("unhealthy."+component, count)
+	}
+	for severity, count := range severities {
+		result.SetAggregate("severity."+severity, count)
+	}
+	result.ApplyFilter()
+	return render.ToolCallResult(params, result), nil
+}
+
 // InitGDPStatusTool creates the fusion.gdp.status tool
 func InitGDPStatusTool() api.ServerTool {
 	return api.ServerTool{
 		Tool: api.Tool{
 			Name:        "fusion.gdp.status",
-			Description: "Get Global Data Platform (IBM Spectrum Scale) status across clusters",
+			Description: "Get Global Data Platform (IBM Spectrum Scale) status across clusters, including the operator version and the health of each Filesystem. Ready is true only when every Filesystem is healthy",
 			Annotations: api.ToolAnnotations{
 				Title:        "GDP Status",
 				ReadOnlyHint: ptr.To(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type:       "object",
-				Properties: map[string]*jsonschema.Schema{"target": targeting.TargetSchema()},
+				Properties: map[string]*jsonschema.Schema{"target": targeting.TargetSchema(), "format": render.FormatSchema(), "namespace": targeting.NamespaceSchema(services.GDPNamespaces...)},
 			},
+			OutputSchema: gdpStatusOutputSchema,
 		},
 		Handler: handleGDPStatus,
 	}
 }
 
 func handleGDPStatus(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	var input struct{ Target targeting.Target }
+	var input struct {
+		Target    targeting.Target
+		Namespace string
+	}
 	argBytes, _ := json.Marshal(params.GetArguments())
 	if err := json.Unmarshal(argBytes, &input); err != nil {
 		input.Target = targeting.Target{Type: targeting.TargetSingle}
 	}
 	registry := clients.GetOrCreateRegistry(params.KubernetesClient)
 	result := services.ExecuteOnClusters(params.Context, registry, input.Target, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
-		return services.NewGDPService().GetStatus(ctx, client)
+		return services.NewGDPService().GetStatus(ctx, client, input.Namespace)
 	})
-	jsonBytes, _ := json.MarshalIndent(result, "", "  ")
-	return api.NewToolCallResult(string(jsonBytes), nil), nil
+	result.ApplyFilter()
+	return render.ToolCallResult(params, result), nil
 }
 
 // InitDRStatusTool creates the fusion.dr.status tool
@@ -50,15 +194,16 @@ func InitDRStatusTool() api.ServerTool {
 	return api.ServerTool{
 		Tool: api.Tool{
 			Name:        "fusion.dr.status",
-			Description: "Get Disaster Recovery status across clusters including Metro DR and Regional DR",
+			Description: "Get Disaster Recovery status across clusters including Metro DR and Regional DR. Lists DRPolicies with their scheduling interval, member DRClusters, and validation condition. Protected workloads (DRPlacementControls) report their last group sync time, lag in seconds, and whether they are within their RPO. The summary counts each policy once even when several clusters report it",
 			Annotations: api.ToolAnnotations{
 				Title:        "DR Status",
 				ReadOnlyHint: ptr.To(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type:       "object",
-				Properties: map[string]*jsonschema.Schema{"target": targeting.TargetSchema()},
+				Properties: map[string]*jsonschema.Schema{"target": targeting.TargetSchema(), "format": render.FormatSchema()},
 			},
+			OutputSchema: drStatusOutputSchema,
 		},
 		Handler: handleDRStatus,
 	}
@@ -74,8 +219,29 @@ func handleDRStatus(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	result := services.ExecuteOnClusters(params.Context, registry, input.Target, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
 		return services.NewDRService().GetStatus(ctx, client)
 	})
-	jsonBytes, _ := json.MarshalIndent(result, "", "  ")
-	return api.NewToolCallResult(string(jsonBytes), nil), nil
+
+	// A DRPolicy is reported by every hub perspective it is visible from, count it once
+	policies := make(map[string]bool)
+	validated := make(map[string]bool)
+	rpoViolations := make(map[string]bool)
+	services.ForEachClusterData(result, func(_ string, status services.DRStatus) {
+		for _, policy := range status.Policies {
+			policies[policy.Key()] = true
+			if policy.Validated {
+				validated[policy.Key()] = true
+			}
+		}
+		for _, workload := range status.Workloads {
+			if !workload.RPOCompliant {
+				rpoViolations[workload.Namespace+"/"+workload.Name] = true
+			}
+		}
+	})
+	result.SetAggregate("policies", len(policies))
+	result.SetAggregate("validatedPolicies", len(validated))
+	result.SetAggregate("rpoViolations", len(rpoViolations))
+	result.ApplyFilter()
+	return render.ToolCallResult(params, result), nil
 }
 
 // InitCatalogStatusTool creates the fusion.catalog.status tool
@@ -83,32 +249,36 @@ func InitCatalogStatusTool() api.ServerTool {
 	return api.ServerTool{
 		Tool: api.Tool{
 			Name:        "fusion.catalog.status",
-			Description: "Get Data Cataloging status across clusters",
+			Description: "Get Data Cataloging status across clusters including the catalog version and each configured connection with its health",
 			Annotations: api.ToolAnnotations{
 				Title:        "Data Catalog Status",
 				ReadOnlyHint: ptr.To(true),
 			},
 			InputSchema: &jsonschema.Schema{
 				Type:       "object",
-				Properties: map[string]*jsonschema.Schema{"target": targeting.TargetSchema()},
+				Properties: map[string]*jsonschema.Schema{"target": targeting.TargetSchema(), "format": render.FormatSchema(), "namespace": targeting.NamespaceSchema(services.CatalogNamespaces...)},
 			},
+			OutputSchema: catalogStatusOutputSchema,
 		},
 		Handler: handleCatalogStatus,
 	}
 }
 
 func handleCatalogStatus(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	var input struct{ Target targeting.Target }
+	var input struct {
+		Target    targeting.Target
+		Namespace string
+	}
 	argBytes, _ := json.Marshal(params.GetArguments())
 	if err := json.Unmarshal(argBytes, &input); err != nil {
 		input.Target = targeting.Target{Type: targeting.TargetSingle}
 	}
 	registry := clients.GetOrCreateRegistry(params.KubernetesClient)
 	result := services.ExecuteOnClusters(params.Context, registry, input.Target, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
-		return services.NewCatalogService().GetStatus(ctx, client)
+		return services.NewCatalogService().GetStatus(ctx, client, input.Namespace)
 	})
-	jsonBytes, _ := json.MarshalIndent(result, "", "  ")
-	return api.NewToolCallResult(string(jsonBytes), nil), nil
+	result.ApplyFilter()
+	return render.ToolCallResult(params, result), nil
 }
 
 // InitCASStatusTool creates the fusion.cas.status tool
@@ -123,25 +293,29 @@ func InitCASStatusTool() api.ServerTool {
 			},
 			InputSchema: &jsonschema.Schema{
 				Type:       "object",
-				Properties: map[string]*jsonschema.Schema{"target": targeting.TargetSchema()},
+				Properties: map[string]*jsonschema.Schema{"target": targeting.TargetSchema(), "format": render.FormatSchema(), "namespace": targeting.NamespaceSchema(services.CASNamespace)},
 			},
+			OutputSchema: casStatusOutputSchema,
 		},
 		Handler: handleCASStatus,
 	}
 }
 
 func handleCASStatus(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	var input struct{ Target targeting.Target }
+	var input struct {
+		Target    targeting.Target
+		Namespace string
+	}
 	argBytes, _ := json.Marshal(params.GetArguments())
 	if err := json.Unmarshal(argBytes, &input); err != nil {
 		input.Target = targeting.Target{Type: targeting.TargetSingle}
 	}
 	registry := clients.GetOrCreateRegistry(params.KubernetesClient)
 	result := services.ExecuteOnClusters(params.Context, registry, input.Target, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
-		return services.NewCASService().GetStatus(ctx, client)
+		return services.NewCASService().GetStatus(ctx, client, input.Namespace)
 	})
-	jsonBytes, _ := json.MarshalIndent(result, "", "  ")
-	return api.NewToolCallResult(string(jsonBytes), nil), nil
+	result.ApplyFilter()
+	return render.ToolCallResult(params, result), nil
 }
 
 // InitServiceabilitySummaryTool creates the fusion.serviceability.summary tool
@@ -156,8 +330,9 @@ func InitServiceabilitySummaryTool() api.ServerTool {
 			},
 			InputSchema: &jsonschema.Schema{
 				Type:       "object",
-				Properties: map[string]*jsonschema.Schema{"target": targeting.TargetSchema()},
+				Properties: map[string]*jsonschema.Schema{"target": targeting.TargetSchema(), "format": render.FormatSchema()},
 			},
+			OutputSchema: serviceabilitySummaryOutputSchema,
 		},
 		Handler: handleServiceabilitySummary,
 	}
@@ -173,8 +348,8 @@ func handleServiceabilitySummary(params api.ToolHandlerParams) (*api.ToolCallRes
 	result := services.ExecuteOnClusters(params.Context, registry, input.Target, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
 		return services.NewServiceabilityService().GetSummary(ctx, client)
 	})
-	jsonBytes, _ := json.MarshalIndent(result, "", "  ")
-	return api.NewToolCallResult(string(jsonBytes), nil), nil
+	result.ApplyFilter()
+	return render.ToolCallResult(params, result), nil
 }
 
 // InitObservabilitySummaryTool creates the fusion.observability.summary tool
@@ -189,8 +364,9 @@ func InitObservabilitySummaryTool() api.ServerTool {
 			},
 			InputSchema: &jsonschema.Schema{
 				Type:       "object",
-				Properties: map[string]*jsonschema.Schema{"target": targeting.TargetSchema()},
+				Properties: map[string]*jsonschema.Schema{"target": targeting.TargetSchema(), "format": render.FormatSchema()},
 			},
+			OutputSchema: observabilitySummaryOutputSchema,
 		},
 		Handler: handleObservabilitySummary,
 	}
@@ -206,8 +382,8 @@ func handleObservabilitySummary(params api.ToolHandlerParams) (*api.ToolCallResu
 	result := services.ExecuteOnClusters(params.Context, registry, input.Target, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
 		return services.NewObservabilityService().GetSummary(ctx, client)
 	})
-	jsonBytes, _ := json.MarshalIndent(result, "", "  ")
-	return api.NewToolCallResult(string(jsonBytes), nil), nil
+	result.ApplyFilter()
+	return render.ToolCallResult(params, result), nil
 }
 
 // InitVirtualizationStatusTool creates the fusion.virtualization.status tool
@@ -222,25 +398,29 @@ func InitVirtualizationStatusTool() api.ServerTool {
 			},
 			InputSchema: &jsonschema.Schema{
 				Type:       "object",
-				Properties: map[string]*jsonschema.Schema{"target": targeting.TargetSchema()},
+				Properties: map[string]*jsonschema.Schema{"target": targeting.TargetSchema(), "format": render.FormatSchema(), "namespace": targeting.NamespaceSchema(services.VirtualizationNamespaces...)},
 			},
+			OutputSchema: virtualizationStatusOutputSchema,
 		},
 		Handler: handleVirtualizationStatus,
 	}
 }
 
 func handleVirtualizationStatus(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	var input struct{ Target targeting.Target }
+	var input struct {
+		Target    targeting.Target
+		Namespace string
+	}
 	argBytes, _ := json.Marshal(params.GetArguments())
 	if err := json.Unmarshal(argBytes, &input); err != nil {
 		input.Target = targeting.Target{Type: targeting.TargetSingle}
 	}
 	registry := clients.GetOrCreateRegistry(params.KubernetesClient)
 	result := services.ExecuteOnClusters(params.Context, registry, input.Target, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
-		return services.NewVirtualizationService().GetStatus(ctx, client)
+		return services.NewVirtualizationService().GetStatus(ctx, client, input.Namespace)
 	})
-	jsonBytes, _ := json.MarshalIndent(result, "", "  ")
-	return api.NewToolCallResult(string(jsonBytes), nil), nil
+	result.ApplyFilter()
+	return render.ToolCallResult(params, result), nil
 }
 
 // InitHCPStatusTool creates the fusion.hcp.status tool
@@ -255,25 +435,29 @@ func InitHCPStatusTool() api.ServerTool {
 			},
 			InputSchema: &jsonschema.Schema{
 				Type:       "object",
-				Properties: map[string]*jsonschema.Schema{"target": targeting.TargetSchema()},
+				Properties: map[string]*jsonschema.Schema{"target": targeting.TargetSchema(), "format": render.FormatSchema(), "namespace": targeting.NamespaceSchema(services.HyperShiftNamespace)},
 			},
+			OutputSchema: hcpStatusOutputSchema,
 		},
 		Handler: handleHCPStatus,
 	}
 }
 
 func handleHCPStatus(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	var input struct{ Target targeting.Target }
+	var input struct {
+		Target    targeting.Target
+		Namespace string
+	}
 	argBytes, _ := json.Marshal(params.GetArguments())
 	if err := json.Unmarshal(argBytes, &input); err != nil {
 		input.Target = targeting.Target{Type: targeting.TargetSingle}
 	}
 	registry := clients.GetOrCreateRegistry(params.KubernetesClient)
 	result := services.ExecuteOnClusters(params.Context, registry, input.Target, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
-		return services.NewHCPService().GetStatus(ctx, client)
+		return services.NewHCPService().GetStatus(ctx, client, input.Namespace)
 	})
-	jsonBytes, _ := json.MarshalIndent(result, "", "  ")
-	return api.NewToolCallResult(string(jsonBytes), nil), nil
+	result.ApplyFilter()
+	return render.ToolCallResult(params, result), nil
 }
 
 // Made with Bob
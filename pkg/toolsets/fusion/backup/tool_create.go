@@ -0,0 +1,97 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/render"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/services"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+)
+
+// InitCreateTool creates the fusion.backup.create tool
+func InitCreateTool() api.ServerTool {
+	return api.ServerTool{
+		Tool: api.Tool{
+			Name:        "fusion.backup.create",
+			Description: "Trigger an on-demand OADP/Velero backup by creating a Velero Backup resource in openshift-adp on the targeted clusters. Fails when OADP/Velero is not installed or a backup with the same name already exists",
+			Annotations: api.ToolAnnotations{
+				Title:           "Create Backup",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"target": targeting.TargetSchema(),
+					"format": render.FormatSchema(),
+					"dryRun": targeting.DryRunSchema(),
+					"name": {
+						Type:        "string",
+						Description: "Name of the Backup resource to create",
+					},
+					"includedNamespaces": {
+						Type:        "array",
+						Items:       &jsonschema.Schema{Type: "string"},
+						Description: "Namespaces to back up (default: all namespaces)",
+					},
+					"ttl": {
+						Type:        "string",
+						Description: "How long to keep the backup as a non-negative duration, e.g. 720h (default: the Velero server default)",
+					},
+					"storageLocation": {
+						Type:        "string",
+						Description: "BackupStorageLocation to store the backup in (default: the default location)",
+					},
+					"idempotencyKey": {
+						Type:        "string",
+						Description: "Key making retries safe: a backup already created with this key is returned instead of creating another, a different request with the same key is a conflict. A label value, at most 63 characters",
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		Handler: handleBackupCreate,
+	}
+}
+
+// handleBackupCreate implements the backup create tool handler
+func handleBackupCreate(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	var input struct {
+		Target targeting.Target `json:"target"`
+		services.BackupRequest
+	}
+	argBytes, _ := json.Marshal(params.GetArguments())
+	if err := json.Unmarshal(argBytes, &input); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("invalid arguments: %w", err)), nil
+	}
+	if input.Name == "" {
+		return api.NewToolCallResult("", fmt.Errorf("name is required")), nil
+	}
+
+	// Get or create registry
+	registry := clients.GetOrCreateRegistry(params.KubernetesClient)
+
+	// Execute on clusters
+	result := services.ExecuteWriteOnClusters(params.Context, registry, input.Target, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
+		service := services.NewBackupService(nil)
+		return service.CreateBackup(ctx, client, input.BackupRequest)
+	})
+
+	// A dry run only validated the writes
+	result.DryRun = input.DryRun
+
+	// Trim cluster results to the requested filter, the summary keeps the full counts
+	result.ApplyFilter()
+
+	// Marshal result in the requested format
+	return render.ToolCallResult(params, result), nil
+}
+
+// Made with Bob
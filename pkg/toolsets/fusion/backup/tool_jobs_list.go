@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 
 	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/render"
 	"github.com/containers/kubernetes-mcp-server/internal/fusion/services"
 	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
 	"github.com/containers/kubernetes-mcp-server/pkg/api"
@@ -17,7 +18,7 @@ func InitJobsListTool() api.ServerTool {
 	return api.ServerTool{
 		Tool: api.Tool{
 			Name:        "fusion.backup.jobs.list",
-			Description: "List backup jobs across clusters including OADP/Velero backups with status and age",
+			Description: "List backups across clusters from OADP/Velero Backup resources with phase, start and completion time, and item counts. Falls back to backup Jobs when the Velero CRD is not installed, the source field tells which was used",
 			Annotations: api.ToolAnnotations{
 				Title:        "Backup Jobs List",
 				ReadOnlyHint: ptr.To(true),
@@ -25,7 +26,14 @@ func InitJobsListTool() api.ServerTool {
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
-					"target": targeting.TargetSchema(),
+					"target":    targeting.TargetSchema(),
+					"format":    render.FormatSchema(),
+					"namespace": targeting.NamespaceSchema(services.OADPNamespace),
+					"maxItems": {
+						Type:        "integer",
+						Description: "Maximum number of backups, newest first, returned in detail per cluster (default: 100). Counts always cover every backup",
+						Minimum:     ptr.To(1.0),
+					},
 				},
 			},
 		},
@@ -37,7 +45,9 @@ func InitJobsListTool() api.ServerTool {
 func handleBackupJobsList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	// Parse target
 	var input struct {
-		Target targeting.Target `json:"target"`
+		Target    targeting.Target `json:"target"`
+		Namespace string           `json:"namespace"`
+		MaxItems  int              `json:"maxItems"`
 	}
 	argBytes, _ := json.Marshal(params.GetArguments())
 	if err := json.Unmarshal(argBytes, &input); err != nil {
@@ -50,16 +60,14 @@ func handleBackupJobsList(params api.ToolHandlerParams) (*api.ToolCallResult, er
 	// Execute on clusters
 	result := services.ExecuteOnClusters(params.Context, registry, input.Target, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
 		service := services.NewBackupService(nil)
-		return service.ListJobs(ctx, client)
+		return service.ListJobs(ctx, client, input.Namespace, input.MaxItems)
 	})
 
-	// Marshal result to JSON
-	jsonBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return api.NewToolCallResult("", err), nil
-	}
+	// Trim cluster results to the requested filter, the summary keeps the full counts
+	result.ApplyFilter()
 
-	return api.NewToolCallResult(string(jsonBytes), nil), nil
+	// Marshal result in the requested format
+	return render.ToolCallResult(params, result), nil
 }
 
 // Made with Bob
@@ -0,0 +1,89 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/render"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/services"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+)
+
+// InitNamespaceTool creates the fusion.backup.namespace tool
+func InitNamespaceTool() api.ServerTool {
+	return api.ServerTool{
+		Tool: api.Tool{
+			Name:        "fusion.backup.namespace",
+			Description: "Back up one namespace now with OADP/Velero, snapshotting its volumes into the default BackupStorageLocation. The backup is named <namespace>-<timestamp> and kept for retentionDays. Fails when the namespace does not exist or OADP/Velero is not installed. Returns the backup name and, when API discovery is available, an estimate of the items Velero will back up. Use fusion.backup.create for other backups",
+			Annotations: api.ToolAnnotations{
+				Title:           "Back Up Namespace",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"target": targeting.TargetSchema(),
+					"format": render.FormatSchema(),
+					"dryRun": targeting.DryRunSchema(),
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace to back up",
+					},
+					"retentionDays": {
+						Type:        "integer",
+						Description: fmt.Sprintf("Days to keep the backup (default: %d)", services.DefaultNamespaceBackupRetentionDays),
+						Minimum:     ptr.To(1.0),
+					},
+					"idempotencyKey": {
+						Type:        "string",
+						Description: "Key making retries safe: a backup already created with this key is returned instead of creating another, a different request with the same key is a conflict. A label value, at most 63 characters",
+					},
+				},
+				Required: []string{"namespace"},
+			},
+		},
+		Handler: handleBackupNamespace,
+	}
+}
+
+// handleBackupNamespace implements the backup namespace tool handler
+func handleBackupNamespace(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	var input struct {
+		Target targeting.Target `json:"target"`
+		services.NamespaceBackupRequest
+	}
+	argBytes, _ := json.Marshal(params.GetArguments())
+	if err := json.Unmarshal(argBytes, &input); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("invalid arguments: %w", err)), nil
+	}
+	if input.Namespace == "" {
+		return api.NewToolCallResult("", fmt.Errorf("namespace is required")), nil
+	}
+
+	// Get or create registry
+	registry := clients.GetOrCreateRegistry(params.KubernetesClient)
+
+	// Execute on clusters
+	result := services.ExecuteWriteOnClusters(params.Context, registry, input.Target, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
+		service := services.NewBackupService(nil)
+		return service.BackupNamespace(ctx, client, input.NamespaceBackupRequest)
+	})
+
+	// A dry run only validated the writes
+	result.DryRun = input.DryRun
+
+	// Trim cluster results to the requested filter, the summary keeps the full counts
+	result.ApplyFilter()
+
+	// Marshal result in the requested format
+	return render.ToolCallResult(params, result), nil
+}
+
+// Made with Bob
@@ -0,0 +1,105 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/render"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/services"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+)
+
+// InitRestoreTool creates the fusion.backup.restore tool
+func InitRestoreTool() api.ServerTool {
+	stringList := func(description string) *jsonschema.Schema {
+		return &jsonschema.Schema{
+			Type:        "array",
+			Items:       &jsonschema.Schema{Type: "string"},
+			Description: description,
+		}
+	}
+
+	return api.ServerTool{
+		Tool: api.Tool{
+			Name:        "fusion.backup.restore",
+			Description: "Restore an OADP/Velero backup by creating a Velero Restore resource in openshift-adp on the targeted clusters. The backup must exist and be Completed. Optional namespace mapping and include/exclude filters narrow what is restored. Rejections by admission webhooks are reported verbatim",
+			Annotations: api.ToolAnnotations{
+				Title:           "Restore Backup",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(false),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"target": targeting.TargetSchema(),
+					"format": render.FormatSchema(),
+					"dryRun": targeting.DryRunSchema(),
+					"backupName": {
+						Type:        "string",
+						Description: "Name of the Completed Velero backup to restore",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the Restore resource to create (default: <backupName>-<timestamp>)",
+					},
+					"namespaceMapping": {
+						Type:                 "object",
+						AdditionalProperties: &jsonschema.Schema{Type: "string"},
+						Description:          "Map of source namespace to target namespace, e.g. {\"app\": \"app-restored\"}",
+					},
+					"idempotencyKey": {
+						Type:        "string",
+						Description: "Key making retries safe: a restore already created with this key is returned instead of creating another, a different request with the same key is a conflict. A label value, at most 63 characters",
+					},
+					"includedNamespaces": stringList("Namespaces to restore (default: all namespaces in the backup)"),
+					"excludedNamespaces": stringList("Namespaces to skip"),
+					"includedResources":  stringList("Resources to restore, e.g. persistentvolumeclaims (default: all)"),
+					"excludedResources":  stringList("Resources to skip"),
+				},
+				Required: []string{"backupName"},
+			},
+		},
+		Handler: handleBackupRestore,
+	}
+}
+
+// handleBackupRestore implements the backup restore tool handler
+func handleBackupRestore(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	var input struct {
+		Target targeting.Target `json:"target"`
+		services.RestoreRequest
+	}
+	argBytes, _ := json.Marshal(params.GetArguments())
+	if err := json.Unmarshal(argBytes, &input); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("invalid arguments: %w", err)), nil
+	}
+	if input.BackupName == "" {
+		return api.NewToolCallResult("", fmt.Errorf("backupName is required")), nil
+	}
+
+	// Get or create registry
+	registry := clients.GetOrCreateRegistry(params.KubernetesClient)
+
+	// Execute on clusters
+	result := services.ExecuteWriteOnClusters(params.Context, registry, input.Target, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
+		service := services.NewBackupService(nil)
+		return service.CreateRestore(ctx, client, input.RestoreRequest)
+	})
+
+	// A dry run only validated the writes
+	result.DryRun = input.DryRun
+
+	// Trim cluster results to the requested filter, the summary keeps the full counts
+	result.ApplyFilter()
+
+	// Marshal result in the requested format
+	return render.ToolCallResult(params, result), nil
+}
+
+// Made with Bob
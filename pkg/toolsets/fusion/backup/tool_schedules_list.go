@@ -0,0 +1,76 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/render"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/services"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+)
+
+// InitSchedulesListTool creates the fusion.backup.schedules.list tool
+func InitSchedulesListTool() api.ServerTool {
+	return api.ServerTool{
+		Tool: api.Tool{
+			Name:        "fusion.backup.schedules.list",
+			Description: "List OADP/Velero backup schedules across clusters with their cron expression, included namespaces, last backup time, and paused state. The summary counts schedules and paused schedules across all targeted clusters",
+			Annotations: api.ToolAnnotations{
+				Title:        "Backup Schedules List",
+				ReadOnlyHint: ptr.To(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"target":    targeting.TargetSchema(),
+					"format":    render.FormatSchema(),
+					"namespace": targeting.NamespaceSchema(services.OADPNamespace),
+				},
+			},
+		},
+		Handler: handleBackupSchedulesList,
+	}
+}
+
+// handleBackupSchedulesList implements the backup schedules list tool handler
+func handleBackupSchedulesList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	// Parse target
+	var input struct {
+		Target    targeting.Target `json:"target"`
+		Namespace string           `json:"namespace"`
+	}
+	argBytes, _ := json.Marshal(params.GetArguments())
+	if err := json.Unmarshal(argBytes, &input); err != nil {
+		input.Target = targeting.Target{Type: targeting.TargetSingle}
+	}
+
+	// Get or create registry
+	registry := clients.GetOrCreateRegistry(params.KubernetesClient)
+
+	// Execute on clusters
+	result := services.ExecuteOnClusters(params.Context, registry, input.Target, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
+		service := services.NewBackupService(nil)
+		return service.ListSchedules(ctx, client, input.Namespace)
+	})
+
+	// Aggregate schedule counts across the fleet
+	schedules, paused := 0, 0
+	services.ForEachClusterData(result, func(_ string, list services.BackupSchedulesList) {
+		schedules += len(list.Schedules)
+		paused += list.Paused
+	})
+	result.SetAggregate("schedules", schedules)
+	result.SetAggregate("pausedSchedules", paused)
+
+	// Trim cluster results to the requested filter, the summary keeps the full counts
+	result.ApplyFilter()
+
+	// Marshal result in the requested format
+	return render.ToolCallResult(params, result), nil
+}
+
+// Made with Bob
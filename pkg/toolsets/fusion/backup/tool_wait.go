@@ -0,0 +1,83 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/render"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/services"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+)
+
+// InitWaitTool creates the fusion.backup.wait tool
+func InitWaitTool() api.ServerTool {
+	return api.ServerTool{
+		Tool: api.Tool{
+			Name:        "fusion.backup.wait",
+			Description: "Wait for one OADP/Velero backup to finish, watching it until it reaches a terminal phase (Completed, PartiallyFailed, Failed, FailedValidation) instead of polling fusion.backup.jobs.list. Returns the final phase, how long the backup ran, and its errors and warnings. The wait lasts at most the target timeout (set target.timeout in seconds for long backups), when it runs out the current phase is returned with timedOut set and the wait can be repeated",
+			Annotations: api.ToolAnnotations{
+				Title:        "Backup Wait",
+				ReadOnlyHint: ptr.To(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"target": targeting.TargetSchema(),
+					"format": render.FormatSchema(),
+					"name": {
+						Type:        "string",
+						Description: "Name of the Velero Backup to wait for",
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		Handler: handleBackupWait,
+	}
+}
+
+// handleBackupWait implements the backup wait tool handler
+func handleBackupWait(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	var input struct {
+		Target targeting.Target `json:"target"`
+		Name   string           `json:"name"`
+	}
+	argBytes, _ := json.Marshal(params.GetArguments())
+	if err := json.Unmarshal(argBytes, &input); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("invalid arguments: %w", err)), nil
+	}
+	if input.Name == "" {
+		return api.NewToolCallResult("", fmt.Errorf("name is required")), nil
+	}
+
+	// Get or create registry
+	registry := clients.GetOrCreateRegistry(params.KubernetesClient)
+
+	// Execute on clusters, each wait ends with the cluster's timeout
+	result := services.ExecuteOnClusters(params.Context, registry, input.Target, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
+		service := services.NewBackupService(nil)
+		return service.WaitForBackup(ctx, client, input.Name)
+	})
+
+	// Count the backups still running when the wait ran out
+	timedOut := 0
+	services.ForEachClusterData(result, func(_ string, wait services.BackupWait) {
+		if wait.TimedOut {
+			timedOut++
+		}
+	})
+	result.SetAggregate("timedOut", timedOut)
+
+	// Trim cluster results to the requested filter, the summary keeps the full counts
+	result.ApplyFilter()
+
+	// Marshal result in the requested format
+	return render.ToolCallResult(params, result), nil
+}
+
+// Made with Bob
@@ -0,0 +1,68 @@
+package clusters
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/render"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/services"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/utils/ptr"
+)
+
+// InitContextsTool creates the fusion.kubeconfig.contexts tool
+func InitContextsTool() api.ServerTool {
+	return api.ServerTool{
+		Tool: api.Tool{
+			Name:        "fusion.kubeconfig.contexts",
+			Description: "List the contexts of a kubeconfig file with their cluster API server URL and whether each is already registered with the IBM Fusion MCP server. No cluster is contacted. Use it to pick a context for fusion.clusters.register; fusion.clusters.list shows the registered clusters",
+			Annotations: api.ToolAnnotations{
+				Title:        "Kubeconfig Contexts",
+				ReadOnlyHint: ptr.To(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"format": render.FormatSchema(),
+					"kubeconfig": {
+						Type:        "string",
+						Description: "Path to the kubeconfig file (default: ~/.kube/config), within the kubeconfig paths the server allows",
+					},
+				},
+			},
+		},
+		Handler: handleKubeconfigContexts,
+	}
+}
+
+// handleKubeconfigContexts implements the kubeconfig contexts tool handler
+func handleKubeconfigContexts(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	var input struct {
+		Kubeconfig string `json:"kubeconfig"`
+	}
+	argBytes, _ := json.Marshal(params.GetArguments())
+	if err := json.Unmarshal(argBytes, &input); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("invalid arguments: %w", err)), nil
+	}
+
+	kubeconfigPath := input.Kubeconfig
+	if kubeconfigPath == "" {
+		kubeconfigPath = clientcmd.RecommendedHomeFile
+	}
+
+	// Get or create registry
+	registry := clients.GetOrCreateRegistry(params.KubernetesClient)
+
+	list, err := services.NewClusterService(registry).KubeconfigContexts(kubeconfigPath)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list kubeconfig contexts: %w", err)), nil
+	}
+
+	// Marshal result in the requested format
+	return render.ToolCallResult(params, list), nil
+}
+
+// Made with Bob
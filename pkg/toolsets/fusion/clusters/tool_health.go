@@ -0,0 +1,60 @@
+package clusters
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/render"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/services"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+)
+
+// InitHealthTool creates the fusion.clusters.health tool
+func InitHealthTool() api.ServerTool {
+	return api.ServerTool{
+		Tool: api.Tool{
+			Name:        "fusion.clusters.health",
+			Description: "Check API server connectivity of the targeted clusters, reporting reachability, round-trip latency, and server version for each. Unreachable clusters are reported with their error and counted as unhealthy in the summary",
+			Annotations: api.ToolAnnotations{
+				Title:        "Cluster Connectivity Health",
+				ReadOnlyHint: ptr.To(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: map[string]*jsonschema.Schema{"target": targeting.TargetSchema(), "format": render.FormatSchema()},
+			},
+		},
+		Handler: handleClustersHealth,
+	}
+}
+
+// handleClustersHealth implements the cluster health tool handler
+func handleClustersHealth(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	var input struct{ Target targeting.Target }
+	argBytes, _ := json.Marshal(params.GetArguments())
+	if err := json.Unmarshal(argBytes, &input); err != nil {
+		input.Target = targeting.Target{Type: targeting.TargetSingle}
+	}
+
+	// Get or create registry
+	registry := clients.GetOrCreateRegistry(params.KubernetesClient)
+	clusterService := services.NewClusterService(registry)
+
+	// Stream cluster lines as they complete when ndjson is requested
+	return render.StreamToolCallResult(params, input.Target, func(onResult func(targeting.ClusterResult)) *targeting.Result {
+		result := services.ExecuteOnClustersStream(params.Context, registry, input.Target, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
+			return clusterService.CheckHealth(ctx, client)
+		}, onResult)
+		result.SetAggregate("healthy", result.Summary.Succeeded)
+		result.SetAggregate("unhealthy", result.Summary.Failed)
+
+		result.ApplyFilter()
+		return result
+	}), nil
+}
+
+// Made with Bob
@@ -0,0 +1,74 @@
+package clusters
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/render"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/services"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+)
+
+// InitLabelTool creates the fusion.clusters.label tool
+func InitLabelTool() api.ServerTool {
+	return api.ServerTool{
+		Tool: api.Tool{
+			Name:        "fusion.clusters.label",
+			Description: "Set or remove labels of a registered cluster, matched by selector targets ({\"type\":\"selector\",\"selector\":\"env=prod\"}) and group selectors. Changes use kubectl label syntax: key=value sets a label, key- removes it; other labels are kept. Labels are kept by the IBM Fusion MCP server until the cluster is unregistered or the server restarts, clusters without labels are matched on their name",
+			Annotations: api.ToolAnnotations{
+				Title:           "Label Cluster",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"format": render.FormatSchema(),
+					"cluster": {
+						Type:        "string",
+						Description: "Name of the registered cluster to label",
+					},
+					"labels": {
+						Type:        "array",
+						Description: "Label changes, key=value to set a label or key- to remove it, for example [\"env=prod\", \"region=us-east\", \"tier-\"]",
+						Items:       &jsonschema.Schema{Type: "string"},
+					},
+				},
+				Required: []string{"cluster", "labels"},
+			},
+		},
+		Handler: handleClustersLabel,
+	}
+}
+
+// handleClustersLabel implements the cluster label tool handler
+func handleClustersLabel(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	var input struct {
+		Cluster string   `json:"cluster"`
+		Labels  []string `json:"labels"`
+	}
+	argBytes, _ := json.Marshal(params.GetArguments())
+	if err := json.Unmarshal(argBytes, &input); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("invalid arguments: %w", err)), nil
+	}
+	if input.Cluster == "" {
+		return api.NewToolCallResult("", fmt.Errorf("cluster is required")), nil
+	}
+
+	// Get or create registry
+	registry := clients.GetOrCreateRegistry(params.KubernetesClient)
+
+	labeled, err := services.NewClusterService(registry).Label(input.Cluster, input.Labels)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to label cluster: %w", err)), nil
+	}
+
+	// Marshal result in the requested format
+	return render.ToolCallResult(params, labeled), nil
+}
+
+// Made with Bob
@@ -0,0 +1,47 @@
+package clusters
+
+import (
+	"fmt"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/render"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/services"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+)
+
+// InitListTool creates the fusion.clusters.list tool
+func InitListTool() api.ServerTool {
+	return api.ServerTool{
+		Tool: api.Tool{
+			Name:        "fusion.clusters.list",
+			Description: "List the clusters registered with the IBM Fusion MCP server, with their kubeconfig context, API server URL, and whether the API server is currently reachable. Use these names when building a target for other Fusion tools",
+			Annotations: api.ToolAnnotations{
+				Title:        "Registered Clusters",
+				ReadOnlyHint: ptr.To(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: map[string]*jsonschema.Schema{"format": render.FormatSchema()},
+			},
+		},
+		Handler: handleClustersList,
+	}
+}
+
+// handleClustersList implements the clusters list tool handler
+func handleClustersList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	// Get or create registry
+	registry := clients.GetOrCreateRegistry(params.KubernetesClient)
+
+	list, err := services.NewClusterService(registry).List(params.Context)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list clusters: %w", err)), nil
+	}
+
+	// Marshal result in the requested format
+	return render.ToolCallResult(params, list), nil
+}
+
+// Made with Bob
@@ -0,0 +1,70 @@
+package clusters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/render"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/services"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+)
+
+// InitPermissionsTool creates the fusion.clusters.permissions tool
+func InitPermissionsTool() api.ServerTool {
+	return api.ServerTool{
+		Tool: api.Tool{
+			Name:        "fusion.clusters.permissions",
+			Description: "Check what the server's credentials can do on the targeted clusters: who the server authenticates as and, through SelfSubjectAccessReviews, whether the key accesses of the Fusion tools are allowed (get namespaces, list CRDs, pods, nodes, PVCs, list and create Velero backups, read DR placements, VMs, hosted clusters, ...). Run it first to explain missing RBAC up front instead of failing mid-operation. The summary counts the denied accesses overall and per cluster",
+			Annotations: api.ToolAnnotations{
+				Title:        "Cluster Permissions",
+				ReadOnlyHint: ptr.To(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: map[string]*jsonschema.Schema{"target": targeting.TargetSchema(), "format": render.FormatSchema()},
+			},
+		},
+		Handler: handleClustersPermissions,
+	}
+}
+
+// handleClustersPermissions implements the cluster permissions tool handler
+func handleClustersPermissions(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	var input struct {
+		Target targeting.Target `json:"target"`
+	}
+	argBytes, _ := json.Marshal(params.GetArguments())
+	if err := json.Unmarshal(argBytes, &input); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("invalid arguments: %w", err)), nil
+	}
+
+	// Get or create registry
+	registry := clients.GetOrCreateRegistry(params.KubernetesClient)
+	clusterService := services.NewClusterService(registry)
+
+	// Execute on clusters
+	result := services.ExecuteOnClusters(params.Context, registry, input.Target, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
+		return clusterService.CheckPermissions(ctx, client)
+	})
+
+	// Count the denied accesses across the fleet and per cluster
+	denied := 0
+	services.ForEachClusterData(result, func(cluster string, report services.PermissionReport) {
+		denied += report.Denied
+		result.SetAggregate("deniedPermissions."+cluster, report.Denied)
+	})
+	result.SetAggregate("deniedPermissions", denied)
+
+	// Trim cluster results to the requested filter, the summary keeps the full counts
+	result.ApplyFilter()
+
+	// Marshal result in the requested format
+	return render.ToolCallResult(params, result), nil
+}
+
+// Made with Bob
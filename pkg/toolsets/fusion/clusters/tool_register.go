@@ -0,0 +1,180 @@
+package clusters
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/render"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/services"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/utils/ptr"
+)
+
+// InitRegisterTool creates the fusion.clusters.register tool
+func InitRegisterTool() api.ServerTool {
+	return api.ServerTool{
+		Tool: api.Tool{
+			Name:        "fusion.clusters.register",
+			Description: "Register a kubeconfig context with the IBM Fusion MCP server at runtime so it can be targeted by other Fusion tools. The API server is checked for reachability before the cluster is added. An existing cluster with the same name is replaced. Disabled unless the server allows cluster registration; kubeconfig paths must be within the allowed kubeconfig paths, and exec plugins and auth providers are refused unless allowed",
+			Annotations: api.ToolAnnotations{
+				Title:           "Register Cluster",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"format": render.FormatSchema(),
+					"context": {
+						Type:        "string",
+						Description: "Name of the kubeconfig context to register, also used as the cluster name",
+					},
+					"kubeconfig": {
+						Type:        "string",
+						Description: "Path to the kubeconfig file (default: ~/.kube/config), within the kubeconfig paths the server allows",
+					},
+					"kubeconfigContent": {
+						Type:        "string",
+						Description: "Inline kubeconfig content, used instead of kubeconfig when provided. Credentials and the CA must be embedded (token or the *-data fields), file references are refused",
+					},
+				},
+				Required: []string{"context"},
+			},
+		},
+		Handler: handleClustersRegister,
+	}
+}
+
+// InitUnregisterTool creates the fusion.clusters.unregister tool
+func InitUnregisterTool() api.ServerTool {
+	return api.ServerTool{
+		Tool: api.Tool{
+			Name:        "fusion.clusters.unregister",
+			Description: "Remove a cluster from the IBM Fusion MCP server registry. Removing a cluster that is not registered does nothing. Disabled unless the server allows cluster registration",
+			Annotations: api.ToolAnnotations{
+				Title:           "Unregister Cluster",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"format": render.FormatSchema(),
+					"cluster": {
+						Type:        "string",
+						Description: "Name of the registered cluster to remove",
+					},
+				},
+				Required: []string{"cluster"},
+			},
+		},
+		Handler: handleClustersUnregister,
+	}
+}
+
+// InitReloadTool creates the fusion.clusters.reload tool
+func InitReloadTool() api.ServerTool {
+	return api.ServerTool{
+		Tool: api.Tool{
+			Name:        "fusion.clusters.reload",
+			Description: "Reload the kubeconfig the IBM Fusion MCP server loaded its clusters from at startup (KUBECONFIG or ~/.kube/config): contexts added since are registered, contexts whose cluster or credentials changed are rebuilt and contexts removed from the kubeconfig are unregistered. Clusters registered with fusion.clusters.register are kept. The kubeconfig is also reloaded automatically when it changes, this forces it, for instance after renewing a login",
+			Annotations: api.ToolAnnotations{
+				Title:           "Reload Clusters",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"format": render.FormatSchema(),
+				},
+			},
+		},
+		Handler: handleClustersReload,
+	}
+}
+
+// handleClustersRegister implements the cluster register tool handler
+func handleClustersRegister(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	var input struct {
+		Context           string `json:"context"`
+		Kubeconfig        string `json:"kubeconfig"`
+		KubeconfigContent string `json:"kubeconfigContent"`
+	}
+	argBytes, _ := json.Marshal(params.GetArguments())
+	if err := json.Unmarshal(argBytes, &input); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("invalid arguments: %w", err)), nil
+	}
+	if input.Context == "" {
+		return api.NewToolCallResult("", fmt.Errorf("context is required")), nil
+	}
+
+	// Get or create registry
+	registry := clients.GetOrCreateRegistry(params.KubernetesClient)
+	service := services.NewClusterService(registry)
+
+	var registered *services.RegisteredCluster
+	var err error
+	if input.KubeconfigContent != "" {
+		registered, err = service.RegisterContent(params.Context, []byte(input.KubeconfigContent), input.Context)
+	} else {
+		kubeconfigPath := input.Kubeconfig
+		if kubeconfigPath == "" {
+			kubeconfigPath = clientcmd.RecommendedHomeFile
+		}
+		registered, err = service.Register(params.Context, kubeconfigPath, input.Context)
+	}
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to register cluster: %w", err)), nil
+	}
+
+	// Marshal result in the requested format
+	return render.ToolCallResult(params, registered), nil
+}
+
+// handleClustersUnregister implements the cluster unregister tool handler
+func handleClustersUnregister(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	var input struct {
+		Cluster string `json:"cluster"`
+	}
+	argBytes, _ := json.Marshal(params.GetArguments())
+	if err := json.Unmarshal(argBytes, &input); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("invalid arguments: %w", err)), nil
+	}
+	if input.Cluster == "" {
+		return api.NewToolCallResult("", fmt.Errorf("cluster is required")), nil
+	}
+
+	// Get or create registry
+	registry := clients.GetOrCreateRegistry(params.KubernetesClient)
+
+	unregistered, err := services.NewClusterService(registry).Unregister(input.Cluster)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to unregister cluster: %w", err)), nil
+	}
+
+	// Marshal result in the requested format
+	return render.ToolCallResult(params, unregistered), nil
+}
+
+// handleClustersReload implements the cluster reload tool handler
+func handleClustersReload(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	// Get or create registry
+	registry := clients.GetOrCreateRegistry(params.KubernetesClient)
+
+	reloaded, err := services.NewClusterService(registry).Reload()
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to reload clusters: %w", err)), nil
+	}
+
+	// Marshal result in the requested format
+	return render.ToolCallResult(params, reloaded), nil
+}
+
+// Made with Bob
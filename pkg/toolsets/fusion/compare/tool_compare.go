@@ -0,0 +1,94 @@
+package compare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/render"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/services"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+)
+
+// InitCompareTool creates the fusion.compare tool
+func InitCompareTool() api.ServerTool {
+	components := make([]any, 0, len(services.CompareComponents()))
+	for _, component := range services.CompareComponents() {
+		components = append(components, component)
+	}
+	return api.ServerTool{
+		Tool: api.Tool{
+			Name:        "fusion.compare",
+			Description: "Compare the status of Fusion components between two clusters, for example a DR primary and its standby. Returns a field by field diff per component, such as storage classes present on one cluster only or version mismatches, instead of two separate statuses. Fields that differ by nature, such as workload counts and capacity usage, are left out. The summary lists the differences that break a failover: missing or different storage and snapshot classes",
+			Annotations: api.ToolAnnotations{
+				Title:        "Compare Clusters",
+				ReadOnlyHint: ptr.To(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"format": render.FormatSchema(),
+					"clusterA": {
+						Type:        "string",
+						Description: "Name of the first cluster, for example the DR primary",
+					},
+					"clusterB": {
+						Type:        "string",
+						Description: "Name of the second cluster, for example the DR standby",
+					},
+					"components": {
+						Type:        "array",
+						Description: "Components to compare (default: all)",
+						Items:       &jsonschema.Schema{Type: "string", Enum: components},
+					},
+					"timeout": {
+						Type:        "integer",
+						Description: "Timeout in seconds for each cluster",
+						Minimum:     ptr.To(0.0),
+					},
+				},
+				Required: []string{"clusterA", "clusterB"},
+			},
+		},
+		Handler: handleCompare,
+	}
+}
+
+// handleCompare implements the compare tool handler
+func handleCompare(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	var input struct {
+		ClusterA   string   `json:"clusterA"`
+		ClusterB   string   `json:"clusterB"`
+		Components []string `json:"components"`
+		Timeout    int      `json:"timeout"`
+	}
+	argBytes, _ := json.Marshal(params.GetArguments())
+	if err := json.Unmarshal(argBytes, &input); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("invalid arguments: %w", err)), nil
+	}
+	if input.ClusterA == "" || input.ClusterB == "" {
+		return api.NewToolCallResult("", fmt.Errorf("clusterA and clusterB are required")), nil
+	}
+	if input.ClusterA == input.ClusterB {
+		return api.NewToolCallResult("", fmt.Errorf("clusterA and clusterB must be different clusters")), nil
+	}
+	service, err := services.NewCompareService(input.Components)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	// Read both clusters like a multi target, so timeouts and retries apply as usual
+	registry := clients.GetOrCreateRegistry(params.KubernetesClient)
+	target := targeting.Target{Type: targeting.TargetMulti, Clusters: []string{input.ClusterA, input.ClusterB}, Timeout: input.Timeout}
+	result := services.ExecuteOnClusters(params.Context, registry, target, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
+		return service.Snapshot(ctx, client)
+	})
+
+	return render.ToolCallResult(params, service.Compare(input.ClusterA, input.ClusterB, result)), nil
+}
+
+// Made with Bob
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 
 	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/render"
 	"github.com/containers/kubernetes-mcp-server/internal/fusion/services"
 	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
 	"github.com/containers/kubernetes-mcp-server/pkg/api"
@@ -12,12 +13,15 @@ import (
 	"k8s.io/utils/ptr"
 )
 
+// statusOutputSchema describes the fusion.datafoundation.status result
+var statusOutputSchema = targeting.MustResultSchemaFor[services.DataFoundationStatus]()
+
 // InitStatusTool creates the fusion.datafoundation.status tool
 func InitStatusTool() api.ServerTool {
 	return api.ServerTool{
 		Tool: api.Tool{
 			Name:        "fusion.datafoundation.status",
-			Description: "Get Data Foundation (ODF/OCS) status across clusters including installation status, storage classes, and Ceph health",
+			Description: "Get Data Foundation (ODF/OCS) status across clusters including installation status, storage classes, Ceph health, and raw and usable capacity. Each cluster reports usedPercent with nearFull (80% or more used) and critical (90% or more) flags, left out when usage is unknown, and the summary counts the nearFullClusters and criticalClusters",
 			Annotations: api.ToolAnnotations{
 				Title:        "Data Foundation Status",
 				ReadOnlyHint: ptr.To(true),
@@ -25,9 +29,12 @@ func InitStatusTool() api.ServerTool {
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
-					"target": targeting.TargetSchema(),
+					"target":    targeting.TargetSchema(),
+					"format":    render.FormatSchema(),
+					"namespace": targeting.NamespaceSchema(services.DataFoundationNamespaces...),
 				},
 			},
+			OutputSchema: statusOutputSchema,
 		},
 		Handler: handleDataFoundationStatus,
 	}
@@ -37,7 +44,8 @@ func InitStatusTool() api.ServerTool {
 func handleDataFoundationStatus(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	// Parse target
 	var input struct {
-		Target targeting.Target `json:"target"`
+		Target    targeting.Target `json:"target"`
+		Namespace string           `json:"namespace"`
 	}
 	argBytes, _ := json.Marshal(params.GetArguments())
 	if err := json.Unmarshal(argBytes, &input); err != nil {
@@ -51,16 +59,27 @@ func handleDataFoundationStatus(params api.ToolHandlerParams) (*api.ToolCallResu
 	// Execute on clusters
 	result := services.ExecuteOnClusters(params.Context, registry, input.Target, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
 		service := services.NewDataFoundationService(nil)
-		return service.GetStatus(ctx, client)
+		return service.GetStatus(ctx, client, input.Namespace)
 	})
 
-	// Marshal result to JSON
-	jsonBytes, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return api.NewToolCallResult("", err), nil
-	}
+	// Count the clusters whose capacity crossed a threshold, critical ones are near full too
+	nearFull, critical := 0, 0
+	services.ForEachClusterData(result, func(_ string, status services.DataFoundationStatus) {
+		if status.NearFull != nil && *status.NearFull {
+			nearFull++
+		}
+		if status.Critical != nil && *status.Critical {
+			critical++
+		}
+	})
+	result.SetAggregate("nearFullClusters", nearFull)
+	result.SetAggregate("criticalClusters", critical)
+
+	// Trim cluster results to the requested filter, the summary keeps the full counts
+	result.ApplyFilter()
 
-	return api.NewToolCallResult(string(jsonBytes), nil), nil
+	// Marshal result in the requested format
+	return render.ToolCallResult(params, result), nil
 }
 
 // Made with Bob
@@ -0,0 +1,103 @@
+package dr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/render"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/services"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+)
+
+// drAction performs a DR action on the DRPlacementControl of a hub cluster
+type drAction func(ctx context.Context, client *clients.ClusterClient, request services.DRActionRequest) (*services.DRActionResult, error)
+
+// InitFailoverTool creates the fusion.dr.failover tool
+func InitFailoverTool() api.ServerTool {
+	return api.ServerTool{
+		Tool: api.Tool{
+			Name:        "fusion.dr.failover",
+			Description: "Fail a DR protected workload over to another cluster by setting action Failover and the failover cluster on its Ramen DRPlacementControl. Target the hub cluster holding the DRPlacementControl. The DRPlacementControl must be PeerReady and the cluster a member of its DRPolicy. Refused while replication is unhealthy (DRPolicy not validated or last group sync behind the RPO) unless force is set. Returns the action and the current phase",
+			Annotations: api.ToolAnnotations{
+				Title:           "DR Failover",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(true),
+			},
+			InputSchema: actionSchema("Cluster to fail the workload over to"),
+		},
+		Handler: actionHandler(func(ctx context.Context, client *clients.ClusterClient, request services.DRActionRequest) (*services.DRActionResult, error) {
+			return services.NewDRService().Failover(ctx, client, request)
+		}),
+	}
+}
+
+// actionSchema returns the input schema of a DR action tool
+func actionSchema(clusterDescription string) *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"target": targeting.TargetSchema(),
+			"format": render.FormatSchema(),
+			"dryRun": targeting.DryRunSchema(),
+			"name": {
+				Type:        "string",
+				Description: "Name of the DRPlacementControl of the workload",
+			},
+			"namespace": {
+				Type:        "string",
+				Description: "Namespace of the DRPlacementControl",
+			},
+			"cluster": {
+				Type:        "string",
+				Description: clusterDescription,
+			},
+			"force": {
+				Type:        "boolean",
+				Description: "Act even when replication is not healthy, the overridden problems are returned as warnings (default: false)",
+			},
+		},
+		Required: []string{"name", "namespace", "cluster"},
+	}
+}
+
+// actionHandler returns the tool handler running act on the targeted clusters
+func actionHandler(act drAction) api.ToolHandlerFunc {
+	return func(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+		var input struct {
+			Target targeting.Target `json:"target"`
+			services.DRActionRequest
+		}
+		argBytes, _ := json.Marshal(params.GetArguments())
+		if err := json.Unmarshal(argBytes, &input); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("invalid arguments: %w", err)), nil
+		}
+		if input.Name == "" || input.Namespace == "" || input.Cluster == "" {
+			return api.NewToolCallResult("", fmt.Errorf("name, namespace and cluster are required")), nil
+		}
+
+		// Get or create registry
+		registry := clients.GetOrCreateRegistry(params.KubernetesClient)
+
+		// Execute on clusters
+		result := services.ExecuteWriteOnClusters(params.Context, registry, input.Target, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
+			return act(ctx, client, input.DRActionRequest)
+		})
+
+		// A dry run only validated the writes
+		result.DryRun = input.DryRun
+
+		// Trim cluster results to the requested filter, the summary keeps the full counts
+		result.ApplyFilter()
+
+		// Marshal result in the requested format
+		return render.ToolCallResult(params, result), nil
+	}
+}
+
+// Made with Bob
@@ -0,0 +1,32 @@
+package dr
+
+import (
+	"context"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/services"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"k8s.io/utils/ptr"
+)
+
+// InitRelocateTool creates the fusion.dr.relocate tool
+func InitRelocateTool() api.ServerTool {
+	return api.ServerTool{
+		Tool: api.Tool{
+			Name:        "fusion.dr.relocate",
+			Description: "Relocate a DR protected workload back to its preferred cluster by setting action Relocate and the preferred cluster on its Ramen DRPlacementControl. Target the hub cluster holding the DRPlacementControl. The DRPlacementControl must be PeerReady and the cluster a member of its DRPolicy. Refused while replication is unhealthy (DRPolicy not validated or last group sync behind the RPO) unless force is set. Returns the action and the current phase",
+			Annotations: api.ToolAnnotations{
+				Title:           "DR Relocate",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(true),
+			},
+			InputSchema: actionSchema("Preferred cluster to relocate the workload to"),
+		},
+		Handler: actionHandler(func(ctx context.Context, client *clients.ClusterClient, request services.DRActionRequest) (*services.DRActionResult, error) {
+			return services.NewDRService().Relocate(ctx, client, request)
+		}),
+	}
+}
+
+// Made with Bob
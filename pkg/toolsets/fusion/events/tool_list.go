@@ -0,0 +1,78 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/render"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/services"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+)
+
+// InitListTool creates the fusion.events.list tool
+func InitListTool() api.ServerTool {
+	return api.ServerTool{
+		Tool: api.Tool{
+			Name:        "fusion.events.list",
+			Description: "List recent Warning events across clusters from the IBM Fusion, ODF, Spectrum Scale, OADP, and OpenShift Virtualization namespaces, or from a given namespace, newest first. Use it to find out why a component is unhealthy",
+			Annotations: api.ToolAnnotations{
+				Title:        "Warning Events",
+				ReadOnlyHint: ptr.To(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"target": targeting.TargetSchema(),
+					"format": render.FormatSchema(),
+					"namespace": {
+						Type:        "string",
+						Description: "Only list events of this namespace (default: the Fusion related namespaces)",
+					},
+					"since": {
+						Type:        "string",
+						Description: "Only list events seen within this duration, e.g. 30m or 2h",
+					},
+					"limit": {
+						Type:        "integer",
+						Description: "Maximum number of events returned per cluster (default: 50)",
+						Minimum:     ptr.To(1.0),
+					},
+				},
+			},
+		},
+		Handler: handleEventsList,
+	}
+}
+
+// handleEventsList implements the events list tool handler
+func handleEventsList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	// Parse target and filters
+	var input struct {
+		Target targeting.Target `json:"target"`
+		services.EventsRequest
+	}
+	argBytes, _ := json.Marshal(params.GetArguments())
+	if err := json.Unmarshal(argBytes, &input); err != nil {
+		input.Target = targeting.Target{Type: targeting.TargetSingle}
+	}
+
+	// Get or create registry
+	registry := clients.GetOrCreateRegistry(params.KubernetesClient)
+
+	// Execute on clusters
+	result := services.ExecuteOnClusters(params.Context, registry, input.Target, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
+		return services.NewEventsService().ListWarnings(ctx, client, input.EventsRequest)
+	})
+
+	// Trim cluster results to the requested filter, the summary keeps the full counts
+	result.ApplyFilter()
+
+	// Marshal result in the requested format
+	return render.ToolCallResult(params, result), nil
+}
+
+// Made with Bob
@@ -0,0 +1,82 @@
+package hcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/render"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/services"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+)
+
+// InitHostedClustersListTool creates the fusion.hcp.hostedclusters.list tool
+func InitHostedClustersListTool() api.ServerTool {
+	return api.ServerTool{
+		Tool: api.Tool{
+			Name:        "fusion.hcp.hostedclusters.list",
+			Description: "List the HyperShift HostedClusters of hub clusters with their namespace, version, Available/Progressing conditions, and node pools with desired vs current replicas and readiness. Node pools still scaling with no progress for stuckAfterMinutes are flagged as stuck. Hubs without the HostedCluster CRD are reported as not installed. The summary counts available and degraded hosted clusters and stuck node pools across the fleet",
+			Annotations: api.ToolAnnotations{
+				Title:        "Hosted Clusters",
+				ReadOnlyHint: ptr.To(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"target": targeting.TargetSchema(),
+					"format": render.FormatSchema(),
+					"stuckAfterMinutes": {
+						Type:        "integer",
+						Description: fmt.Sprintf("Minutes a node pool may scale without any condition changing before it is flagged as stuck (default: %d)", int(services.DefaultNodePoolStuckAfter.Minutes())),
+						Minimum:     ptr.To(1.0),
+					},
+				},
+			},
+		},
+		Handler: handleHostedClustersList,
+	}
+}
+
+// handleHostedClustersList implements the hosted clusters list tool handler
+func handleHostedClustersList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	var input struct {
+		Target            targeting.Target `json:"target"`
+		StuckAfterMinutes int              `json:"stuckAfterMinutes"`
+	}
+	argBytes, _ := json.Marshal(params.GetArguments())
+	if err := json.Unmarshal(argBytes, &input); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("invalid arguments: %w", err)), nil
+	}
+
+	// Get or create registry
+	registry := clients.GetOrCreateRegistry(params.KubernetesClient)
+
+	// Execute on clusters
+	result := services.ExecuteOnClusters(params.Context, registry, input.Target, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
+		return services.NewHCPService().WithStuckAfter(time.Duration(input.StuckAfterMinutes)*time.Minute).ListHostedClusters(ctx, client)
+	})
+
+	// Count available and degraded hosted clusters and stuck node pools across the fleet
+	available, degraded, stuck := 0, 0, 0
+	services.ForEachClusterData(result, func(_ string, list services.HostedClusterList) {
+		available += list.Available
+		degraded += list.Degraded
+		stuck += list.StuckNodePools
+	})
+	result.SetAggregate("availableHostedClusters", available)
+	result.SetAggregate("degradedHostedClusters", degraded)
+	result.SetAggregate("stuckNodePools", stuck)
+
+	// Trim cluster results to the requested filter, the summary keeps the full counts
+	result.ApplyFilter()
+
+	// Marshal result in the requested format
+	return render.ToolCallResult(params, result), nil
+}
+
+// Made with Bob
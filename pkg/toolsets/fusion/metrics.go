@@ -0,0 +1,102 @@
+package fusion
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/klog/v2"
+)
+
+// Tool invocation results
+const (
+	toolResultSuccess = "success"
+	toolResultError   = "error"
+)
+
+var (
+	toolMetrics     *ToolMetrics
+	toolMetricsOnce sync.Once
+)
+
+// ToolMetrics is the Prometheus collector of Fusion tool invocations
+// It counts invocations by tool and result and records the handler duration of each tool.
+type ToolMetrics struct {
+	invocations *prometheus.CounterVec
+	duration    *prometheus.HistogramVec
+}
+
+// NewToolMetrics creates an unregistered collector
+func NewToolMetrics() *ToolMetrics {
+	return &ToolMetrics{
+		invocations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "fusion_tool_invocations_total",
+			Help: "Invocations of Fusion tools by result, \"error\" when the tool returned an error.",
+		}, []string{"tool", "result"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "fusion_tool_duration_seconds",
+			Help:    "Duration of Fusion tool handlers.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"tool"}),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (m *ToolMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.invocations.Describe(ch)
+	m.duration.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (m *ToolMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.invocations.Collect(ch)
+	m.duration.Collect(ch)
+}
+
+// instrument returns a handler recording the invocations of a tool, it returns what
+// handler returns
+func (m *ToolMetrics) instrument(tool string, handler api.ToolHandlerFunc) api.ToolHandlerFunc {
+	return func(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+		start := time.Now()
+		result, err := handler(params)
+		m.duration.WithLabelValues(tool).Observe(time.Since(start).Seconds())
+		outcome := toolResultSuccess
+		if err != nil || result == nil || result.Error != nil {
+			outcome = toolResultError
+		}
+		m.invocations.WithLabelValues(tool, outcome).Inc()
+		return result, err
+	}
+}
+
+// GetToolMetrics returns the collector of the Fusion tools, registered with the default
+// Prometheus registry the host process exposes
+func GetToolMetrics() *ToolMetrics {
+	toolMetricsOnce.Do(func() {
+		metrics := NewToolMetrics()
+		if err := prometheus.Register(metrics); err != nil {
+			var registered prometheus.AlreadyRegisteredError
+			if !errors.As(err, &registered) {
+				klog.Errorf("failed to register Fusion tool metrics: %v", err)
+			} else if existing, ok := registered.ExistingCollector.(*ToolMetrics); ok {
+				metrics = existing
+			}
+		}
+		toolMetrics = metrics
+	})
+	return toolMetrics
+}
+
+// instrumentTools wraps the handler of every tool with the tool metrics
+func instrumentTools(tools []api.ServerTool, metrics *ToolMetrics) []api.ServerTool {
+	instrumented := make([]api.ServerTool, 0, len(tools))
+	for _, tool := range tools {
+		tool.Handler = metrics.instrument(tool.Tool.Name, tool.Handler)
+		instrumented = append(instrumented, tool)
+	}
+	return instrumented
+}
+
+// Made with Bob
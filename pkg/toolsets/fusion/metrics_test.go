@@ -0,0 +1,68 @@
+package fusion
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+type ToolMetricsSuite struct {
+	suite.Suite
+}
+
+func (s *ToolMetricsSuite) TestInstrument() {
+	metrics := NewToolMetrics()
+	toolErr := errors.New("invalid arguments")
+	tools := instrumentTools([]api.ServerTool{
+		{
+			Tool: api.Tool{Name: "fusion.clusters.list"},
+			Handler: func(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+				return api.NewToolCallResult("{}", nil), nil
+			},
+		},
+		{
+			Tool: api.Tool{Name: "fusion.backup.create"},
+			Handler: func(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+				return api.NewToolCallResult("", toolErr), nil
+			},
+		},
+	}, metrics)
+
+	for range 2 {
+		result, err := tools[0].Handler(api.ToolHandlerParams{})
+		s.Require().NoError(err)
+		s.Equal("{}", result.Content)
+	}
+	result, err := tools[1].Handler(api.ToolHandlerParams{})
+	s.Require().NoError(err)
+
+	s.Run("counts invocations by tool and result", func() {
+		s.Equal(float64(2), testutil.ToFloat64(metrics.invocations.WithLabelValues("fusion.clusters.list", "success")))
+		s.Equal(float64(1), testutil.ToFloat64(metrics.invocations.WithLabelValues("fusion.backup.create", "error")))
+		s.Equal(float64(0), testutil.ToFloat64(metrics.invocations.WithLabelValues("fusion.clusters.list", "error")))
+	})
+	s.Run("observes the duration of every invocation", func() {
+		s.Equal(2, testutil.CollectAndCount(metrics.duration))
+	})
+	s.Run("returns what the handler returns", func() {
+		s.Same(toolErr, result.Error)
+	})
+}
+
+func (s *ToolMetricsSuite) TestGetTools() {
+	tools := (&Toolset{}).GetTools(nil)
+	s.Len(tools, len(allTools()))
+	for _, tool := range tools {
+		s.NotNil(tool.Handler, tool.Tool.Name)
+	}
+	s.NotNil(GetToolMetrics())
+}
+
+func TestToolMetricsSuite(t *testing.T) {
+	suite.Run(t, new(ToolMetricsSuite))
+}
+
+// Made with Bob
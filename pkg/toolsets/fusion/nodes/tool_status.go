@@ -0,0 +1,79 @@
+package nodes
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/render"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/services"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+)
+
+// InitStatusTool creates the fusion.nodes.status tool
+func InitStatusTool() api.ServerTool {
+	return api.ServerTool{
+		Tool: api.Tool{
+			Name:        "fusion.nodes.status",
+			Description: "Get node status across clusters: Ready condition, roles (master/worker/infra), kubelet version, and allocatable vs capacity CPU and memory. Counts cover every node, per-node details are capped with NotReady nodes listed first",
+			Annotations: api.ToolAnnotations{
+				Title:        "Node Status",
+				ReadOnlyHint: ptr.To(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"target": targeting.TargetSchema(),
+					"format": render.FormatSchema(),
+					"maxNodes": {
+						Type:        "integer",
+						Description: "Maximum number of nodes returned in detail per cluster (default: 100)",
+						Minimum:     ptr.To(1.0),
+					},
+				},
+			},
+		},
+		Handler: handleNodesStatus,
+	}
+}
+
+// handleNodesStatus implements the node status tool handler
+func handleNodesStatus(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	// Parse target
+	var input struct {
+		Target   targeting.Target `json:"target"`
+		MaxNodes int              `json:"maxNodes"`
+	}
+	argBytes, _ := json.Marshal(params.GetArguments())
+	if err := json.Unmarshal(argBytes, &input); err != nil {
+		input.Target = targeting.Target{Type: targeting.TargetSingle}
+	}
+
+	// Get or create registry
+	registry := clients.GetOrCreateRegistry(params.KubernetesClient)
+
+	// Execute on clusters
+	result := services.ExecuteOnClusters(params.Context, registry, input.Target, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
+		return services.NewNodeService().GetStatus(ctx, client, input.MaxNodes)
+	})
+
+	// Aggregate node readiness across the fleet
+	total, notReady := 0, 0
+	services.ForEachClusterData(result, func(_ string, list services.NodeStatusList) {
+		total += list.Total
+		notReady += list.NotReady
+	})
+	result.SetAggregate("nodes", total)
+	result.SetAggregate("notReadyNodes", notReady)
+
+	// Trim cluster results to the requested filter, the summary keeps the full counts
+	result.ApplyFilter()
+
+	// Marshal result in the requested format
+	return render.ToolCallResult(params, result), nil
+}
+
+// Made with Bob
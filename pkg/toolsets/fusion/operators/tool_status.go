@@ -0,0 +1,82 @@
+package operators
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/render"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/services"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+)
+
+// InitStatusTool creates the fusion.operators.status tool
+func InitStatusTool() api.ServerTool {
+	return api.ServerTool{
+		Tool: api.Tool{
+			Name:        "fusion.operators.status",
+			Description: "Get operator health across clusters from OLM ClusterServiceVersions, reporting each operator's name, version, and phase (Succeeded/Installing/Failed). Filter by namespace or by name prefix such as ibm-, odf-, or velero",
+			Annotations: api.ToolAnnotations{
+				Title:        "Operator Status",
+				ReadOnlyHint: ptr.To(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"target": targeting.TargetSchema(),
+					"format": render.FormatSchema(),
+					"namespace": {
+						Type:        "string",
+						Description: "Only report operators installed in this namespace (default: all namespaces)",
+					},
+					"prefix": {
+						Type:        "string",
+						Description: "Only report operators whose ClusterServiceVersion name starts with this prefix, e.g. ibm-",
+					},
+				},
+			},
+		},
+		Handler: handleOperatorsStatus,
+	}
+}
+
+// handleOperatorsStatus implements the operators status tool handler
+func handleOperatorsStatus(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	// Parse target and filter
+	var input struct {
+		Target targeting.Target `json:"target"`
+		services.OperatorFilter
+	}
+	argBytes, _ := json.Marshal(params.GetArguments())
+	if err := json.Unmarshal(argBytes, &input); err != nil {
+		input.Target = targeting.Target{Type: targeting.TargetSingle}
+	}
+
+	// Get or create registry
+	registry := clients.GetOrCreateRegistry(params.KubernetesClient)
+
+	// Execute on clusters
+	result := services.ExecuteOnClusters(params.Context, registry, input.Target, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
+		return services.NewOperatorService().GetStatus(ctx, client, input.OperatorFilter)
+	})
+
+	// Aggregate operator health across the fleet
+	failed, notSucceeded := 0, 0
+	services.ForEachClusterData(result, func(_ string, list services.OperatorStatusList) {
+		failed += list.Failed
+		notSucceeded += list.Total - list.Succeeded
+	})
+	result.SetAggregate("failedOperators", failed)
+	result.SetAggregate("notSucceededOperators", notSucceeded)
+
+	// Trim cluster results to the requested filter, the summary keeps the full counts
+	result.ApplyFilter()
+
+	// Marshal result in the requested format
+	return render.ToolCallResult(params, result), nil
+}
+
+// Made with Bob
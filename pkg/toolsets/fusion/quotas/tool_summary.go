@@ -0,0 +1,84 @@
+package quotas
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/render"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/services"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+)
+
+// InitSummaryTool creates the fusion.quotas.summary tool
+func InitSummaryTool() api.ServerTool {
+	return api.ServerTool{
+		Tool: api.Tool{
+			Name:        "fusion.quotas.summary",
+			Description: fmt.Sprintf("Summarize ResourceQuotas across clusters for tenancy oversight: used versus hard CPU, memory and storage of every quota, flagging quotas more than %.0f%% consumed, which block new pods or PVCs of their namespace. Complements fusion.storage.summary, which reports capacity rather than what tenants may claim. Near-exhausted quotas are listed first and counted in the summary", services.QuotaNearExhaustedPercent),
+			Annotations: api.ToolAnnotations{
+				Title:        "Resource Quotas",
+				ReadOnlyHint: ptr.To(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"target": targeting.TargetSchema(),
+					"format": render.FormatSchema(),
+					"namespace": {
+						Type:        "string",
+						Description: "Only summarize the quotas of this namespace (default: all namespaces)",
+					},
+					"maxItems": {
+						Type:        "integer",
+						Description: fmt.Sprintf("Maximum number of quotas returned per cluster (default: %d). Counts always cover every quota", services.DefaultMaxItems),
+						Minimum:     ptr.To(1.0),
+					},
+				},
+			},
+		},
+		Handler: handleQuotasSummary,
+	}
+}
+
+// handleQuotasSummary implements the quota summary tool handler
+func handleQuotasSummary(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	var input struct {
+		Target    targeting.Target `json:"target"`
+		Namespace string           `json:"namespace"`
+		MaxItems  int              `json:"maxItems"`
+	}
+	argBytes, _ := json.Marshal(params.GetArguments())
+	if err := json.Unmarshal(argBytes, &input); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("invalid arguments: %w", err)), nil
+	}
+
+	// Get or create registry
+	registry := clients.GetOrCreateRegistry(params.KubernetesClient)
+
+	// Execute on clusters
+	result := services.ExecuteOnClusters(params.Context, registry, input.Target, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
+		return services.NewQuotaService().GetSummary(ctx, client, input.Namespace, input.MaxItems)
+	})
+
+	// Count the quotas and the near-exhausted ones across the fleet
+	total, nearExhausted := 0, 0
+	services.ForEachClusterData(result, func(_ string, summary services.QuotaSummary) {
+		total += summary.Total
+		nearExhausted += summary.NearExhausted
+	})
+	result.SetAggregate("quotas", total)
+	result.SetAggregate("nearExhaustedQuotas", nearExhausted)
+
+	// Trim cluster results to the requested filter, the summary keeps the full counts
+	result.ApplyFilter()
+
+	// Marshal result in the requested format
+	return render.ToolCallResult(params, result), nil
+}
+
+// Made with Bob
@@ -1,7 +1,11 @@
 package fusion
 
 import (
+	"encoding/json"
+
 	"github.com/containers/kubernetes-mcp-server/internal/fusion/config"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/services"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
 	"github.com/containers/kubernetes-mcp-server/pkg/toolsets"
 	"k8s.io/klog/v2"
 )
@@ -9,15 +13,65 @@ import (
 // RegisterTools registers IBM Fusion tools if enabled via configuration
 // This is the single integration point with the upstream toolsets registry
 func RegisterTools() {
-	cfg := config.LoadFromEnv()
+	cfg, err := config.Load()
+	if err != nil {
+		klog.Warningf("IBM Fusion config file ignored, using environment variables: %v", err)
+		cfg = config.LoadFromEnv()
+	}
 
 	if !cfg.Enabled {
 		klog.V(2).Info("IBM Fusion tools are disabled (FUSION_TOOLS_ENABLED not set to true)")
 		return
 	}
 
+	if err := cfg.ValidateToolNames(toolNames()); err != nil {
+		klog.Errorf("IBM Fusion toolset not registered, fix FUSION_ENABLED_TOOLS/FUSION_DISABLED_TOOLS: %v", err)
+		return
+	}
+
+	if len(cfg.AllowedNamespaces) > 0 {
+		klog.V(1).Infof("IBM Fusion services restricted to namespaces %v", cfg.AllowedNamespaces)
+		services.SetAllowedNamespaces(cfg.AllowedNamespaces)
+	}
+
+	if cfg.AllowImpersonation {
+		klog.V(1).Info("IBM Fusion tools may impersonate users")
+		services.SetImpersonationAllowed(true)
+	}
+
+	if cfg.AllowClusterRegistration {
+		klog.V(1).Info("IBM Fusion tools may register clusters at runtime")
+		services.SetClusterRegistrationAllowed(true)
+	}
+	if cfg.AllowAuthPlugins {
+		klog.V(1).Info("IBM Fusion runtime kubeconfigs may use exec plugins and auth providers")
+		services.SetAuthPluginsAllowed(true)
+	}
+	services.SetKubeconfigPaths(cfg.KubeconfigPaths)
+
+	if len(cfg.DefaultTarget) > 0 {
+		var defaultTarget targeting.Target
+		err := json.Unmarshal(cfg.DefaultTarget, &defaultTarget)
+		if err == nil {
+			err = targeting.SetDefaultTarget(&defaultTarget)
+		}
+		if err != nil {
+			klog.Errorf("IBM Fusion default target ignored: %v", err)
+		}
+	}
+
+	if len(cfg.Groups) > 0 {
+		groups := make(map[string]targeting.Group, len(cfg.Groups))
+		for name, group := range cfg.Groups {
+			groups[name] = targeting.Group{Clusters: group.Clusters, Selector: group.Selector}
+		}
+		if err := targeting.SetGroups(groups); err != nil {
+			klog.Errorf("IBM Fusion cluster groups ignored: %v", err)
+		}
+	}
+
 	klog.V(1).Info("Registering IBM Fusion toolset")
-	toolsets.Register(&Toolset{})
+	toolsets.Register(&Toolset{config: cfg})
 }
 
 func init() {
@@ -0,0 +1,118 @@
+package serviceability
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/render"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/services"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+)
+
+// InitLogsTailTool creates the fusion.logs.tail tool
+func InitLogsTailTool() api.ServerTool {
+	components := make([]any, 0, len(services.LogComponents))
+	for _, component := range services.LogComponentNames() {
+		components = append(components, component)
+	}
+	return api.ServerTool{
+		Tool: api.Tool{
+			Name:        "fusion.logs.tail",
+			Description: "Read the last log lines of the pods of a component, to diagnose why it is not ready from evidence instead of guessing. Select the pods with a known component, or with a namespace and labelSelector, and optionally one container. The logs returned per cluster are capped in bytes, the oldest lines are dropped first and truncated is set when they were",
+			Annotations: api.ToolAnnotations{
+				Title:        "Tail Logs",
+				ReadOnlyHint: ptr.To(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"target": targeting.TargetSchema(),
+					"format": render.FormatSchema(),
+					"component": {
+						Type:        "string",
+						Description: fmt.Sprintf("Known component to read the logs of: %s", strings.Join(services.LogComponentNames(), ", ")),
+						Enum:        components,
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the pods, required with labelSelector; with a component it replaces the namespaces the component is looked for in",
+					},
+					"labelSelector": {
+						Type:        "string",
+						Description: "Label selector of the pods, e.g. app=odf-operator, when no component is given",
+					},
+					"container": {
+						Type:        "string",
+						Description: "Only read the logs of this container (default: every container of the pods)",
+					},
+					"tailLines": {
+						Type:        "integer",
+						Description: fmt.Sprintf("Number of lines read from the end of each container log (default: %d)", services.DefaultLogTailLines),
+						Minimum:     ptr.To(1.0),
+						Maximum:     ptr.To(float64(services.MaxLogTailLines)),
+					},
+					"sinceSeconds": {
+						Type:        "integer",
+						Description: "Only read lines logged within this many seconds",
+						Minimum:     ptr.To(1.0),
+					},
+					"maxBytes": {
+						Type:        "integer",
+						Description: fmt.Sprintf("Maximum log bytes returned per cluster, shared evenly between the containers (default: %d)", services.DefaultLogMaxBytes),
+						Minimum:     ptr.To(1.0),
+						Maximum:     ptr.To(float64(services.MaxLogMaxBytes)),
+					},
+				},
+			},
+		},
+		Handler: handleLogsTail,
+	}
+}
+
+// handleLogsTail implements the logs tail tool handler
+func handleLogsTail(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	var input struct {
+		Target targeting.Target `json:"target"`
+		services.LogsRequest
+	}
+	argBytes, _ := json.Marshal(params.GetArguments())
+	if err := json.Unmarshal(argBytes, &input); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("invalid arguments: %w", err)), nil
+	}
+	if err := input.LogsRequest.Validate(); err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	// Get or create registry
+	registry := clients.GetOrCreateRegistry(params.KubernetesClient)
+
+	// Execute on clusters
+	result := services.ExecuteOnClusters(params.Context, registry, input.Target, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
+		return services.NewServiceabilityService().TailLogs(ctx, client, input.LogsRequest)
+	})
+
+	// Count the containers read and the clusters whose logs were cut
+	containers, truncated := 0, 0
+	services.ForEachClusterData(result, func(_ string, logs services.PodLogs) {
+		containers += len(logs.Logs)
+		if logs.Truncated {
+			truncated++
+		}
+	})
+	result.SetAggregate("containers", containers)
+	result.SetAggregate("truncatedClusters", truncated)
+
+	// Trim cluster results to the requested filter, the summary keeps the full counts
+	result.ApplyFilter()
+
+	// Marshal result in the requested format
+	return render.ToolCallResult(params, result), nil
+}
+
+// Made with Bob
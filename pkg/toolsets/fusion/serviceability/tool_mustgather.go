@@ -0,0 +1,84 @@
+package serviceability
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/render"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/services"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+)
+
+// InitMustGatherStartTool creates the fusion.serviceability.mustgather.start tool
+func InitMustGatherStartTool() api.ServerTool {
+	return api.ServerTool{
+		Tool: api.Tool{
+			Name:        "fusion.serviceability.mustgather.start",
+			Description: "Start a must-gather collection on the targeted clusters by creating a Job that runs the must-gather image. Returns the pod to follow and copy the output from; the pod is kept for one hour after collection. Fails when the namespace or ServiceAccount does not exist or a collection with the same image is still collecting there",
+			Annotations: api.ToolAnnotations{
+				Title:           "Start Must-Gather",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"target": targeting.TargetSchema(),
+					"format": render.FormatSchema(),
+					"dryRun": targeting.DryRunSchema(),
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace to run the must-gather Job in; it must exist",
+					},
+					"image": {
+						Type:        "string",
+						Description: fmt.Sprintf("must-gather image to run, e.g. the IBM Fusion or ODF must-gather image (default: %s)", services.DefaultMustGatherImage),
+					},
+					"serviceAccount": {
+						Type:        "string",
+						Description: "ServiceAccount of the namespace the collection runs as, e.g. one bound to the cluster-reader ClusterRole; the default ServiceAccount cannot read the collected resources",
+					},
+				},
+				Required: []string{"namespace", "serviceAccount"},
+			},
+		},
+		Handler: handleMustGatherStart,
+	}
+}
+
+// handleMustGatherStart implements the must-gather start tool handler
+func handleMustGatherStart(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	var input struct {
+		Target targeting.Target `json:"target"`
+		services.MustGatherRequest
+	}
+	argBytes, _ := json.Marshal(params.GetArguments())
+	if err := json.Unmarshal(argBytes, &input); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("invalid arguments: %w", err)), nil
+	}
+
+	// Get or create registry
+	registry := clients.GetOrCreateRegistry(params.KubernetesClient)
+
+	// Execute on clusters
+	result := services.ExecuteWriteOnClusters(params.Context, registry, input.Target, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
+		return services.NewServiceabilityService().TriggerMustGather(ctx, client, input.MustGatherRequest)
+	})
+
+	// A dry run only validated the writes
+	result.DryRun = input.DryRun
+
+	// Trim cluster results to the requested filter, the summary keeps the full counts
+	result.ApplyFilter()
+
+	// Marshal result in the requested format
+	return render.ToolCallResult(params, result), nil
+}
+
+// Made with Bob
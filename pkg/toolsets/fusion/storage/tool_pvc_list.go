@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/render"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/services"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+)
+
+// InitPVCListTool creates the fusion.storage.pvc.list tool
+func InitPVCListTool() api.ServerTool {
+	return api.ServerTool{
+		Tool: api.Tool{
+			Name:        "fusion.storage.pvc.list",
+			Description: "List PersistentVolumeClaims across clusters with their phase, storage class, requested size, and age. Filter by phase (for example all Pending PVCs) and namespace to find the problematic ones fusion.storage.summary counts. The summary counts the matching PVCs overall and per cluster",
+			Annotations: api.ToolAnnotations{
+				Title:        "PersistentVolumeClaims",
+				ReadOnlyHint: ptr.To(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"target": targeting.TargetSchema(),
+					"format": render.FormatSchema(),
+					"namespace": {
+						Type:        "string",
+						Description: "Only list PVCs in this namespace (default: all namespaces)",
+					},
+					"phase": {
+						Type:        "string",
+						Description: "Only list PVCs in this phase",
+						Enum:        []interface{}{"Pending", "Bound", "Lost"},
+					},
+					"maxItems": {
+						Type:        "integer",
+						Description: fmt.Sprintf("Maximum number of PVCs returned per cluster (default: %d). Counts always cover every matching PVC", services.DefaultMaxItems),
+						Minimum:     ptr.To(1.0),
+					},
+				},
+			},
+		},
+		Handler: handlePVCList,
+	}
+}
+
+// handlePVCList implements the PVC list tool handler
+func handlePVCList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	var input struct {
+		Target   targeting.Target `json:"target"`
+		MaxItems int              `json:"maxItems"`
+		services.PVCFilter
+	}
+	argBytes, _ := json.Marshal(params.GetArguments())
+	if err := json.Unmarshal(argBytes, &input); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("invalid arguments: %w", err)), nil
+	}
+
+	// Get or create registry
+	registry := clients.GetOrCreateRegistry(params.KubernetesClient)
+
+	// Execute on clusters
+	result := services.ExecuteOnClusters(params.Context, registry, input.Target, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
+		service := services.NewStorageService(clients.NewClusterKubernetesClient(client))
+		return service.ListPVCs(ctx, input.PVCFilter, input.MaxItems)
+	})
+
+	// Count the matching PVCs across the fleet and per cluster
+	matching := 0
+	services.ForEachClusterData(result, func(cluster string, list services.PVCList) {
+		matching += list.Total
+		result.SetAggregate("matchingPVCs."+cluster, list.Total)
+	})
+	result.SetAggregate("matchingPVCs", matching)
+
+	// Trim cluster results to the requested filter, the summary keeps the full counts
+	result.ApplyFilter()
+
+	// Marshal result in the requested format
+	return render.ToolCallResult(params, result), nil
+}
+
+// Made with Bob
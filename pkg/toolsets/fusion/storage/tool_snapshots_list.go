@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/render"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/services"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+)
+
+// InitSnapshotsListTool creates the fusion.storage.snapshots.list tool
+func InitSnapshotsListTool() api.ServerTool {
+	return api.ServerTool{
+		Tool: api.Tool{
+			Name:        "fusion.storage.snapshots.list",
+			Description: "List CSI VolumeSnapshots across clusters, newest first, with source PVC, restore size, readyToUse state, and creation time. Optionally filter by namespace and VolumeSnapshotClass. The summary aggregates ready and not-ready snapshot counts across the fleet",
+			Annotations: api.ToolAnnotations{
+				Title:        "Volume Snapshots",
+				ReadOnlyHint: ptr.To(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"target": targeting.TargetSchema(),
+					"format": render.FormatSchema(),
+					"namespace": {
+						Type:        "string",
+						Description: "Only list snapshots in this namespace (default: all namespaces)",
+					},
+					"snapshotClass": {
+						Type:        "string",
+						Description: "Only list snapshots of this VolumeSnapshotClass",
+					},
+				},
+			},
+		},
+		Handler: handleSnapshotsList,
+	}
+}
+
+// handleSnapshotsList implements the snapshots list tool handler
+func handleSnapshotsList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	// Parse target
+	var input struct {
+		Target targeting.Target `json:"target"`
+		services.SnapshotFilter
+	}
+	argBytes, _ := json.Marshal(params.GetArguments())
+	if err := json.Unmarshal(argBytes, &input); err != nil {
+		input.Target = targeting.Target{Type: targeting.TargetSingle}
+	}
+
+	// Get or create registry
+	registry := clients.GetOrCreateRegistry(params.KubernetesClient)
+
+	// Execute on clusters
+	result := services.ExecuteOnClusters(params.Context, registry, input.Target, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
+		return services.NewSnapshotService().ListSnapshots(ctx, client, input.SnapshotFilter)
+	})
+
+	// Aggregate snapshot readiness across the fleet
+	ready, notReady := 0, 0
+	services.ForEachClusterData(result, func(_ string, list services.SnapshotList) {
+		ready += list.Ready
+		notReady += list.NotReady
+	})
+	result.SetAggregate("readySnapshots", ready)
+	result.SetAggregate("notReadySnapshots", notReady)
+
+	// Trim cluster results to the requested filter, the summary keeps the full counts
+	result.ApplyFilter()
+
+	// Marshal result in the requested format
+	return render.ToolCallResult(params, result), nil
+}
+
+// Made with Bob
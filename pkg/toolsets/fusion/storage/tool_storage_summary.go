@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/render"
 	"github.com/containers/kubernetes-mcp-server/internal/fusion/services"
 	"github.com/containers/kubernetes-mcp-server/pkg/api"
 	"github.com/google/jsonschema-go/jsonschema"
@@ -16,15 +17,20 @@ func InitStorageSummary() api.ServerTool {
 	return api.ServerTool{
 		Tool: api.Tool{
 			Name:        "fusion.storage.summary",
-			Description: "Get a comprehensive summary of storage status for IBM Fusion/OpenShift including storage classes, PVC statistics, and ODF/OCS detection",
+			Description: "Get a comprehensive summary of storage status for IBM Fusion/OpenShift including storage classes, PVC statistics (counts by phase, requested capacity, per storage class breakdown, and Pending PVCs that look stuck), and ODF/OCS detection",
 			Annotations: api.ToolAnnotations{
 				Title:        "IBM Fusion Storage Summary",
 				ReadOnlyHint: ptr.To(true),
 			},
 			InputSchema: &jsonschema.Schema{
-				Type:       "object",
+				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
-					// No input parameters for now
+					"format": render.FormatSchema(),
+					"maxItems": {
+						Type:        "integer",
+						Description: "Maximum number of stuck Pending PVCs returned in detail per cluster (default: 100). Counts always cover every PVC",
+						Minimum:     ptr.To(1.0),
+					},
 				},
 			},
 		},
@@ -34,6 +40,12 @@ func InitStorageSummary() api.ServerTool {
 
 // handleStorageSummary implements the storage summary tool handler
 func handleStorageSummary(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	var input struct {
+		MaxItems int `json:"maxItems"`
+	}
+	argBytes, _ := json.Marshal(params.GetArguments())
+	_ = json.Unmarshal(argBytes, &input)
+
 	// Create Fusion Kubernetes client wrapper
 	fusionClient := clients.NewKubernetesClient(params.KubernetesClient)
 
@@ -41,7 +53,7 @@ func handleStorageSummary(params api.ToolHandlerParams) (*api.ToolCallResult, er
 	storageService := services.NewStorageService(fusionClient)
 
 	// Get storage summary
-	summary, err := storageService.GetStorageSummary(params.Context)
+	summary, err := storageService.GetStorageSummary(params.Context, input.MaxItems)
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to get storage summary: %w", err)), nil
 	}
@@ -51,13 +63,8 @@ func handleStorageSummary(params api.ToolHandlerParams) (*api.ToolCallResult, er
 		Summary: summary,
 	}
 
-	// Marshal to JSON
-	jsonBytes, err := json.MarshalIndent(output, "", "  ")
-	if err != nil {
-		return api.NewToolCallResult("", fmt.Errorf("failed to marshal output: %w", err)), nil
-	}
-
-	return api.NewToolCallResult(string(jsonBytes), nil), nil
+	// Marshal result in the requested format
+	return render.ToolCallResult(params, output), nil
 }
 
 // Made with Bob
@@ -1,15 +1,30 @@
 package fusion
 
 import (
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/config"
 	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/fusion/alerts"
 	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/fusion/alltools"
 	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/fusion/backup"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/fusion/clusters"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/fusion/compare"
 	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/fusion/datafoundation"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/fusion/dr"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/fusion/events"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/fusion/hcp"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/fusion/nodes"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/fusion/operators"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/fusion/quotas"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/fusion/serviceability"
 	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/fusion/storage"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/fusion/virtualization"
 )
 
 // Toolset implements the IBM Fusion toolset
-type Toolset struct{}
+type Toolset struct {
+	// config selects the exposed tools, all tools are exposed when nil
+	config *config.FusionConfig
+}
 
 var _ api.Toolset = (*Toolset)(nil)
 
@@ -23,23 +38,90 @@ func (t *Toolset) GetDescription() string {
 	return "IBM Fusion multi-cluster capabilities for OpenShift including Data Foundation, GDP, Backup, DR, Cataloging, CAS, Observability, Serviceability, Virtualization, and HCP"
 }
 
-// GetTools returns all tools provided by the IBM Fusion toolset
+// GetTools returns the tools of the IBM Fusion toolset enabled by the configuration
+// Every handler records its invocations in the tool metrics
 func (t *Toolset) GetTools(o api.Openshift) []api.ServerTool {
+	tools := instrumentTools(allTools(), GetToolMetrics())
+	if t.config == nil {
+		return tools
+	}
+	enabled := make([]api.ServerTool, 0, len(tools))
+	for _, tool := range tools {
+		if t.config.ToolEnabled(tool.Tool.Name) {
+			enabled = append(enabled, tool)
+		}
+	}
+	return enabled
+}
+
+// toolNames returns the names of all tools of the IBM Fusion toolset
+func toolNames() []string {
+	tools := allTools()
+	names := make([]string, 0, len(tools))
+	for _, tool := range tools {
+		names = append(names, tool.Tool.Name)
+	}
+	return names
+}
+
+// allTools returns every tool of the IBM Fusion toolset
+func allTools() []api.ServerTool {
 	return []api.ServerTool{
+		// Cluster registry
+		clusters.InitListTool(),
+		clusters.InitContextsTool(),
+		clusters.InitRegisterTool(),
+		clusters.InitUnregisterTool(),
+		clusters.InitReloadTool(),
+		clusters.InitLabelTool(),
+		clusters.InitHealthTool(),
+		clusters.InitPermissionsTool(),
+
+		// IBM Fusion core
+		alltools.InitFusionStatusTool(),
+		alltools.InitOverviewTool(),
+		compare.InitCompareTool(),
+
+		// Nodes
+		nodes.InitStatusTool(),
+
+		// Events
+		events.InitListTool(),
+
+		// Alerts
+		alerts.InitListTool(),
+
+		// Operators
+		operators.InitStatusTool(),
+
 		// Storage
 		storage.InitStorageSummary(),
+		storage.InitPVCListTool(),
+		storage.InitSnapshotsListTool(),
+
+		// Resource Quotas
+		quotas.InitSummaryTool(),
 
 		// Data Foundation
 		datafoundation.InitStatusTool(),
 
 		// Backup & Restore
 		backup.InitJobsListTool(),
+		backup.InitDescribeTool(),
+		backup.InitWaitTool(),
+		backup.InitSchedulesListTool(),
+		backup.InitBSLStatusTool(),
+		backup.InitCreateTool(),
+		backup.InitNamespaceTool(),
+		backup.InitRestoreTool(),
 
 		// Global Data Platform
 		alltools.InitGDPStatusTool(),
 
 		// Disaster Recovery
 		alltools.InitDRStatusTool(),
+		dr.InitFailoverTool(),
+		dr.InitRelocateTool(),
 
 		// Data Cataloging
 		alltools.InitCatalogStatusTool(),
@@ -49,15 +131,19 @@ func (t *Toolset) GetTools(o api.Openshift) []api.ServerTool {
 
 		// Serviceability
 		alltools.InitServiceabilitySummaryTool(),
+		serviceability.InitMustGatherStartTool(),
+		serviceability.InitLogsTailTool(),
 
 		// Observability
 		alltools.InitObservabilitySummaryTool(),
 
 		// Virtualization
 		alltools.InitVirtualizationStatusTool(),
+		virtualization.InitMigrationsListTool(),
 
 		// Hosted Control Planes
 		alltools.InitHCPStatusTool(),
+		hcp.InitHostedClustersListTool(),
 	}
 }
 
@@ -0,0 +1,85 @@
+package virtualization
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/clients"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/render"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/services"
+	"github.com/containers/kubernetes-mcp-server/internal/fusion/targeting"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+)
+
+// InitMigrationsListTool creates the fusion.virtualization.migrations.list tool
+func InitMigrationsListTool() api.ServerTool {
+	return api.ServerTool{
+		Tool: api.Tool{
+			Name:        "fusion.virtualization.migrations.list",
+			Description: "List VM live migrations (VirtualMachineInstanceMigrations) in progress across clusters with their VMI, phase, source and target node, newest first. Filter by node to tell whether it is safe to drain it: a node with active migrations is still moving VMs. Clusters without OpenShift Virtualization are reported as not installed. The summary counts active migrations overall and per cluster",
+			Annotations: api.ToolAnnotations{
+				Title:        "VM Live Migrations",
+				ReadOnlyHint: ptr.To(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"target": targeting.TargetSchema(),
+					"format": render.FormatSchema(),
+					"namespace": {
+						Type:        "string",
+						Description: "Only list migrations in this namespace (default: all namespaces)",
+					},
+					"node": {
+						Type:        "string",
+						Description: "Only list migrations leaving or landing on this node",
+					},
+					"includeCompleted": {
+						Type:        "boolean",
+						Description: "Also list migrations that Succeeded or Failed (default: false)",
+					},
+				},
+			},
+		},
+		Handler: handleMigrationsList,
+	}
+}
+
+// handleMigrationsList implements the migrations list tool handler
+func handleMigrationsList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	var input struct {
+		Target targeting.Target `json:"target"`
+		services.MigrationFilter
+	}
+	argBytes, _ := json.Marshal(params.GetArguments())
+	if err := json.Unmarshal(argBytes, &input); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("invalid arguments: %w", err)), nil
+	}
+
+	// Get or create registry
+	registry := clients.GetOrCreateRegistry(params.KubernetesClient)
+
+	// Execute on clusters
+	result := services.ExecuteOnClusters(params.Context, registry, input.Target, func(ctx context.Context, client *clients.ClusterClient) (interface{}, error) {
+		return services.NewVirtualizationService().ListMigrations(ctx, client, input.MigrationFilter)
+	})
+
+	// Count the active migrations across the fleet and per cluster
+	active := 0
+	services.ForEachClusterData(result, func(cluster string, list services.MigrationList) {
+		active += list.Active
+		result.SetAggregate("activeMigrations."+cluster, list.Active)
+	})
+	result.SetAggregate("activeMigrations", active)
+
+	// Trim cluster results to the requested filter, the summary keeps the full counts
+	result.ApplyFilter()
+
+	// Marshal result in the requested format
+	return render.ToolCallResult(params, result), nil
+}
+
+// Made with Bob